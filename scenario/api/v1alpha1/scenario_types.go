@@ -18,28 +18,296 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 )
 
-// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
-// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
-
 // ScenarioSpec defines the desired state of Scenario
 type ScenarioSpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Operations field has all operations for a scenario.
+	// Also, you can add a new operation while the scenario runs.
+	//
+	// +patchMergeKey=ID
+	// +patchStrategy=merge
+	Operations []*ScenarioOperation `json:"operations"`
+}
+
+type ScenarioOperation struct {
+	// ID for this operation. Normally, the system sets this field for you.
+	ID string `json:"id"`
+	// MajorStep indicates when the operation should be done.
+	MajorStep int32 `json:"step"`
+
+	// One of the following four fields must be specified.
+	// If more than one is set or all are empty, the operation is invalid, and the scenario will fail.
+
+	// Create is the operation to create a new resource.
+	//
+	// +optional
+	Create *CreateOperation `json:"createOperation,omitempty"`
+	// Patch is the operation to patch a resource.
+	//
+	// +optional
+	Patch *PatchOperation `json:"patchOperation,omitempty"`
+	// Delete indicates the operation to delete a resource.
+	//
+	// +optional
+	Delete *DeleteOperation `json:"deleteOperation,omitempty"`
+	// Done indicates the operation to mark the scenario as Succeeded.
+	// When finish the step DoneOperation belongs, this Scenario changes its status to Succeeded.
+	//
+	// +optional
+	Done *DoneOperation `json:"doneOperation,omitempty"`
+}
+
+type CreateOperation struct {
+	// Object is the Object to be created.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Object *unstructured.Unstructured `json:"object"`
+
+	// +optional
+	CreateOptions metav1.CreateOptions `json:"createOptions,omitempty"`
+}
+
+type PatchOperation struct {
+	TypeMeta   metav1.TypeMeta   `json:"typeMeta"`
+	ObjectMeta metav1.ObjectMeta `json:"objectMeta"`
+	// Patch is the patch for target.
+	Patch string `json:"patch"`
+	// PatchType is the type of Patch.
+	PatchType types.PatchType `json:"patchType"`
 
-	// Foo is an example field of Scenario. Edit scenario_types.go to remove/update
-	Foo string `json:"foo,omitempty"`
+	// +optional
+	PatchOptions metav1.PatchOptions `json:"patchOptions,omitempty"`
+}
+
+type DeleteOperation struct {
+	TypeMeta   metav1.TypeMeta   `json:"typeMeta"`
+	ObjectMeta metav1.ObjectMeta `json:"objectMeta"`
+
+	// +optional
+	DeleteOptions metav1.DeleteOptions `json:"deleteOptions,omitempty"`
+}
+
+type DoneOperation struct{}
+
+// ScenarioStep is the time represented by a set of numbers, MajorStep and MinorStep,
+// which are like hours and minutes in clocks in the real world.
+// ScenarioStep.Major is moved to the next ScenarioStep.Major when the scheduler can no longer do anything with the current cluster state.
+// ScenarioStep.Minor is moved to the next ScenarioStep.Minor when the scheduler operates a resource (i.e., binds or preempts a Pod),
+// and is reset to 0 when ScenarioStep.Major is moved.
+type ScenarioStep struct {
+	Major int32 `json:"major"`
+	Minor int32 `json:"minor"`
 }
 
 // ScenarioStatus defines the observed state of Scenario
 type ScenarioStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// The phase is a simple, high-level summary of where the Scenario is in its lifecycle.
+	//
+	// +optional
+	Phase ScenarioPhase `json:"phase,omitempty"`
+	// A human-readable message indicating details about why the scenario is in this phase.
+	//
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// StepStatus has the status related to step.
+	//
+	// +optional
+	StepStatus ScenarioStepStatus `json:"stepStatus,omitempty"`
+	// ScenarioResult has the result of the simulation.
+	// This result is updated with the events that happen in the step while the step is running,
+	// and the Pods left unschedulable are added just before Step advances.
+	//
+	// +optional
+	ScenarioResult ScenarioResult `json:"scenarioResult,omitempty"`
+}
+
+type ScenarioStepStatus struct {
+	// Step indicates the current ScenarioStep.
+	//
+	// +optional
+	Step ScenarioStep `json:"step,omitempty"`
+	// Phase indicates the current phase in a single step.
+	//
+	// +optional
+	Phase StepPhase `json:"phase,omitempty"`
+	// OperatingCompletedTime is the time when all operations for the step were finished.
+	// The scheduling attempts before that time don't reflect the operations.
+	//
+	// +optional
+	OperatingCompletedTime *metav1.MicroTime `json:"operatingCompletedTime,omitempty"`
+	// OperatedIDs has the IDs of the operations for the step which have been operated.
+	// The controller doesn't operate them again when it runs the step again. (e.g., after the controller restarts.)
+	//
+	// +optional
+	OperatedIDs []string `json:"operatedIDs,omitempty"`
+}
+
+type StepPhase string
+
+const (
+	// Operating means controller is currently operating operation defined for the step.
+	Operating StepPhase = "Operating"
+	// OperatingCompleted means the controller has finished operating operation defined for the step.
+	OperatingCompleted StepPhase = "OperatingCompleted"
+	// ControllerRunning means the scheduler is working.
+	ControllerRunning StepPhase = "ControllerRunning"
+	// ControllerCompleted means the scheduler no longer do anything with the current cluster state.
+	ControllerCompleted StepPhase = "ControllerCompleted"
+	// StepCompleted means the controller is preparing to move to the next step.
+	StepCompleted StepPhase = "Finished"
+)
+
+type ScenarioResult struct {
+	// Timeline is a map of events keyed with MajorStep(string).
+	// This may have many of the same operations as .spec.operations but has additional events for Pods
+	// to represent a Pod is created, scheduled, preempted or left unschedulable in the step.
+	//
+	// Timeline has at most 5000 events in total, and the events after that are omitted.
+	// Also, SchedulingResults in the events are omitted when they are too large.
+	//
+	// +optional
+	Timeline map[string][]ScenarioTimelineEvent `json:"timeline,omitempty"`
+	// OmittedEvents is the number of events omitted from Timeline because Timeline has too many events.
+	//
+	// +optional
+	OmittedEvents int32 `json:"omittedEvents,omitempty"`
+	// OmittedSchedulingResults is the number of events in Timeline whose SchedulingResults are omitted
+	// because the scheduling results in Timeline are too large.
+	// The full results can be seen on the Pod's annotations or in the simulator's scheduling result history.
+	//
+	// +optional
+	OmittedSchedulingResults int32 `json:"omittedSchedulingResults,omitempty"`
+}
+
+type ScenarioTimelineEvent struct {
+	// The ID will be the same as spec.ScenarioOperations.ID if it is from the defined operation.
+	// Otherwise, it'll be newly generated.
+	ID string `json:"id"`
+	// Step indicates the ScenarioStep at which the event happened.
+	Step ScenarioStep `json:"step"`
+
+	// Only one of the following fields must be non-empty.
+
+	// Create is the result of ScenarioSpec.Operations.CreateOperation.
+	//
+	// +optional
+	Create *CreateOperationResult `json:"create,omitempty"`
+	// Patch is the result of ScenarioSpec.Operations.PatchOperation.
+	//
+	// +optional
+	Patch *PatchOperationResult `json:"patch,omitempty"`
+	// Delete is the result of ScenarioSpec.Operations.DeleteOperation.
+	//
+	// +optional
+	Delete *DeleteOperationResult `json:"delete,omitempty"`
+	// Done is the result of ScenarioSpec.Operations.DoneOperation.
+	//
+	// +optional
+	Done *DoneOperationResult `json:"done,omitempty"`
+	// PodCreated represents the Pod is created by someone other than the scenario. (e.g., ReplicaSet controller)
+	//
+	// +optional
+	PodCreated *PodResult `json:"podCreated,omitempty"`
+	// PodScheduled represents the Pod is bound to the Node by the scheduler.
+	//
+	// +optional
+	PodScheduled *PodResult `json:"podScheduled,omitempty"`
+	// PodPreempted represents the Pod is deleted by the scheduler's preemption.
+	//
+	// +optional
+	PodPreempted *PodResult `json:"podPreempted,omitempty"`
+	// PodDeleted represents the Pod is deleted by someone other than the scenario and the scheduler's preemption.
+	//
+	// +optional
+	PodDeleted *PodResult `json:"podDeleted,omitempty"`
+	// PodUnschedulable represents the Pod is left unschedulable at the end of the step.
+	//
+	// +optional
+	PodUnschedulable *PodResult `json:"podUnschedulable,omitempty"`
+}
+
+type CreateOperationResult struct {
+	// Operation is the operation that was done.
+	// The object in it is summarized in the same way as Result.
+	Operation CreateOperation `json:"operation"`
+	// Result is the resource after the creation.
+	// Only the type and the identity in metadata (name, namespace, uid and resourceVersion) are kept to keep the status small.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Result unstructured.Unstructured `json:"result"`
+}
+
+type PatchOperationResult struct {
+	// Operation is the operation that was done.
+	Operation PatchOperation `json:"operation"`
+	// Result is the resource after the patch.
+	// Only the type and the identity in metadata (name, namespace, uid and resourceVersion) are kept to keep the status small.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Result unstructured.Unstructured `json:"result"`
+}
+
+type DeleteOperationResult struct {
+	// Operation is the operation that was done.
+	Operation DeleteOperation `json:"operation"`
+}
+
+type DoneOperationResult struct {
+	// Operation is the operation that was done.
+	Operation DoneOperation `json:"operation"`
 }
 
+// PodResult has what happened to the Pod in the step.
+type PodResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// NodeName is the Node that the Pod is bound to (or was bound to before deletion).
+	//
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// Message is a human-readable message. (e.g., why the Pod is unschedulable.)
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+	// SchedulingResults has the scheduling results recorded by the simulator.
+	// It's keyed with the annotation key (scheduler-simulator/*) on the Pod.
+	//
+	// +optional
+	SchedulingResults map[string]string `json:"schedulingResults,omitempty"`
+}
+
+type ScenarioPhase string
+
+const (
+	// ScenarioPending phase indicates the scenario isn't started yet.
+	// e.g., waiting for another scenario to finish running.
+	ScenarioPending ScenarioPhase = "Pending"
+	// ScenarioRunning phase indicates the scenario is running.
+	ScenarioRunning ScenarioPhase = "Running"
+	// ScenarioPaused phase indicates all ScenarioSpec.Operations
+	// has been finished but not marked as done by ScenarioDone ScenarioOperations.
+	ScenarioPaused ScenarioPhase = "Paused"
+	// ScenarioSucceeded phase describes Scenario is fully completed
+	// by ScenarioDone ScenarioOperations. User
+	// can't add any ScenarioOperations once
+	// Scenario reached this phase.
+	ScenarioSucceeded ScenarioPhase = "Succeeded"
+	// ScenarioFailed phase indicates something wrong happened while running the scenario.
+	// For example:
+	// - the controller cannot create a resource for some reason.
+	// - users change the scheduler configuration via simulator API.
+	ScenarioFailed ScenarioPhase = "Failed"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
 
 // Scenario is the Schema for the scenarios API
 type Scenario struct {
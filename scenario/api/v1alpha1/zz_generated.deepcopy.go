@@ -25,13 +25,172 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreateOperation) DeepCopyInto(out *CreateOperation) {
+	*out = *in
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = (*in).DeepCopy()
+	}
+	in.CreateOptions.DeepCopyInto(&out.CreateOptions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreateOperation.
+func (in *CreateOperation) DeepCopy() *CreateOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(CreateOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreateOperationResult) DeepCopyInto(out *CreateOperationResult) {
+	*out = *in
+	in.Operation.DeepCopyInto(&out.Operation)
+	in.Result.DeepCopyInto(&out.Result)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreateOperationResult.
+func (in *CreateOperationResult) DeepCopy() *CreateOperationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CreateOperationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeleteOperation) DeepCopyInto(out *DeleteOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.DeleteOptions.DeepCopyInto(&out.DeleteOptions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteOperation.
+func (in *DeleteOperation) DeepCopy() *DeleteOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeleteOperationResult) DeepCopyInto(out *DeleteOperationResult) {
+	*out = *in
+	in.Operation.DeepCopyInto(&out.Operation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteOperationResult.
+func (in *DeleteOperationResult) DeepCopy() *DeleteOperationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteOperationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DoneOperation) DeepCopyInto(out *DoneOperation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DoneOperation.
+func (in *DoneOperation) DeepCopy() *DoneOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(DoneOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DoneOperationResult) DeepCopyInto(out *DoneOperationResult) {
+	*out = *in
+	out.Operation = in.Operation
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DoneOperationResult.
+func (in *DoneOperationResult) DeepCopy() *DoneOperationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(DoneOperationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchOperation) DeepCopyInto(out *PatchOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.PatchOptions.DeepCopyInto(&out.PatchOptions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchOperation.
+func (in *PatchOperation) DeepCopy() *PatchOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchOperationResult) DeepCopyInto(out *PatchOperationResult) {
+	*out = *in
+	in.Operation.DeepCopyInto(&out.Operation)
+	in.Result.DeepCopyInto(&out.Result)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchOperationResult.
+func (in *PatchOperationResult) DeepCopy() *PatchOperationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchOperationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodResult) DeepCopyInto(out *PodResult) {
+	*out = *in
+	if in.SchedulingResults != nil {
+		in, out := &in.SchedulingResults, &out.SchedulingResults
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodResult.
+func (in *PodResult) DeepCopy() *PodResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PodResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scenario) DeepCopyInto(out *Scenario) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scenario.
@@ -84,9 +243,87 @@ func (in *ScenarioList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioOperation) DeepCopyInto(out *ScenarioOperation) {
+	*out = *in
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(CreateOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = new(PatchOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(DeleteOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Done != nil {
+		in, out := &in.Done, &out.Done
+		*out = new(DoneOperation)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioOperation.
+func (in *ScenarioOperation) DeepCopy() *ScenarioOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioResult) DeepCopyInto(out *ScenarioResult) {
+	*out = *in
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make(map[string][]ScenarioTimelineEvent, len(*in))
+		for key, val := range *in {
+			var outVal []ScenarioTimelineEvent
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]ScenarioTimelineEvent, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioResult.
+func (in *ScenarioResult) DeepCopy() *ScenarioResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScenarioSpec) DeepCopyInto(out *ScenarioSpec) {
 	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]*ScenarioOperation, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ScenarioOperation)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioSpec.
@@ -102,6 +339,13 @@ func (in *ScenarioSpec) DeepCopy() *ScenarioSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScenarioStatus) DeepCopyInto(out *ScenarioStatus) {
 	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	in.StepStatus.DeepCopyInto(&out.StepStatus)
+	in.ScenarioResult.DeepCopyInto(&out.ScenarioResult)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioStatus.
@@ -113,3 +357,104 @@ func (in *ScenarioStatus) DeepCopy() *ScenarioStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioStep) DeepCopyInto(out *ScenarioStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioStep.
+func (in *ScenarioStep) DeepCopy() *ScenarioStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioStepStatus) DeepCopyInto(out *ScenarioStepStatus) {
+	*out = *in
+	out.Step = in.Step
+	if in.OperatingCompletedTime != nil {
+		in, out := &in.OperatingCompletedTime, &out.OperatingCompletedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.OperatedIDs != nil {
+		in, out := &in.OperatedIDs, &out.OperatedIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioStepStatus.
+func (in *ScenarioStepStatus) DeepCopy() *ScenarioStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioTimelineEvent) DeepCopyInto(out *ScenarioTimelineEvent) {
+	*out = *in
+	out.Step = in.Step
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(CreateOperationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = new(PatchOperationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(DeleteOperationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Done != nil {
+		in, out := &in.Done, &out.Done
+		*out = new(DoneOperationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodCreated != nil {
+		in, out := &in.PodCreated, &out.PodCreated
+		*out = new(PodResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodScheduled != nil {
+		in, out := &in.PodScheduled, &out.PodScheduled
+		*out = new(PodResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodPreempted != nil {
+		in, out := &in.PodPreempted, &out.PodPreempted
+		*out = new(PodResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDeleted != nil {
+		in, out := &in.PodDeleted, &out.PodDeleted
+		*out = new(PodResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodUnschedulable != nil {
+		in, out := &in.PodUnschedulable, &out.PodUnschedulable
+		*out = new(PodResult)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioTimelineEvent.
+func (in *ScenarioTimelineEvent) DeepCopy() *ScenarioTimelineEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioTimelineEvent)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	simulationv1alpha1 "sigs.k8s.io/kube-scheduler-simulator/scenario/api/v1alpha1"
+)
+
+// validateOperations checks that each operation has exactly one of Create, Patch, Delete and Done.
+func validateOperations(ops []*simulationv1alpha1.ScenarioOperation) error {
+	for i, op := range ops {
+		if op == nil {
+			return fmt.Errorf("operations[%d] is empty", i)
+		}
+		n := 0
+		if op.Create != nil {
+			n++
+			if op.Create.Object == nil {
+				return fmt.Errorf("operations[%d] (id: %q): createOperation.object is empty", i, op.ID)
+			}
+		}
+		if op.Patch != nil {
+			n++
+		}
+		if op.Delete != nil {
+			n++
+		}
+		if op.Done != nil {
+			n++
+		}
+		if n != 1 {
+			return fmt.Errorf("operations[%d] (id: %q): exactly one of createOperation, patchOperation, deleteOperation and doneOperation must be specified", i, op.ID)
+		}
+	}
+	return nil
+}
+
+// runOperation operates the given ScenarioOperation against kube-apiserver.
+//
+// The operated operations are recorded in .status.stepStatus.operatedIDs,
+// but the operation may be run again when the controller restarts before the status update after the operation.
+// So, the creation of the resource which already exists as the operation creates it and the deletion of the missing resource are regarded as done.
+// The patch is applied again in that case, so it should be idempotent. (e.g., a merge patch, not a JSON patch adding an item to a list.)
+func (r *ScenarioReconciler) runOperation(ctx context.Context, op *simulationv1alpha1.ScenarioOperation) error {
+	switch {
+	case op.Create != nil:
+		obj := op.Create.Object.DeepCopy()
+		opts := op.Create.CreateOptions.DeepCopy()
+		r.recorder.markOperatedPod(obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		if err := r.Create(ctx, obj, &client.CreateOptions{Raw: opts}); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("create %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+			}
+			existing := obj.DeepCopy()
+			if err := r.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+				return fmt.Errorf("get the existing %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+			}
+			if !isCreatedFrom(existing, op.Create.Object) {
+				return fmt.Errorf("create %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+			}
+			obj = existing
+		}
+		operation := op.Create.DeepCopy()
+		operation.Object = summarizeObject(operation.Object)
+		r.recorder.recordOperation(simulationv1alpha1.ScenarioTimelineEvent{
+			ID:     op.ID,
+			Create: &simulationv1alpha1.CreateOperationResult{Operation: *operation, Result: *summarizeObject(obj)},
+		})
+	case op.Patch != nil:
+		obj := targetObject(op.Patch.TypeMeta.APIVersion, op.Patch.TypeMeta.Kind, op.Patch.ObjectMeta.Namespace, op.Patch.ObjectMeta.Name)
+		opts := op.Patch.PatchOptions.DeepCopy()
+		patch := client.RawPatch(op.Patch.PatchType, []byte(op.Patch.Patch))
+		if err := r.Patch(ctx, obj, patch, &client.PatchOptions{Raw: opts}); err != nil {
+			return fmt.Errorf("patch %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+		}
+		r.recorder.recordOperation(simulationv1alpha1.ScenarioTimelineEvent{
+			ID:    op.ID,
+			Patch: &simulationv1alpha1.PatchOperationResult{Operation: *op.Patch.DeepCopy(), Result: *summarizeObject(obj)},
+		})
+	case op.Delete != nil:
+		obj := targetObject(op.Delete.TypeMeta.APIVersion, op.Delete.TypeMeta.Kind, op.Delete.ObjectMeta.Namespace, op.Delete.ObjectMeta.Name)
+		opts := op.Delete.DeleteOptions.DeepCopy()
+		if obj.GetKind() == "Pod" && obj.GroupVersionKind().Group == corev1.GroupName && opts.GracePeriodSeconds == nil {
+			// need to use noGrace to avoid waiting kubelet checking.
+			// There is no kubelet in the simulator, and the Pod will stay Terminating forever otherwise.
+			noGrace := int64(0)
+			opts.GracePeriodSeconds = &noGrace
+		}
+		r.recorder.markOperatedPod(obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		if err := r.Delete(ctx, obj, &client.DeleteOptions{Raw: opts}); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+		}
+		r.recorder.recordOperation(simulationv1alpha1.ScenarioTimelineEvent{
+			ID:     op.ID,
+			Delete: &simulationv1alpha1.DeleteOperationResult{Operation: *op.Delete.DeepCopy()},
+		})
+	case op.Done != nil:
+		// The Scenario is marked as Succeeded when the step finishes.
+		r.recorder.recordOperation(simulationv1alpha1.ScenarioTimelineEvent{
+			ID:   op.ID,
+			Done: &simulationv1alpha1.DoneOperationResult{Operation: *op.Done},
+		})
+	}
+	return nil
+}
+
+// isCreatedFrom checks whether the existing object has all fields in the object of CreateOperation,
+// which means the object may have been created by the operation.
+// The fields set by kube-apiserver (e.g., the defaulted fields and the server-populated metadata) are ignored,
+// and the status is ignored since it's dropped on the creation of most resources.
+func isCreatedFrom(existing, desired *unstructured.Unstructured) bool {
+	desired = desired.DeepCopy()
+	unstructured.RemoveNestedField(desired.Object, "status")
+	return equality.Semantic.DeepDerivative(desired.Object, existing.Object)
+}
+
+// targetObject returns the unstructured object to specify the target of operations.
+func targetObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
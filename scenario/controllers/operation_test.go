@@ -0,0 +1,277 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	simulationv1alpha1 "sigs.k8s.io/kube-scheduler-simulator/scenario/api/v1alpha1"
+)
+
+func newTestReconciler(t *testing.T, objs ...client.Object) *ScenarioReconciler {
+	t.Helper()
+
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatalf("add client-go scheme: %v", err)
+	}
+	if err := simulationv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("add simulation scheme: %v", err)
+	}
+	return &ScenarioReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build(),
+		Scheme:   s,
+		recorder: newStepResultRecorder(),
+	}
+}
+
+// testContainers are the containers of the Pod that podObject returns.
+func testContainers() []corev1.Container {
+	return []corev1.Container{{Name: "container", Image: "k8s.gcr.io/pause:3.5"}}
+}
+
+func podObject(namespace, name string) *unstructured.Unstructured {
+	obj := targetObject("v1", "Pod", namespace, name)
+	if err := unstructured.SetNestedSlice(obj.Object, []interface{}{
+		map[string]interface{}{"name": "container", "image": "k8s.gcr.io/pause:3.5"},
+	}, "spec", "containers"); err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+func Test_validateOperations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ops     []*simulationv1alpha1.ScenarioOperation
+		wantErr bool
+	}{
+		{
+			name: "valid operations",
+			ops: []*simulationv1alpha1.ScenarioOperation{
+				{ID: "1", Create: &simulationv1alpha1.CreateOperation{Object: podObject("default", "pod1")}},
+				{ID: "2", MajorStep: 1, Done: &simulationv1alpha1.DoneOperation{}},
+			},
+		},
+		{
+			name:    "empty operation",
+			ops:     []*simulationv1alpha1.ScenarioOperation{nil},
+			wantErr: true,
+		},
+		{
+			name: "no operation is specified",
+			ops: []*simulationv1alpha1.ScenarioOperation{
+				{ID: "1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple operations are specified",
+			ops: []*simulationv1alpha1.ScenarioOperation{
+				{ID: "1", Create: &simulationv1alpha1.CreateOperation{Object: podObject("default", "pod1")}, Done: &simulationv1alpha1.DoneOperation{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "create operation without object",
+			ops: []*simulationv1alpha1.ScenarioOperation{
+				{ID: "1", Create: &simulationv1alpha1.CreateOperation{}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			err := validateOperations(tt.ops)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestScenarioReconciler_runOperation(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	existing := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"},
+		Spec:       corev1.PodSpec{Containers: testContainers()},
+	}
+	r := newTestReconciler(t, existing)
+	r.recorder.start("scenario", simulationv1alpha1.ScenarioStep{})
+
+	ops := []*simulationv1alpha1.ScenarioOperation{
+		{ID: "create", Create: &simulationv1alpha1.CreateOperation{Object: podObject("default", "pod1")}},
+		// The creation of the resource which already exists as the operation creates it is regarded as done.
+		{ID: "create-existing", Create: &simulationv1alpha1.CreateOperation{Object: podObject("default", "existing")}},
+		{ID: "patch", Patch: &simulationv1alpha1.PatchOperation{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod1"},
+			Patch:      `{"metadata":{"labels":{"app":"test"}}}`,
+			PatchType:  "application/merge-patch+json",
+		}},
+		{ID: "delete", Delete: &simulationv1alpha1.DeleteOperation{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"},
+		}},
+		// The deletion of the missing resource is regarded as done.
+		{ID: "delete-missing", Delete: &simulationv1alpha1.DeleteOperation{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "missing"},
+		}},
+		{ID: "done", Done: &simulationv1alpha1.DoneOperation{}},
+	}
+	for _, op := range ops {
+		g.Expect(r.runOperation(ctx, op)).To(Succeed(), "operation %s", op.ID)
+		g.Expect(r.recorder.isOperated(op.ID)).To(BeTrue(), "operation %s", op.ID)
+	}
+
+	pod := &corev1.Pod{}
+	g.Expect(r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "pod1"}, pod)).To(Succeed())
+	g.Expect(pod.Labels).To(Equal(map[string]string{"app": "test"}))
+	err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "existing"}, pod)
+	g.Expect(client.IgnoreNotFound(err)).To(Succeed())
+	g.Expect(err).To(HaveOccurred())
+
+	events := r.recorder.pendingEvents("scenario")
+	g.Expect(events).To(HaveLen(len(ops)))
+	// The objects in the results are summarized.
+	g.Expect(events[0].Create.Result.Object).To(HaveKey("metadata"))
+	g.Expect(events[0].Create.Result.Object).NotTo(HaveKey("spec"))
+	g.Expect(events[0].Create.Operation.Object.Object).NotTo(HaveKey("spec"))
+	g.Expect(events[1].Create.Result.GetName()).To(Equal("existing"))
+	g.Expect(events[2].Patch.Result.GetName()).To(Equal("pod1"))
+	g.Expect(events[2].Patch.Result.GetResourceVersion()).NotTo(BeEmpty())
+	g.Expect(events[2].Patch.Result.GetLabels()).To(BeEmpty())
+}
+
+func TestScenarioReconciler_runOperation_patchMissing(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	r := newTestReconciler(t)
+	r.recorder.start("scenario", simulationv1alpha1.ScenarioStep{})
+	err := r.runOperation(context.Background(), &simulationv1alpha1.ScenarioOperation{ID: "patch", Patch: &simulationv1alpha1.PatchOperation{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "missing"},
+		Patch:      `{"metadata":{"labels":{"app":"test"}}}`,
+		PatchType:  "application/merge-patch+json",
+	}})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(r.recorder.isOperated("patch")).To(BeFalse())
+}
+
+func TestScenarioReconciler_runOperation_createDifferentExisting(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	// The existing Pod isn't the one that the operation creates.
+	existing := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "container", Image: "nginx"}}},
+	}
+	r := newTestReconciler(t, existing)
+	r.recorder.start("scenario", simulationv1alpha1.ScenarioStep{})
+	err := r.runOperation(context.Background(), &simulationv1alpha1.ScenarioOperation{
+		ID:     "create",
+		Create: &simulationv1alpha1.CreateOperation{Object: podObject("default", "existing")},
+	})
+	g.Expect(apierrors.IsAlreadyExists(err)).To(BeTrue())
+	g.Expect(r.recorder.isOperated("create")).To(BeFalse())
+}
+
+func Test_isCreatedFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		existing *unstructured.Unstructured
+		desired  *unstructured.Unstructured
+		want     bool
+	}{
+		{
+			name: "the fields added by kube-apiserver are ignored",
+			existing: func() *unstructured.Unstructured {
+				obj := podObject("default", "pod1")
+				obj.SetUID("uid")
+				obj.SetResourceVersion("1")
+				g := NewWithT(t)
+				g.Expect(unstructured.SetNestedField(obj.Object, "default-scheduler", "spec", "schedulerName")).To(Succeed())
+				return obj
+			}(),
+			desired: podObject("default", "pod1"),
+			want:    true,
+		},
+		{
+			name:     "the status is ignored",
+			existing: podObject("default", "pod1"),
+			desired: func() *unstructured.Unstructured {
+				obj := podObject("default", "pod1")
+				g := NewWithT(t)
+				g.Expect(unstructured.SetNestedField(obj.Object, "Running", "status", "phase")).To(Succeed())
+				return obj
+			}(),
+			want: true,
+		},
+		{
+			name:     "the labels differ",
+			existing: podObject("default", "pod1"),
+			desired: func() *unstructured.Unstructured {
+				obj := podObject("default", "pod1")
+				obj.SetLabels(map[string]string{"app": "test"})
+				return obj
+			}(),
+			want: false,
+		},
+		{
+			name:     "the spec differs",
+			existing: targetObject("v1", "Pod", "default", "pod1"),
+			desired:  podObject("default", "pod1"),
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(isCreatedFrom(tt.existing, tt.desired)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,516 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	simulationv1alpha1 "sigs.k8s.io/kube-scheduler-simulator/scenario/api/v1alpha1"
+)
+
+const (
+	// schedulingResultAnnotationPrefix is the prefix of annotations that the simulator records the scheduling results with.
+	schedulingResultAnnotationPrefix = "scheduler-simulator/"
+
+	// podDisruptionTargetCondition and preemptionByKubeSchedulerReason are set to the Pod by the scheduler
+	// just before the Pod is deleted by the preemption.
+	podDisruptionTargetCondition    corev1.PodConditionType = "DisruptionTarget"
+	preemptionByKubeSchedulerReason                         = "PreemptionByKubeScheduler"
+
+	// maxTimelineEvents is the maximum number of events in .status.scenarioResult.timeline.
+	maxTimelineEvents = 5000
+	// maxTimelineSchedulingResultsSize is the maximum total size (in bytes) of the scheduling results in .status.scenarioResult.timeline.
+	// The whole Scenario must be smaller than the limit of etcd, which is 1.5MiB by default.
+	maxTimelineSchedulingResultsSize = 512 * 1024
+)
+
+// stepResultRecorder records all events that happen in the current MajorStep of the running Scenario.
+// The recorded events are flushed into .status.scenarioResult.timeline whenever the status is updated
+// so that they are kept even when the controller restarts.
+type stepResultRecorder struct {
+	mu sync.Mutex
+
+	// recording represents whether the recorder is recording events now.
+	recording bool
+	// scenario is the UID of the Scenario which is being recorded.
+	scenario types.UID
+	// step is the MajorStep which is being recorded.
+	step int32
+	// minor is the current MinorStep in the step, which is incremented when the scheduler binds or preempts a Pod.
+	minor int32
+	// events are the events recorded in the step, which haven't been flushed yet.
+	events []simulationv1alpha1.ScenarioTimelineEvent
+	// operatedPods has the Pods that are created or deleted by ScenarioOperations in the step.
+	// Those are recorded as the operation results, and thus, not recorded as PodCreated or PodDeleted.
+	operatedPods map[types.NamespacedName]bool
+	// pods has the latest Pod objects that are seen in the step.
+	// It's used to fill the scheduling results of the Pod deleted in the step
+	// because the Pod in the delete event may not have them.
+	pods map[types.NamespacedName]*corev1.Pod
+}
+
+func newStepResultRecorder() *stepResultRecorder {
+	return &stepResultRecorder{}
+}
+
+// start resets the recorder and starts recording events for the MajorStep of the Scenario from the given ScenarioStep.
+// It keeps the recorded events and the MinorStep if the recorder is already recording the MajorStep
+// because the step is run again when the status update fails.
+//
+// It's called in every phase of the step so that the recording is resumed after the controller restarts.
+// The events that happen while the controller is down can't be recorded
+// except Pods left unschedulable, which are recorded at the end of the step.
+func (r *stepResultRecorder) start(scenario types.UID, step simulationv1alpha1.ScenarioStep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.recording && r.scenario == scenario && r.step == step.Major {
+		return
+	}
+	r.recording = true
+	r.scenario = scenario
+	r.step = step.Major
+	r.minor = step.Minor
+	r.events = []simulationv1alpha1.ScenarioTimelineEvent{}
+	r.operatedPods = map[types.NamespacedName]bool{}
+	r.pods = map[types.NamespacedName]*corev1.Pod{}
+}
+
+// stop stops recording the Scenario and drops the events which haven't been flushed.
+func (r *stepResultRecorder) stop(scenario types.UID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.scenario != scenario {
+		return
+	}
+
+	r.recording = false
+	r.events = nil
+	r.operatedPods = nil
+	r.pods = nil
+}
+
+// markOperatedPod marks the Pod as operated by ScenarioOperations.
+// It must be called before the operation is done so that the event from the operation isn't recorded as PodCreated or PodDeleted.
+func (r *stepResultRecorder) markOperatedPod(apiVersion, kind, namespace, name string) {
+	if kind != "Pod" || apiVersion != corev1.SchemeGroupVersion.String() {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording {
+		return
+	}
+	r.operatedPods[types.NamespacedName{Namespace: namespace, Name: name}] = true
+}
+
+// recordOperation records the result of ScenarioOperation.
+func (r *stepResultRecorder) recordOperation(event simulationv1alpha1.ScenarioTimelineEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording {
+		return
+	}
+	event.Step = simulationv1alpha1.ScenarioStep{Major: r.step, Minor: r.minor}
+	r.events = append(r.events, event)
+}
+
+// currentStep returns the ScenarioStep being recorded for the Scenario.
+// It returns false when the recorder isn't recording the Scenario.
+func (r *stepResultRecorder) currentStep(scenario types.UID) (simulationv1alpha1.ScenarioStep, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording || r.scenario != scenario {
+		return simulationv1alpha1.ScenarioStep{}, false
+	}
+	return simulationv1alpha1.ScenarioStep{Major: r.step, Minor: r.minor}, true
+}
+
+// isOperated checks whether the ScenarioOperation has already been done in the step being recorded.
+func (r *stepResultRecorder) isOperated(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording {
+		return false
+	}
+	for i := range r.events {
+		if r.events[i].ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingEvents returns the events of the Scenario which haven't been flushed.
+func (r *stepResultRecorder) pendingEvents(scenario types.UID) []simulationv1alpha1.ScenarioTimelineEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording || r.scenario != scenario {
+		return nil
+	}
+
+	ret := make([]simulationv1alpha1.ScenarioTimelineEvent, len(r.events))
+	for i := range r.events {
+		r.events[i].DeepCopyInto(&ret[i])
+	}
+	return ret
+}
+
+// flushed drops the first n events, which are returned by pendingEvents and have been written in the status.
+func (r *stepResultRecorder) flushed(scenario types.UID, n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording || r.scenario != scenario || n == 0 {
+		return
+	}
+	r.events = r.events[n:]
+}
+
+// podEventHandler returns the handler to record events of Pods.
+// It never enqueues any requests.
+func (r *stepResultRecorder) podEventHandler() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+			pod, ok := e.Object.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			r.onPodAdd(pod)
+		},
+		UpdateFunc: func(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			r.onPodUpdate(oldPod, newPod)
+		},
+		DeleteFunc: func(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+			pod, ok := e.Object.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			r.onPodDelete(pod)
+		},
+	}
+}
+
+func (r *stepResultRecorder) onPodAdd(pod *corev1.Pod) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording {
+		return
+	}
+
+	k := client.ObjectKeyFromObject(pod)
+	r.pods[k] = pod.DeepCopy()
+	if r.operatedPods[k] {
+		return
+	}
+	r.events = append(r.events, r.newPodEvent(&simulationv1alpha1.ScenarioTimelineEvent{PodCreated: newPodResult(pod)}))
+}
+
+func (r *stepResultRecorder) onPodUpdate(oldPod, newPod *corev1.Pod) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording {
+		return
+	}
+
+	r.pods[client.ObjectKeyFromObject(newPod)] = newPod.DeepCopy()
+	if oldPod.Spec.NodeName == "" && newPod.Spec.NodeName != "" {
+		r.minor++
+		r.events = append(r.events, r.newPodEvent(&simulationv1alpha1.ScenarioTimelineEvent{PodScheduled: newPodResult(newPod)}))
+	}
+}
+
+func (r *stepResultRecorder) onPodDelete(pod *corev1.Pod) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording {
+		return
+	}
+
+	k := client.ObjectKeyFromObject(pod)
+	if latest, ok := r.pods[k]; ok && latest.UID == pod.UID {
+		// The Pod in DeleteEvent may not have the scheduling results when the deletion happens just after the scheduling.
+		pod = latest
+	}
+	delete(r.pods, k)
+	if r.operatedPods[k] {
+		return
+	}
+
+	result := newPodResult(pod)
+	result.SchedulingResults = schedulingResults(pod)
+	if isPreempted(pod) {
+		r.minor++
+		r.events = append(r.events, r.newPodEvent(&simulationv1alpha1.ScenarioTimelineEvent{PodPreempted: result}))
+		return
+	}
+	r.events = append(r.events, r.newPodEvent(&simulationv1alpha1.ScenarioTimelineEvent{PodDeleted: result}))
+}
+
+// newPodEvent fills ID and Step in the event.
+// Note: we assume the lock is already acquired.
+func (r *stepResultRecorder) newPodEvent(e *simulationv1alpha1.ScenarioTimelineEvent) simulationv1alpha1.ScenarioTimelineEvent {
+	e.ID = string(uuid.NewUUID())
+	e.Step = simulationv1alpha1.ScenarioStep{Major: r.step, Minor: r.minor}
+	return *e
+}
+
+// updateStatus flushes the events recorded in the step into .status.scenarioResult.timeline
+// and the current MinorStep into .status.stepStatus.step, and updates the status.
+// When finishStep is true, the result of the step is recorded as well, and the recording is stopped.
+// The events are dropped from the recorder only after the update succeeds so that they're flushed again on the retry.
+func (r *ScenarioReconciler) updateStatus(ctx context.Context, scenario *simulationv1alpha1.Scenario, finishStep bool) error {
+	events := r.recorder.pendingEvents(scenario.UID)
+	if step, ok := r.recorder.currentStep(scenario.UID); ok && step.Major == scenario.Status.StepStatus.Step.Major {
+		scenario.Status.StepStatus.Step.Minor = step.Minor
+	}
+	appendTimelineEvents(&scenario.Status.ScenarioResult, scenario.Status.StepStatus.Step.Major, events)
+	if finishStep {
+		if err := r.recordStepResult(ctx, scenario); err != nil {
+			return fmt.Errorf("record the result of step %d: %w", scenario.Status.StepStatus.Step.Major, err)
+		}
+	}
+	if err := r.Status().Update(ctx, scenario); err != nil {
+		return err
+	}
+
+	if finishStep {
+		r.recorder.stop(scenario.UID)
+		return nil
+	}
+	r.recorder.flushed(scenario.UID, len(events))
+	return nil
+}
+
+// recordStepResult records the Pods left unschedulable at the end of the step,
+// and fills the scheduling results of the Pods scheduled in the step
+// because the simulator adds them on the Pod after binding.
+func (r *ScenarioReconciler) recordStepResult(ctx context.Context, scenario *simulationv1alpha1.Scenario) error {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods); err != nil {
+		return fmt.Errorf("list pods: %w", err)
+	}
+
+	major := scenario.Status.StepStatus.Step.Major
+	minor := scenario.Status.StepStatus.Step.Minor
+	result := &scenario.Status.ScenarioResult
+	latest := map[types.NamespacedName]*corev1.Pod{}
+	unschedulable := []simulationv1alpha1.ScenarioTimelineEvent{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		latest[client.ObjectKeyFromObject(pod)] = pod
+		if pod.DeletionTimestamp != nil || !isUnschedulable(pod) {
+			continue
+		}
+		podResult := newPodResult(pod)
+		podResult.Message = unschedulableMessage(pod)
+		podResult.SchedulingResults = schedulingResults(pod)
+		unschedulable = append(unschedulable, simulationv1alpha1.ScenarioTimelineEvent{
+			ID:               string(uuid.NewUUID()),
+			Step:             simulationv1alpha1.ScenarioStep{Major: major, Minor: minor},
+			PodUnschedulable: podResult,
+		})
+	}
+
+	// fill the scheduling results with the latest Pods.
+	size := timelineSchedulingResultsSize(result)
+	step := strconv.Itoa(int(major))
+	for i := range result.Timeline[step] {
+		e := &result.Timeline[step][i]
+		if e.PodScheduled == nil || e.PodScheduled.SchedulingResults != nil {
+			continue
+		}
+		pod, ok := latest[types.NamespacedName{Namespace: e.PodScheduled.Namespace, Name: e.PodScheduled.Name}]
+		if !ok {
+			continue
+		}
+		results := schedulingResults(pod)
+		if results == nil {
+			continue
+		}
+		if s := schedulingResultsSize(results); size+s <= maxTimelineSchedulingResultsSize {
+			e.PodScheduled.SchedulingResults = results
+			size += s
+			continue
+		}
+		result.OmittedSchedulingResults++
+	}
+
+	appendTimelineEvents(result, major, unschedulable)
+	return nil
+}
+
+// appendTimelineEvents appends the events in the MajorStep to the timeline.
+// The events over maxTimelineEvents are omitted,
+// and the scheduling results in the events are omitted when they make the timeline larger than maxTimelineSchedulingResultsSize.
+func appendTimelineEvents(result *simulationv1alpha1.ScenarioResult, major int32, events []simulationv1alpha1.ScenarioTimelineEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if result.Timeline == nil {
+		result.Timeline = map[string][]simulationv1alpha1.ScenarioTimelineEvent{}
+	}
+
+	n := 0
+	for _, es := range result.Timeline {
+		n += len(es)
+	}
+	size := timelineSchedulingResultsSize(result)
+	step := strconv.Itoa(int(major))
+	for i := range events {
+		if n >= maxTimelineEvents {
+			result.OmittedEvents += int32(len(events) - i)
+			return
+		}
+		e := events[i]
+		if pr := podResultOf(&e); pr != nil && pr.SchedulingResults != nil {
+			s := schedulingResultsSize(pr.SchedulingResults)
+			if size+s > maxTimelineSchedulingResultsSize {
+				pr.SchedulingResults = nil
+				result.OmittedSchedulingResults++
+			} else {
+				size += s
+			}
+		}
+		result.Timeline[step] = append(result.Timeline[step], e)
+		n++
+	}
+}
+
+// timelineSchedulingResultsSize returns the total size of the scheduling results in the timeline.
+func timelineSchedulingResultsSize(result *simulationv1alpha1.ScenarioResult) int {
+	size := 0
+	for _, es := range result.Timeline {
+		for i := range es {
+			if pr := podResultOf(&es[i]); pr != nil {
+				size += schedulingResultsSize(pr.SchedulingResults)
+			}
+		}
+	}
+	return size
+}
+
+// podResultOf returns the PodResult in the event, or nil if the event isn't about Pods.
+func podResultOf(e *simulationv1alpha1.ScenarioTimelineEvent) *simulationv1alpha1.PodResult {
+	switch {
+	case e.PodCreated != nil:
+		return e.PodCreated
+	case e.PodScheduled != nil:
+		return e.PodScheduled
+	case e.PodPreempted != nil:
+		return e.PodPreempted
+	case e.PodDeleted != nil:
+		return e.PodDeleted
+	case e.PodUnschedulable != nil:
+		return e.PodUnschedulable
+	}
+	return nil
+}
+
+func schedulingResultsSize(results map[string]string) int {
+	size := 0
+	for k, v := range results {
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// summarizeObject returns the object which has only the type and the identity in metadata.
+// The objects in the timeline are summarized to keep the status small.
+func summarizeObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	ret := &unstructured.Unstructured{}
+	ret.SetAPIVersion(obj.GetAPIVersion())
+	ret.SetKind(obj.GetKind())
+	ret.SetNamespace(obj.GetNamespace())
+	ret.SetName(obj.GetName())
+	ret.SetUID(obj.GetUID())
+	ret.SetResourceVersion(obj.GetResourceVersion())
+	return ret
+}
+
+func newPodResult(pod *corev1.Pod) *simulationv1alpha1.PodResult {
+	return &simulationv1alpha1.PodResult{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		NodeName:  pod.Spec.NodeName,
+	}
+}
+
+// schedulingResults returns the scheduling results that the simulator records on the Pod's annotations.
+func schedulingResults(pod *corev1.Pod) map[string]string {
+	ret := map[string]string{}
+	for k, v := range pod.GetAnnotations() {
+		if strings.HasPrefix(k, schedulingResultAnnotationPrefix) {
+			ret[k] = v
+		}
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return ret
+}
+
+func isUnschedulable(pod *corev1.Pod) bool {
+	if pod.Spec.NodeName != "" {
+		return false
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+			return true
+		}
+	}
+	return false
+}
+
+func unschedulableMessage(pod *corev1.Pod) string {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled {
+			return c.Message
+		}
+	}
+	return ""
+}
+
+func isPreempted(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == podDisruptionTargetCondition && c.Reason == preemptionByKubeSchedulerReason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	simulationv1alpha1 "sigs.k8s.io/kube-scheduler-simulator/scenario/api/v1alpha1"
+)
+
+func testPod(name, nodeName string, annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID("uid-" + name), Annotations: annotations},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+// eventTypes returns the type of each event to compare the events easily.
+func eventTypes(events []simulationv1alpha1.ScenarioTimelineEvent) []string {
+	ret := []string{}
+	for i := range events {
+		e := &events[i]
+		switch {
+		case e.Create != nil:
+			ret = append(ret, "Create")
+		case e.Patch != nil:
+			ret = append(ret, "Patch")
+		case e.Delete != nil:
+			ret = append(ret, "Delete")
+		case e.Done != nil:
+			ret = append(ret, "Done")
+		case e.PodCreated != nil:
+			ret = append(ret, "PodCreated:"+e.PodCreated.Name)
+		case e.PodScheduled != nil:
+			ret = append(ret, "PodScheduled:"+e.PodScheduled.Name)
+		case e.PodPreempted != nil:
+			ret = append(ret, "PodPreempted:"+e.PodPreempted.Name)
+		case e.PodDeleted != nil:
+			ret = append(ret, "PodDeleted:"+e.PodDeleted.Name)
+		case e.PodUnschedulable != nil:
+			ret = append(ret, "PodUnschedulable:"+e.PodUnschedulable.Name)
+		}
+	}
+	return ret
+}
+
+func Test_stepResultRecorder(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	r := newStepResultRecorder()
+
+	// The events are ignored when the recorder isn't recording.
+	r.onPodAdd(testPod("ignored", "", nil))
+	g.Expect(r.pendingEvents("scenario")).To(BeEmpty())
+
+	r.start("scenario", simulationv1alpha1.ScenarioStep{Major: 1})
+	r.markOperatedPod("v1", "Pod", "default", "operated")
+	r.onPodAdd(testPod("operated", "", nil))
+	r.recordOperation(simulationv1alpha1.ScenarioTimelineEvent{ID: "create", Create: &simulationv1alpha1.CreateOperationResult{}})
+	r.onPodAdd(testPod("pod1", "", nil))
+	r.onPodUpdate(testPod("pod1", "", nil), testPod("pod1", "node1", nil))
+	// The update without binding isn't recorded.
+	r.onPodUpdate(testPod("pod1", "node1", nil), testPod("pod1", "node1", map[string]string{"scheduler-simulator/filter-result": "{}"}))
+	// The deleted Pod gets the latest scheduling results.
+	r.onPodDelete(testPod("pod1", "node1", nil))
+	preempted := testPod("pod2", "node1", nil)
+	preempted.Status.Conditions = []corev1.PodCondition{{Type: podDisruptionTargetCondition, Reason: preemptionByKubeSchedulerReason}}
+	r.onPodDelete(preempted)
+
+	events := r.pendingEvents("scenario")
+	g.Expect(eventTypes(events)).To(Equal([]string{"Create", "PodCreated:pod1", "PodScheduled:pod1", "PodDeleted:pod1", "PodPreempted:pod2"}))
+	g.Expect(events[3].PodDeleted.SchedulingResults).To(Equal(map[string]string{"scheduler-simulator/filter-result": "{}"}))
+	for i := range events {
+		g.Expect(events[i].Step.Major).To(Equal(int32(1)))
+		g.Expect(events[i].ID).NotTo(BeEmpty())
+	}
+	// MinorStep is moved when the scheduler binds or preempts a Pod.
+	minors := []int32{}
+	for i := range events {
+		minors = append(minors, events[i].Step.Minor)
+	}
+	g.Expect(minors).To(Equal([]int32{0, 0, 1, 1, 2}))
+	step, ok := r.currentStep("scenario")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(step).To(Equal(simulationv1alpha1.ScenarioStep{Major: 1, Minor: 2}))
+	_, ok = r.currentStep("another")
+	g.Expect(ok).To(BeFalse())
+	g.Expect(r.isOperated("create")).To(BeTrue())
+	// The events of other Scenarios aren't returned.
+	g.Expect(r.pendingEvents("another")).To(BeEmpty())
+
+	// The flushed events are dropped.
+	r.flushed("scenario", 2)
+	g.Expect(eventTypes(r.pendingEvents("scenario"))).To(Equal([]string{"PodScheduled:pod1", "PodDeleted:pod1", "PodPreempted:pod2"}))
+
+	// start keeps the events and MinorStep when the same step is being recorded.
+	r.start("scenario", simulationv1alpha1.ScenarioStep{Major: 1})
+	g.Expect(r.pendingEvents("scenario")).To(HaveLen(3))
+	step, _ = r.currentStep("scenario")
+	g.Expect(step.Minor).To(Equal(int32(2)))
+	// start resets the events for another step, and resumes MinorStep from the given step.
+	r.start("scenario", simulationv1alpha1.ScenarioStep{Major: 2, Minor: 3})
+	g.Expect(r.pendingEvents("scenario")).To(BeEmpty())
+	step, _ = r.currentStep("scenario")
+	g.Expect(step).To(Equal(simulationv1alpha1.ScenarioStep{Major: 2, Minor: 3}))
+
+	r.onPodAdd(testPod("pod3", "", nil))
+	// stop ignores another Scenario.
+	r.stop("another")
+	g.Expect(r.pendingEvents("scenario")).To(HaveLen(1))
+	r.stop("scenario")
+	g.Expect(r.pendingEvents("scenario")).To(BeEmpty())
+}
+
+func Test_appendTimelineEvents(t *testing.T) {
+	t.Parallel()
+
+	largeResults := map[string]string{"scheduler-simulator/filter-result": strings.Repeat("a", maxTimelineSchedulingResultsSize/2)}
+	podEvent := func(results map[string]string) simulationv1alpha1.ScenarioTimelineEvent {
+		return simulationv1alpha1.ScenarioTimelineEvent{ID: "pod", PodScheduled: &simulationv1alpha1.PodResult{Name: "pod", SchedulingResults: results}}
+	}
+	events := func(n int) []simulationv1alpha1.ScenarioTimelineEvent {
+		ret := make([]simulationv1alpha1.ScenarioTimelineEvent, n)
+		for i := range ret {
+			ret[i] = podEvent(nil)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name                         string
+		result                       simulationv1alpha1.ScenarioResult
+		events                       []simulationv1alpha1.ScenarioTimelineEvent
+		wantEvents                   map[string]int
+		wantOmittedEvents            int32
+		wantOmittedSchedulingResults int32
+	}{
+		{
+			name:       "append events to the empty timeline",
+			events:     events(2),
+			wantEvents: map[string]int{"1": 2},
+		},
+		{
+			name: "append events to the existing timeline",
+			result: simulationv1alpha1.ScenarioResult{Timeline: map[string][]simulationv1alpha1.ScenarioTimelineEvent{
+				"0": events(1),
+				"1": events(1),
+			}},
+			events:     events(2),
+			wantEvents: map[string]int{"0": 1, "1": 3},
+		},
+		{
+			name: "omit events over the limit",
+			result: simulationv1alpha1.ScenarioResult{Timeline: map[string][]simulationv1alpha1.ScenarioTimelineEvent{
+				"0": events(maxTimelineEvents - 1),
+			}},
+			events:            events(3),
+			wantEvents:        map[string]int{"0": maxTimelineEvents - 1, "1": 1},
+			wantOmittedEvents: 2,
+		},
+		{
+			name:                         "omit scheduling results over the limit",
+			events:                       []simulationv1alpha1.ScenarioTimelineEvent{podEvent(largeResults), podEvent(largeResults), podEvent(largeResults)},
+			wantEvents:                   map[string]int{"1": 3},
+			wantOmittedSchedulingResults: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			appendTimelineEvents(&tt.result, 1, tt.events)
+
+			got := map[string]int{}
+			for step, es := range tt.result.Timeline {
+				got[step] = len(es)
+			}
+			g.Expect(got).To(Equal(tt.wantEvents))
+			g.Expect(tt.result.OmittedEvents).To(Equal(tt.wantOmittedEvents))
+			g.Expect(tt.result.OmittedSchedulingResults).To(Equal(tt.wantOmittedSchedulingResults))
+			g.Expect(timelineSchedulingResultsSize(&tt.result)).To(BeNumerically("<=", maxTimelineSchedulingResultsSize))
+		})
+	}
+}
+
+func TestScenarioReconciler_recordStepResult(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	results := map[string]string{"scheduler-simulator/filter-result": "{}"}
+	scheduled := testPod("scheduled", "node1", results)
+	unschedulable := testPod("unschedulable", "", results)
+	unschedulable.Status.Conditions = []corev1.PodCondition{{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Reason:  corev1.PodReasonUnschedulable,
+		Message: "0/1 nodes are available",
+	}}
+	r := newTestReconciler(t, scheduled, unschedulable)
+
+	scenario := &simulationv1alpha1.Scenario{Status: simulationv1alpha1.ScenarioStatus{
+		StepStatus: simulationv1alpha1.ScenarioStepStatus{Step: simulationv1alpha1.ScenarioStep{Major: 1}},
+		ScenarioResult: simulationv1alpha1.ScenarioResult{Timeline: map[string][]simulationv1alpha1.ScenarioTimelineEvent{
+			"1": {{ID: "1", PodScheduled: &simulationv1alpha1.PodResult{Namespace: "default", Name: "scheduled", NodeName: "node1"}}},
+		}},
+	}}
+	g.Expect(r.recordStepResult(context.Background(), scenario)).To(Succeed())
+
+	timeline := scenario.Status.ScenarioResult.Timeline["1"]
+	g.Expect(eventTypes(timeline)).To(Equal([]string{"PodScheduled:scheduled", "PodUnschedulable:unschedulable"}))
+	// The scheduling results are filled with the latest Pod.
+	g.Expect(timeline[0].PodScheduled.SchedulingResults).To(Equal(results))
+	g.Expect(timeline[1].PodUnschedulable.Message).To(Equal("0/1 nodes are available"))
+	g.Expect(timeline[1].PodUnschedulable.SchedulingResults).To(Equal(results))
+}
+
+func TestScenarioReconciler_runScenarioStep_restartControllerRunning(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	pod := testPod("pod1", "", nil)
+	scenario := &simulationv1alpha1.Scenario{
+		ObjectMeta: metav1.ObjectMeta{Name: "scenario", UID: "scenario"},
+		Status: simulationv1alpha1.ScenarioStatus{
+			Phase:      simulationv1alpha1.ScenarioRunning,
+			StepStatus: simulationv1alpha1.ScenarioStepStatus{Phase: simulationv1alpha1.ControllerRunning},
+			// The event flushed before the controller restarts.
+			ScenarioResult: simulationv1alpha1.ScenarioResult{Timeline: map[string][]simulationv1alpha1.ScenarioTimelineEvent{
+				"0": {{ID: "done", Done: &simulationv1alpha1.DoneOperationResult{}}},
+			}},
+		},
+	}
+	// The recorder is empty because the controller has restarted.
+	r := newTestReconciler(t, scenario, pod)
+
+	// The recording is resumed, and the events are flushed while waiting for the scheduler.
+	_, err := r.runScenarioStep(ctx, scenario.DeepCopy())
+	g.Expect(err).NotTo(HaveOccurred())
+	r.recorder.onPodUpdate(pod, testPod("pod1", "node1", nil))
+	got := &simulationv1alpha1.Scenario{}
+	g.Expect(r.Get(ctx, client.ObjectKeyFromObject(scenario), got)).To(Succeed())
+	_, err = r.runScenarioStep(ctx, got)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(r.Get(ctx, client.ObjectKeyFromObject(scenario), got)).To(Succeed())
+	g.Expect(got.Status.StepStatus.Phase).To(Equal(simulationv1alpha1.ControllerRunning))
+	g.Expect(eventTypes(got.Status.ScenarioResult.Timeline["0"])).To(Equal([]string{"Done", "PodScheduled:pod1"}))
+	g.Expect(r.recorder.pendingEvents(scenario.UID)).To(BeEmpty())
+}
@@ -18,45 +18,321 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	simulationv1alpha1 "sigs.k8s.io/kube-scheduler-simulator/scenario/api/v1alpha1"
 )
 
+const (
+	// waitInterval is the interval to check whether the scheduler has finished working on the current cluster state.
+	waitInterval = 1 * time.Second
+	// pendingInterval is the interval to check whether another running Scenario has finished.
+	pendingInterval = 5 * time.Second
+)
+
 // ScenarioReconciler reconciles a Scenario object
 type ScenarioReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// recorder records what happens in the current step of the running Scenario.
+	recorder *stepResultRecorder
 }
 
 //+kubebuilder:rbac:groups=simulation.kube-scheduler-simulator.x-k8s.io,resources=scenarios,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=simulation.kube-scheduler-simulator.x-k8s.io,resources=scenarios/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=simulation.kube-scheduler-simulator.x-k8s.io,resources=scenarios/finalizers,verbs=update
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the Scenario object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
+// The following are the resources that ScenarioOperations can operate.
+// Grant the controller the permissions by yourself when you want to operate other resources in your Scenario.
+//+kubebuilder:rbac:groups="",resources=pods;nodes;namespaces;persistentvolumes;persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets;statefulsets;daemonsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile runs the Scenario step by step.
+//
+// In each MajorStep, the operations defined for that step in .spec.operations are operated against kube-apiserver first.
+// After that, the reconciler waits for the scheduler to handle all Pods,
+// and then, moves .status.stepStatus.step to the next MajorStep which has some operations.
+//
+// Each phase transition is recorded in .status.stepStatus.phase, and the reconciler updates the status once per call.
+// The next call is triggered by the update event of the Scenario itself.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.1/pkg/reconcile
+//
+//nolint:cyclop
 func (r *ScenarioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+	logger := log.FromContext(ctx)
+
+	scenario := &simulationv1alpha1.Scenario{}
+	if err := r.Get(ctx, req.NamespacedName, scenario); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch scenario.Status.Phase {
+	case simulationv1alpha1.ScenarioSucceeded, simulationv1alpha1.ScenarioFailed:
+		// The Scenario has already finished.
+		return ctrl.Result{}, nil
+	case "", simulationv1alpha1.ScenarioPending:
+		return r.startScenario(ctx, scenario)
+	case simulationv1alpha1.ScenarioPaused:
+		return r.resumeScenario(ctx, scenario)
+	case simulationv1alpha1.ScenarioRunning:
+		return r.runScenarioStep(ctx, scenario)
+	default:
+		logger.Info("the Scenario has unknown phase", "phase", scenario.Status.Phase)
+		return ctrl.Result{}, nil
+	}
+}
+
+// startScenario starts the Scenario if no other Scenario is running.
+// Scenarios run one by one, and multiple Scenarios are never run simultaneously.
+func (r *ScenarioReconciler) startScenario(ctx context.Context, scenario *simulationv1alpha1.Scenario) (ctrl.Result, error) {
+	running, err := r.anotherScenarioIsRunning(ctx, scenario.Name)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("check another Scenario is running: %w", err)
+	}
+	if running {
+		if scenario.Status.Phase != simulationv1alpha1.ScenarioPending {
+			scenario.Status.Phase = simulationv1alpha1.ScenarioPending
+			if err := r.Status().Update(ctx, scenario); err != nil {
+				return ctrl.Result{}, fmt.Errorf("update the Scenario status to Pending: %w", err)
+			}
+		}
+		return ctrl.Result{RequeueAfter: pendingInterval}, nil
+	}
 
-	// TODO(user): your logic here
+	if err := validateOperations(scenario.Spec.Operations); err != nil {
+		return ctrl.Result{}, r.failScenario(ctx, scenario, err.Error())
+	}
 
+	first, ok := nextMajorStep(scenario.Spec.Operations, -1)
+	if !ok {
+		// No operation is defined yet.
+		first = 0
+	}
+	scenario.Status.Phase = simulationv1alpha1.ScenarioRunning
+	scenario.Status.StepStatus = simulationv1alpha1.ScenarioStepStatus{
+		Step:  simulationv1alpha1.ScenarioStep{Major: first},
+		Phase: simulationv1alpha1.Operating,
+	}
+	if err := r.Status().Update(ctx, scenario); err != nil {
+		return ctrl.Result{}, fmt.Errorf("update the Scenario status to Running: %w", err)
+	}
 	return ctrl.Result{}, nil
 }
 
+// resumeScenario moves the paused Scenario to the next MajorStep
+// when some operations are newly added for the future MajorStep.
+func (r *ScenarioReconciler) resumeScenario(ctx context.Context, scenario *simulationv1alpha1.Scenario) (ctrl.Result, error) {
+	if err := validateOperations(scenario.Spec.Operations); err != nil {
+		return ctrl.Result{}, r.failScenario(ctx, scenario, err.Error())
+	}
+
+	next, ok := nextMajorStep(scenario.Spec.Operations, scenario.Status.StepStatus.Step.Major)
+	if !ok {
+		// no operations are added yet.
+		return ctrl.Result{}, nil
+	}
+
+	scenario.Status.Phase = simulationv1alpha1.ScenarioRunning
+	scenario.Status.StepStatus = simulationv1alpha1.ScenarioStepStatus{
+		Step:  simulationv1alpha1.ScenarioStep{Major: next},
+		Phase: simulationv1alpha1.Operating,
+	}
+	if err := r.Status().Update(ctx, scenario); err != nil {
+		return ctrl.Result{}, fmt.Errorf("update the Scenario status to Running: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// runScenarioStep moves the current MajorStep forward by one StepPhase.
+//
+//nolint:cyclop
+func (r *ScenarioReconciler) runScenarioStep(ctx context.Context, scenario *simulationv1alpha1.Scenario) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	status := &scenario.Status.StepStatus
+	major := status.Step.Major
+	// finishStep is set when the events in the step are all recorded.
+	finishStep := false
+	if status.Phase != simulationv1alpha1.StepCompleted {
+		// (re)start recording the step. It's needed here to resume the recording after the controller restarts.
+		r.recorder.start(scenario.UID, status.Step)
+	}
+
+	switch status.Phase {
+	case "", simulationv1alpha1.Operating:
+		if err := validateOperations(scenario.Spec.Operations); err != nil {
+			return ctrl.Result{}, r.failScenario(ctx, scenario, err.Error())
+		}
+		for _, op := range operationsForMajorStep(scenario.Spec.Operations, major) {
+			if isOperated(status.OperatedIDs, op.ID) || r.recorder.isOperated(op.ID) {
+				// The operation was done before the controller restarted, or in the previous try whose status update failed.
+				continue
+			}
+			if err := r.runOperation(ctx, op); err != nil {
+				return ctrl.Result{}, r.failScenario(ctx, scenario, fmt.Sprintf("operation %q at step %d failed: %v", op.ID, major, err))
+			}
+			// persist the operation one by one so that it isn't operated again even if the controller restarts.
+			status.OperatedIDs = append(status.OperatedIDs, op.ID)
+			if err := r.updateStatus(ctx, scenario, false); err != nil {
+				return ctrl.Result{}, fmt.Errorf("update the Scenario status after operation %q: %w", op.ID, err)
+			}
+		}
+		now := metav1.NowMicro()
+		status.OperatingCompletedTime = &now
+		status.Phase = simulationv1alpha1.OperatingCompleted
+	case simulationv1alpha1.OperatingCompleted:
+		status.Phase = simulationv1alpha1.ControllerRunning
+	case simulationv1alpha1.ControllerRunning:
+		operatedAt := time.Time{}
+		if status.OperatingCompletedTime != nil {
+			operatedAt = status.OperatingCompletedTime.Time
+		}
+		condition, err := newSchedulerWaiter(r.Client, operatedAt).WaitConditionFunc(ctx)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("get the wait condition of the scheduler: %w", err)
+		}
+		done, err := condition()
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("check the scheduler has finished: %w", err)
+		}
+		if !done {
+			// flush the events so far so that they aren't lost even if the controller restarts.
+			if len(r.recorder.pendingEvents(scenario.UID)) != 0 {
+				if err := r.updateStatus(ctx, scenario, false); err != nil {
+					return ctrl.Result{}, fmt.Errorf("update the Scenario status: %w", err)
+				}
+			}
+			return ctrl.Result{RequeueAfter: waitInterval}, nil
+		}
+		status.Phase = simulationv1alpha1.ControllerCompleted
+	case simulationv1alpha1.ControllerCompleted:
+		finishStep = true
+		status.Phase = simulationv1alpha1.StepCompleted
+	case simulationv1alpha1.StepCompleted:
+		if hasDoneOperation(operationsForMajorStep(scenario.Spec.Operations, major)) {
+			logger.Info("the Scenario is marked as done", "step", major)
+			scenario.Status.Phase = simulationv1alpha1.ScenarioSucceeded
+			break
+		}
+		next, ok := nextMajorStep(scenario.Spec.Operations, major)
+		if !ok {
+			// All operations have been finished, but the Scenario isn't marked as done.
+			scenario.Status.Phase = simulationv1alpha1.ScenarioPaused
+			break
+		}
+		status.Step = simulationv1alpha1.ScenarioStep{Major: next}
+		status.Phase = simulationv1alpha1.Operating
+	default:
+		return ctrl.Result{}, r.failScenario(ctx, scenario, fmt.Sprintf("unknown step phase: %s", status.Phase))
+	}
+
+	if err := r.updateStatus(ctx, scenario, finishStep); err != nil {
+		return ctrl.Result{}, fmt.Errorf("update the Scenario status: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// failScenario changes the Scenario's phase to Failed with the message.
+func (r *ScenarioReconciler) failScenario(ctx context.Context, scenario *simulationv1alpha1.Scenario, msg string) error {
+	log.FromContext(ctx).Info("the Scenario failed", "message", msg)
+
+	// keep what happened in the step until the failure.
+	finishStep := scenario.Status.Phase == simulationv1alpha1.ScenarioRunning
+	scenario.Status.Phase = simulationv1alpha1.ScenarioFailed
+	scenario.Status.Message = &msg
+	if err := r.updateStatus(ctx, scenario, finishStep); err != nil {
+		return fmt.Errorf("update the Scenario status to Failed: %w", err)
+	}
+	return nil
+}
+
+// anotherScenarioIsRunning checks whether any Scenario other than the given one is running.
+func (r *ScenarioReconciler) anotherScenarioIsRunning(ctx context.Context, name string) (bool, error) {
+	scenarios := &simulationv1alpha1.ScenarioList{}
+	if err := r.List(ctx, scenarios); err != nil {
+		return false, fmt.Errorf("list Scenarios: %w", err)
+	}
+	for _, s := range scenarios.Items {
+		if s.Name == name {
+			continue
+		}
+		if s.Status.Phase == simulationv1alpha1.ScenarioRunning {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// operationsForMajorStep returns operations that should be done in the given MajorStep.
+func operationsForMajorStep(ops []*simulationv1alpha1.ScenarioOperation, major int32) []*simulationv1alpha1.ScenarioOperation {
+	ret := []*simulationv1alpha1.ScenarioOperation{}
+	for _, op := range ops {
+		if op.MajorStep == major {
+			ret = append(ret, op)
+		}
+	}
+	return ret
+}
+
+// nextMajorStep returns the smallest MajorStep which is bigger than current and has some operations.
+// It returns false when no such MajorStep exists.
+func nextMajorStep(ops []*simulationv1alpha1.ScenarioOperation, current int32) (int32, bool) {
+	steps := []int32{}
+	for _, op := range ops {
+		if op.MajorStep > current {
+			steps = append(steps, op.MajorStep)
+		}
+	}
+	if len(steps) == 0 {
+		return 0, false
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+	return steps[0], true
+}
+
+// isOperated checks whether the operation is in the IDs of the operated operations.
+func isOperated(operatedIDs []string, id string) bool {
+	for _, operated := range operatedIDs {
+		if operated == id {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDoneOperation(ops []*simulationv1alpha1.ScenarioOperation) bool {
+	for _, op := range ops {
+		if op.Done != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ScenarioReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.recorder == nil {
+		r.recorder = newStepResultRecorder()
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&simulationv1alpha1.Scenario{}).
+		// Pods are watched only to record the events in ScenarioResult.
+		Watches(&source.Kind{Type: &corev1.Pod{}}, r.recorder.podEventHandler()).
 		Complete(r)
 }
@@ -0,0 +1,301 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	simulationv1alpha1 "sigs.k8s.io/kube-scheduler-simulator/scenario/api/v1alpha1"
+)
+
+// failingStatusClient fails the status updates as many times as failures.
+type failingStatusClient struct {
+	client.Client
+	failures int
+}
+
+func (c *failingStatusClient) Status() client.StatusWriter {
+	return &failingStatusWriter{StatusWriter: c.Client.Status(), c: c}
+}
+
+type failingStatusWriter struct {
+	client.StatusWriter
+	c *failingStatusClient
+}
+
+func (w *failingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if w.c.failures > 0 {
+		w.c.failures--
+		return errors.New("status update failed")
+	}
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func TestScenarioReconciler_runScenarioStep_retryOperating(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scenario := &simulationv1alpha1.Scenario{
+		ObjectMeta: metav1.ObjectMeta{Name: "scenario"},
+		Spec: simulationv1alpha1.ScenarioSpec{Operations: []*simulationv1alpha1.ScenarioOperation{
+			{ID: "create", Create: &simulationv1alpha1.CreateOperation{Object: podObject("default", "pod1")}},
+			{ID: "delete", Delete: &simulationv1alpha1.DeleteOperation{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod2"},
+			}},
+		}},
+		Status: simulationv1alpha1.ScenarioStatus{
+			Phase:      simulationv1alpha1.ScenarioRunning,
+			StepStatus: simulationv1alpha1.ScenarioStepStatus{Phase: simulationv1alpha1.Operating},
+		},
+	}
+	pod2 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod2"}}
+	r := newTestReconciler(t, scenario, pod2)
+	r.Client = &failingStatusClient{Client: r.Client, failures: 1}
+
+	// The operations are done, but the status update fails.
+	_, err := r.runScenarioStep(ctx, scenario.DeepCopy())
+	g.Expect(err).To(HaveOccurred())
+
+	// The retry doesn't fail even though the operations have already been done.
+	got := &simulationv1alpha1.Scenario{}
+	g.Expect(r.Get(ctx, client.ObjectKeyFromObject(scenario), got)).To(Succeed())
+	_, err = r.runScenarioStep(ctx, got)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(r.Get(ctx, client.ObjectKeyFromObject(scenario), got)).To(Succeed())
+	g.Expect(got.Status.Phase).To(Equal(simulationv1alpha1.ScenarioRunning))
+	g.Expect(got.Status.StepStatus.Phase).To(Equal(simulationv1alpha1.OperatingCompleted))
+	g.Expect(got.Status.StepStatus.OperatingCompletedTime).NotTo(BeNil())
+
+	// The operations are recorded only once.
+	ids := []string{}
+	for _, e := range got.Status.ScenarioResult.Timeline["0"] {
+		ids = append(ids, e.ID)
+	}
+	g.Expect(ids).To(Equal([]string{"create", "delete"}))
+}
+
+func TestScenarioReconciler_runScenarioStep_restartOperating(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scenario := &simulationv1alpha1.Scenario{
+		ObjectMeta: metav1.ObjectMeta{Name: "scenario"},
+		Spec: simulationv1alpha1.ScenarioSpec{Operations: []*simulationv1alpha1.ScenarioOperation{
+			{ID: "create", Create: &simulationv1alpha1.CreateOperation{Object: podObject("default", "pod1")}},
+			{ID: "delete", Delete: &simulationv1alpha1.DeleteOperation{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod2"},
+			}},
+		}},
+		Status: simulationv1alpha1.ScenarioStatus{
+			Phase:      simulationv1alpha1.ScenarioRunning,
+			StepStatus: simulationv1alpha1.ScenarioStepStatus{Phase: simulationv1alpha1.Operating},
+		},
+	}
+	// The operations were done before the controller restarted, but the status wasn't updated:
+	// pod1 is created and pod2 is deleted.
+	pod1 := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod1"},
+		Spec:       corev1.PodSpec{Containers: testContainers()},
+	}
+	r := newTestReconciler(t, scenario, pod1)
+
+	_, err := r.runScenarioStep(ctx, scenario.DeepCopy())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := &simulationv1alpha1.Scenario{}
+	g.Expect(r.Get(ctx, client.ObjectKeyFromObject(scenario), got)).To(Succeed())
+	g.Expect(got.Status.Phase).To(Equal(simulationv1alpha1.ScenarioRunning))
+	g.Expect(got.Status.StepStatus.Phase).To(Equal(simulationv1alpha1.OperatingCompleted))
+	g.Expect(got.Status.StepStatus.OperatedIDs).To(Equal([]string{"create", "delete"}))
+}
+
+func TestScenarioReconciler_runScenarioStep_restartOperatingWithOperatedIDs(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scenario := &simulationv1alpha1.Scenario{
+		ObjectMeta: metav1.ObjectMeta{Name: "scenario"},
+		Spec: simulationv1alpha1.ScenarioSpec{Operations: []*simulationv1alpha1.ScenarioOperation{
+			{ID: "patch", Patch: &simulationv1alpha1.PatchOperation{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod1"},
+				Patch:      `[{"op":"add","path":"/spec/containers/-","value":{"name":"sidecar","image":"k8s.gcr.io/pause:3.5"}}]`,
+				PatchType:  "application/json-patch+json",
+			}},
+			{ID: "delete", Delete: &simulationv1alpha1.DeleteOperation{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod2"},
+			}},
+		}},
+		Status: simulationv1alpha1.ScenarioStatus{
+			Phase: simulationv1alpha1.ScenarioRunning,
+			StepStatus: simulationv1alpha1.ScenarioStepStatus{
+				Phase: simulationv1alpha1.Operating,
+				// The patch was recorded before the controller restarted.
+				OperatedIDs: []string{"patch"},
+			},
+		},
+	}
+	pod1 := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod1"},
+		Spec:       corev1.PodSpec{Containers: append(testContainers(), corev1.Container{Name: "sidecar", Image: "k8s.gcr.io/pause:3.5"})},
+	}
+	pod2 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod2"}}
+	r := newTestReconciler(t, scenario, pod1, pod2)
+
+	_, err := r.runScenarioStep(ctx, scenario.DeepCopy())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := &simulationv1alpha1.Scenario{}
+	g.Expect(r.Get(ctx, client.ObjectKeyFromObject(scenario), got)).To(Succeed())
+	g.Expect(got.Status.StepStatus.Phase).To(Equal(simulationv1alpha1.OperatingCompleted))
+	g.Expect(got.Status.StepStatus.OperatedIDs).To(Equal([]string{"patch", "delete"}))
+	ids := []string{}
+	for _, e := range got.Status.ScenarioResult.Timeline["0"] {
+		ids = append(ids, e.ID)
+	}
+	g.Expect(ids).To(Equal([]string{"delete"}))
+
+	// The patch, which isn't idempotent, isn't applied again.
+	pod := &corev1.Pod{}
+	g.Expect(r.Get(ctx, client.ObjectKeyFromObject(pod1), pod)).To(Succeed())
+	g.Expect(pod.Spec.Containers).To(HaveLen(2))
+	err = r.Get(ctx, client.ObjectKeyFromObject(pod2), pod)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+// reconcileUntil calls Reconcile until the Scenario gets the phase, and returns the Scenario.
+func reconcileUntil(ctx context.Context, t *testing.T, r *ScenarioReconciler, name string, phase simulationv1alpha1.ScenarioPhase) *simulationv1alpha1.Scenario {
+	t.Helper()
+
+	scenario := &simulationv1alpha1.Scenario{}
+	for i := 0; i < 20; i++ {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}}); err != nil {
+			t.Fatalf("reconcile: %v", err)
+		}
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, scenario); err != nil {
+			t.Fatalf("get the Scenario: %v", err)
+		}
+		if scenario.Status.Phase == phase {
+			return scenario
+		}
+	}
+	t.Fatalf("the Scenario doesn't get %s phase: %s", phase, scenario.Status.Phase)
+	return nil
+}
+
+func TestScenarioReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	node := targetObject("v1", "Node", "", "node1")
+	scenario := &simulationv1alpha1.Scenario{
+		ObjectMeta: metav1.ObjectMeta{Name: "scenario"},
+		Spec: simulationv1alpha1.ScenarioSpec{Operations: []*simulationv1alpha1.ScenarioOperation{
+			{ID: "create-node", MajorStep: 1, Create: &simulationv1alpha1.CreateOperation{Object: node}},
+		}},
+	}
+	r := newTestReconciler(t, scenario)
+
+	// The Scenario is paused after all operations because it isn't marked as done.
+	got := reconcileUntil(ctx, t, r, scenario.Name, simulationv1alpha1.ScenarioPaused)
+	g.Expect(got.Status.StepStatus.Step.Major).To(Equal(int32(1)))
+	g.Expect(got.Status.ScenarioResult.Timeline).To(HaveKey("1"))
+	g.Expect(got.Status.ScenarioResult.Timeline["1"][0].ID).To(Equal("create-node"))
+	g.Expect(r.Get(ctx, types.NamespacedName{Name: "node1"}, &corev1.Node{})).To(Succeed())
+
+	// The Scenario is resumed when the operations are added.
+	got.Spec.Operations = append(got.Spec.Operations,
+		&simulationv1alpha1.ScenarioOperation{ID: "delete-node", MajorStep: 3, Delete: &simulationv1alpha1.DeleteOperation{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		}},
+		&simulationv1alpha1.ScenarioOperation{ID: "done", MajorStep: 3, Done: &simulationv1alpha1.DoneOperation{}},
+	)
+	g.Expect(r.Update(ctx, got)).To(Succeed())
+
+	got = reconcileUntil(ctx, t, r, scenario.Name, simulationv1alpha1.ScenarioSucceeded)
+	g.Expect(got.Status.StepStatus.Step.Major).To(Equal(int32(3)))
+	ids := []string{}
+	for _, e := range got.Status.ScenarioResult.Timeline["3"] {
+		ids = append(ids, e.ID)
+	}
+	g.Expect(ids).To(Equal([]string{"delete-node", "done"}))
+	err := r.Get(ctx, types.NamespacedName{Name: "node1"}, &corev1.Node{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestScenarioReconciler_Reconcile_pending(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	running := &simulationv1alpha1.Scenario{
+		ObjectMeta: metav1.ObjectMeta{Name: "running"},
+		Status:     simulationv1alpha1.ScenarioStatus{Phase: simulationv1alpha1.ScenarioRunning},
+	}
+	scenario := &simulationv1alpha1.Scenario{ObjectMeta: metav1.ObjectMeta{Name: "scenario"}}
+	r := newTestReconciler(t, running, scenario)
+
+	// The Scenario waits for another Scenario to finish.
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: scenario.Name}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(pendingInterval))
+	got := &simulationv1alpha1.Scenario{}
+	g.Expect(r.Get(ctx, types.NamespacedName{Name: scenario.Name}, got)).To(Succeed())
+	g.Expect(got.Status.Phase).To(Equal(simulationv1alpha1.ScenarioPending))
+}
+
+func TestScenarioReconciler_Reconcile_failed(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scenario := &simulationv1alpha1.Scenario{
+		ObjectMeta: metav1.ObjectMeta{Name: "scenario"},
+		Spec: simulationv1alpha1.ScenarioSpec{Operations: []*simulationv1alpha1.ScenarioOperation{
+			{ID: "patch-missing", Patch: &simulationv1alpha1.PatchOperation{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+				ObjectMeta: metav1.ObjectMeta{Name: "missing"},
+				Patch:      `{"metadata":{"labels":{"app":"test"}}}`,
+				PatchType:  "application/merge-patch+json",
+			}},
+		}},
+	}
+	r := newTestReconciler(t, scenario)
+
+	got := reconcileUntil(ctx, t, r, scenario.Name, simulationv1alpha1.ScenarioFailed)
+	g.Expect(got.Status.Message).NotTo(BeNil())
+	g.Expect(*got.Status.Message).To(ContainSubstring("patch-missing"))
+}
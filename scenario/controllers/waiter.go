@@ -0,0 +1,121 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ControllerWaiter is used to know when a cluster state gets converged by the controller.
+type ControllerWaiter interface {
+	// Name returns the controller's name.
+	Name() string
+	// WaitConditionFunc returns wait.ConditionFunc that detects when the controller cannot do anything in this cluster state.
+	WaitConditionFunc(ctx context.Context) (wait.ConditionFunc, error)
+}
+
+const (
+	// attemptTimestampAnnotationKey has the time when the scheduling attempt whose results are reflected on the Pod started.
+	// The simulator adds it on the Pod with the other scheduling results.
+	attemptTimestampAnnotationKey = "scheduler-simulator/attempt-timestamp"
+	// podMaxBackoffDuration is the maximum backoff of the scheduler's queue by default.
+	// The scheduler retries the Pod within this duration after the operations if they may make the Pod schedulable.
+	podMaxBackoffDuration = 10 * time.Second
+)
+
+// schedulerWaiter is the ControllerWaiter for the scheduler.
+type schedulerWaiter struct {
+	client client.Reader
+	// operatedAt is the time when the operations in the current step were finished.
+	operatedAt time.Time
+	// now returns the current time. It's replaced in tests.
+	now func() time.Time
+}
+
+var _ ControllerWaiter = &schedulerWaiter{}
+
+func newSchedulerWaiter(c client.Reader, operatedAt time.Time) *schedulerWaiter {
+	return &schedulerWaiter{client: c, operatedAt: operatedAt, now: time.Now}
+}
+
+func (w *schedulerWaiter) Name() string {
+	return "scheduler"
+}
+
+// WaitConditionFunc returns the condition that is satisfied when every Pod is bound to a Node or is marked as unschedulable,
+// which means the scheduler can no longer do anything with the current cluster state.
+func (w *schedulerWaiter) WaitConditionFunc(ctx context.Context) (wait.ConditionFunc, error) {
+	return func() (bool, error) {
+		pods := &corev1.PodList{}
+		if err := w.client.List(ctx, pods); err != nil {
+			return false, fmt.Errorf("list pods: %w", err)
+		}
+		for i := range pods.Items {
+			if !w.podIsHandledByScheduler(&pods.Items[i]) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}, nil
+}
+
+// podIsHandledByScheduler checks whether the scheduler has finished working on the Pod.
+func (w *schedulerWaiter) podIsHandledByScheduler(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		// The Pod is going to be deleted.
+		return true
+	}
+	if pod.Spec.NodeName != "" {
+		// The Pod has already been bound.
+		return true
+	}
+	if !isUnschedulable(pod) {
+		return false
+	}
+
+	// The scheduler has given up scheduling the Pod in this cluster state
+	// only if the Pod is marked as unschedulable by the attempt after the operations.
+	// The Pod marked before them may become schedulable by the operations, and thus, is given time to be retried.
+	attemptedAt, ok := lastAttemptTime(pod)
+	if ok && !attemptedAt.Before(w.operatedAt) {
+		return true
+	}
+	return w.now().Sub(w.operatedAt) >= podMaxBackoffDuration
+}
+
+// lastAttemptTime returns the time of the scheduling attempt which marks the Pod as unschedulable.
+// It prefers the attempt timestamp added by the simulator,
+// and falls back to the last transition time of the PodScheduled condition, which has only second precision.
+func lastAttemptTime(pod *corev1.Pod) (time.Time, bool) {
+	if v, ok := pod.GetAnnotations()[attemptTimestampAnnotationKey]; ok {
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, true
+		}
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && !c.LastTransitionTime.IsZero() {
+			return c.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
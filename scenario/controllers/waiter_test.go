@@ -0,0 +1,138 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func unschedulablePod(name string, transitionedAt time.Time, annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type:               corev1.PodScheduled,
+			Status:             corev1.ConditionFalse,
+			Reason:             corev1.PodReasonUnschedulable,
+			LastTransitionTime: metav1.NewTime(transitionedAt),
+		}}},
+	}
+}
+
+func Test_schedulerWaiter_podIsHandledByScheduler(t *testing.T) {
+	t.Parallel()
+
+	operatedAt := time.Date(2022, 1, 1, 0, 0, 10, 500000000, time.UTC)
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		now  time.Time
+		want bool
+	}{
+		{
+			name: "bound Pod",
+			pod:  &corev1.Pod{Spec: corev1.PodSpec{NodeName: "node1"}},
+			now:  operatedAt,
+			want: true,
+		},
+		{
+			name: "deleting Pod",
+			pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{Time: operatedAt}}},
+			now:  operatedAt,
+			want: true,
+		},
+		{
+			name: "Pod which isn't scheduled yet",
+			pod:  &corev1.Pod{},
+			now:  operatedAt.Add(time.Hour),
+			want: false,
+		},
+		{
+			name: "Pod marked as unschedulable by the attempt after the operations",
+			pod: unschedulablePod("pod1", operatedAt.Add(-time.Minute), map[string]string{
+				attemptTimestampAnnotationKey: operatedAt.Add(time.Millisecond).Format(time.RFC3339Nano),
+			}),
+			now:  operatedAt.Add(time.Second),
+			want: true,
+		},
+		{
+			name: "Pod marked as unschedulable by the attempt before the operations",
+			pod: unschedulablePod("pod1", operatedAt.Add(-time.Minute), map[string]string{
+				attemptTimestampAnnotationKey: operatedAt.Add(-time.Millisecond).Format(time.RFC3339Nano),
+			}),
+			now:  operatedAt.Add(time.Second),
+			want: false,
+		},
+		{
+			name: "Pod marked as unschedulable after the operations without the attempt timestamp",
+			pod:  unschedulablePod("pod1", operatedAt.Add(time.Second), nil),
+			now:  operatedAt.Add(time.Second),
+			want: true,
+		},
+		{
+			name: "Pod marked as unschedulable before the operations without the attempt timestamp",
+			pod:  unschedulablePod("pod1", operatedAt.Add(-time.Minute), nil),
+			now:  operatedAt.Add(time.Second),
+			want: false,
+		},
+		{
+			name: "Pod which isn't retried after the operations for a while",
+			pod:  unschedulablePod("pod1", operatedAt.Add(-time.Minute), nil),
+			now:  operatedAt.Add(podMaxBackoffDuration),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			w := newSchedulerWaiter(nil, operatedAt)
+			w.now = func() time.Time { return tt.now }
+			g.Expect(w.podIsHandledByScheduler(tt.pod)).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_schedulerWaiter_WaitConditionFunc(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	operatedAt := time.Now()
+	bound := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bound"}, Spec: corev1.PodSpec{NodeName: "node1"}}
+	stale := unschedulablePod("stale", operatedAt.Add(-time.Minute), nil)
+	r := newTestReconciler(t, bound, stale)
+
+	condition, err := newSchedulerWaiter(r.Client, operatedAt).WaitConditionFunc(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	done, err := condition()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+
+	stale.Annotations = map[string]string{attemptTimestampAnnotationKey: time.Now().Format(time.RFC3339Nano)}
+	g.Expect(r.Update(ctx, stale)).To(Succeed())
+	done, err = condition()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+}
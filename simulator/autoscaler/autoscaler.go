@@ -0,0 +1,279 @@
+package autoscaler
+
+//go:generate mockgen -destination=./mock_$GOPACKAGE/pod.go . PodService
+//go:generate mockgen -destination=./mock_$GOPACKAGE/node.go . NodeService
+//go:generate mockgen -destination=./mock_$GOPACKAGE/nodegroup.go . NodeGroupService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/nodegroup"
+)
+
+const (
+	defaultScanInterval                  = 10 * time.Second
+	defaultScaleDownUtilizationThreshold = 0.5
+	defaultScaleDownUnneededTime         = 10 * time.Minute
+	defaultScaleDownDelayAfterAdd        = 10 * time.Minute
+	// maxEvents is the number of the latest events kept in Status.
+	maxEvents = 100
+)
+
+var ErrInvalidConfig = errors.New("invalid autoscaler config")
+
+// PodService represents service for manage Pods.
+type PodService interface {
+	List(ctx context.Context, namespace string) (*corev1.PodList, error)
+}
+
+// NodeService represents service for manage Nodes.
+type NodeService interface {
+	List(ctx context.Context) (*corev1.NodeList, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// NodeGroupService represents service for the groups of Nodes generated from the templates.
+type NodeGroupService interface {
+	Add(ctx context.Context, g nodegroup.NodeGroup, indexes []int) ([]string, error)
+}
+
+// Service simulates the cluster autoscaler.
+// It adds the Nodes to the node groups for the unschedulable Pods, and removes the underused Nodes.
+type Service struct {
+	podService       PodService
+	nodeService      NodeService
+	nodeGroupService NodeGroupService
+
+	mu sync.Mutex
+	// cancel stops the running autoscaler, and done is closed when it stops. They are nil when it's disabled.
+	cancel context.CancelFunc
+	done   chan struct{}
+	status Status
+}
+
+// Config is the configuration of the autoscaler.
+type Config struct {
+	// NodeGroups are the node groups which the autoscaler manages.
+	// When a Pod fits in more than one group, the first one in the list is scaled up.
+	NodeGroups []NodeGroup `json:"nodeGroups"`
+	// ScanInterval is how often the Pods and the Nodes are checked. It defaults to 10s.
+	ScanInterval metav1.Duration `json:"scanInterval,omitempty"`
+	// ScaleDownDisabled disables removing the underused Nodes.
+	ScaleDownDisabled bool `json:"scaleDownDisabled,omitempty"`
+	// ScaleDownUtilizationThreshold is the ratio of the requests to the allocatable of cpu or memory,
+	// under which a Node is considered for the removal. It defaults to 0.5.
+	ScaleDownUtilizationThreshold float64 `json:"scaleDownUtilizationThreshold,omitempty"`
+	// ScaleDownUnneededTime is how long a Node has to be underused before it's removed. It defaults to 10m.
+	ScaleDownUnneededTime metav1.Duration `json:"scaleDownUnneededTime,omitempty"`
+	// ScaleDownDelayAfterAdd is how long the removal is suspended after the Nodes are added. It defaults to 10m.
+	ScaleDownDelayAfterAdd metav1.Duration `json:"scaleDownDelayAfterAdd,omitempty"`
+}
+
+// NodeGroup is a node group managed by the autoscaler.
+// The Nodes are generated in the same way as the node groups generated via nodegroup.Service.
+type NodeGroup struct {
+	Name    string `json:"name"`
+	MinSize int    `json:"minSize"`
+	MaxSize int    `json:"maxSize"`
+	// ScaleUpDelay is the time to provision a Node. The Nodes are created after it passes since the scale-up is decided.
+	ScaleUpDelay metav1.Duration    `json:"scaleUpDelay,omitempty"`
+	Seed         int64              `json:"seed,omitempty"`
+	Template     nodegroup.Template `json:"template"`
+}
+
+// Status is the status of the autoscaler.
+type Status struct {
+	Enabled    bool              `json:"enabled"`
+	Config     *Config           `json:"config,omitempty"`
+	NodeGroups []NodeGroupStatus `json:"nodeGroups"`
+	// Events are the latest scale-ups and scale-downs in chronological order.
+	Events []Event `json:"events"`
+}
+
+// NodeGroupStatus is the status of a node group as of the last scan.
+type NodeGroupStatus struct {
+	Name string `json:"name"`
+	// Size is the number of the Nodes in the group.
+	Size int `json:"size"`
+	// Upcoming is the number of the Nodes being provisioned.
+	Upcoming int `json:"upcoming"`
+	// UnneededNodes are the Nodes which will be removed if they are underused for ScaleDownUnneededTime.
+	UnneededNodes []string `json:"unneededNodes,omitempty"`
+}
+
+// EventType is the type of an event of the autoscaler.
+type EventType string
+
+const (
+	EventScaleUp   EventType = "ScaleUp"
+	EventScaleDown EventType = "ScaleDown"
+)
+
+// Event is a scale-up or a scale-down of a node group.
+type Event struct {
+	Time      metav1.Time `json:"time"`
+	Type      EventType   `json:"type"`
+	NodeGroup string      `json:"nodeGroup"`
+	// Nodes are the Nodes added or removed.
+	Nodes []string `json:"nodes"`
+	// Pods are the unschedulable Pods which triggered the scale-up, in the form of "<namespace>/<name>".
+	// It's empty when the group is scaled up to MinSize.
+	Pods []string `json:"pods,omitempty"`
+}
+
+// NewAutoscalerService initializes Service. The autoscaler is disabled until it's configured.
+func NewAutoscalerService(ps PodService, ns NodeService, ngs NodeGroupService) *Service {
+	return &Service{
+		podService:       ps,
+		nodeService:      ns,
+		nodeGroupService: ngs,
+		status:           Status{NodeGroups: []NodeGroupStatus{}, Events: []Event{}},
+	}
+}
+
+// Configure enables the autoscaler with the config, or replaces the config if it's already enabled.
+func (s *Service) Configure(cfg Config) error {
+	cfg.setDefaults()
+	if err := cfg.validate(); err != nil {
+		return xerrors.Errorf("%v: %w", err, ErrInvalidConfig)
+	}
+
+	s.Disable()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	s.status.Enabled = true
+	s.status.Config = &cfg
+	s.status.NodeGroups = []NodeGroupStatus{}
+
+	sc := newScaler(s.podService, s.nodeService, s.nodeGroupService, cfg)
+	done := s.done
+	go func() {
+		defer close(done)
+		s.run(ctx, sc, cfg.ScanInterval.Duration)
+	}()
+	return nil
+}
+
+// Disable stops the autoscaler and waits for it to stop. The Nodes are kept as they are.
+// The Nodes being provisioned are never created.
+func (s *Service) Disable() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.status.Enabled = false
+	s.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Status returns the status of the autoscaler.
+func (s *Service) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.status
+	st.NodeGroups = append([]NodeGroupStatus{}, s.status.NodeGroups...)
+	st.Events = append([]Event{}, s.status.Events...)
+	return st
+}
+
+// run scans the Pods and the Nodes periodically until ctx is canceled.
+func (s *Service) run(ctx context.Context, sc *scaler, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		events, groups, err := sc.scan(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			klog.Errorf("failed to scan for autoscaling: %+v", err)
+		}
+		s.mu.Lock()
+		if groups != nil {
+			s.status.NodeGroups = groups
+		}
+		s.status.Events = append(s.status.Events, events...)
+		if len(s.status.Events) > maxEvents {
+			s.status.Events = s.status.Events[len(s.status.Events)-maxEvents:]
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (c *Config) setDefaults() {
+	if c.ScanInterval.Duration == 0 {
+		c.ScanInterval.Duration = defaultScanInterval
+	}
+	if c.ScaleDownUtilizationThreshold == 0 {
+		c.ScaleDownUtilizationThreshold = defaultScaleDownUtilizationThreshold
+	}
+	if c.ScaleDownUnneededTime.Duration == 0 {
+		c.ScaleDownUnneededTime.Duration = defaultScaleDownUnneededTime
+	}
+	if c.ScaleDownDelayAfterAdd.Duration == 0 {
+		c.ScaleDownDelayAfterAdd.Duration = defaultScaleDownDelayAfterAdd
+	}
+}
+
+func (c *Config) validate() error {
+	var errs []string
+	if len(c.NodeGroups) == 0 {
+		errs = append(errs, "at least one node group must be set")
+	}
+	if c.ScanInterval.Duration < 0 || c.ScaleDownUnneededTime.Duration < 0 || c.ScaleDownDelayAfterAdd.Duration < 0 {
+		errs = append(errs, "scanInterval, scaleDownUnneededTime and scaleDownDelayAfterAdd must not be negative")
+	}
+	if c.ScaleDownUtilizationThreshold < 0 || c.ScaleDownUtilizationThreshold > 1 {
+		errs = append(errs, "scaleDownUtilizationThreshold must be between 0 and 1")
+	}
+	names := map[string]bool{}
+	for i := range c.NodeGroups {
+		g := &c.NodeGroups[i]
+		if names[g.Name] {
+			errs = append(errs, fmt.Sprintf("nodeGroups[%d]: name %s is duplicated", i, g.Name))
+		}
+		names[g.Name] = true
+		for _, e := range validation.IsDNS1123Label(g.Name) {
+			errs = append(errs, fmt.Sprintf("nodeGroups[%d]: %s", i, e))
+		}
+		if g.MinSize < 0 || g.MaxSize <= 0 || g.MinSize > g.MaxSize {
+			errs = append(errs, fmt.Sprintf("nodeGroups[%d]: minSize and maxSize must satisfy 0 <= minSize <= maxSize and 0 < maxSize", i))
+		}
+		if g.ScaleUpDelay.Duration < 0 {
+			errs = append(errs, fmt.Sprintf("nodeGroups[%d]: scaleUpDelay must not be negative", i))
+		}
+		ng := g.nodeGroup()
+		if err := ng.Validate(); err != nil {
+			errs = append(errs, fmt.Sprintf("nodeGroups[%d]: %v", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// nodeGroup returns the node group which generates the Nodes of the group.
+func (g *NodeGroup) nodeGroup() nodegroup.NodeGroup {
+	return nodegroup.NodeGroup{Name: g.Name, Seed: g.Seed, Template: g.Template}
+}
@@ -0,0 +1,363 @@
+package autoscaler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/autoscaler/mock_autoscaler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/nodegroup"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/plugin/annotation"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+func testGroup(name string, minSize, maxSize int, taints ...corev1.Taint) NodeGroup {
+	cpu := resource.MustParse("4")
+	memory := resource.MustParse("16Gi")
+	return NodeGroup{
+		Name:    name,
+		MinSize: minSize,
+		MaxSize: maxSize,
+		Template: nodegroup.Template{
+			Resources: map[corev1.ResourceName]util.Distribution{
+				corev1.ResourceCPU:    {Value: &cpu},
+				corev1.ResourceMemory: {Value: &memory},
+			},
+			Taints: taints,
+		},
+	}
+}
+
+// groupNode returns the Node at the index in the group as it's created.
+func groupNode(t *testing.T, g NodeGroup, index int) corev1.Node {
+	t.Helper()
+	ng := g.nodeGroup()
+	n, err := ng.Node(index)
+	assert.NoError(t, err)
+	return *n
+}
+
+func testPod(name, cpu, nodeName string, unschedulable bool) corev1.Pod {
+	p := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", Controller: boolPtr(true)}},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{{
+				Name:      "c1",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+			}},
+		},
+	}
+	if unschedulable {
+		p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}}
+	}
+	return p
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+//nolint:funlen // For readability.
+func TestScaler_scan(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	worker := testGroup("worker", 0, 2)
+	gpu := testGroup("gpu", 0, 2, corev1.Taint{Key: "gpu", Effect: corev1.TaintEffectNoSchedule})
+	gpuPod := testPod("pod2", "2", "", true)
+	gpuPod.Spec.Tolerations = []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}
+	orphan := testPod("pod1", "1", "worker-0", false)
+	orphan.OwnerReferences = nil
+	// The Pods can't be scheduled to worker-0 because of the anti-affinity to the Pod on it.
+	antiAffinityPod := func(topologyKey string) corev1.Pod {
+		p := testPod("pod2", "1", "", true)
+		p.Annotations = map[string]string{annotation.FilterResultAnnotationKey: `{"worker-0":{"InterPodAffinity":"node(s) didn't match pod anti-affinity rules","NodeResourcesFit":"passed"}}`}
+		p.Spec.Affinity = &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: topologyKey}},
+		}}
+		return p
+	}
+	spreadPod := testPod("pod2", "1", "", true)
+	spreadPod.Annotations = map[string]string{annotation.FilterResultAnnotationKey: `{"worker-0":{"PodTopologySpread":"node(s) didn't match pod topology spread constraints","NodeResourcesFit":"passed"}}`}
+	spreadPod.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.DoNotSchedule}}
+
+	tests := []struct {
+		name              string
+		cfg               Config
+		nodes             func(t *testing.T) []corev1.Node
+		pods              []corev1.Pod
+		unneededSince     map[string]time.Time
+		wantAdded         map[string]int
+		wantDeleted       []string
+		wantEvents        []Event
+		wantUpcoming      map[string]int
+		wantUnneededNodes map[string][]string
+	}{
+		{
+			name:       "scale up the group for the unschedulable Pods",
+			cfg:        Config{NodeGroups: []NodeGroup{worker}},
+			pods:       []corev1.Pod{testPod("pod1", "3", "", true), testPod("pod2", "3", "", true), testPod("pod3", "1", "", true)},
+			wantAdded:  map[string]int{"worker": 2},
+			wantEvents: []Event{{Time: metav1.NewTime(now), Type: EventScaleUp, NodeGroup: "worker", Nodes: []string{"worker-0", "worker-1"}, Pods: []string{"default/pod1", "default/pod3", "default/pod2"}}},
+		},
+		{
+			name: "do nothing with the Pods not unschedulable or too large for the group",
+			cfg:  Config{NodeGroups: []NodeGroup{worker}},
+			pods: []corev1.Pod{testPod("pod1", "8", "", true), testPod("pod2", "1", "", false)},
+		},
+		{
+			name:      "scale up the first group which the Pod fits in",
+			cfg:       Config{NodeGroups: []NodeGroup{gpu, worker}},
+			pods:      []corev1.Pod{testPod("pod1", "2", "", true), gpuPod},
+			wantAdded: map[string]int{"gpu": 1, "worker": 1},
+			wantEvents: []Event{
+				{Time: metav1.NewTime(now), Type: EventScaleUp, NodeGroup: "gpu", Nodes: []string{"gpu-0"}, Pods: []string{"default/pod2"}},
+				{Time: metav1.NewTime(now), Type: EventScaleUp, NodeGroup: "worker", Nodes: []string{"worker-0"}, Pods: []string{"default/pod1"}},
+			},
+		},
+		{
+			name: "not scale up the group over MaxSize",
+			cfg:  Config{NodeGroups: []NodeGroup{worker}},
+			nodes: func(t *testing.T) []corev1.Node {
+				t.Helper()
+				return []corev1.Node{groupNode(t, worker, 0), groupNode(t, worker, 1)}
+			},
+			pods: []corev1.Pod{testPod("pod1", "3", "worker-0", false), testPod("pod2", "3", "worker-1", false), testPod("pod3", "3", "", true)},
+		},
+		{
+			name: "scale up the group for the Pod with the anti-affinity per Node",
+			cfg:  Config{NodeGroups: []NodeGroup{worker}},
+			nodes: func(t *testing.T) []corev1.Node {
+				t.Helper()
+				return []corev1.Node{groupNode(t, worker, 0)}
+			},
+			pods:       []corev1.Pod{testPod("pod1", "1", "worker-0", false), antiAffinityPod(corev1.LabelHostname)},
+			wantAdded:  map[string]int{"worker": 1},
+			wantEvents: []Event{{Time: metav1.NewTime(now), Type: EventScaleUp, NodeGroup: "worker", Nodes: []string{"worker-1"}, Pods: []string{"default/pod2"}}},
+		},
+		{
+			name: "not scale up the group for the Pod with the anti-affinity per zone",
+			cfg:  Config{NodeGroups: []NodeGroup{worker}},
+			nodes: func(t *testing.T) []corev1.Node {
+				t.Helper()
+				return []corev1.Node{groupNode(t, worker, 0)}
+			},
+			pods: []corev1.Pod{testPod("pod1", "1", "worker-0", false), antiAffinityPod(corev1.LabelTopologyZone)},
+		},
+		{
+			name: "scale up the group for the Pod spread over the Nodes",
+			cfg:  Config{NodeGroups: []NodeGroup{worker}},
+			nodes: func(t *testing.T) []corev1.Node {
+				t.Helper()
+				return []corev1.Node{groupNode(t, worker, 0)}
+			},
+			pods:       []corev1.Pod{testPod("pod1", "1", "worker-0", false), spreadPod},
+			wantAdded:  map[string]int{"worker": 1},
+			wantEvents: []Event{{Time: metav1.NewTime(now), Type: EventScaleUp, NodeGroup: "worker", Nodes: []string{"worker-1"}, Pods: []string{"default/pod2"}}},
+		},
+		{
+			name: "add the Node at the index which isn't used",
+			cfg:  Config{NodeGroups: []NodeGroup{testGroup("worker", 0, 3)}},
+			nodes: func(t *testing.T) []corev1.Node {
+				t.Helper()
+				// worker-0 was deleted.
+				return []corev1.Node{groupNode(t, worker, 1)}
+			},
+			pods:       []corev1.Pod{testPod("pod1", "3", "worker-1", false), testPod("pod2", "3", "", true), testPod("pod3", "3", "", true)},
+			wantAdded:  map[string]int{"worker": 2},
+			wantEvents: []Event{{Time: metav1.NewTime(now), Type: EventScaleUp, NodeGroup: "worker", Nodes: []string{"worker-0", "worker-2"}, Pods: []string{"default/pod2", "default/pod3"}}},
+		},
+		{
+			name:       "scale up the group to MinSize",
+			cfg:        Config{NodeGroups: []NodeGroup{testGroup("worker", 1, 2)}},
+			wantAdded:  map[string]int{"worker": 1},
+			wantEvents: []Event{{Time: metav1.NewTime(now), Type: EventScaleUp, NodeGroup: "worker", Nodes: []string{"worker-0"}}},
+		},
+		{
+			name: "keep the Nodes upcoming until they are provisioned",
+			cfg: func() Config {
+				g := testGroup("worker", 0, 2)
+				g.ScaleUpDelay.Duration = time.Minute
+				return Config{NodeGroups: []NodeGroup{g}}
+			}(),
+			pods:         []corev1.Pod{testPod("pod1", "3", "", true)},
+			wantUpcoming: map[string]int{"worker": 1},
+		},
+		{
+			name:              "mark the underused Node as unneeded",
+			cfg:               Config{NodeGroups: []NodeGroup{worker}},
+			nodes:             func(t *testing.T) []corev1.Node { t.Helper(); return []corev1.Node{groupNode(t, worker, 0)} },
+			wantUnneededNodes: map[string][]string{"worker": {"worker-0"}},
+		},
+		{
+			name: "remove the Node unneeded for ScaleDownUnneededTime",
+			cfg:  Config{NodeGroups: []NodeGroup{worker}},
+			nodes: func(t *testing.T) []corev1.Node {
+				t.Helper()
+				return []corev1.Node{groupNode(t, worker, 0), groupNode(t, worker, 1)}
+			},
+			pods:          []corev1.Pod{testPod("pod1", "1", "worker-0", false), testPod("pod2", "1", "worker-1", false)},
+			unneededSince: map[string]time.Time{"worker-0": now.Add(-10 * time.Minute)},
+			wantDeleted:   []string{"worker-0"},
+			wantEvents:    []Event{{Time: metav1.NewTime(now), Type: EventScaleDown, NodeGroup: "worker", Nodes: []string{"worker-0"}}},
+			// worker-1 can't be removed in the same scan because the Pods of worker-0 are moved to it.
+		},
+		{
+			name: "not remove the Node with the Pod which isn't recreated",
+			cfg:  Config{NodeGroups: []NodeGroup{worker}},
+			nodes: func(t *testing.T) []corev1.Node {
+				t.Helper()
+				return []corev1.Node{groupNode(t, worker, 0), groupNode(t, worker, 1)}
+			},
+			pods:              []corev1.Pod{orphan},
+			unneededSince:     map[string]time.Time{"worker-0": now.Add(-10 * time.Minute)},
+			wantUnneededNodes: map[string][]string{"worker": {"worker-1"}},
+		},
+		{
+			name:              "not remove the Node below MinSize",
+			cfg:               Config{NodeGroups: []NodeGroup{testGroup("worker", 1, 2)}},
+			nodes:             func(t *testing.T) []corev1.Node { t.Helper(); return []corev1.Node{groupNode(t, worker, 0)} },
+			unneededSince:     map[string]time.Time{"worker-0": now.Add(-10 * time.Minute)},
+			wantUnneededNodes: map[string][]string{"worker": {"worker-0"}},
+		},
+		{
+			name:          "not remove the Node when the scale-down is disabled",
+			cfg:           Config{NodeGroups: []NodeGroup{worker}, ScaleDownDisabled: true},
+			nodes:         func(t *testing.T) []corev1.Node { t.Helper(); return []corev1.Node{groupNode(t, worker, 0)} },
+			unneededSince: map[string]time.Time{"worker-0": now.Add(-10 * time.Minute)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			ps := mock_autoscaler.NewMockPodService(ctrl)
+			ns := mock_autoscaler.NewMockNodeService(ctrl)
+			ngs := mock_autoscaler.NewMockNodeGroupService(ctrl)
+
+			var nodes []corev1.Node
+			if tt.nodes != nil {
+				nodes = tt.nodes(t)
+			}
+			ps.EXPECT().List(gomock.Any(), metav1.NamespaceAll).Return(&corev1.PodList{Items: tt.pods}, nil)
+			ns.EXPECT().List(gomock.Any()).DoAndReturn(func(context.Context) (*corev1.NodeList, error) {
+				return &corev1.NodeList{Items: nodes}, nil
+			}).AnyTimes()
+			added := map[string]int{}
+			ngs.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, g nodegroup.NodeGroup, indexes []int) ([]string, error) {
+				added[g.Name] += len(indexes)
+				var names []string
+				for _, idx := range indexes {
+					n := groupNode(t, NodeGroup{Name: g.Name, Seed: g.Seed, Template: g.Template}, idx)
+					nodes = append(nodes, n)
+					names = append(names, n.Name)
+				}
+				return names, nil
+			}).AnyTimes()
+			var deleted []string
+			ns.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, name string) error {
+				deleted = append(deleted, name)
+				remaining := []corev1.Node{}
+				for i := range nodes {
+					if nodes[i].Name != name {
+						remaining = append(remaining, nodes[i])
+					}
+				}
+				nodes = remaining
+				return nil
+			}).AnyTimes()
+
+			cfg := tt.cfg
+			cfg.setDefaults()
+			sc := newScaler(ps, ns, ngs, cfg)
+			if tt.unneededSince != nil {
+				sc.unneededSince = tt.unneededSince
+			}
+			events, statuses, err := sc.scan(context.Background(), now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEvents, events)
+			if tt.wantAdded == nil {
+				tt.wantAdded = map[string]int{}
+			}
+			assert.Equal(t, tt.wantAdded, added)
+			assert.Equal(t, tt.wantDeleted, deleted)
+			for _, st := range statuses {
+				assert.Equal(t, tt.wantUpcoming[st.Name], st.Upcoming, st.Name)
+				assert.Equal(t, tt.wantUnneededNodes[st.Name], st.UnneededNodes, st.Name)
+			}
+		})
+	}
+}
+
+func TestService_Configure(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "enable the autoscaler",
+			cfg:  Config{NodeGroups: []NodeGroup{testGroup("worker", 0, 2)}},
+		},
+		{
+			name:    "no node group",
+			cfg:     Config{},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated node groups",
+			cfg:     Config{NodeGroups: []NodeGroup{testGroup("worker", 0, 2), testGroup("worker", 0, 2)}},
+			wantErr: true,
+		},
+		{
+			name:    "minSize larger than maxSize",
+			cfg:     Config{NodeGroups: []NodeGroup{testGroup("worker", 3, 2)}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid threshold",
+			cfg:     Config{NodeGroups: []NodeGroup{testGroup("worker", 0, 2)}, ScaleDownUtilizationThreshold: 1.5},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			ps := mock_autoscaler.NewMockPodService(ctrl)
+			ns := mock_autoscaler.NewMockNodeService(ctrl)
+			ps.EXPECT().List(gomock.Any(), gomock.Any()).Return(&corev1.PodList{}, nil).AnyTimes()
+			ns.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{}, nil).AnyTimes()
+			s := NewAutoscalerService(ps, ns, mock_autoscaler.NewMockNodeGroupService(ctrl))
+
+			err := s.Configure(tt.cfg)
+			defer s.Disable()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidConfig))
+				assert.False(t, s.Status().Enabled)
+				return
+			}
+			assert.NoError(t, err)
+			st := s.Status()
+			assert.True(t, st.Enabled)
+			assert.Equal(t, defaultScanInterval, st.Config.ScanInterval.Duration)
+
+			s.Disable()
+			assert.False(t, s.Status().Enabled)
+		})
+	}
+}
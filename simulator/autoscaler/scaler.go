@@ -0,0 +1,598 @@
+package autoscaler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	resourcehelper "k8s.io/kubernetes/pkg/api/v1/resource"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/nodegroup"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/plugin/annotation"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/plugin/resultstore"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/storereflector"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+// resourceFilterPlugins are the Filter plugins which the Pod may pass on a new Node of the same group.
+var resourceFilterPlugins = map[string]bool{"NodeResourcesFit": true, "NodePorts": true}
+
+const (
+	interPodAffinityPlugin  = "InterPodAffinity"
+	podTopologySpreadPlugin = "PodTopologySpread"
+)
+
+// scaler decides the scale-ups and the scale-downs of the node groups.
+// It keeps the state between the scans, so it must not be used after the config is changed.
+type scaler struct {
+	podService       PodService
+	nodeService      NodeService
+	nodeGroupService NodeGroupService
+	cfg              Config
+
+	// upcoming are the Nodes being provisioned in each group.
+	upcoming map[string][]*upcomingNode
+	// unneededSince is when each Node became unneeded.
+	unneededSince map[string]time.Time
+	// lastScaleUp is when the Nodes were added last time.
+	lastScaleUp time.Time
+}
+
+// upcomingNode is a Node which will be added to the group after it's provisioned.
+type upcomingNode struct {
+	readyAt time.Time
+	// index is the index of the Node in the group.
+	index int
+	// node is the Node generated from the template, against which the Pods are checked.
+	node *corev1.Node
+	free corev1.ResourceList
+	// pods are the Pods which will be scheduled to the Node.
+	pods []string
+}
+
+func newScaler(ps PodService, ns NodeService, ngs NodeGroupService, cfg Config) *scaler {
+	return &scaler{
+		podService:       ps,
+		nodeService:      ns,
+		nodeGroupService: ngs,
+		cfg:              cfg,
+		upcoming:         map[string][]*upcomingNode{},
+		unneededSince:    map[string]time.Time{},
+	}
+}
+
+// scan scales up the groups for the unschedulable Pods and to MinSize,
+// creates the upcoming Nodes which have been provisioned, and then scales down the groups if no group is scaling up.
+// It returns the events and the status of the groups.
+func (s *scaler) scan(ctx context.Context, now time.Time) ([]Event, []NodeGroupStatus, error) {
+	nl, err := s.nodeService.List(ctx)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("list nodes: %w", err)
+	}
+	pl, err := s.podService.List(ctx, metav1.NamespaceAll)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("list pods: %w", err)
+	}
+	groupNodes := map[string][]*corev1.Node{}
+	for i := range nl.Items {
+		n := &nl.Items[i]
+		if g, ok := n.Labels[nodegroup.GroupLabel]; ok {
+			groupNodes[g] = append(groupNodes[g], n)
+		}
+	}
+
+	scaledUp, err := s.scaleUp(pl.Items, groupNodes, now)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("scale up: %w", err)
+	}
+	events, err := s.addUpcoming(ctx, now)
+	if err != nil {
+		return events, nil, xerrors.Errorf("add upcoming nodes: %w", err)
+	}
+	if len(events) > 0 {
+		// The Nodes are added, so the scale-down is decided in the next scan with them.
+		return s.withStatuses(ctx, events)
+	}
+
+	if s.cfg.ScaleDownDisabled || scaledUp || s.provisioning() || now.Sub(s.lastScaleUp) < s.cfg.ScaleDownDelayAfterAdd.Duration {
+		s.unneededSince = map[string]time.Time{}
+		return s.withStatuses(ctx, events)
+	}
+	downEvents, err := s.scaleDown(ctx, nl.Items, pl.Items, groupNodes, now)
+	events = append(events, downEvents...)
+	if err != nil {
+		return events, nil, xerrors.Errorf("scale down: %w", err)
+	}
+	return s.withStatuses(ctx, events)
+}
+
+// provisioning returns true if any Node is being provisioned.
+func (s *scaler) provisioning() bool {
+	for _, nodes := range s.upcoming {
+		if len(nodes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// withStatuses returns the events with the status of the groups.
+func (s *scaler) withStatuses(ctx context.Context, events []Event) ([]Event, []NodeGroupStatus, error) {
+	statuses, err := s.groupStatuses(ctx)
+	if err != nil {
+		return events, nil, err
+	}
+	return events, statuses, nil
+}
+
+// scaleUp plans the upcoming Nodes for MinSize and for the unschedulable Pods.
+// It returns true if any Node is planned.
+func (s *scaler) scaleUp(pods []corev1.Pod, groupNodes map[string][]*corev1.Node, now time.Time) (bool, error) {
+	scaledUp := false
+	for i := range s.cfg.NodeGroups {
+		g := &s.cfg.NodeGroups[i]
+		for len(groupNodes[g.Name])+len(s.upcoming[g.Name]) < g.MinSize {
+			idx, n, err := s.nextNode(g, groupNodes[g.Name])
+			if err != nil {
+				return false, err
+			}
+			s.planNode(g, idx, n, now)
+			scaledUp = true
+		}
+	}
+
+	planned := map[string]bool{}
+	for _, nodes := range s.upcoming {
+		for _, u := range nodes {
+			for _, p := range u.pods {
+				planned[p] = true
+			}
+		}
+	}
+	for i := range pods {
+		pod := &pods[i]
+		key := pod.Namespace + "/" + pod.Name
+		if planned[key] || !s.isUnschedulable(pod) {
+			continue
+		}
+		requests := podRequests(pod)
+		for j := range s.cfg.NodeGroups {
+			g := &s.cfg.NodeGroups[j]
+			ok, err := s.fitsGroup(pod, requests, g, groupNodes[g.Name])
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				continue
+			}
+			u, err := s.upcomingNodeFor(pod, requests, g, groupNodes[g.Name], now)
+			if err != nil {
+				return false, err
+			}
+			if u == nil {
+				// The group has reached MaxSize.
+				continue
+			}
+			scaledUp = true
+			u.pods = append(u.pods, key)
+			subtract(u.free, requests)
+			break
+		}
+	}
+	return scaledUp, nil
+}
+
+// upcomingNodeFor returns the first upcoming Node in the group which the Pod fits in.
+// It plans a new Node if no upcoming Node has room for the Pod, and returns nil if the group can't be scaled up anymore.
+func (s *scaler) upcomingNodeFor(pod *corev1.Pod, requests corev1.ResourceList, g *NodeGroup, nodes []*corev1.Node, now time.Time) (*upcomingNode, error) {
+	for _, u := range s.upcoming[g.Name] {
+		if fitsResources(requests, u.free) && fitsNode(pod, u.node) {
+			return u, nil
+		}
+	}
+	if len(nodes)+len(s.upcoming[g.Name]) >= g.MaxSize {
+		return nil, nil
+	}
+	idx, n, err := s.nextNode(g, nodes)
+	if err != nil {
+		return nil, err
+	}
+	if !fitsResources(requests, n.Status.Allocatable) || !fitsNode(pod, n) {
+		// The random values of the template don't suit the Pod.
+		return nil, nil
+	}
+	return s.planNode(g, idx, n, now), nil
+}
+
+// nextNode returns the Node which the group generates next and its index,
+// which is the smallest one used by neither the Nodes nor the upcoming Nodes of the group.
+func (s *scaler) nextNode(g *NodeGroup, nodes []*corev1.Node) (int, *corev1.Node, error) {
+	used := map[int]bool{}
+	for _, n := range nodes {
+		if idx, ok := nodegroup.NodeIndex(g.Name, n.Name); ok {
+			used[idx] = true
+		}
+	}
+	for _, u := range s.upcoming[g.Name] {
+		used[u.index] = true
+	}
+	idx := 0
+	for used[idx] {
+		idx++
+	}
+	ng := g.nodeGroup()
+	n, err := ng.Node(idx)
+	if err != nil {
+		return 0, nil, xerrors.Errorf("generate node of group %s: %w", g.Name, err)
+	}
+	return idx, n, nil
+}
+
+// planNode adds the upcoming Node at the index to the group, which is ready after the ScaleUpDelay.
+func (s *scaler) planNode(g *NodeGroup, index int, n *corev1.Node, now time.Time) *upcomingNode {
+	u := &upcomingNode{readyAt: now.Add(g.ScaleUpDelay.Duration), index: index, node: n, free: n.Status.Allocatable.DeepCopy()}
+	s.upcoming[g.Name] = append(s.upcoming[g.Name], u)
+	return u
+}
+
+// addUpcoming creates the upcoming Nodes which have been provisioned.
+func (s *scaler) addUpcoming(ctx context.Context, now time.Time) ([]Event, error) {
+	var events []Event
+	for i := range s.cfg.NodeGroups {
+		g := &s.cfg.NodeGroups[i]
+		var ready, pending []*upcomingNode
+		for _, u := range s.upcoming[g.Name] {
+			if u.readyAt.After(now) {
+				pending = append(pending, u)
+			} else {
+				ready = append(ready, u)
+			}
+		}
+		if len(ready) == 0 {
+			continue
+		}
+		indexes := make([]int, 0, len(ready))
+		for _, u := range ready {
+			indexes = append(indexes, u.index)
+		}
+		names, err := s.nodeGroupService.Add(ctx, g.nodeGroup(), indexes)
+		if err != nil {
+			return events, xerrors.Errorf("add nodes to group %s: %w", g.Name, err)
+		}
+		s.upcoming[g.Name] = pending
+		s.lastScaleUp = now
+		e := Event{Time: metav1.NewTime(now), Type: EventScaleUp, NodeGroup: g.Name, Nodes: names}
+		for _, u := range ready {
+			e.Pods = append(e.Pods, u.pods...)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// isUnschedulable returns true if the scheduler has failed to schedule the Pod since the Nodes were added last time.
+func (s *scaler) isUnschedulable(pod *corev1.Pod) bool {
+	if pod.Spec.NodeName != "" || pod.Status.NominatedNodeName != "" || pod.DeletionTimestamp != nil {
+		return false
+	}
+	if util.UnschedulableCondition(pod) == nil {
+		return false
+	}
+	if ts, ok := pod.Annotations[storereflector.AttemptTimestampAnnotationKey]; ok && !s.lastScaleUp.IsZero() {
+		attempted, err := time.Parse(time.RFC3339Nano, ts)
+		// The scheduler hasn't tried the Pod with the Nodes added last time.
+		if err == nil && attempted.Before(s.lastScaleUp) {
+			return false
+		}
+	}
+	return true
+}
+
+// fitsGroup returns true if a new Node of the group can run the Pod.
+// When the scheduler has recorded the Filter results on the Nodes of the group,
+// the Pod has to have failed only on the resources or the ports on some of them,
+// so that the constraints like the inter-pod affinity and the topology spread are taken into account.
+// The constraints whose topology domain is each Node are regarded as satisfied since the new Node has no Pods.
+func (s *scaler) fitsGroup(pod *corev1.Pod, requests corev1.ResourceList, g *NodeGroup, nodes []*corev1.Node) (bool, error) {
+	_, n, err := s.nextNode(g, nodes)
+	if err != nil {
+		return false, err
+	}
+	if !fitsResources(requests, n.Status.Allocatable) || !fitsNode(pod, n) {
+		return false, nil
+	}
+
+	var results map[string]map[string]string
+	if err := json.Unmarshal([]byte(pod.Annotations[annotation.FilterResultAnnotationKey]), &results); err != nil {
+		//nolint:nilerr // The Pod doesn't have the results.
+		return true, nil
+	}
+	satisfiable := newNodeFilterPlugins(pod)
+	checked := false
+	for _, node := range nodes {
+		r, ok := results[node.Name]
+		if !ok {
+			continue
+		}
+		checked = true
+		onlyResources := true
+		for plugin, reason := range r {
+			if reason != resultstore.PassedFilterMessage && !satisfiable[plugin] {
+				onlyResources = false
+				break
+			}
+		}
+		if onlyResources {
+			return true, nil
+		}
+	}
+	return !checked, nil
+}
+
+// newNodeFilterPlugins returns the Filter plugins which the Pod may pass on a new Node of the group.
+// In addition to resourceFilterPlugins, they include InterPodAffinity if the Pod has only the required anti-affinity per Node,
+// and PodTopologySpread if all the DoNotSchedule constraints of the Pod spread the Pods over the Nodes.
+func newNodeFilterPlugins(pod *corev1.Pod) map[string]bool {
+	plugins := map[string]bool{}
+	for p := range resourceFilterPlugins {
+		plugins[p] = true
+	}
+
+	perNode := true
+	if a := pod.Spec.Affinity; a != nil {
+		if a.PodAffinity != nil && len(a.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution) > 0 {
+			perNode = false
+		}
+		if a.PodAntiAffinity != nil {
+			for _, term := range a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+				if term.TopologyKey != corev1.LabelHostname {
+					perNode = false
+				}
+			}
+		}
+	}
+	plugins[interPodAffinityPlugin] = perNode
+
+	perNode = true
+	for _, c := range pod.Spec.TopologySpreadConstraints {
+		if c.WhenUnsatisfiable == corev1.DoNotSchedule && c.TopologyKey != corev1.LabelHostname {
+			perNode = false
+		}
+	}
+	plugins[podTopologySpreadPlugin] = perNode
+	return plugins
+}
+
+// scaleDown removes the Nodes which have been unneeded for ScaleDownUnneededTime.
+// All empty Nodes and at most one Node with Pods are removed at once.
+//
+//nolint:funlen,cyclop // For readability.
+func (s *scaler) scaleDown(ctx context.Context, nodes []corev1.Node, pods []corev1.Pod, groupNodes map[string][]*corev1.Node, now time.Time) ([]Event, error) {
+	podsOnNode := map[string][]*corev1.Pod{}
+	free := map[string]corev1.ResourceList{}
+	for i := range nodes {
+		free[nodes[i].Name] = nodes[i].Status.Allocatable.DeepCopy()
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podsOnNode[pod.Spec.NodeName] = append(podsOnNode[pod.Spec.NodeName], pod)
+		if f, ok := free[pod.Spec.NodeName]; ok {
+			subtract(f, podRequests(pod))
+		}
+	}
+
+	// removed are the Nodes which are unneeded. Their Pods are never moved to each other.
+	removed := map[string]bool{}
+	unneeded := map[string]time.Time{}
+	for i := range s.cfg.NodeGroups {
+		g := &s.cfg.NodeGroups[i]
+		for _, n := range sortedNodes(groupNodes[g.Name]) {
+			if n.DeletionTimestamp != nil || utilization(n, podsOnNode[n.Name]) >= s.cfg.ScaleDownUtilizationThreshold {
+				continue
+			}
+			if !s.movable(n, nodes, podsOnNode[n.Name], free, removed) {
+				continue
+			}
+			removed[n.Name] = true
+			since, ok := s.unneededSince[n.Name]
+			if !ok {
+				since = now
+			}
+			unneeded[n.Name] = since
+		}
+	}
+	s.unneededSince = unneeded
+
+	var events []Event
+	removedNonEmpty := false
+	for i := range s.cfg.NodeGroups {
+		g := &s.cfg.NodeGroups[i]
+		size := len(groupNodes[g.Name])
+		var deleted []string
+		for _, n := range sortedNodes(groupNodes[g.Name]) {
+			since, ok := unneeded[n.Name]
+			if !ok || now.Sub(since) < s.cfg.ScaleDownUnneededTime.Duration || size <= g.MinSize {
+				continue
+			}
+			empty := len(workloadPods(podsOnNode[n.Name])) == 0
+			if !empty && removedNonEmpty {
+				continue
+			}
+			if err := s.nodeService.Delete(ctx, n.Name); err != nil {
+				return events, xerrors.Errorf("delete node %s: %w", n.Name, err)
+			}
+			delete(s.unneededSince, n.Name)
+			removedNonEmpty = removedNonEmpty || !empty
+			deleted = append(deleted, n.Name)
+			size--
+		}
+		if len(deleted) > 0 {
+			events = append(events, Event{Time: metav1.NewTime(now), Type: EventScaleDown, NodeGroup: g.Name, Nodes: deleted})
+		}
+	}
+	return events, nil
+}
+
+// movable returns true if all Pods on the Node can be moved to the other Nodes.
+// The free resources of the Nodes which the Pods are moved to are reserved for them.
+func (s *scaler) movable(n *corev1.Node, nodes []corev1.Node, pods []*corev1.Pod, free map[string]corev1.ResourceList, removed map[string]bool) bool {
+	reserved := map[string]corev1.ResourceList{}
+	freeOf := func(name string) corev1.ResourceList {
+		if _, ok := reserved[name]; !ok {
+			reserved[name] = free[name].DeepCopy()
+		}
+		return reserved[name]
+	}
+	for _, pod := range workloadPods(pods) {
+		if metav1.GetControllerOf(pod) == nil {
+			// The Pod won't be recreated on another Node.
+			return false
+		}
+		requests := podRequests(pod)
+		moved := false
+		for i := range nodes {
+			target := &nodes[i]
+			if target.Name == n.Name || removed[target.Name] || target.DeletionTimestamp != nil || target.Spec.Unschedulable {
+				continue
+			}
+			if fitsResources(requests, freeOf(target.Name)) && fitsNode(pod, target) {
+				subtract(freeOf(target.Name), requests)
+				moved = true
+				break
+			}
+		}
+		if !moved {
+			return false
+		}
+	}
+	for name, f := range reserved {
+		free[name] = f
+	}
+	return true
+}
+
+// groupStatuses returns the status of the groups with the Nodes after the scan.
+func (s *scaler) groupStatuses(ctx context.Context) ([]NodeGroupStatus, error) {
+	nl, err := s.nodeService.List(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("list nodes: %w", err)
+	}
+	sizes := map[string]int{}
+	unneeded := map[string][]string{}
+	for i := range nl.Items {
+		g, ok := nl.Items[i].Labels[nodegroup.GroupLabel]
+		if !ok {
+			continue
+		}
+		sizes[g]++
+		if _, ok := s.unneededSince[nl.Items[i].Name]; ok {
+			unneeded[g] = append(unneeded[g], nl.Items[i].Name)
+		}
+	}
+	statuses := make([]NodeGroupStatus, 0, len(s.cfg.NodeGroups))
+	for i := range s.cfg.NodeGroups {
+		name := s.cfg.NodeGroups[i].Name
+		sort.Strings(unneeded[name])
+		statuses = append(statuses, NodeGroupStatus{
+			Name:          name,
+			Size:          sizes[name],
+			Upcoming:      len(s.upcoming[name]),
+			UnneededNodes: unneeded[name],
+		})
+	}
+	return statuses, nil
+}
+
+// podRequests returns the resources the Pod requests, including a slot of the pods.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests, _ := resourcehelper.PodRequestsAndLimits(pod)
+	requests[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	return requests
+}
+
+// fitsResources returns true if the free resources have all requests.
+func fitsResources(requests, free corev1.ResourceList) bool {
+	for name, q := range requests {
+		if q.IsZero() {
+			continue
+		}
+		f, ok := free[name]
+		if !ok || f.Cmp(q) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func subtract(free, requests corev1.ResourceList) {
+	for name, q := range requests {
+		if f, ok := free[name]; ok {
+			f.Sub(q)
+			free[name] = f
+		}
+	}
+}
+
+// fitsNode returns true if the Pod matches the required node affinity of the Node and tolerates its taints.
+func fitsNode(pod *corev1.Pod, n *corev1.Node) bool {
+	if ok, err := nodeaffinity.GetRequiredNodeAffinity(pod).Match(n); err != nil || !ok {
+		return false
+	}
+	_, untolerated := corev1helpers.FindMatchingUntoleratedTaint(n.Spec.Taints, pod.Spec.Tolerations, func(t *corev1.Taint) bool {
+		return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
+	})
+	return !untolerated
+}
+
+// utilization returns the larger ratio of the requests to the allocatable of cpu and memory.
+func utilization(n *corev1.Node, pods []*corev1.Pod) float64 {
+	requested := corev1.ResourceList{}
+	for _, pod := range pods {
+		requests, _ := resourcehelper.PodRequestsAndLimits(pod)
+		for name, q := range requests {
+			r := requested[name]
+			r.Add(q)
+			requested[name] = r
+		}
+	}
+	u := 0.0
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		allocatable := n.Status.Allocatable[name]
+		if allocatable.IsZero() {
+			continue
+		}
+		r := requested[name]
+		if v := float64(r.MilliValue()) / float64(allocatable.MilliValue()); v > u {
+			u = v
+		}
+	}
+	return u
+}
+
+// workloadPods returns the Pods which need to be moved when the Node is removed, i.e., ones not run by DaemonSets.
+func workloadPods(pods []*corev1.Pod) []*corev1.Pod {
+	var ret []*corev1.Pod
+	for _, pod := range pods {
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		ret = append(ret, pod)
+	}
+	return ret
+}
+
+func sortedNodes(nodes []*corev1.Node) []*corev1.Node {
+	ret := append([]*corev1.Node{}, nodes...)
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
@@ -0,0 +1,192 @@
+package batch
+
+//go:generate mockgen -destination=./mock_$GOPACKAGE/export.go . ExportService
+//go:generate mockgen -destination=./mock_$GOPACKAGE/scheduler.go . SchedulerService
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
+)
+
+// Exit codes of the batch simulation.
+const (
+	// ExitCodeSucceeded means all Pods are bound.
+	ExitCodeSucceeded = 0
+	// ExitCodeFailed means the simulation couldn't be run. (e.g., the resources file is invalid.)
+	ExitCodeFailed = 1
+	// ExitCodeUnschedulable means some Pods are unschedulable.
+	ExitCodeUnschedulable = 2
+	// ExitCodeTimedOut means some Pods are neither bound nor unschedulable, or their results aren't reflected, before the timeout.
+	ExitCodeTimedOut = 3
+)
+
+// annotationPrefix is the prefix of the annotations which have the scheduling results.
+const annotationPrefix = "scheduler-simulator/"
+
+const defaultPollInterval = time.Second
+
+type ExportService interface {
+	Import(ctx context.Context, resources *export.ResourcesForImport, opts ...export.Option) error
+}
+
+type SchedulerService interface {
+	GetSchedulerConfig() (*v1beta2config.KubeSchedulerConfiguration, error)
+}
+
+// Service runs a simulation without the simulator server's clients.
+type Service struct {
+	client           clientset.Interface
+	exportService    ExportService
+	schedulerService SchedulerService
+	pollInterval     time.Duration
+}
+
+// Options is the options of a batch simulation.
+type Options struct {
+	// ResourcesPath is the path to the file or the directory of the resources.
+	// It can be anything that export.ReadResourcesForImport accepts.
+	ResourcesPath string
+	// SchedulerConfigPath is the path to the file of KubeSchedulerConfiguration in any supported version.
+	// It's optional. If it's empty, the scheduler configuration in the resources file is used,
+	// and the current scheduler configuration is used if the resources file doesn't have it either.
+	SchedulerConfigPath string
+	// Timeout is the time to wait for all Pods to be bound or unschedulable. It waits forever if it's 0.
+	Timeout time.Duration
+}
+
+// NewBatchService initializes Service.
+func NewBatchService(client clientset.Interface, exportService ExportService, schedulerService SchedulerService) *Service {
+	return &Service{
+		client:           client,
+		exportService:    exportService,
+		schedulerService: schedulerService,
+		pollInterval:     defaultPollInterval,
+	}
+}
+
+// Run imports the resources with the scheduler configuration,
+// and waits until all Pods are bound or unschedulable, or the timeout passes.
+// The returned report has the status of all Pods at that time.
+func (s *Service) Run(ctx context.Context, opts Options) (*Report, error) {
+	resources, err := s.loadResources(opts)
+	if err != nil {
+		return nil, xerrors.Errorf("load resources: %w", err)
+	}
+	if err := s.exportService.Import(ctx, resources); err != nil {
+		return nil, xerrors.Errorf("import resources: %w", err)
+	}
+
+	// The scheduling results are reflected on the Pods after they're bound or found unschedulable, so we also wait for them.
+	// They're never reflected on the Pods bound in the resources, nor on any Pods when an external scheduler is used.
+	_, err = s.schedulerService.GetSchedulerConfig()
+	waitForResults := !errors.Is(err, scheduler.ErrServiceDisabled)
+	prebound := preboundPods(resources)
+
+	timedOut := false
+	if err := wait.PollImmediateWithContext(ctx, s.pollInterval, opts.Timeout, func(ctx context.Context) (bool, error) {
+		return s.allPodsScheduled(ctx, waitForResults, prebound)
+	}); err != nil {
+		if !errors.Is(err, wait.ErrWaitTimeout) {
+			return nil, xerrors.Errorf("wait for Pods to be scheduled: %w", err)
+		}
+		timedOut = true
+	}
+
+	pods, err := s.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, xerrors.Errorf("list pods: %w", err)
+	}
+	r := newReport(pods.Items)
+	r.Summary.TimedOut = timedOut
+	return r, nil
+}
+
+// loadResources reads the resources and determines the scheduler configuration to be applied.
+func (s *Service) loadResources(opts Options) (*export.ResourcesForImport, error) {
+	resources, err := export.ReadResourcesForImport(opts.ResourcesPath)
+	if err != nil {
+		return nil, xerrors.Errorf("read resources: %w", err)
+	}
+
+	if opts.SchedulerConfigPath != "" {
+		data, err := os.ReadFile(opts.SchedulerConfigPath)
+		if err != nil {
+			return nil, xerrors.Errorf("read scheduler config file: %w", err)
+		}
+		resources.SchedulerConfig, err = config.DecodeSchedulerCfg(data)
+		if err != nil {
+			return nil, xerrors.Errorf("decode scheduler config file: %w", err)
+		}
+	}
+	if resources.SchedulerConfig == nil {
+		cfg, err := s.schedulerService.GetSchedulerConfig()
+		if err != nil && !errors.Is(err, scheduler.ErrServiceDisabled) {
+			return nil, xerrors.Errorf("get scheduler config: %w", err)
+		}
+		resources.SchedulerConfig = cfg
+	}
+	return resources, nil
+}
+
+// allPodsScheduled checks whether all Pods are bound or unschedulable.
+// If waitForResults is true, it also checks whether the scheduling results are reflected on them except for the prebound Pods.
+func (s *Service) allPodsScheduled(ctx context.Context, waitForResults bool, prebound map[string]bool) (bool, error) {
+	pods, err := s.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, xerrors.Errorf("list pods: %w", err)
+	}
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if status, _ := PodStatusOf(p); status == PodStatusPending {
+			klog.V(4).Infof("waiting for Pod %s/%s to be scheduled", p.Namespace, p.Name)
+			return false, nil
+		}
+		if waitForResults && !prebound[p.Namespace+"/"+p.Name] && !hasResults(p) {
+			klog.V(4).Infof("waiting for the scheduling results to be reflected on Pod %s/%s", p.Namespace, p.Name)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// preboundPods returns the keys (namespace/name) of the Pods which are bound in the resources.
+func preboundPods(resources *export.ResourcesForImport) map[string]bool {
+	prebound := map[string]bool{}
+	for i := range resources.Pods {
+		p := &resources.Pods[i]
+		if p.Spec == nil || p.Spec.NodeName == nil || *p.Spec.NodeName == "" || p.Name == nil {
+			continue
+		}
+		namespace := metav1.NamespaceDefault
+		if p.Namespace != nil && *p.Namespace != "" {
+			namespace = *p.Namespace
+		}
+		prebound[namespace+"/"+*p.Name] = true
+	}
+	return prebound
+}
+
+// hasResults checks whether the pod has any scheduling results in the annotations.
+func hasResults(pod *corev1.Pod) bool {
+	for k := range pod.Annotations {
+		if strings.HasPrefix(k, annotationPrefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,313 @@
+package batch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/batch/mock_batch"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler"
+)
+
+func boundPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Annotations: map[string]string{
+				"scheduler-simulator/selected-node": "node1",
+				"other-annotation":                  "value",
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: "node1"},
+	}
+}
+
+func boundPodWithoutResults(name string) *corev1.Pod {
+	p := boundPod(name)
+	p.Annotations = nil
+	return p
+}
+
+func unschedulablePod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{"scheduler-simulator/filter-result": "{}"},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable, Message: "0/1 nodes are available"},
+			},
+		},
+	}
+}
+
+func pendingPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+}
+
+func TestService_Run(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                string
+		pods                []*corev1.Pod
+		resources           string
+		schedulerDisabled   bool
+		prepareMockExportFn func(m *mock_batch.MockExportService)
+		want                *Report
+		wantExitCode        int
+		wantErr             bool
+	}{
+		{
+			name: "all pods are bound",
+			pods: []*corev1.Pod{boundPod("pod2"), boundPod("pod1")},
+			prepareMockExportFn: func(m *mock_batch.MockExportService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: &Report{
+				Summary: Summary{Total: 2, StatusCounts: StatusCounts{Bound: 2}},
+				Pods: []PodResult{
+					{Namespace: "default", Name: "pod1", NodeName: "node1", Status: PodStatusBound, Annotations: map[string]string{"scheduler-simulator/selected-node": "node1"}},
+					{Namespace: "default", Name: "pod2", NodeName: "node1", Status: PodStatusBound, Annotations: map[string]string{"scheduler-simulator/selected-node": "node1"}},
+				},
+			},
+			wantExitCode: ExitCodeSucceeded,
+		},
+		{
+			name: "some pods are unschedulable",
+			pods: []*corev1.Pod{boundPod("pod1"), unschedulablePod("pod2")},
+			prepareMockExportFn: func(m *mock_batch.MockExportService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: &Report{
+				Summary: Summary{Total: 2, StatusCounts: StatusCounts{Bound: 1, Unschedulable: 1}},
+				Pods: []PodResult{
+					{Namespace: "default", Name: "pod1", NodeName: "node1", Status: PodStatusBound, Annotations: map[string]string{"scheduler-simulator/selected-node": "node1"}},
+					{Namespace: "default", Name: "pod2", Status: PodStatusUnschedulable, Message: "0/1 nodes are available", Annotations: map[string]string{"scheduler-simulator/filter-result": "{}"}},
+				},
+			},
+			wantExitCode: ExitCodeUnschedulable,
+		},
+		{
+			name: "some pods are still pending after the timeout",
+			pods: []*corev1.Pod{unschedulablePod("pod1"), pendingPod("pod2")},
+			prepareMockExportFn: func(m *mock_batch.MockExportService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: &Report{
+				Summary: Summary{Total: 2, StatusCounts: StatusCounts{Unschedulable: 1, Pending: 1}, TimedOut: true},
+				Pods: []PodResult{
+					{Namespace: "default", Name: "pod1", Status: PodStatusUnschedulable, Message: "0/1 nodes are available", Annotations: map[string]string{"scheduler-simulator/filter-result": "{}"}},
+					{Namespace: "default", Name: "pod2", Status: PodStatusPending},
+				},
+			},
+			wantExitCode: ExitCodeTimedOut,
+		},
+		{
+			name: "the scheduling results aren't reflected on the bound pod before the timeout",
+			pods: []*corev1.Pod{boundPodWithoutResults("pod1")},
+			prepareMockExportFn: func(m *mock_batch.MockExportService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: &Report{
+				Summary: Summary{Total: 1, StatusCounts: StatusCounts{Bound: 1}, TimedOut: true},
+				Pods: []PodResult{
+					{Namespace: "default", Name: "pod1", NodeName: "node1", Status: PodStatusBound},
+				},
+			},
+			wantExitCode: ExitCodeTimedOut,
+		},
+		{
+			name:      "don't wait for the scheduling results of the pod bound in the resources",
+			pods:      []*corev1.Pod{boundPodWithoutResults("pod1")},
+			resources: `{"pods":[{"metadata":{"name":"pod1"},"spec":{"nodeName":"node1"}}]}`,
+			prepareMockExportFn: func(m *mock_batch.MockExportService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: &Report{
+				Summary: Summary{Total: 1, StatusCounts: StatusCounts{Bound: 1}},
+				Pods: []PodResult{
+					{Namespace: "default", Name: "pod1", NodeName: "node1", Status: PodStatusBound},
+				},
+			},
+			wantExitCode: ExitCodeSucceeded,
+		},
+		{
+			name:              "don't wait for the scheduling results when an external scheduler is used",
+			pods:              []*corev1.Pod{boundPodWithoutResults("pod1")},
+			schedulerDisabled: true,
+			prepareMockExportFn: func(m *mock_batch.MockExportService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: &Report{
+				Summary: Summary{Total: 1, StatusCounts: StatusCounts{Bound: 1}},
+				Pods: []PodResult{
+					{Namespace: "default", Name: "pod1", NodeName: "node1", Status: PodStatusBound},
+				},
+			},
+			wantExitCode: ExitCodeSucceeded,
+		},
+		{
+			name: "return error if the import fails",
+			prepareMockExportFn: func(m *mock_batch.MockExportService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).Return(os.ErrInvalid)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			exportService := mock_batch.NewMockExportService(ctrl)
+			tt.prepareMockExportFn(exportService)
+			schedulerService := mock_batch.NewMockSchedulerService(ctrl)
+			if tt.schedulerDisabled {
+				schedulerService.EXPECT().GetSchedulerConfig().Return(nil, scheduler.ErrServiceDisabled).AnyTimes()
+			} else {
+				schedulerService.EXPECT().GetSchedulerConfig().Return(&v1beta2config.KubeSchedulerConfiguration{}, nil).AnyTimes()
+			}
+			client := fake.NewSimpleClientset()
+			for _, p := range tt.pods {
+				_, err := client.CoreV1().Pods(p.Namespace).Create(context.Background(), p, metav1.CreateOptions{})
+				assert.NoError(t, err)
+			}
+			path := filepath.Join(t.TempDir(), "resources.json")
+			resources := tt.resources
+			if resources == "" {
+				resources = `{"pods":[]}`
+			}
+			assert.NoError(t, os.WriteFile(path, []byte(resources), 0o600))
+
+			s := NewBatchService(client, exportService, schedulerService)
+			s.pollInterval = 10 * time.Millisecond
+			got, err := s.Run(context.Background(), Options{ResourcesPath: path, Timeout: 50 * time.Millisecond})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantExitCode, got.ExitCode())
+		})
+	}
+}
+
+func TestService_loadResources(t *testing.T) {
+	t.Parallel()
+	current := &v1beta2config.KubeSchedulerConfiguration{Parallelism: pointer.Int32(16)}
+	tests := []struct {
+		name            string
+		resources       string
+		schedulerConfig string
+		want            *export.ResourcesForImport
+		wantErr         bool
+	}{
+		{
+			name:      "use the current scheduler configuration if no configuration is given",
+			resources: `{}`,
+			want:      &export.ResourcesForImport{SchedulerConfig: current},
+		},
+		{
+			name:      "use the scheduler configuration in the resources file",
+			resources: `{"schedulerConfig":{"parallelism":8}}`,
+			want:      &export.ResourcesForImport{SchedulerConfig: &v1beta2config.KubeSchedulerConfiguration{Parallelism: pointer.Int32(8)}},
+		},
+		{
+			name:            "the scheduler configuration file overrides the one in the resources file",
+			resources:       `{"schedulerConfig":{"parallelism":8}}`,
+			schedulerConfig: "apiVersion: kubescheduler.config.k8s.io/v1beta2\nkind: KubeSchedulerConfiguration\nparallelism: 4\n",
+		},
+		{
+			name:      "return error if the resources file is invalid",
+			resources: `{"pods":`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			schedulerService := mock_batch.NewMockSchedulerService(ctrl)
+			schedulerService.EXPECT().GetSchedulerConfig().Return(current, nil).AnyTimes()
+			dir := t.TempDir()
+			opts := Options{ResourcesPath: filepath.Join(dir, "resources.json")}
+			assert.NoError(t, os.WriteFile(opts.ResourcesPath, []byte(tt.resources), 0o600))
+			if tt.schedulerConfig != "" {
+				opts.SchedulerConfigPath = filepath.Join(dir, "config.yaml")
+				assert.NoError(t, os.WriteFile(opts.SchedulerConfigPath, []byte(tt.schedulerConfig), 0o600))
+			}
+
+			s := NewBatchService(fake.NewSimpleClientset(), nil, schedulerService)
+			got, err := s.loadResources(opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.schedulerConfig != "" {
+				assert.Equal(t, pointer.Int32(4), got.SchedulerConfig.Parallelism)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPodStatusOf(t *testing.T) {
+	t.Parallel()
+	nominated := unschedulablePod("pod1")
+	nominated.Status.NominatedNodeName = "node1"
+	tests := []struct {
+		name        string
+		pod         *corev1.Pod
+		wantStatus  PodStatus
+		wantMessage string
+	}{
+		{
+			name:       "bound",
+			pod:        boundPod("pod1"),
+			wantStatus: PodStatusBound,
+		},
+		{
+			name:        "unschedulable",
+			pod:         unschedulablePod("pod1"),
+			wantStatus:  PodStatusUnschedulable,
+			wantMessage: "0/1 nodes are available",
+		},
+		{
+			name:       "pending while waiting for the preemption",
+			pod:        nominated,
+			wantStatus: PodStatusPending,
+		},
+		{
+			name:       "pending",
+			pod:        pendingPod("pod1"),
+			wantStatus: PodStatusPending,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotStatus, gotMessage := PodStatusOf(tt.pod)
+			assert.Equal(t, tt.wantStatus, gotStatus)
+			assert.Equal(t, tt.wantMessage, gotMessage)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package batch
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+// PodStatus is the scheduling status of a Pod.
+type PodStatus string
+
+const (
+	PodStatusBound         PodStatus = "Bound"
+	PodStatusUnschedulable PodStatus = "Unschedulable"
+	PodStatusPending       PodStatus = "Pending"
+)
+
+// Report is the result of a batch simulation.
+type Report struct {
+	Summary Summary     `json:"summary"`
+	Pods    []PodResult `json:"pods"`
+}
+
+// Summary is the number of Pods in each status.
+type Summary struct {
+	Total int `json:"total"`
+	StatusCounts
+	// TimedOut is true when some Pods are still pending after the timeout.
+	TimedOut bool `json:"timedOut"`
+}
+
+// StatusCounts is the number of Pods in each status.
+// It's embedded in the summaries of the reports so that they have the same fields.
+type StatusCounts struct {
+	Bound         int `json:"bound"`
+	Unschedulable int `json:"unschedulable"`
+	Pending       int `json:"pending"`
+}
+
+// Add counts a Pod in the status.
+func (c *StatusCounts) Add(status PodStatus) {
+	switch status {
+	case PodStatusBound:
+		c.Bound++
+	case PodStatusUnschedulable:
+		c.Unschedulable++
+	case PodStatusPending:
+		c.Pending++
+	}
+}
+
+// PodResult is the placement of a Pod and its scheduling results.
+type PodResult struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	NodeName  string    `json:"nodeName,omitempty"`
+	Status    PodStatus `json:"status"`
+	// Message is the message of the PodScheduled condition when the Pod is unschedulable.
+	Message string `json:"message,omitempty"`
+	// Annotations has the scheduler-simulator/* annotations of the Pod.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ExitCode returns the exit code which represents the report.
+func (r *Report) ExitCode() int {
+	switch {
+	case r.Summary.TimedOut:
+		return ExitCodeTimedOut
+	case r.Summary.Unschedulable != 0:
+		return ExitCodeUnschedulable
+	default:
+		return ExitCodeSucceeded
+	}
+}
+
+// WriteReport writes the report to the file in JSON.
+// It's shared with the other reports, e.g., the report of a replay.
+func WriteReport(path string, r interface{}) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("encode the report: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		return xerrors.Errorf("write the report to %s: %w", path, err)
+	}
+	return nil
+}
+
+func newReport(pods []corev1.Pod) *Report {
+	r := &Report{Pods: make([]PodResult, 0, len(pods))}
+	for i := range pods {
+		p := &pods[i]
+		status, message := PodStatusOf(p)
+		result := PodResult{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+			NodeName:  p.Spec.NodeName,
+			Status:    status,
+			Message:   message,
+		}
+		for k, v := range p.Annotations {
+			if !strings.HasPrefix(k, annotationPrefix) {
+				continue
+			}
+			if result.Annotations == nil {
+				result.Annotations = map[string]string{}
+			}
+			result.Annotations[k] = v
+		}
+		r.Pods = append(r.Pods, result)
+
+		r.Summary.Total++
+		r.Summary.Add(status)
+	}
+	sort.Slice(r.Pods, func(i, j int) bool {
+		if r.Pods[i].Namespace != r.Pods[j].Namespace {
+			return r.Pods[i].Namespace < r.Pods[j].Namespace
+		}
+		return r.Pods[i].Name < r.Pods[j].Name
+	})
+	return r
+}
+
+// PodStatusOf returns the scheduling status of the pod, and the message of the PodScheduled condition if it's unschedulable.
+// The pod which is nominated to a node (= waiting for the preemption victims to be deleted) is still pending.
+func PodStatusOf(pod *corev1.Pod) (PodStatus, string) {
+	if pod.Spec.NodeName != "" {
+		return PodStatusBound, ""
+	}
+	if pod.Status.NominatedNodeName != "" {
+		return PodStatusPending, ""
+	}
+	if c := util.UnschedulableCondition(pod); c != nil {
+		return PodStatusUnschedulable, c.Message
+	}
+	return PodStatusPending, ""
+}
@@ -11,8 +11,10 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
-	"k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
+	"sigs.k8s.io/yaml"
 
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/autoscaler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/replicateexistingcluster"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
 )
 
@@ -27,12 +29,24 @@ type Config struct {
 	CorsAllowedOriginList []string
 	// ExternalImportEnabled indicates whether the simulator will import resources from an existing cluster or not.
 	ExternalImportEnabled bool
+	// ExternalImportSyncEnabled indicates whether the simulator keeps mirroring the Nodes and the Pods in the existing cluster after the import.
+	// It can be true only when ExternalImportEnabled == true.
+	ExternalImportSyncEnabled bool
+	// ExternalImportOptions is the options to filter and transform the resources imported from the existing cluster.
+	ExternalImportOptions replicateexistingcluster.ImportOptions
 	// ExternalKubeClientCfg is KubeConfig to get resources from external cluster.
 	// This field is non-empty only when ExternalImportEnabled == true.
 	ExternalKubeClientCfg *rest.Config
 	InitialSchedulerCfg   *v1beta2config.KubeSchedulerConfiguration
 	// ExternalSchedulerEnabled indicates whether an external scheduler is enabled.
 	ExternalSchedulerEnabled bool
+	// SchedulingResultsPersistenceEnabled indicates whether the history of scheduling results is persisted in etcd.
+	SchedulingResultsPersistenceEnabled bool
+	// Controllers is the list of the controllers to be enabled in the same format as the --controllers flag of kube-controller-manager.
+	Controllers []string
+	// AutoscalerConfig is the config the cluster autoscaler is enabled with at startup.
+	// It's nil if the autoscaler isn't enabled at startup.
+	AutoscalerConfig *autoscaler.Config
 }
 
 // NewConfig gets some settings from environment variables.
@@ -63,6 +77,19 @@ func NewConfig() (*Config, error) {
 		}
 	}
 
+	externalImportSyncEnabled, err := getExternalImportSyncEnabled()
+	if err != nil {
+		return nil, xerrors.Errorf("get externalImportSyncEnabled: %w", err)
+	}
+	if externalImportSyncEnabled && !externalimportenabled {
+		return nil, xerrors.New("EXTERNAL_IMPORT_SYNC_ENABLED requires EXTERNAL_IMPORT_ENABLED to be 1")
+	}
+
+	externalImportOptions, err := getExternalImportOptions()
+	if err != nil {
+		return nil, xerrors.Errorf("get externalImportOptions: %w", err)
+	}
+
 	initialschedulerCfg, err := getSchedulerCfg()
 	if err != nil {
 		return nil, xerrors.Errorf("get SchedulerCfg: %w", err)
@@ -73,15 +100,32 @@ func NewConfig() (*Config, error) {
 		return nil, xerrors.Errorf("get externalSchedulerEnabled: %w", err)
 	}
 
+	schedulingResultsPersistenceEnabled, err := getSchedulingResultsPersistenceEnabled()
+	if err != nil {
+		return nil, xerrors.Errorf("get schedulingResultsPersistenceEnabled: %w", err)
+	}
+
+	controllers := getControllers()
+
+	autoscalerCfg, err := getAutoscalerConfig()
+	if err != nil {
+		return nil, xerrors.Errorf("get autoscaler config: %w", err)
+	}
+
 	return &Config{
-		Port:                     port,
-		KubeAPIServerURL:         apiurl,
-		EtcdURL:                  etcdurl,
-		CorsAllowedOriginList:    corsAllowedOriginList,
-		InitialSchedulerCfg:      initialschedulerCfg,
-		ExternalImportEnabled:    externalimportenabled,
-		ExternalKubeClientCfg:    externalKubeClientCfg,
-		ExternalSchedulerEnabled: externalSchedEnabled,
+		Port:                                port,
+		KubeAPIServerURL:                    apiurl,
+		EtcdURL:                             etcdurl,
+		CorsAllowedOriginList:               corsAllowedOriginList,
+		InitialSchedulerCfg:                 initialschedulerCfg,
+		ExternalImportEnabled:               externalimportenabled,
+		ExternalImportSyncEnabled:           externalImportSyncEnabled,
+		ExternalImportOptions:               externalImportOptions,
+		ExternalKubeClientCfg:               externalKubeClientCfg,
+		ExternalSchedulerEnabled:            externalSchedEnabled,
+		SchedulingResultsPersistenceEnabled: schedulingResultsPersistenceEnabled,
+		Controllers:                         controllers,
+		AutoscalerConfig:                    autoscalerCfg,
 	}, nil
 }
 
@@ -126,6 +170,20 @@ func getExternalSchedulerEnabled() (bool, error) {
 	return b, nil
 }
 
+func getSchedulingResultsPersistenceEnabled() (bool, error) {
+	e := os.Getenv("SCHEDULING_RESULTS_PERSISTENCE_ENABLED")
+	if e == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(e)
+	if err != nil {
+		return false, xerrors.Errorf("SCHEDULING_RESULTS_PERSISTENCE_ENABLED is specified, but it's not bool: %s.", e)
+	}
+
+	return b, nil
+}
+
 func getEtcdURL() (string, error) {
 	e := os.Getenv("KUBE_SCHEDULER_SIMULATOR_ETCD_URL")
 	if e == "" {
@@ -177,6 +235,7 @@ func parseStringListEnv(e string) []string {
 
 // getSchedulerCfg reads KUBE_SCHEDULER_CONFIG_PATH which means initial kube-scheduler configuration
 // and converts it into *v1beta2config.KubeSchedulerConfiguration.
+// The configuration can be written in any version in config.SupportedVersions.
 // KUBE_SCHEDULER_CONFIG_PATH is not required.
 // If KUBE_SCHEDULER_CONFIG_PATH is not set, the default configuration of kube-scheduler will be used.
 func getSchedulerCfg() (*v1beta2config.KubeSchedulerConfiguration, error) {
@@ -194,7 +253,7 @@ func getSchedulerCfg() (*v1beta2config.KubeSchedulerConfiguration, error) {
 		return nil, xerrors.Errorf("read scheduler config file: %w", err)
 	}
 
-	sc, err := decodeSchedulerCfg(data)
+	sc, err := config.DecodeSchedulerCfg(data)
 	if err != nil {
 		return nil, xerrors.Errorf("decode scheduler config file: %w", err)
 	}
@@ -209,22 +268,85 @@ func getExternalImportEnabled() bool {
 	return i == "1"
 }
 
-func decodeSchedulerCfg(buf []byte) (*v1beta2config.KubeSchedulerConfiguration, error) {
-	decoder := scheme.Codecs.UniversalDeserializer()
-	obj, _, err := decoder.Decode(buf, nil, nil)
+// getExternalImportSyncEnabled reads EXTERNAL_IMPORT_SYNC_ENABLED and convert it to bool.
+func getExternalImportSyncEnabled() (bool, error) {
+	return getBoolEnv("EXTERNAL_IMPORT_SYNC_ENABLED")
+}
+
+// getExternalImportOptions reads EXTERNAL_IMPORT_* variables for ImportOptions.
+// All of them are optional.
+func getExternalImportOptions() (replicateexistingcluster.ImportOptions, error) {
+	opts := replicateexistingcluster.ImportOptions{
+		NamespaceSelector: os.Getenv("EXTERNAL_IMPORT_NAMESPACE_SELECTOR"),
+		LabelSelector:     os.Getenv("EXTERNAL_IMPORT_LABEL_SELECTOR"),
+		NodeSelector:      os.Getenv("EXTERNAL_IMPORT_NODE_SELECTOR"),
+	}
+	var err error
+	if opts.ExcludeDaemonSetPods, err = getBoolEnv("EXTERNAL_IMPORT_EXCLUDE_DAEMONSET_PODS"); err != nil {
+		return opts, err
+	}
+	if opts.ExcludeSystemPods, err = getBoolEnv("EXTERNAL_IMPORT_EXCLUDE_SYSTEM_PODS"); err != nil {
+		return opts, err
+	}
+	if opts.StripBindings, err = getBoolEnv("EXTERNAL_IMPORT_STRIP_BINDINGS"); err != nil {
+		return opts, err
+	}
+	if opts.Anonymize, err = getBoolEnv("EXTERNAL_IMPORT_ANONYMIZE"); err != nil {
+		return opts, err
+	}
+	if err := opts.Validate(); err != nil {
+		return opts, xerrors.Errorf("validate import options: %w", err)
+	}
+	return opts, nil
+}
+
+// getControllers reads CONTROLLERS, the comma-separated list of the controllers to be enabled.
+// '*' enables all controllers enabled by default, 'foo' enables the controller named 'foo',
+// and '-foo' disables the controller named 'foo'.
+// CONTROLLERS is not required. If it's not set, all controllers enabled by default are enabled.
+func getControllers() []string {
+	e := os.Getenv("CONTROLLERS")
+	if e == "" {
+		return []string{"*"}
+	}
+	return parseStringListEnv(e)
+}
+
+// getAutoscalerConfig reads AUTOSCALER_CONFIG_PATH and loads the autoscaler config from the file in YAML or JSON.
+// AUTOSCALER_CONFIG_PATH is not required. If it's not set, it returns nil.
+func getAutoscalerConfig() (*autoscaler.Config, error) {
+	e := os.Getenv("AUTOSCALER_CONFIG_PATH")
+	if e == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(e)
 	if err != nil {
-		return nil, xerrors.Errorf("load an k8s object from buffer: %w", err)
+		return nil, xerrors.Errorf("read autoscaler config file: %w", err)
 	}
 
-	sc, ok := obj.(*v1beta2config.KubeSchedulerConfiguration)
-	if !ok {
-		return nil, xerrors.Errorf("convert to *v1beta2config.KubeSchedulerConfiguration, but got unexpected type: %T", obj)
+	cfg := &autoscaler.Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, xerrors.Errorf("decode autoscaler config file: %w", err)
 	}
 
-	if err = sc.DecodeNestedObjects(decoder); err != nil {
-		return nil, xerrors.Errorf("decode nested plugin args: %w", err)
+	return cfg, nil
+}
+
+// getBoolEnv reads the environment variable and convert it to bool.
+// It returns false if the variable is empty.
+func getBoolEnv(name string) (bool, error) {
+	e := os.Getenv(name)
+	if e == "" {
+		return false, nil
 	}
-	return sc, nil
+
+	b, err := strconv.ParseBool(e)
+	if err != nil {
+		return false, xerrors.Errorf("%s is specified, but it's not bool: %s.", name, e)
+	}
+
+	return b, nil
 }
 
 func GetKubeClientConfig() (*rest.Config, error) {
@@ -3,15 +3,20 @@ package controller
 import (
 	"context"
 	"math/rand"
+	"strings"
 	"time"
 
 	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/metadata/metadatainformer"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	genericcontrollermanager "k8s.io/controller-manager/app"
 	"k8s.io/controller-manager/pkg/clientbuilder"
 	"k8s.io/controller-manager/pkg/informerfactory"
 	"k8s.io/klog/v2"
@@ -27,15 +32,38 @@ const (
 // initFunc is the func to start a controller.
 type initFunc func(ctx context.Context, controllerCtx controllerContext) error
 
-// RunControllers runs all controllers.
-func RunController(client clientset.Interface, cfg *restclient.Config) (func(), error) {
+// ControllersDisabledByDefault is the set of the controllers which are enabled only when they're specified explicitly.
+// The garbage collector would delete the objects imported without their owners, e.g., the Pods owned by custom resources.
+var ControllersDisabledByDefault = sets.NewString(
+	"garbagecollector",
+)
+
+// KnownControllers returns the names of all controllers which can be enabled.
+func KnownControllers() []string {
+	names := sets.NewString()
+	for name := range newControllerInitializers() {
+		names.Insert(name)
+	}
+	return names.List()
+}
+
+// RunController runs the enabled controllers.
+// controllers has the same semantics as the --controllers flag of kube-controller-manager:
+// '*' enables all controllers enabled by default, 'foo' enables the controller named 'foo',
+// and '-foo' disables the controller named 'foo'. The first item matching the controller wins.
+func RunController(client clientset.Interface, cfg *restclient.Config, controllers []string) (func(), error) {
+	initializers, err := enabledControllerInitializers(controllers)
+	if err != nil {
+		return nil, xerrors.Errorf("select controllers: %w", err)
+	}
+
 	controllerCtx, err := createControllerContext(client, cfg)
 	if err != nil {
 		return nil, xerrors.Errorf("building controller context: %v", err)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	go run(controllerCtx, ctx.Done())
+	go run(ctx, controllerCtx, initializers)
 	shutdownFunc := func() {
 		klog.Info("shutdown controllers...")
 		cancel()
@@ -44,14 +72,37 @@ func RunController(client clientset.Interface, cfg *restclient.Config) (func(),
 	return shutdownFunc, nil
 }
 
-// run runs the KubeControllerManagerOptions.
-func run(controllerCtx controllerContext, stopCh <-chan struct{}) {
-	controllerInitializers := newControllerInitializers()
-	if err := startControllers(context.Background(), controllerCtx, controllerInitializers); err != nil {
+// enabledControllerInitializers returns the initializers of the enabled controllers.
+func enabledControllerInitializers(controllers []string) (map[string]initFunc, error) {
+	all := newControllerInitializers()
+	for _, c := range controllers {
+		if c == "*" {
+			continue
+		}
+		if _, ok := all[strings.TrimPrefix(c, "-")]; !ok {
+			return nil, xerrors.Errorf("unknown controller %q (known controllers: %s)", c, strings.Join(KnownControllers(), ", "))
+		}
+	}
+
+	enabled := map[string]initFunc{}
+	for name, initFn := range all {
+		if genericcontrollermanager.IsControllerEnabled(name, ControllersDisabledByDefault, controllers) {
+			enabled[name] = initFn
+		} else {
+			klog.Infof("%q is disabled", name)
+		}
+	}
+	return enabled, nil
+}
+
+// run runs the controllers.
+func run(ctx context.Context, controllerCtx controllerContext, controllerInitializers map[string]initFunc) {
+	go wait.Until(controllerCtx.RESTMapper.Reset, 30*time.Second, ctx.Done())
+	if err := startControllers(ctx, controllerCtx, controllerInitializers); err != nil {
 		klog.Fatalf("error starting controllers: %v", err)
 	}
-	controllerCtx.InformerFactory.Start(stopCh)
-	controllerCtx.ObjectOrMetadataInformerFactory.Start(stopCh)
+	controllerCtx.InformerFactory.Start(ctx.Done())
+	controllerCtx.ObjectOrMetadataInformerFactory.Start(ctx.Done())
 
 	close(controllerCtx.InformersStarted)
 }
@@ -78,7 +129,18 @@ func newControllerInitializers() map[string]initFunc {
 	controllers := map[string]initFunc{}
 	controllers["deployment"] = startDeploymentController
 	controllers["replicaset"] = startReplicaSetController
-	controllers["persistent-volume"] = startPersistentVolumeController
+	controllers["statefulset"] = startStatefulSetController
+	controllers["daemonset"] = startDaemonSetController
+	controllers["job"] = startJobController
+	controllers["cronjob"] = startCronJobController
+	controllers["resourcequota"] = startResourceQuotaController
+	controllers["persistentvolume-binder"] = startPersistentVolumeController
+	controllers["disruption"] = startDisruptionController
+	controllers["garbagecollector"] = startGarbageCollectorController
+	controllers["nodelifecycle"] = startNodeLifecycleController
+	controllers["podgc"] = startPodGCController
+	controllers["fake-kubelet"] = startFakeKubeletController
+	controllers["fake-node-agent"] = startFakeNodeAgentController
 	return controllers
 }
 
@@ -92,6 +154,10 @@ type controllerContext struct {
 	// InformerFactory gives access to informers for the controller.
 	InformerFactory informers.SharedInformerFactory
 
+	// RESTMapper is a RESTMapper that will defer initialization of the RESTMapper until the first mapping is requested,
+	// or if an attempt to load a mapping fails. It's reset periodically to find the new resources.
+	RESTMapper *restmapper.DeferredDiscoveryRESTMapper
+
 	// ObjectOrMetadataInformerFactory gives access to informers for typed resources
 	// and dynamic resources by their metadata. All generic controllers currently use
 	// object metadata - if a future controller needs access to the full object this
@@ -122,10 +188,14 @@ func createControllerContext(client clientset.Interface, config *restclient.Conf
 	metadataClient := metadata.NewForConfigOrDie(clientbuilder.ConfigOrDie("metadata-informers"))
 	metadataInformers := metadatainformer.NewSharedInformerFactory(metadataClient, resyncPeriod(componentConfig)())
 
+	discoveryClient := clientbuilder.DiscoveryClientOrDie("controller-discovery")
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
 	ctx := controllerContext{
 		ClientBuilder:                   clientbuilder,
 		ComponentConfig:                 componentConfig,
 		InformerFactory:                 sharedInformers,
+		RESTMapper:                      restMapper,
 		ObjectOrMetadataInformerFactory: informerfactory.NewInformerFactory(sharedInformers, metadataInformers),
 		InformersStarted:                make(chan struct{}),
 		ResyncPeriod:                    resyncPeriod(componentConfig),
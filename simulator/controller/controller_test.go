@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_enabledControllerInitializers(t *testing.T) {
+	t.Parallel()
+	allButGC := []string{}
+	for _, name := range KnownControllers() {
+		if name != "garbagecollector" {
+			allButGC = append(allButGC, name)
+		}
+	}
+	tests := []struct {
+		name        string
+		controllers []string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "'*' enables all controllers enabled by default",
+			controllers: []string{"*"},
+			want:        allButGC,
+		},
+		{
+			name:        "'*' and the controller disabled by default",
+			controllers: []string{"*", "garbagecollector"},
+			want:        KnownControllers(),
+		},
+		{
+			name:        "'-foo' disables the controller",
+			controllers: []string{"*", "-garbagecollector", "-job", "-cronjob"},
+			want:        without(allButGC, "job", "cronjob"),
+		},
+		{
+			name:        "only the specified controllers are enabled without '*'",
+			controllers: []string{"deployment", "replicaset"},
+			want:        []string{"deployment", "replicaset"},
+		},
+		{
+			name:        "the first item matching the controller wins",
+			controllers: []string{"-job", "job", "deployment"},
+			want:        []string{"deployment"},
+		},
+		{
+			name:        "nothing is enabled by the empty list",
+			controllers: []string{},
+			want:        []string{},
+		},
+		{
+			name:        "return error if the controller is unknown",
+			controllers: []string{"*", "-unknown"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := enabledControllerInitializers(tt.controllers)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			names := []string{}
+			for name := range got {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func without(names []string, excluded ...string) []string {
+	result := []string{}
+	for _, n := range names {
+		if !contains(excluded, n) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	"k8s.io/kubernetes/pkg/controller/cronjob"
+)
+
+var _ initFunc = startCronJobController
+
+func startCronJobController(ctx context.Context, controllerCtx controllerContext) error {
+	cronJobController, err := cronjob.NewControllerV2(
+		controllerCtx.InformerFactory.Batch().V1().Jobs(),
+		controllerCtx.InformerFactory.Batch().V1().CronJobs(),
+		controllerCtx.ClientBuilder.ClientOrDie("cronjob-controller"),
+	)
+	if err != nil {
+		return xerrors.Errorf("error creating CronJob controller: %v", err)
+	}
+	go cronJobController.Run(ctx, int(controllerCtx.ComponentConfig.CronJobController.ConcurrentCronJobSyncs))
+	return nil
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/kubernetes/pkg/controller/daemon"
+)
+
+var _ initFunc = startDaemonSetController
+
+func startDaemonSetController(ctx context.Context, controllerCtx controllerContext) error {
+	dsc, err := daemon.NewDaemonSetsController(
+		controllerCtx.InformerFactory.Apps().V1().DaemonSets(),
+		controllerCtx.InformerFactory.Apps().V1().ControllerRevisions(),
+		controllerCtx.InformerFactory.Core().V1().Pods(),
+		controllerCtx.InformerFactory.Core().V1().Nodes(),
+		controllerCtx.ClientBuilder.ClientOrDie("daemon-set-controller"),
+		flowcontrol.NewBackOff(1*time.Second, 15*time.Minute),
+	)
+	if err != nil {
+		return xerrors.Errorf("error creating DaemonSets controller: %v", err)
+	}
+	go dsc.Run(ctx, int(controllerCtx.ComponentConfig.DaemonSetController.ConcurrentDaemonSetSyncs))
+	return nil
+}
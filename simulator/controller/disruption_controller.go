@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/scale"
+	"k8s.io/kubernetes/pkg/controller/disruption"
+)
+
+var _ initFunc = startDisruptionController
+
+// startDisruptionController starts the controller which calculates the status of PodDisruptionBudgets.
+// The preemption of the scheduler takes the PodDisruptionBudgets into account with their status.
+func startDisruptionController(ctx context.Context, controllerCtx controllerContext) error {
+	client := controllerCtx.ClientBuilder.ClientOrDie("disruption-controller")
+	config := controllerCtx.ClientBuilder.ConfigOrDie("disruption-controller")
+	scaleKindResolver := scale.NewDiscoveryScaleKindResolver(client.Discovery())
+	scaleClient, err := scale.NewForConfig(config, controllerCtx.RESTMapper, dynamic.LegacyAPIPathResolverFunc, scaleKindResolver)
+	if err != nil {
+		return xerrors.Errorf("error creating scale client: %v", err)
+	}
+
+	go disruption.NewDisruptionController(
+		controllerCtx.InformerFactory.Core().V1().Pods(),
+		controllerCtx.InformerFactory.Policy().V1().PodDisruptionBudgets(),
+		controllerCtx.InformerFactory.Core().V1().ReplicationControllers(),
+		controllerCtx.InformerFactory.Apps().V1().ReplicaSets(),
+		controllerCtx.InformerFactory.Apps().V1().Deployments(),
+		controllerCtx.InformerFactory.Apps().V1().StatefulSets(),
+		client,
+		controllerCtx.RESTMapper,
+		scaleClient,
+		client.Discovery(),
+	).Run(ctx)
+	return nil
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/metadata"
+	"k8s.io/kubernetes/pkg/controller/garbagecollector"
+)
+
+var _ initFunc = startGarbageCollectorController
+
+// startGarbageCollectorController starts the controller which deletes the objects whose owners are deleted,
+// e.g., the ReplicaSets of a deleted Deployment and their Pods.
+func startGarbageCollectorController(ctx context.Context, controllerCtx controllerContext) error {
+	gcClientset := controllerCtx.ClientBuilder.ClientOrDie("generic-garbage-collector")
+	discoveryClient := controllerCtx.ClientBuilder.DiscoveryClientOrDie("generic-garbage-collector")
+
+	config := controllerCtx.ClientBuilder.ConfigOrDie("generic-garbage-collector")
+	// Each object deletion takes two API calls, so allow 2x more requests for this controller as well as kube-controller-manager.
+	config.QPS *= 2
+	metadataClient, err := metadata.NewForConfig(config)
+	if err != nil {
+		return xerrors.Errorf("error creating metadata client: %v", err)
+	}
+
+	garbageCollector, err := garbagecollector.NewGarbageCollector(
+		gcClientset,
+		metadataClient,
+		controllerCtx.RESTMapper,
+		garbagecollector.DefaultIgnoredResources(),
+		controllerCtx.ObjectOrMetadataInformerFactory,
+		controllerCtx.InformersStarted,
+	)
+	if err != nil {
+		return xerrors.Errorf("error creating garbage collector: %v", err)
+	}
+	go garbageCollector.Run(ctx, int(controllerCtx.ComponentConfig.GarbageCollectorController.ConcurrentGCSyncs))
+
+	// Periodically refresh the RESTMapper with new discovery information and sync the garbage collector.
+	go garbageCollector.Sync(discoveryClient, 30*time.Second, ctx.Done())
+	return nil
+}
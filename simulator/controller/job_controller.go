@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/kubernetes/pkg/controller/job"
+)
+
+var _ initFunc = startJobController
+
+func startJobController(ctx context.Context, controllerCtx controllerContext) error {
+	go job.NewController(
+		controllerCtx.InformerFactory.Core().V1().Pods(),
+		controllerCtx.InformerFactory.Batch().V1().Jobs(),
+		controllerCtx.ClientBuilder.ClientOrDie("job-controller"),
+	).Run(ctx, int(controllerCtx.ComponentConfig.JobController.ConcurrentJobSyncs))
+	return nil
+}
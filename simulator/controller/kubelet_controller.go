@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+const (
+	// RunDurationAnnotationKey is the annotation of the Pod which has how long the Pod runs, e.g., "30s".
+	// The Pod finishes with the phase in ExitPhaseAnnotationKey after it has run for the duration.
+	// The Pod without it keeps running until it's deleted.
+	RunDurationAnnotationKey = "scheduler-simulator/run-duration"
+	// ExitPhaseAnnotationKey is the annotation of the Pod which has the phase the Pod finishes with.
+	// It's "Succeeded" or "Failed", and "Succeeded" by default.
+	ExitPhaseAnnotationKey = "scheduler-simulator/exit-phase"
+
+	fakeKubeletControllerName = "fake-kubelet"
+	fakeKubeletWorkers        = 5
+)
+
+var _ initFunc = startFakeKubeletController
+
+// startFakeKubeletController starts the controller which plays the kubelets of all Nodes.
+// There is no kubelet in the simulator, so the Pods stay Pending after they're bound without it.
+func startFakeKubeletController(ctx context.Context, controllerCtx controllerContext) error {
+	go newFakeKubeletController(
+		controllerCtx.InformerFactory.Core().V1().Pods(),
+		controllerCtx.ClientBuilder.ClientOrDie("fake-kubelet-controller"),
+	).Run(ctx, fakeKubeletWorkers)
+	return nil
+}
+
+// fakeKubeletController moves the bound Pods to Running,
+// and then to Succeeded or Failed after the duration in RunDurationAnnotationKey.
+// It also deletes the Pods being deleted gracefully, which the kubelets do after the containers are stopped.
+type fakeKubeletController struct {
+	client     clientset.Interface
+	podLister  corelisters.PodLister
+	podsSynced cache.InformerSynced
+	queue      workqueue.RateLimitingInterface
+	now        func() time.Time
+}
+
+func newFakeKubeletController(podInformer coreinformers.PodInformer, client clientset.Interface) *fakeKubeletController {
+	c := &fakeKubeletController{
+		client:     client,
+		podLister:  podInformer.Lister(),
+		podsSynced: podInformer.Informer().HasSynced,
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), fakeKubeletControllerName),
+		now:        time.Now,
+	}
+	podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			pod, ok := obj.(*corev1.Pod)
+			return ok && pod.Spec.NodeName != ""
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.enqueue,
+			UpdateFunc: func(_, newObj interface{}) { c.enqueue(newObj) },
+		},
+	})
+	return c
+}
+
+func (c *fakeKubeletController) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Run runs the workers until ctx is done.
+func (c *fakeKubeletController) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s controller", fakeKubeletControllerName)
+	defer klog.Infof("Shutting down %s controller", fakeKubeletControllerName)
+
+	if !cache.WaitForNamedCacheSync(fakeKubeletControllerName, ctx.Done(), c.podsSynced) {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.worker, time.Second)
+	}
+	<-ctx.Done()
+}
+
+func (c *fakeKubeletController) worker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *fakeKubeletController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	requeueAfter, err := c.sync(ctx, key.(string))
+	if err != nil {
+		utilruntime.HandleError(xerrors.Errorf("sync pod %q: %w", key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	if requeueAfter > 0 {
+		c.queue.AddAfter(key, requeueAfter)
+	}
+	return true
+}
+
+// sync moves the Pod to the next phase if it's time to.
+// It returns the time after which the Pod needs to be synced again.
+func (c *fakeKubeletController) sync(ctx context.Context, key string) (time.Duration, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return 0, xerrors.Errorf("split key: %w", err)
+	}
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, xerrors.Errorf("get pod: %w", err)
+	}
+	if pod.Spec.NodeName == "" {
+		return 0, nil
+	}
+
+	if pod.DeletionTimestamp != nil {
+		noGrace := int64(0)
+		err := c.client.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{
+			GracePeriodSeconds: &noGrace,
+			Preconditions:      metav1.NewUIDPreconditions(string(pod.UID)),
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return 0, xerrors.Errorf("delete pod: %w", err)
+		}
+		return 0, nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodPending, "":
+		return 0, c.updateStatus(ctx, runningStatus(pod, metav1.NewTime(c.now())))
+	case corev1.PodRunning:
+		runDuration, exitPhase, ok, err := lifetime(pod)
+		if err != nil {
+			// It won't be fixed by retrying, so the Pod keeps running.
+			klog.Errorf("invalid lifetime of pod %s: %v", key, err)
+			return 0, nil
+		}
+		if !ok {
+			return 0, nil
+		}
+		startTime := pod.CreationTimestamp
+		if pod.Status.StartTime != nil {
+			startTime = *pod.Status.StartTime
+		}
+		if remaining := startTime.Add(runDuration).Sub(c.now()); remaining > 0 {
+			return remaining, nil
+		}
+		return 0, c.updateStatus(ctx, terminatedStatus(pod, exitPhase, metav1.NewTime(c.now())))
+	default:
+		// The Pod has finished.
+		return 0, nil
+	}
+}
+
+func (c *fakeKubeletController) updateStatus(ctx context.Context, pod *corev1.Pod) error {
+	if _, err := c.client.CoreV1().Pods(pod.Namespace).UpdateStatus(ctx, pod, metav1.UpdateOptions{}); err != nil {
+		return xerrors.Errorf("update status of pod: %w", err)
+	}
+	return nil
+}
+
+// lifetime returns how long the Pod runs and the phase it finishes with.
+// ok is false if the Pod keeps running until it's deleted.
+func lifetime(pod *corev1.Pod) (runDuration time.Duration, exitPhase corev1.PodPhase, ok bool, err error) {
+	d, ok := pod.Annotations[RunDurationAnnotationKey]
+	if !ok {
+		return 0, "", false, nil
+	}
+	runDuration, err = time.ParseDuration(d)
+	if err != nil {
+		return 0, "", false, xerrors.Errorf("parse %s: %w", RunDurationAnnotationKey, err)
+	}
+
+	exitPhase = corev1.PodPhase(pod.Annotations[ExitPhaseAnnotationKey])
+	switch exitPhase {
+	case "":
+		exitPhase = corev1.PodSucceeded
+	case corev1.PodSucceeded, corev1.PodFailed:
+	default:
+		return 0, "", false, xerrors.Errorf("%s must be %s or %s: %s", ExitPhaseAnnotationKey, corev1.PodSucceeded, corev1.PodFailed, exitPhase)
+	}
+	return runDuration, exitPhase, true, nil
+}
+
+// runningStatus returns the Pod with the status that all containers have started and are ready.
+func runningStatus(pod *corev1.Pod, now metav1.Time) *corev1.Pod {
+	pod = pod.DeepCopy()
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.StartTime = &now
+	for _, t := range []corev1.PodConditionType{corev1.PodInitialized, corev1.ContainersReady, corev1.PodReady} {
+		podutil.UpdatePodCondition(&pod.Status, &corev1.PodCondition{Type: t, Status: corev1.ConditionTrue, LastTransitionTime: now})
+	}
+	started := true
+	pod.Status.ContainerStatuses = make([]corev1.ContainerStatus, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:    container.Name,
+			Image:   container.Image,
+			Ready:   true,
+			Started: &started,
+			State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: now}},
+		})
+	}
+	return pod
+}
+
+// terminatedStatus returns the Pod with the status that all containers have exited.
+// They exit with 0 if the phase is Succeeded, and with 1 otherwise.
+func terminatedStatus(pod *corev1.Pod, phase corev1.PodPhase, now metav1.Time) *corev1.Pod {
+	pod = pod.DeepCopy()
+	pod.Status.Phase = phase
+	for _, t := range []corev1.PodConditionType{corev1.ContainersReady, corev1.PodReady} {
+		podutil.UpdatePodCondition(&pod.Status, &corev1.PodCondition{Type: t, Status: corev1.ConditionFalse, Reason: "PodCompleted", LastTransitionTime: now})
+	}
+	terminated := corev1.ContainerStateTerminated{Reason: "Completed", FinishedAt: now}
+	if phase != corev1.PodSucceeded {
+		terminated.ExitCode = 1
+		terminated.Reason = "Error"
+	}
+	started := false
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+		t := terminated
+		if cs.State.Running != nil {
+			t.StartedAt = cs.State.Running.StartedAt
+		}
+		cs.Ready = false
+		cs.Started = &started
+		cs.State = corev1.ContainerState{Terminated: &t}
+	}
+	return pod
+}
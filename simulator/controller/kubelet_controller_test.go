@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+//nolint:funlen // For readability.
+func TestFakeKubeletController_sync(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	started := metav1.NewTime(now.Add(-40 * time.Second))
+	pod := func(nodeName string, phase corev1.PodPhase, annotations map[string]string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", Annotations: annotations},
+			Spec:       corev1.PodSpec{NodeName: nodeName, Containers: []corev1.Container{{Name: "c1", Image: "image"}}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		if phase == corev1.PodRunning {
+			p = runningStatus(p, started)
+		}
+		return p
+	}
+	terminating := pod("node1", corev1.PodRunning, nil)
+	terminating.DeletionTimestamp = &started
+
+	tests := []struct {
+		name             string
+		pod              *corev1.Pod
+		wantRequeueAfter time.Duration
+		wantDeleted      bool
+		wantPhase        corev1.PodPhase
+		wantExitCode     int32
+	}{
+		{
+			name:      "start the bound Pod",
+			pod:       pod("node1", corev1.PodPending, nil),
+			wantPhase: corev1.PodRunning,
+		},
+		{
+			name:      "do nothing with the Pod not bound",
+			pod:       pod("", corev1.PodPending, nil),
+			wantPhase: corev1.PodPending,
+		},
+		{
+			name:      "keep running the Pod without the run duration",
+			pod:       pod("node1", corev1.PodRunning, nil),
+			wantPhase: corev1.PodRunning,
+		},
+		{
+			name:             "requeue the Pod until the run duration passes",
+			pod:              pod("node1", corev1.PodRunning, map[string]string{RunDurationAnnotationKey: "1m"}),
+			wantRequeueAfter: 20 * time.Second,
+			wantPhase:        corev1.PodRunning,
+		},
+		{
+			name:      "finish the Pod with Succeeded after the run duration",
+			pod:       pod("node1", corev1.PodRunning, map[string]string{RunDurationAnnotationKey: "30s"}),
+			wantPhase: corev1.PodSucceeded,
+		},
+		{
+			name:         "finish the Pod with the exit phase after the run duration",
+			pod:          pod("node1", corev1.PodRunning, map[string]string{RunDurationAnnotationKey: "30s", ExitPhaseAnnotationKey: "Failed"}),
+			wantPhase:    corev1.PodFailed,
+			wantExitCode: 1,
+		},
+		{
+			name:      "keep running the Pod with the invalid exit phase",
+			pod:       pod("node1", corev1.PodRunning, map[string]string{RunDurationAnnotationKey: "30s", ExitPhaseAnnotationKey: "Unknown"}),
+			wantPhase: corev1.PodRunning,
+		},
+		{
+			name:        "delete the Pod being deleted",
+			pod:         terminating,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			client := fake.NewSimpleClientset(tt.pod)
+			podInformer := informers.NewSharedInformerFactory(client, 0).Core().V1().Pods()
+			assert.NoError(t, podInformer.Informer().GetIndexer().Add(tt.pod))
+			c := newFakeKubeletController(podInformer, client)
+			c.now = func() time.Time { return now }
+
+			requeueAfter, err := c.sync(ctx, "default/pod1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRequeueAfter, requeueAfter)
+
+			got, err := client.CoreV1().Pods("default").Get(ctx, "pod1", metav1.GetOptions{})
+			if tt.wantDeleted {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPhase, got.Status.Phase)
+			switch tt.wantPhase {
+			case corev1.PodRunning:
+				if tt.pod.Status.Phase == corev1.PodPending {
+					assert.Equal(t, now, got.Status.StartTime.Time)
+				}
+				assert.True(t, got.Status.ContainerStatuses[0].Ready)
+				assert.NotNil(t, got.Status.ContainerStatuses[0].State.Running)
+			case corev1.PodSucceeded, corev1.PodFailed:
+				terminated := got.Status.ContainerStatuses[0].State.Terminated
+				assert.Equal(t, tt.wantExitCode, terminated.ExitCode)
+				assert.Equal(t, started, terminated.StartedAt)
+				assert.Equal(t, now, terminated.FinishedAt.Time)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/node"
+)
+
+const (
+	fakeNodeAgentControllerName = "fake-node-agent"
+	fakeNodeAgentWorkers        = 5
+
+	// The same values as the kubelet's defaults.
+	nodeLeaseDurationSeconds  = 40
+	nodeLeaseRenewInterval    = 10 * time.Second
+	nodeStatusReportFrequency = 5 * time.Minute
+)
+
+var _ initFunc = startFakeNodeAgentController
+
+// startFakeNodeAgentController starts the controller which plays the node agents (the kubelets) of all Nodes.
+// The node-lifecycle controller taints and evicts the Nodes without the heartbeats, so it needs to run with this controller.
+func startFakeNodeAgentController(ctx context.Context, controllerCtx controllerContext) error {
+	go newFakeNodeAgentController(
+		controllerCtx.InformerFactory.Core().V1().Nodes(),
+		controllerCtx.ClientBuilder.ClientOrDie("fake-node-agent-controller"),
+	).Run(ctx, fakeNodeAgentWorkers)
+	return nil
+}
+
+// fakeNodeAgentController renews the Leases of the Nodes and reports their conditions periodically.
+// The conditions and the heartbeats follow the failures injected to the Nodes. (See node.FailureAnnotationKey.)
+type fakeNodeAgentController struct {
+	client      clientset.Interface
+	nodeLister  corelisters.NodeLister
+	nodesSynced cache.InformerSynced
+	queue       workqueue.RateLimitingInterface
+	now         func() time.Time
+}
+
+func newFakeNodeAgentController(nodeInformer coreinformers.NodeInformer, client clientset.Interface) *fakeNodeAgentController {
+	c := &fakeNodeAgentController{
+		client:      client,
+		nodeLister:  nodeInformer.Lister(),
+		nodesSynced: nodeInformer.Informer().HasSynced,
+		queue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), fakeNodeAgentControllerName),
+		now:         time.Now,
+	}
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNode, ok1 := oldObj.(*corev1.Node)
+			newNode, ok2 := newObj.(*corev1.Node)
+			// The Node is synced periodically, so it only needs to be synced now when the failures change.
+			if ok1 && ok2 && oldNode.Annotations[node.FailureAnnotationKey] == newNode.Annotations[node.FailureAnnotationKey] {
+				return
+			}
+			c.enqueue(newObj)
+		},
+	})
+	return c
+}
+
+func (c *fakeNodeAgentController) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Run runs the workers until ctx is done.
+func (c *fakeNodeAgentController) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s controller", fakeNodeAgentControllerName)
+	defer klog.Infof("Shutting down %s controller", fakeNodeAgentControllerName)
+
+	if !cache.WaitForNamedCacheSync(fakeNodeAgentControllerName, ctx.Done(), c.nodesSynced) {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.worker, time.Second)
+	}
+	<-ctx.Done()
+}
+
+func (c *fakeNodeAgentController) worker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *fakeNodeAgentController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	found, err := c.sync(ctx, key.(string))
+	if err != nil {
+		utilruntime.HandleError(xerrors.Errorf("sync node %q: %w", key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	if found {
+		c.queue.AddAfter(key, nodeLeaseRenewInterval)
+	}
+	return true
+}
+
+// sync renews the Lease of the Node and reports its conditions unless it's unreachable.
+// It returns false if the Node doesn't exist anymore.
+func (c *fakeNodeAgentController) sync(ctx context.Context, name string) (bool, error) {
+	n, err := c.nodeLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, xerrors.Errorf("get node: %w", err)
+	}
+	if node.HasFailure(n, node.FailureUnreachable) {
+		return true, nil
+	}
+
+	if err := c.renewLease(ctx, n); err != nil {
+		return true, xerrors.Errorf("renew lease: %w", err)
+	}
+	if err := c.reportConditions(ctx, n); err != nil {
+		return true, xerrors.Errorf("report conditions: %w", err)
+	}
+	return true, nil
+}
+
+// renewLease creates or renews the Lease of the Node in the kube-node-lease namespace.
+func (c *fakeNodeAgentController) renewLease(ctx context.Context, n *corev1.Node) error {
+	now := metav1.NewMicroTime(c.now())
+	leases := c.client.CoordinationV1().Leases(corev1.NamespaceNodeLease)
+	lease, err := leases.Get(ctx, n.Name, metav1.GetOptions{})
+	if err == nil {
+		lease.Spec.RenewTime = &now
+		if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+			return xerrors.Errorf("update lease: %w", err)
+		}
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return xerrors.Errorf("get lease: %w", err)
+	}
+
+	holder := n.Name
+	duration := int32(nodeLeaseDurationSeconds)
+	lease = &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n.Name,
+			Namespace: corev1.NamespaceNodeLease,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Node",
+				Name:       n.Name,
+				UID:        n.UID,
+			}},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &now,
+		},
+	}
+	if _, err := leases.Create(ctx, lease, metav1.CreateOptions{}); err != nil {
+		return xerrors.Errorf("create lease: %w", err)
+	}
+	return nil
+}
+
+// reportConditions updates the conditions of the Node if they change or the last report is old.
+func (c *fakeNodeAgentController) reportConditions(ctx context.Context, n *corev1.Node) error {
+	now := metav1.NewTime(c.now())
+	desired := nodeConditions(n)
+	updated := n.DeepCopy()
+	changed := false
+	for _, d := range desired {
+		i := conditionIndex(updated.Status.Conditions, d.Type)
+		if i < 0 {
+			d.LastHeartbeatTime = now
+			d.LastTransitionTime = now
+			updated.Status.Conditions = append(updated.Status.Conditions, d)
+			changed = true
+			continue
+		}
+		current := &updated.Status.Conditions[i]
+		if current.Status != d.Status || current.Reason != d.Reason {
+			current.LastTransitionTime = now
+			changed = true
+		}
+		if now.Sub(current.LastHeartbeatTime.Time) >= nodeStatusReportFrequency {
+			changed = true
+		}
+		current.Status = d.Status
+		current.Reason = d.Reason
+		current.Message = d.Message
+	}
+	if !changed {
+		return nil
+	}
+	for i := range updated.Status.Conditions {
+		if conditionIndex(desired, updated.Status.Conditions[i].Type) >= 0 {
+			updated.Status.Conditions[i].LastHeartbeatTime = now
+		}
+	}
+	if _, err := c.client.CoreV1().Nodes().UpdateStatus(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return xerrors.Errorf("update status of node: %w", err)
+	}
+	return nil
+}
+
+// nodeConditions returns the conditions which the Node reports with the failures injected to it.
+// The reasons are the same as the kubelet's.
+func nodeConditions(n *corev1.Node) []corev1.NodeCondition {
+	condition := func(t corev1.NodeConditionType, failure node.FailureType, failed corev1.ConditionStatus, okReason, failedReason string) corev1.NodeCondition {
+		if node.HasFailure(n, failure) {
+			return corev1.NodeCondition{Type: t, Status: failed, Reason: failedReason, Message: "the failure is injected by the simulator"}
+		}
+		ok := corev1.ConditionFalse
+		if failed == corev1.ConditionFalse {
+			ok = corev1.ConditionTrue
+		}
+		return corev1.NodeCondition{Type: t, Status: ok, Reason: okReason}
+	}
+	return []corev1.NodeCondition{
+		condition(corev1.NodeReady, node.FailureNotReady, corev1.ConditionFalse, "KubeletReady", "KubeletNotReady"),
+		condition(corev1.NodeMemoryPressure, node.FailureMemoryPressure, corev1.ConditionTrue, "KubeletHasSufficientMemory", "KubeletHasInsufficientMemory"),
+		condition(corev1.NodeDiskPressure, node.FailureDiskPressure, corev1.ConditionTrue, "KubeletHasNoDiskPressure", "KubeletHasDiskPressure"),
+		condition(corev1.NodePIDPressure, node.FailurePIDPressure, corev1.ConditionTrue, "KubeletHasSufficientPID", "KubeletHasInsufficientPID"),
+		condition(corev1.NodeNetworkUnavailable, node.FailureNetworkUnavailable, corev1.ConditionTrue, "RouteCreated", "NoRouteCreated"),
+	}
+}
+
+func conditionIndex(conditions []corev1.NodeCondition, t corev1.NodeConditionType) int {
+	for i := range conditions {
+		if conditions[i].Type == t {
+			return i
+		}
+	}
+	return -1
+}
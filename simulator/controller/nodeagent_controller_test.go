@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/node"
+)
+
+//nolint:funlen // For readability.
+func TestFakeNodeAgentController_sync(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := metav1.NewTime(now.Add(-time.Minute))
+	healthy := func(heartbeat metav1.Time) []corev1.NodeCondition {
+		conditions := nodeConditions(&corev1.Node{})
+		for i := range conditions {
+			conditions[i].LastHeartbeatTime = heartbeat
+			conditions[i].LastTransitionTime = heartbeat
+		}
+		return conditions
+	}
+	tests := []struct {
+		name           string
+		failures       string
+		conditions     []corev1.NodeCondition
+		wantLease      bool
+		wantConditions map[corev1.NodeConditionType]corev1.ConditionStatus
+		wantHeartbeat  metav1.Time
+	}{
+		{
+			name:      "report the healthy conditions of the new Node",
+			wantLease: true,
+			wantConditions: map[corev1.NodeConditionType]corev1.ConditionStatus{
+				corev1.NodeReady:              corev1.ConditionTrue,
+				corev1.NodeMemoryPressure:     corev1.ConditionFalse,
+				corev1.NodeDiskPressure:       corev1.ConditionFalse,
+				corev1.NodePIDPressure:        corev1.ConditionFalse,
+				corev1.NodeNetworkUnavailable: corev1.ConditionFalse,
+			},
+			wantHeartbeat: metav1.NewTime(now),
+		},
+		{
+			name:       "report the conditions with the failures",
+			failures:   "NotReady,MemoryPressure,NetworkUnavailable",
+			conditions: healthy(recent),
+			wantLease:  true,
+			wantConditions: map[corev1.NodeConditionType]corev1.ConditionStatus{
+				corev1.NodeReady:              corev1.ConditionFalse,
+				corev1.NodeMemoryPressure:     corev1.ConditionTrue,
+				corev1.NodeDiskPressure:       corev1.ConditionFalse,
+				corev1.NodePIDPressure:        corev1.ConditionFalse,
+				corev1.NodeNetworkUnavailable: corev1.ConditionTrue,
+			},
+			wantHeartbeat: metav1.NewTime(now),
+		},
+		{
+			name:       "renew only the lease if the conditions don't change",
+			conditions: healthy(recent),
+			wantLease:  true,
+			wantConditions: map[corev1.NodeConditionType]corev1.ConditionStatus{
+				corev1.NodeReady: corev1.ConditionTrue,
+			},
+			wantHeartbeat: recent,
+		},
+		{
+			name:       "send no heartbeats from the unreachable Node",
+			failures:   "Unreachable",
+			conditions: healthy(recent),
+			wantLease:  false,
+			wantConditions: map[corev1.NodeConditionType]corev1.ConditionStatus{
+				corev1.NodeReady: corev1.ConditionTrue,
+			},
+			wantHeartbeat: recent,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			n := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1", UID: "uid1", Annotations: map[string]string{node.FailureAnnotationKey: tt.failures}},
+				Status:     corev1.NodeStatus{Conditions: tt.conditions},
+			}
+			client := fake.NewSimpleClientset(n)
+			nodeInformer := informers.NewSharedInformerFactory(client, 0).Core().V1().Nodes()
+			assert.NoError(t, nodeInformer.Informer().GetIndexer().Add(n))
+			c := newFakeNodeAgentController(nodeInformer, client)
+			c.now = func() time.Time { return now }
+
+			found, err := c.sync(ctx, "node1")
+			assert.NoError(t, err)
+			assert.True(t, found)
+
+			lease, err := client.CoordinationV1().Leases(corev1.NamespaceNodeLease).Get(ctx, "node1", metav1.GetOptions{})
+			if tt.wantLease {
+				assert.NoError(t, err)
+				assert.Equal(t, now, lease.Spec.RenewTime.Time)
+				assert.Equal(t, "node1", lease.OwnerReferences[0].Name)
+			} else {
+				assert.True(t, apierrors.IsNotFound(err))
+			}
+
+			got, err := client.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+			assert.NoError(t, err)
+			for ct, want := range tt.wantConditions {
+				i := conditionIndex(got.Status.Conditions, ct)
+				assert.GreaterOrEqual(t, i, 0, "condition %s not found", ct)
+				assert.Equal(t, want, got.Status.Conditions[i].Status, "status of %s", ct)
+				assert.True(t, tt.wantHeartbeat.Equal(&got.Status.Conditions[i].LastHeartbeatTime), "heartbeat of %s", ct)
+			}
+
+			// The lease is renewed next time.
+			if tt.wantLease {
+				later := now.Add(nodeLeaseRenewInterval)
+				c.now = func() time.Time { return later }
+				_, err = c.sync(ctx, "node1")
+				assert.NoError(t, err)
+				lease, err = client.CoordinationV1().Leases(corev1.NamespaceNodeLease).Get(ctx, "node1", metav1.GetOptions{})
+				assert.NoError(t, err)
+				assert.Equal(t, later, lease.Spec.RenewTime.Time)
+			}
+		})
+	}
+}
+
+func TestFakeNodeAgentController_sync_NotFound(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	c := newFakeNodeAgentController(informers.NewSharedInformerFactory(client, 0).Core().V1().Nodes(), client)
+
+	found, err := c.sync(context.Background(), "node1")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
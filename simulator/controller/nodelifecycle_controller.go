@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	"k8s.io/kubernetes/pkg/controller/nodelifecycle"
+)
+
+var _ initFunc = startNodeLifecycleController
+
+// startNodeLifecycleController starts the controller which monitors the heartbeats of the Nodes,
+// taints the Nodes by their conditions, and evicts the Pods from the unhealthy Nodes.
+// The heartbeats are sent by the fake node agent controller.
+func startNodeLifecycleController(ctx context.Context, controllerCtx controllerContext) error {
+	lifecycleController, err := nodelifecycle.NewNodeLifecycleController(
+		ctx,
+		controllerCtx.InformerFactory.Coordination().V1().Leases(),
+		controllerCtx.InformerFactory.Core().V1().Pods(),
+		controllerCtx.InformerFactory.Core().V1().Nodes(),
+		controllerCtx.InformerFactory.Apps().V1().DaemonSets(),
+		controllerCtx.ClientBuilder.ClientOrDie("node-controller"),
+		controllerCtx.ComponentConfig.KubeCloudShared.NodeMonitorPeriod.Duration,
+		controllerCtx.ComponentConfig.NodeLifecycleController.NodeStartupGracePeriod.Duration,
+		controllerCtx.ComponentConfig.NodeLifecycleController.NodeMonitorGracePeriod.Duration,
+		controllerCtx.ComponentConfig.NodeLifecycleController.PodEvictionTimeout.Duration,
+		controllerCtx.ComponentConfig.NodeLifecycleController.NodeEvictionRate,
+		controllerCtx.ComponentConfig.NodeLifecycleController.SecondaryNodeEvictionRate,
+		controllerCtx.ComponentConfig.NodeLifecycleController.LargeClusterSizeThreshold,
+		controllerCtx.ComponentConfig.NodeLifecycleController.UnhealthyZoneThreshold,
+		controllerCtx.ComponentConfig.NodeLifecycleController.EnableTaintManager,
+	)
+	if err != nil {
+		return xerrors.Errorf("error creating node lifecycle controller: %v", err)
+	}
+	go lifecycleController.Run(ctx)
+	return nil
+}
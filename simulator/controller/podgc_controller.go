@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/kubernetes/pkg/controller/podgc"
+)
+
+var _ initFunc = startPodGCController
+
+// startPodGCController starts the controller which deletes the Pods bound to the Nodes which don't exist anymore,
+// so that the workload controllers recreate them on the other Nodes.
+func startPodGCController(ctx context.Context, controllerCtx controllerContext) error {
+	go podgc.NewPodGC(
+		ctx,
+		controllerCtx.ClientBuilder.ClientOrDie("pod-garbage-collector"),
+		controllerCtx.InformerFactory.Core().V1().Pods(),
+		controllerCtx.InformerFactory.Core().V1().Nodes(),
+		int(controllerCtx.ComponentConfig.PodGCController.TerminatedPodGCThreshold),
+	).Run(ctx)
+	return nil
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apiserver/pkg/quota/v1/generic"
+	pkgcontroller "k8s.io/kubernetes/pkg/controller"
+	resourcequotacontroller "k8s.io/kubernetes/pkg/controller/resourcequota"
+	quotainstall "k8s.io/kubernetes/pkg/quota/v1/install"
+)
+
+var _ initFunc = startResourceQuotaController
+
+// startResourceQuotaController starts the controller which calculates the usage of ResourceQuotas.
+// The ResourceQuota admission plugin rejects Pods until the usage is calculated.
+func startResourceQuotaController(ctx context.Context, controllerCtx controllerContext) error {
+	resourceQuotaControllerClient := controllerCtx.ClientBuilder.ClientOrDie("resourcequota-controller")
+	resourceQuotaControllerDiscoveryClient := controllerCtx.ClientBuilder.DiscoveryClientOrDie("resourcequota-controller")
+	discoveryFunc := resourceQuotaControllerDiscoveryClient.ServerPreferredNamespacedResources
+	listerFuncForResource := generic.ListerFuncForResourceFunc(controllerCtx.InformerFactory.ForResource)
+	quotaConfiguration := quotainstall.NewQuotaConfigurationForControllers(listerFuncForResource)
+
+	resourceQuotaController, err := resourcequotacontroller.NewController(&resourcequotacontroller.ControllerOptions{
+		QuotaClient:               resourceQuotaControllerClient.CoreV1(),
+		ResourceQuotaInformer:     controllerCtx.InformerFactory.Core().V1().ResourceQuotas(),
+		ResyncPeriod:              pkgcontroller.StaticResyncPeriodFunc(controllerCtx.ComponentConfig.ResourceQuotaController.ResourceQuotaSyncPeriod.Duration),
+		InformerFactory:           controllerCtx.ObjectOrMetadataInformerFactory,
+		ReplenishmentResyncPeriod: controllerCtx.ResyncPeriod,
+		DiscoveryFunc:             discoveryFunc,
+		IgnoredResourcesFunc:      quotaConfiguration.IgnoredResources,
+		InformersStarted:          controllerCtx.InformersStarted,
+		Registry:                  generic.NewRegistry(quotaConfiguration.Evaluators()),
+		UpdateFilter:              quotainstall.DefaultUpdateFilter(),
+	})
+	if err != nil {
+		return xerrors.Errorf("error creating ResourceQuota controller: %v", err)
+	}
+	go resourceQuotaController.Run(ctx, int(controllerCtx.ComponentConfig.ResourceQuotaController.ConcurrentResourceQuotaSyncs))
+
+	// Periodically sync the quota controller to detect new resource types.
+	go resourceQuotaController.Sync(discoveryFunc, 30*time.Second, ctx.Done())
+	return nil
+}
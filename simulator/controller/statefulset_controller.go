@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/kubernetes/pkg/controller/statefulset"
+)
+
+var _ initFunc = startStatefulSetController
+
+func startStatefulSetController(ctx context.Context, controllerCtx controllerContext) error {
+	go statefulset.NewStatefulSetController(
+		controllerCtx.InformerFactory.Core().V1().Pods(),
+		controllerCtx.InformerFactory.Apps().V1().StatefulSets(),
+		controllerCtx.InformerFactory.Core().V1().PersistentVolumeClaims(),
+		controllerCtx.InformerFactory.Apps().V1().ControllerRevisions(),
+		controllerCtx.ClientBuilder.ClientOrDie("statefulset-controller"),
+	).Run(ctx, int(controllerCtx.ComponentConfig.StatefulSetController.ConcurrentStatefulSetSyncs))
+	return nil
+}
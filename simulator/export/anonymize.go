@@ -0,0 +1,538 @@
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// anonymizedPrefix is the prefix of the anonymized names.
+const anonymizedPrefix = "anon-"
+
+// keptNames are the names which the simulator treats specially, so they aren't anonymized.
+var keptNames = map[string]bool{
+	metav1.NamespaceDefault:   true,
+	metav1.NamespaceSystem:    true,
+	metav1.NamespacePublic:    true,
+	corev1.NamespaceNodeLease: true,
+	"system-cluster-critical": true,
+	"system-node-critical":    true,
+}
+
+// keptLabelValueKeys are the label keys whose values don't have to be anonymized,
+// because they don't have anything specific to a cluster and they are useful to analyze the scheduling.
+var keptLabelValueKeys = map[string]bool{
+	corev1.LabelOSStable:               true,
+	corev1.LabelArchStable:             true,
+	corev1.LabelInstanceTypeStable:     true,
+	corev1.LabelInstanceType:           true,
+	"beta.kubernetes.io/os":            true,
+	"beta.kubernetes.io/arch":          true,
+	"node.kubernetes.io/windows-build": true,
+}
+
+// Anonymizer replaces the names, the label values and the images in the resources with their hashes,
+// and drops the fields which don't affect the scheduling. (e.g., env, command and annotations.)
+// The same value is always replaced with the same hash by an Anonymizer,
+// so the references between the objects (e.g., selectors, affinities, owner references and PV/PVC bindings) still match.
+// The hashes are salted with a random key for each Anonymizer, so that they can't be reversed by hashing the guessed names.
+//
+// The Pods and the PVCs of the StatefulSets keep the names which the StatefulSet controller gives them,
+// i.e., "<set>-<ordinal>" and "<template>-<set>-<ordinal>" with the anonymized set and template names,
+// if the StatefulSets are anonymized before them.
+type Anonymizer struct {
+	key []byte
+	// ordinalPrefixes maps the prefixes of the names of the Pods and the PVCs of the StatefulSets (e.g., "web-" and "data-web-")
+	// to the anonymized ones.
+	ordinalPrefixes map[string]string
+}
+
+// NewAnonymizer initializes Anonymizer with a random key.
+func NewAnonymizer() *Anonymizer {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		// crypto/rand.Read doesn't fail on the supported platforms.
+		panic(err)
+	}
+	return &Anonymizer{key: key, ordinalPrefixes: map[string]string{}}
+}
+
+// Name returns the anonymized value of the name.
+// The names which the simulator treats specially (e.g., "default", "kube-system" and "system-node-critical") are kept.
+func (a *Anonymizer) Name(name string) string {
+	if name == "" || keptNames[name] || strings.HasPrefix(name, anonymizedPrefix) {
+		return name
+	}
+	if i := strings.LastIndex(name, "-"); i >= 0 {
+		if prefix, ok := a.ordinalPrefixes[name[:i+1]]; ok && isOrdinal(name[i+1:]) {
+			return prefix + name[i+1:]
+		}
+	}
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(name))
+	return anonymizedPrefix + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// Anonymize anonymizes all resources in r.
+//
+//nolint:funlen,cyclop // For readability.
+func (a *Anonymizer) Anonymize(r *ResourcesForExport) {
+	// The StatefulSets are added first so that the names of their Pods and PVCs are derived from them.
+	for i := range r.StatefulSets {
+		a.addStatefulSet(&r.StatefulSets[i])
+	}
+	for i := range r.Pods {
+		a.pod(&r.Pods[i])
+	}
+	for i := range r.Nodes {
+		a.node(&r.Nodes[i])
+	}
+	for i := range r.Pvs {
+		a.persistentVolume(&r.Pvs[i])
+	}
+	for i := range r.Pvcs {
+		a.objectMeta(&r.Pvcs[i].ObjectMeta)
+		a.persistentVolumeClaimSpec(&r.Pvcs[i].Spec)
+		r.Pvcs[i].Status.Conditions = nil
+	}
+	for i := range r.StorageClasses {
+		sc := &r.StorageClasses[i]
+		a.objectMeta(&sc.ObjectMeta)
+		sc.Parameters = nil
+		sc.MountOptions = nil
+		for j := range sc.AllowedTopologies {
+			for k := range sc.AllowedTopologies[j].MatchLabelExpressions {
+				e := &sc.AllowedTopologies[j].MatchLabelExpressions[k]
+				for l := range e.Values {
+					e.Values[l] = a.labelValue(e.Key, e.Values[l])
+				}
+			}
+		}
+	}
+	for i := range r.PriorityClasses {
+		a.objectMeta(&r.PriorityClasses[i].ObjectMeta)
+		r.PriorityClasses[i].Description = ""
+	}
+	for i := range r.Namespaces {
+		a.objectMeta(&r.Namespaces[i].ObjectMeta)
+	}
+	for i := range r.Deployments {
+		a.objectMeta(&r.Deployments[i].ObjectMeta)
+		a.labelSelector(r.Deployments[i].Spec.Selector)
+		a.podTemplate(&r.Deployments[i].Spec.Template)
+		r.Deployments[i].Status.Conditions = nil
+	}
+	for i := range r.ReplicaSets {
+		a.objectMeta(&r.ReplicaSets[i].ObjectMeta)
+		a.labelSelector(r.ReplicaSets[i].Spec.Selector)
+		a.podTemplate(&r.ReplicaSets[i].Spec.Template)
+		r.ReplicaSets[i].Status.Conditions = nil
+	}
+	for i := range r.StatefulSets {
+		a.statefulSet(&r.StatefulSets[i])
+	}
+	for i := range r.DaemonSets {
+		a.objectMeta(&r.DaemonSets[i].ObjectMeta)
+		a.labelSelector(r.DaemonSets[i].Spec.Selector)
+		a.podTemplate(&r.DaemonSets[i].Spec.Template)
+		r.DaemonSets[i].Status.Conditions = nil
+	}
+	for i := range r.Jobs {
+		a.objectMeta(&r.Jobs[i].ObjectMeta)
+		a.labelSelector(r.Jobs[i].Spec.Selector)
+		a.podTemplate(&r.Jobs[i].Spec.Template)
+		r.Jobs[i].Status.Conditions = nil
+	}
+	for i := range r.PodDisruptionBudgets {
+		a.objectMeta(&r.PodDisruptionBudgets[i].ObjectMeta)
+		a.labelSelector(r.PodDisruptionBudgets[i].Spec.Selector)
+		r.PodDisruptionBudgets[i].Status.DisruptedPods = nil
+		r.PodDisruptionBudgets[i].Status.Conditions = nil
+	}
+	for i := range r.ResourceQuotas {
+		q := &r.ResourceQuotas[i]
+		a.objectMeta(&q.ObjectMeta)
+		if q.Spec.ScopeSelector != nil {
+			for j := range q.Spec.ScopeSelector.MatchExpressions {
+				e := &q.Spec.ScopeSelector.MatchExpressions[j]
+				if e.ScopeName != corev1.ResourceQuotaScopePriorityClass {
+					continue
+				}
+				for k := range e.Values {
+					e.Values[k] = a.Name(e.Values[k])
+				}
+			}
+		}
+	}
+	for i := range r.LimitRanges {
+		a.objectMeta(&r.LimitRanges[i].ObjectMeta)
+	}
+	for i := range r.CSINodes {
+		a.objectMeta(&r.CSINodes[i].ObjectMeta)
+		for j := range r.CSINodes[i].Spec.Drivers {
+			r.CSINodes[i].Spec.Drivers[j].NodeID = a.Name(r.CSINodes[i].Spec.Drivers[j].NodeID)
+		}
+	}
+	for i := range r.CSIDrivers {
+		// The name of CSIDriver is kept, since it's the name of the driver referred by the PVs and the StorageClasses.
+		name := r.CSIDrivers[i].Name
+		a.objectMeta(&r.CSIDrivers[i].ObjectMeta)
+		r.CSIDrivers[i].Name = name
+	}
+	for i := range r.CSIStorageCapacities {
+		c := &r.CSIStorageCapacities[i]
+		a.objectMeta(&c.ObjectMeta)
+		a.labelSelector(c.NodeTopology)
+		c.StorageClassName = a.Name(c.StorageClassName)
+	}
+	for i := range r.RuntimeClasses {
+		rc := &r.RuntimeClasses[i]
+		a.objectMeta(&rc.ObjectMeta)
+		if rc.Scheduling != nil {
+			a.nodeSelectorMap(rc.Scheduling.NodeSelector)
+			a.tolerations(rc.Scheduling.Tolerations)
+		}
+	}
+}
+
+// labelValue returns the anonymized value of the label or the selector.
+func (a *Anonymizer) labelValue(key, value string) string {
+	if keptLabelValueKeys[key] {
+		return value
+	}
+	return a.Name(value)
+}
+
+func (a *Anonymizer) objectMeta(m *metav1.ObjectMeta) {
+	m.Name = a.Name(m.Name)
+	m.Namespace = a.Name(m.Namespace)
+	m.GenerateName = ""
+	for k, v := range m.Labels {
+		m.Labels[k] = a.labelValue(k, v)
+	}
+	m.Annotations = nil
+	m.ManagedFields = nil
+	for i := range m.OwnerReferences {
+		m.OwnerReferences[i].Name = a.Name(m.OwnerReferences[i].Name)
+	}
+}
+
+func (a *Anonymizer) labelSelector(s *metav1.LabelSelector) {
+	if s == nil {
+		return
+	}
+	for k, v := range s.MatchLabels {
+		s.MatchLabels[k] = a.labelValue(k, v)
+	}
+	for i := range s.MatchExpressions {
+		e := &s.MatchExpressions[i]
+		for j := range e.Values {
+			e.Values[j] = a.labelValue(e.Key, e.Values[j])
+		}
+	}
+}
+
+func (a *Anonymizer) nodeSelectorMap(m map[string]string) {
+	for k, v := range m {
+		m[k] = a.labelValue(k, v)
+	}
+}
+
+func (a *Anonymizer) nodeSelectorTerm(t *corev1.NodeSelectorTerm) {
+	for i := range t.MatchExpressions {
+		e := &t.MatchExpressions[i]
+		for j := range e.Values {
+			e.Values[j] = a.labelValue(e.Key, e.Values[j])
+		}
+	}
+	// The only supported field is metadata.name.
+	for i := range t.MatchFields {
+		e := &t.MatchFields[i]
+		for j := range e.Values {
+			e.Values[j] = a.Name(e.Values[j])
+		}
+	}
+}
+
+func (a *Anonymizer) nodeSelector(s *corev1.NodeSelector) {
+	if s == nil {
+		return
+	}
+	for i := range s.NodeSelectorTerms {
+		a.nodeSelectorTerm(&s.NodeSelectorTerms[i])
+	}
+}
+
+func (a *Anonymizer) podAffinityTerm(t *corev1.PodAffinityTerm) {
+	a.labelSelector(t.LabelSelector)
+	a.labelSelector(t.NamespaceSelector)
+	for i := range t.Namespaces {
+		t.Namespaces[i] = a.Name(t.Namespaces[i])
+	}
+}
+
+func (a *Anonymizer) affinity(aff *corev1.Affinity) {
+	if aff == nil {
+		return
+	}
+	if na := aff.NodeAffinity; na != nil {
+		a.nodeSelector(na.RequiredDuringSchedulingIgnoredDuringExecution)
+		for i := range na.PreferredDuringSchedulingIgnoredDuringExecution {
+			a.nodeSelectorTerm(&na.PreferredDuringSchedulingIgnoredDuringExecution[i].Preference)
+		}
+	}
+	if pa := aff.PodAffinity; pa != nil {
+		for i := range pa.RequiredDuringSchedulingIgnoredDuringExecution {
+			a.podAffinityTerm(&pa.RequiredDuringSchedulingIgnoredDuringExecution[i])
+		}
+		for i := range pa.PreferredDuringSchedulingIgnoredDuringExecution {
+			a.podAffinityTerm(&pa.PreferredDuringSchedulingIgnoredDuringExecution[i].PodAffinityTerm)
+		}
+	}
+	if paa := aff.PodAntiAffinity; paa != nil {
+		for i := range paa.RequiredDuringSchedulingIgnoredDuringExecution {
+			a.podAffinityTerm(&paa.RequiredDuringSchedulingIgnoredDuringExecution[i])
+		}
+		for i := range paa.PreferredDuringSchedulingIgnoredDuringExecution {
+			a.podAffinityTerm(&paa.PreferredDuringSchedulingIgnoredDuringExecution[i].PodAffinityTerm)
+		}
+	}
+}
+
+func (a *Anonymizer) tolerations(ts []corev1.Toleration) {
+	for i := range ts {
+		ts[i].Value = a.labelValue(ts[i].Key, ts[i].Value)
+	}
+}
+
+// container returns the container which only has the fields affecting the scheduling.
+// The image is kept as the hash so that ImageLocality plugin still works with the images on the Nodes.
+func (a *Anonymizer) container(c *corev1.Container) corev1.Container {
+	var ports []corev1.ContainerPort
+	for _, p := range c.Ports {
+		ports = append(ports, corev1.ContainerPort{ContainerPort: p.ContainerPort, HostPort: p.HostPort, Protocol: p.Protocol})
+	}
+	return corev1.Container{
+		Name:      a.Name(c.Name),
+		Image:     a.Name(c.Image),
+		Resources: c.Resources,
+		Ports:     ports,
+	}
+}
+
+// volumes returns the volumes which affect the scheduling. (i.e., the PVCs and the volumes counted by the volume limits.)
+func (a *Anonymizer) volumes(vs []corev1.Volume) []corev1.Volume {
+	var ret []corev1.Volume
+	for i := range vs {
+		v := vs[i].DeepCopy()
+		src := corev1.VolumeSource{}
+		switch {
+		case v.PersistentVolumeClaim != nil:
+			src.PersistentVolumeClaim = v.PersistentVolumeClaim
+			src.PersistentVolumeClaim.ClaimName = a.Name(src.PersistentVolumeClaim.ClaimName)
+		case v.Ephemeral != nil:
+			src.Ephemeral = v.Ephemeral
+			if t := src.Ephemeral.VolumeClaimTemplate; t != nil {
+				a.objectMeta(&t.ObjectMeta)
+				a.persistentVolumeClaimSpec(&t.Spec)
+			}
+		case v.AWSElasticBlockStore != nil, v.GCEPersistentDisk != nil, v.AzureDisk != nil:
+			src.AWSElasticBlockStore = v.AWSElasticBlockStore
+			src.GCEPersistentDisk = v.GCEPersistentDisk
+			src.AzureDisk = v.AzureDisk
+			a.cloudDisks(&src)
+		default:
+			continue
+		}
+		ret = append(ret, corev1.Volume{Name: a.Name(v.Name), VolumeSource: src})
+	}
+	return ret
+}
+
+// cloudDisks anonymizes the IDs of the in-tree cloud disks.
+// They are hashed instead of being dropped, because the volume limits count the unique IDs.
+func (a *Anonymizer) cloudDisks(src *corev1.VolumeSource) {
+	if src.AWSElasticBlockStore != nil {
+		src.AWSElasticBlockStore.VolumeID = a.Name(src.AWSElasticBlockStore.VolumeID)
+	}
+	if src.GCEPersistentDisk != nil {
+		src.GCEPersistentDisk.PDName = a.Name(src.GCEPersistentDisk.PDName)
+	}
+	if src.AzureDisk != nil {
+		src.AzureDisk.DiskName = a.Name(src.AzureDisk.DiskName)
+		src.AzureDisk.DataDiskURI = a.Name(src.AzureDisk.DataDiskURI)
+	}
+}
+
+//nolint:cyclop // For readability.
+func (a *Anonymizer) podSpec(s *corev1.PodSpec) {
+	for i := range s.InitContainers {
+		s.InitContainers[i] = a.container(&s.InitContainers[i])
+	}
+	for i := range s.Containers {
+		s.Containers[i] = a.container(&s.Containers[i])
+	}
+	s.EphemeralContainers = nil
+	s.Volumes = a.volumes(s.Volumes)
+	s.NodeName = a.Name(s.NodeName)
+	a.nodeSelectorMap(s.NodeSelector)
+	a.affinity(s.Affinity)
+	a.tolerations(s.Tolerations)
+	for i := range s.TopologySpreadConstraints {
+		a.labelSelector(s.TopologySpreadConstraints[i].LabelSelector)
+	}
+	s.PriorityClassName = a.Name(s.PriorityClassName)
+	if s.RuntimeClassName != nil {
+		name := a.Name(*s.RuntimeClassName)
+		s.RuntimeClassName = &name
+	}
+	s.ServiceAccountName = ""
+	s.DeprecatedServiceAccount = ""
+	s.ImagePullSecrets = nil
+	s.Hostname = ""
+	s.Subdomain = ""
+	s.HostAliases = nil
+	s.DNSConfig = nil
+	s.SecurityContext = nil
+	s.ReadinessGates = nil
+}
+
+func (a *Anonymizer) podTemplate(t *corev1.PodTemplateSpec) {
+	a.objectMeta(&t.ObjectMeta)
+	a.podSpec(&t.Spec)
+}
+
+func (a *Anonymizer) pod(p *corev1.Pod) {
+	a.objectMeta(&p.ObjectMeta)
+	a.podSpec(&p.Spec)
+	conditions := make([]corev1.PodCondition, 0, len(p.Status.Conditions))
+	for _, c := range p.Status.Conditions {
+		conditions = append(conditions, corev1.PodCondition{Type: c.Type, Status: c.Status, Reason: c.Reason})
+	}
+	p.Status = corev1.PodStatus{
+		Phase:             p.Status.Phase,
+		Conditions:        conditions,
+		NominatedNodeName: a.Name(p.Status.NominatedNodeName),
+		QOSClass:          p.Status.QOSClass,
+	}
+}
+
+func (a *Anonymizer) node(n *corev1.Node) {
+	a.objectMeta(&n.ObjectMeta)
+	n.Spec.PodCIDR = ""
+	n.Spec.PodCIDRs = nil
+	n.Spec.ProviderID = ""
+	n.Spec.ConfigSource = nil
+	for i := range n.Spec.Taints {
+		n.Spec.Taints[i].Value = a.labelValue(n.Spec.Taints[i].Key, n.Spec.Taints[i].Value)
+	}
+
+	n.Status.Addresses = nil
+	n.Status.NodeInfo.MachineID = ""
+	n.Status.NodeInfo.SystemUUID = ""
+	n.Status.NodeInfo.BootID = ""
+	n.Status.NodeInfo.KernelVersion = ""
+	n.Status.NodeInfo.OSImage = ""
+	for i := range n.Status.Images {
+		for j := range n.Status.Images[i].Names {
+			n.Status.Images[i].Names[j] = a.Name(n.Status.Images[i].Names[j])
+		}
+	}
+	for i := range n.Status.Conditions {
+		n.Status.Conditions[i].Message = ""
+	}
+	n.Status.VolumesInUse = nil
+	n.Status.VolumesAttached = nil
+	n.Status.Config = nil
+}
+
+func (a *Anonymizer) persistentVolumeClaimSpec(s *corev1.PersistentVolumeClaimSpec) {
+	a.labelSelector(s.Selector)
+	if s.StorageClassName != nil {
+		name := a.Name(*s.StorageClassName)
+		s.StorageClassName = &name
+	}
+	s.VolumeName = a.Name(s.VolumeName)
+	s.DataSource = nil
+	s.DataSourceRef = nil
+}
+
+//nolint:cyclop // For readability.
+func (a *Anonymizer) persistentVolume(pv *corev1.PersistentVolume) {
+	a.objectMeta(&pv.ObjectMeta)
+	if ref := pv.Spec.ClaimRef; ref != nil {
+		ref.Name = a.Name(ref.Name)
+		ref.Namespace = a.Name(ref.Namespace)
+		ref.ResourceVersion = ""
+	}
+	pv.Spec.StorageClassName = a.Name(pv.Spec.StorageClassName)
+	pv.Spec.MountOptions = nil
+	if pv.Spec.NodeAffinity != nil {
+		a.nodeSelector(pv.Spec.NodeAffinity.Required)
+	}
+	src := &pv.Spec.PersistentVolumeSource
+	if src.CSI != nil {
+		src.CSI.VolumeHandle = a.Name(src.CSI.VolumeHandle)
+		src.CSI.VolumeAttributes = nil
+		src.CSI.ControllerPublishSecretRef = nil
+		src.CSI.NodeStageSecretRef = nil
+		src.CSI.NodePublishSecretRef = nil
+		src.CSI.ControllerExpandSecretRef = nil
+	}
+	if src.HostPath != nil {
+		src.HostPath.Path = "/" + a.Name(src.HostPath.Path)
+	}
+	if src.Local != nil {
+		src.Local.Path = "/" + a.Name(src.Local.Path)
+	}
+	if src.NFS != nil {
+		src.NFS.Server = a.Name(src.NFS.Server)
+		src.NFS.Path = "/" + a.Name(src.NFS.Path)
+	}
+	if src.AWSElasticBlockStore != nil {
+		src.AWSElasticBlockStore.VolumeID = a.Name(src.AWSElasticBlockStore.VolumeID)
+	}
+	if src.GCEPersistentDisk != nil {
+		src.GCEPersistentDisk.PDName = a.Name(src.GCEPersistentDisk.PDName)
+	}
+	if src.AzureDisk != nil {
+		src.AzureDisk.DiskName = a.Name(src.AzureDisk.DiskName)
+		src.AzureDisk.DataDiskURI = a.Name(src.AzureDisk.DataDiskURI)
+	}
+	pv.Status.Message = ""
+}
+
+// addStatefulSet makes the names of the Pods and the PVCs of the StatefulSet derived from its anonymized name.
+func (a *Anonymizer) addStatefulSet(ss *appsv1.StatefulSet) {
+	set := a.Name(ss.Name)
+	a.ordinalPrefixes[ss.Name+"-"] = set + "-"
+	for i := range ss.Spec.VolumeClaimTemplates {
+		tmpl := ss.Spec.VolumeClaimTemplates[i].Name
+		a.ordinalPrefixes[tmpl+"-"+ss.Name+"-"] = a.Name(tmpl) + "-" + set + "-"
+	}
+}
+
+func (a *Anonymizer) statefulSet(ss *appsv1.StatefulSet) {
+	a.objectMeta(&ss.ObjectMeta)
+	a.labelSelector(ss.Spec.Selector)
+	a.podTemplate(&ss.Spec.Template)
+	ss.Spec.ServiceName = a.Name(ss.Spec.ServiceName)
+	for i := range ss.Spec.VolumeClaimTemplates {
+		a.objectMeta(&ss.Spec.VolumeClaimTemplates[i].ObjectMeta)
+		a.persistentVolumeClaimSpec(&ss.Spec.VolumeClaimTemplates[i].Spec)
+	}
+	ss.Status.Conditions = nil
+}
+
+// isOrdinal returns true if s is the ordinal of a StatefulSet's Pod.
+func isOrdinal(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && strconv.Itoa(n) == s
+}
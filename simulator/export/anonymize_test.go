@@ -0,0 +1,197 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+)
+
+//nolint:funlen // For readability.
+func TestAnonymizer_Anonymize(t *testing.T) {
+	t.Parallel()
+	r := &ResourcesForExport{
+		Namespaces: []corev1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"kubernetes.io/metadata.name": "payments"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		},
+		PriorityClasses: []schedulingv1.PriorityClass{
+			{ObjectMeta: metav1.ObjectMeta{Name: "system-node-critical"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "payments-high"}, Description: "for the payments team"},
+		},
+		Nodes: []corev1.Node{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "node-10-0-0-1.internal",
+					Labels:      map[string]string{corev1.LabelHostname: "node-10-0-0-1.internal", corev1.LabelOSStable: "linux", "team": "payments"},
+					Annotations: map[string]string{"internal.example.com/owner": "alice"},
+				},
+				Spec: corev1.NodeSpec{ProviderID: "aws:///i-0123", Taints: []corev1.Taint{{Key: "dedicated", Value: "payments", Effect: corev1.TaintEffectNoSchedule}}},
+				Status: corev1.NodeStatus{
+					Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}},
+					Images:    []corev1.ContainerImage{{Names: []string{"registry.example.com/payments/api:v1"}}},
+				},
+			},
+		},
+		Pvs: []corev1.PersistentVolume{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-payments"},
+				Spec: corev1.PersistentVolumeSpec{
+					ClaimRef:               &corev1.ObjectReference{Namespace: "payments", Name: "data"},
+					PersistentVolumeSource: corev1.PersistentVolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/mnt/payments"}},
+				},
+			},
+		},
+		Pvcs: []corev1.PersistentVolumeClaim{
+			{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "payments"}, Spec: corev1.PersistentVolumeClaimSpec{VolumeName: "pv-payments"}},
+		},
+		ReplicaSets: []appsv1.ReplicaSet{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "api-abc", Namespace: "payments"},
+				Spec: appsv1.ReplicaSetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "payments-api"}},
+				},
+			},
+		},
+		Pods: []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "api-abc-xyz",
+					Namespace:       "payments",
+					Labels:          map[string]string{"app": "payments-api"},
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "api-abc"}},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:          "node-10-0-0-1.internal",
+					PriorityClassName: "payments-high",
+					Containers: []corev1.Container{{
+						Name:    "api",
+						Image:   "registry.example.com/payments/api:v1",
+						Command: []string{"/api", "--db=postgres://secret"},
+						Env:     []corev1.EnvVar{{Name: "TOKEN", Value: "secret"}},
+					}},
+					Volumes: []corev1.Volume{
+						{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+						{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+					},
+					Tolerations: []corev1.Toleration{{Key: "dedicated", Value: "payments", Effect: corev1.TaintEffectNoSchedule}},
+					Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+							LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"payments-api"}}}},
+							TopologyKey:   corev1.LabelHostname,
+						}},
+					}},
+					RuntimeClassName: pointer.String("gvisor"),
+				},
+			},
+		},
+	}
+
+	a := NewAnonymizer()
+	a.Anonymize(r)
+
+	// The system names are kept, but the names only prefixed like them aren't.
+	assert.Equal(t, "kube-system", r.Namespaces[1].Name)
+	assert.Equal(t, "system-node-critical", r.PriorityClasses[0].Name)
+	assert.NotEqual(t, "kube-payments", a.Name("kube-payments"))
+	assert.NotEqual(t, "system-payments", a.Name("system-payments"))
+	assert.Equal(t, "linux", r.Nodes[0].Labels[corev1.LabelOSStable])
+
+	// The names and the label values are replaced consistently.
+	ns := r.Namespaces[0].Name
+	assert.True(t, strings.HasPrefix(ns, anonymizedPrefix))
+	assert.Equal(t, a.Name("payments"), ns)
+	assert.Equal(t, ns, r.Namespaces[0].Labels["kubernetes.io/metadata.name"])
+	nodeName := r.Nodes[0].Name
+	assert.NotEqual(t, "node-10-0-0-1.internal", nodeName)
+	assert.Equal(t, nodeName, r.Nodes[0].Labels[corev1.LabelHostname])
+	assert.Equal(t, a.Name("payments"), r.Nodes[0].Labels["team"])
+	assert.Nil(t, r.Nodes[0].Annotations)
+	assert.Empty(t, r.Nodes[0].Spec.ProviderID)
+	assert.Nil(t, r.Nodes[0].Status.Addresses)
+
+	pod := r.Pods[0]
+	assert.Equal(t, ns, pod.Namespace)
+	assert.Equal(t, nodeName, pod.Spec.NodeName)
+	assert.Equal(t, r.PriorityClasses[1].Name, pod.Spec.PriorityClassName)
+	assert.Empty(t, r.PriorityClasses[1].Description)
+	assert.Equal(t, r.ReplicaSets[0].Name, pod.OwnerReferences[0].Name)
+	assert.Equal(t, a.Name("gvisor"), *pod.Spec.RuntimeClassName)
+	selector, err := metav1.LabelSelectorAsSelector(r.ReplicaSets[0].Spec.Selector)
+	assert.NoError(t, err)
+	assert.True(t, selector.Matches(labels.Set(pod.Labels)))
+	antiAffinity, err := metav1.LabelSelectorAsSelector(pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].LabelSelector)
+	assert.NoError(t, err)
+	assert.True(t, antiAffinity.Matches(labels.Set(pod.Labels)))
+	assert.Equal(t, r.Nodes[0].Spec.Taints[0].Value, pod.Spec.Tolerations[0].Value)
+
+	// The containers only have the fields affecting the scheduling, and the image matches the one on the Node.
+	assert.Equal(t, []corev1.Container{{Name: a.Name("api"), Image: r.Nodes[0].Status.Images[0].Names[0]}}, pod.Spec.Containers)
+	// Only the PVC is kept among the volumes, and it's still bound to the PV.
+	assert.Len(t, pod.Spec.Volumes, 1)
+	pvc := r.Pvcs[0]
+	assert.Equal(t, pvc.Name, pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, r.Pvs[0].Name, pvc.Spec.VolumeName)
+	assert.Equal(t, pvc.Name, r.Pvs[0].Spec.ClaimRef.Name)
+	assert.Equal(t, pvc.Namespace, r.Pvs[0].Spec.ClaimRef.Namespace)
+	assert.Equal(t, "/"+a.Name("/mnt/payments"), r.Pvs[0].Spec.HostPath.Path)
+
+	// Another Anonymizer gives different names.
+	assert.NotEqual(t, a.Name("payments"), NewAnonymizer().Name("payments"))
+}
+
+func TestAnonymizer_Anonymize_statefulSet(t *testing.T) {
+	t.Parallel()
+	r := &ResourcesForExport{
+		Pvcs: []corev1.PersistentVolumeClaim{
+			{ObjectMeta: metav1.ObjectMeta{Name: "data-web-0", Namespace: "payments"}},
+		},
+		StatefulSets: []appsv1.StatefulSet{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "payments"},
+				Spec: appsv1.StatefulSetSpec{
+					VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+				},
+			},
+		},
+		Pods: []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "web-0",
+					Namespace:       "payments",
+					Labels:          map[string]string{appsv1.StatefulSetPodNameLabel: "web-0"},
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web"}},
+				},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-web-0"}}},
+					},
+				},
+			},
+			{ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "payments"}},
+		},
+	}
+
+	a := NewAnonymizer()
+	a.Anonymize(r)
+
+	// The Pod and the PVC have the names which the StatefulSet controller gives them.
+	set := r.StatefulSets[0].Name
+	tmpl := r.StatefulSets[0].Spec.VolumeClaimTemplates[0].Name
+	assert.True(t, strings.HasPrefix(set, anonymizedPrefix))
+	assert.True(t, strings.HasPrefix(tmpl, anonymizedPrefix))
+	pod := r.Pods[0]
+	assert.Equal(t, set+"-0", pod.Name)
+	assert.Equal(t, pod.Name, pod.Labels[appsv1.StatefulSetPodNameLabel])
+	assert.Equal(t, set, pod.OwnerReferences[0].Name)
+	assert.Equal(t, tmpl+"-"+set+"-0", r.Pvcs[0].Name)
+	assert.Equal(t, r.Pvcs[0].Name, pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	// The names without the ordinal are hashed as usual.
+	assert.False(t, strings.HasPrefix(r.Pods[1].Name, set))
+}
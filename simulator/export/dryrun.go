@@ -0,0 +1,76 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// dryRunImported is the names of the objects in the dry-run import which the other objects may depend on.
+// They aren't persisted in the dry-run mode, so kube-apiserver rejects the objects that depend on them
+// unless they already exist in the simulator.
+type dryRunImported struct {
+	namespaces      sets.String
+	priorityClasses sets.String
+	runtimeClasses  sets.String
+}
+
+func newDryRunImported() *dryRunImported {
+	return &dryRunImported{
+		namespaces:      sets.NewString(),
+		priorityClasses: sets.NewString(),
+		runtimeClasses:  sets.NewString(),
+	}
+}
+
+// add adds the objects in r. It must not be called while the objects in the other batch are being applied.
+func (d *dryRunImported) add(r *ResourcesForImport) {
+	for _, ns := range r.Namespaces {
+		if ns.Name != nil {
+			d.namespaces.Insert(*ns.Name)
+		}
+	}
+	for _, pc := range r.PriorityClasses {
+		if pc.Name != nil {
+			d.priorityClasses.Insert(*pc.Name)
+		}
+	}
+	for _, rc := range r.RuntimeClasses {
+		if rc.Name != nil {
+			d.runtimeClasses.Insert(*rc.Name)
+		}
+	}
+}
+
+// missingDependency returns the object in the import that err says doesn't exist.
+// It recognizes the errors from the NamespaceLifecycle, Priority and RuntimeClass admission plugins.
+func (d *dryRunImported) missingDependency(err error) (string, bool) {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return "", false
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		details := status.Status().Details
+		if details != nil && details.Kind == "namespaces" && d.namespaces.Has(details.Name) {
+			return fmt.Sprintf("Namespace %q", details.Name), true
+		}
+	case apierrors.IsForbidden(err):
+		msg := status.Status().Message
+		for _, name := range d.priorityClasses.List() {
+			if strings.Contains(msg, fmt.Sprintf("no PriorityClass with name %s was found", name)) {
+				return fmt.Sprintf("PriorityClass %q", name), true
+			}
+		}
+		for _, name := range d.runtimeClasses.List() {
+			if strings.Contains(msg, fmt.Sprintf("RuntimeClass %q not found", name)) {
+				return fmt.Sprintf("RuntimeClass %q", name), true
+			}
+		}
+	}
+	return "", false
+}
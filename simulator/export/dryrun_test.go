@@ -0,0 +1,72 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/storage/etcd3/testserver"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	cfgnodev1 "k8s.io/client-go/applyconfigurations/node/v1"
+	schedulingcfgv1 "k8s.io/client-go/applyconfigurations/scheduling/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/k8sapiserver"
+)
+
+// TestService_Import_DryRunWithAPIServer checks the report of the dry-run import against a real kube-apiserver,
+// because the admission of the objects depending on the other objects in the same import cannot be faked.
+func TestService_Import_DryRunWithAPIServer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the test with kube-apiserver in short mode")
+	}
+
+	etcdCfg := testserver.NewTestConfig(t)
+	testserver.RunEtcd(t, etcdCfg)
+	restCfg, shutdown, err := k8sapiserver.StartAPIServer("127.0.0.1:0", etcdCfg.ACUrls[0].String(), nil)
+	require.NoError(t, err)
+	t.Cleanup(shutdown)
+	client := clientset.NewForConfigOrDie(restCfg)
+
+	resources := &ResourcesForImport{
+		Namespaces:      []v1.NamespaceApplyConfiguration{*v1.Namespace("team-a")},
+		PriorityClasses: []schedulingcfgv1.PriorityClassApplyConfiguration{*schedulingcfgv1.PriorityClass("high").WithValue(1000)},
+		RuntimeClasses:  []cfgnodev1.RuntimeClassApplyConfiguration{*cfgnodev1.RuntimeClass("gvisor").WithHandler("runsc")},
+		Pods: []v1.PodApplyConfiguration{
+			*v1.Pod("uses-all", "team-a").WithSpec(v1.PodSpec().
+				WithPriorityClassName("high").
+				WithRuntimeClassName("gvisor").
+				WithContainers(v1.Container().WithName("pause").WithImage("registry.k8s.io/pause:3.5"))),
+			*v1.Pod("uses-unknown", "team-a").WithSpec(v1.PodSpec().
+				WithPriorityClassName("unknown").
+				WithContainers(v1.Container().WithName("pause").WithImage("registry.k8s.io/pause:3.5"))),
+		},
+	}
+
+	s := NewExportService(client, nil, nil, nil, nil, nil, nil, nil)
+	report := &ImportReport{}
+	err = s.Import(context.Background(), resources, s.DryRun(), s.IgnoreErr(), s.WithImportReport(report))
+	require.NoError(t, err)
+
+	results := map[string]ObjectResult{}
+	for _, o := range report.Objects {
+		results[o.Kind+"/"+o.Name] = o
+	}
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 4, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	for _, key := range []string{"Namespace/team-a", "PriorityClass/high", "RuntimeClass/gvisor", "Pod/uses-all"} {
+		assert.Equal(t, ImportResultSucceeded, results[key].Result, key)
+	}
+	assert.Equal(t, ImportResultFailed, results["Pod/uses-unknown"].Result)
+	assert.Contains(t, results["Pod/uses-unknown"].Message, "unknown")
+
+	// nothing is persisted.
+	_, err = client.SchedulingV1().PriorityClasses().Get(context.Background(), "high", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = client.CoreV1().Pods("team-a").Get(context.Background(), "uses-all", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
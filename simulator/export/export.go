@@ -11,14 +11,26 @@ package export
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cfgappsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
+	cfgbatchv1 "k8s.io/client-go/applyconfigurations/batch/v1"
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	cfgmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	cfgnodev1 "k8s.io/client-go/applyconfigurations/node/v1"
+	cfgpolicyv1 "k8s.io/client-go/applyconfigurations/policy/v1"
 	schedulingcfgv1 "k8s.io/client-go/applyconfigurations/scheduling/v1"
 	confstoragev1 "k8s.io/client-go/applyconfigurations/storage/v1"
 	clientset "k8s.io/client-go/kubernetes"
@@ -50,6 +62,22 @@ type ResourcesForExport struct {
 	PriorityClasses []schedulingv1.PriorityClass              `json:"priorityClasses"`
 	SchedulerConfig *v1beta2config.KubeSchedulerConfiguration `json:"schedulerConfig"`
 	Namespaces      []corev1.Namespace                        `json:"namespaces"`
+	Deployments     []appsv1.Deployment                       `json:"deployments"`
+	ReplicaSets     []appsv1.ReplicaSet                       `json:"replicaSets"`
+	StatefulSets    []appsv1.StatefulSet                      `json:"statefulSets"`
+	DaemonSets      []appsv1.DaemonSet                        `json:"daemonSets"`
+	Jobs            []batchv1.Job                             `json:"jobs"`
+	// PodDisruptionBudgets are referred by the preemption.
+	PodDisruptionBudgets []policyv1.PodDisruptionBudget `json:"podDisruptionBudgets"`
+	// ResourceQuotas and LimitRanges are used in the admission of Pods.
+	ResourceQuotas []corev1.ResourceQuota `json:"resourceQuotas"`
+	LimitRanges    []corev1.LimitRange    `json:"limitRanges"`
+	// CSINodes, CSIDrivers and CSIStorageCapacities are referred by the volume plugins.
+	CSINodes             []storagev1.CSINode            `json:"csiNodes"`
+	CSIDrivers           []storagev1.CSIDriver          `json:"csiDrivers"`
+	CSIStorageCapacities []storagev1.CSIStorageCapacity `json:"csiStorageCapacities"`
+	// RuntimeClasses are used to set the overhead of Pods in the admission.
+	RuntimeClasses []nodev1.RuntimeClass `json:"runtimeClasses"`
 }
 
 // ResourcesForImport denotes all resources and scheduler configuration for import.
@@ -62,6 +90,22 @@ type ResourcesForImport struct {
 	PriorityClasses []schedulingcfgv1.PriorityClassApplyConfiguration `json:"priorityClasses"`
 	SchedulerConfig *v1beta2config.KubeSchedulerConfiguration         `json:"schedulerConfig"`
 	Namespaces      []v1.NamespaceApplyConfiguration                  `json:"namespaces"`
+	Deployments     []cfgappsv1.DeploymentApplyConfiguration          `json:"deployments"`
+	ReplicaSets     []cfgappsv1.ReplicaSetApplyConfiguration          `json:"replicaSets"`
+	StatefulSets    []cfgappsv1.StatefulSetApplyConfiguration         `json:"statefulSets"`
+	DaemonSets      []cfgappsv1.DaemonSetApplyConfiguration           `json:"daemonSets"`
+	Jobs            []cfgbatchv1.JobApplyConfiguration                `json:"jobs"`
+	// PodDisruptionBudgets are referred by the preemption.
+	PodDisruptionBudgets []cfgpolicyv1.PodDisruptionBudgetApplyConfiguration `json:"podDisruptionBudgets"`
+	// ResourceQuotas and LimitRanges are used in the admission of Pods.
+	ResourceQuotas []v1.ResourceQuotaApplyConfiguration `json:"resourceQuotas"`
+	LimitRanges    []v1.LimitRangeApplyConfiguration    `json:"limitRanges"`
+	// CSINodes, CSIDrivers and CSIStorageCapacities are referred by the volume plugins.
+	CSINodes             []confstoragev1.CSINodeApplyConfiguration            `json:"csiNodes"`
+	CSIDrivers           []confstoragev1.CSIDriverApplyConfiguration          `json:"csiDrivers"`
+	CSIStorageCapacities []confstoragev1.CSIStorageCapacityApplyConfiguration `json:"csiStorageCapacities"`
+	// RuntimeClasses are used to set the overhead of Pods in the admission.
+	RuntimeClasses []cfgnodev1.RuntimeClassApplyConfiguration `json:"runtimeClasses"`
 }
 
 type PodService interface {
@@ -116,11 +160,19 @@ func NewExportService(client clientset.Interface, pods PodService, nodes NodeSer
 type options struct {
 	ignoreErr                    bool
 	ignoreSchedulerConfiguration bool
+	dryRun                       bool
+	report                       *ImportReport
+	anonymize                    bool
+	// dryRunImported is set in the dry-run mode to tell which objects are imported together.
+	dryRunImported *dryRunImported
 }
 
 type (
 	ignoreErrOption                    bool
 	ignoreSchedulerConfigurationOption bool
+	dryRunOption                       bool
+	importReportOption                 struct{ report *ImportReport }
+	anonymizeOption                    bool
 )
 
 type Option interface {
@@ -135,6 +187,41 @@ func (i ignoreSchedulerConfigurationOption) apply(opts *options) {
 	opts.ignoreSchedulerConfiguration = bool(i)
 }
 
+func (d dryRunOption) apply(opts *options) {
+	opts.dryRun = bool(d)
+}
+
+func (i importReportOption) apply(opts *options) {
+	opts.report = i.report
+}
+
+func (a anonymizeOption) apply(opts *options) {
+	opts.anonymize = bool(a)
+}
+
+// applyOptions returns the options for applying the objects with the client.
+func (o options) applyOptions() metav1.ApplyOptions {
+	ao := metav1.ApplyOptions{Force: true, FieldManager: "simulator"}
+	if o.dryRun {
+		ao.DryRun = []string{metav1.DryRunAll}
+	}
+	return ao
+}
+
+// record records the result of applying the object into the report, and returns the error to be handled.
+// In the dry-run mode, the object rejected only because it depends on the object imported together is regarded as validated,
+// because that object isn't persisted by the dry-run.
+func (o options) record(kind string, meta *cfgmetav1.ObjectMetaApplyConfiguration, err error) error {
+	if err != nil && o.dryRunImported != nil {
+		if dep, ok := o.dryRunImported.missingDependency(err); ok {
+			o.report.add(kind, meta, ImportResultSucceeded, fmt.Sprintf("validated without %s, which is imported together", dep))
+			return nil
+		}
+	}
+	o.report.record(kind, meta, err)
+	return err
+}
+
 // IgnoreErr is the option to literally ignore errors.
 // If it is enabled, the method won't return any errors, but just log errors as error logs.
 func (s *Service) IgnoreErr() Option {
@@ -148,6 +235,26 @@ func (s *Service) IgnoreSchedulerConfiguration() Option {
 	return ignoreSchedulerConfigurationOption(true)
 }
 
+// DryRun is the option to validate the resources with the server-side dry-run without persisting them.
+// Note: this option is only for Import method.
+// If it is enabled, the scheduler will not be restarted in import method either.
+func (s *Service) DryRun() Option {
+	return dryRunOption(true)
+}
+
+// WithImportReport is the option to record the result of importing each object into the given report.
+// Note: this option is only for Import method.
+func (s *Service) WithImportReport(r *ImportReport) Option {
+	return importReportOption{report: r}
+}
+
+// Anonymize is the option to anonymize the exported resources with a new Anonymizer.
+// Note: this option is only for Export method.
+// See Anonymizer for what is anonymized.
+func (s *Service) Anonymize() Option {
+	return anonymizeOption(true)
+}
+
 // Get gets all resources from each service.
 func (s *Service) get(ctx context.Context, opts options) (*ResourcesForExport, error) {
 	errgrp := util.NewErrGroupWithSemaphore(ctx)
@@ -174,6 +281,42 @@ func (s *Service) get(ctx context.Context, opts options) (*ResourcesForExport, e
 	if err := s.listNamespaces(ctx, &resources, errgrp, opts); err != nil {
 		return nil, xerrors.Errorf("call listNamespaces: %w", err)
 	}
+	if err := s.listDeployments(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listDeployments: %w", err)
+	}
+	if err := s.listReplicaSets(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listReplicaSets: %w", err)
+	}
+	if err := s.listStatefulSets(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listStatefulSets: %w", err)
+	}
+	if err := s.listDaemonSets(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listDaemonSets: %w", err)
+	}
+	if err := s.listJobs(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listJobs: %w", err)
+	}
+	if err := s.listPodDisruptionBudgets(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listPodDisruptionBudgets: %w", err)
+	}
+	if err := s.listResourceQuotas(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listResourceQuotas: %w", err)
+	}
+	if err := s.listLimitRanges(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listLimitRanges: %w", err)
+	}
+	if err := s.listCSINodes(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listCSINodes: %w", err)
+	}
+	if err := s.listCSIDrivers(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listCSIDrivers: %w", err)
+	}
+	if err := s.listCSIStorageCapacities(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listCSIStorageCapacities: %w", err)
+	}
+	if err := s.listRuntimeClasses(ctx, &resources, errgrp, opts); err != nil {
+		return nil, xerrors.Errorf("call listRuntimeClasses: %w", err)
+	}
 	if err := s.getSchedulerConfig(&resources, errgrp, opts); err != nil {
 		return nil, xerrors.Errorf("call getSchedulerConfig: %w", err)
 	}
@@ -193,18 +336,29 @@ func (s *Service) Export(ctx context.Context, opts ...Option) (*ResourcesForExpo
 	if err != nil {
 		return nil, xerrors.Errorf("export resources all: %w", err)
 	}
+	if options.anonymize {
+		NewAnonymizer().Anonymize(resources)
+	}
 	return resources, nil
 }
 
 // Apply applies all resources from each service.
 //
-//nolint:cyclop // For readability.
+//nolint:funlen,cyclop // For readability.
 func (s *Service) apply(ctx context.Context, resources *ResourcesForImport, opts options) error {
+	if opts.dryRunImported != nil {
+		opts.dryRunImported.add(resources)
+	}
 	errgrp := util.NewErrGroupWithSemaphore(ctx)
 	// `applyNamespaces` must be called before calling namespaced resources  applying.
 	if err := s.applyNamespaces(ctx, resources, errgrp, opts); err != nil {
 		return xerrors.Errorf("call applyNamespaces: %w", err)
 	}
+	// `applyRuntimeClasses` must be called before `applyPods`,
+	// because the Pods referring to nonexistent RuntimeClasses are rejected.
+	if err := s.applyRuntimeClasses(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyRuntimeClasses: %w", err)
+	}
 	if err := errgrp.Wait(); err != nil {
 		return xerrors.Errorf("apply resources: %w", err)
 	}
@@ -221,6 +375,15 @@ func (s *Service) apply(ctx context.Context, resources *ResourcesForImport, opts
 	if err := s.applyNodes(ctx, resources, errgrp, opts); err != nil {
 		return xerrors.Errorf("call applyNodes: %w", err)
 	}
+	if err := s.applyCSINodes(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyCSINodes: %w", err)
+	}
+	if err := s.applyCSIDrivers(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyCSIDrivers: %w", err)
+	}
+	if err := s.applyCSIStorageCapacities(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyCSIStorageCapacities: %w", err)
+	}
 	if err := s.applyPods(ctx, resources, errgrp, opts); err != nil {
 		return xerrors.Errorf("call applyPods: %w", err)
 	}
@@ -228,13 +391,46 @@ func (s *Service) apply(ctx context.Context, resources *ResourcesForImport, opts
 		return xerrors.Errorf("apply resources: %w", err)
 	}
 
+	// The workload controllers should be applied after `applyPods` finished,
+	// so that they adopt the imported Pods instead of creating new ones.
+	if err := s.applyReplicaSets(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyReplicaSets: %w", err)
+	}
+	if err := s.applyStatefulSets(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyStatefulSets: %w", err)
+	}
+	if err := s.applyDaemonSets(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyDaemonSets: %w", err)
+	}
+	if err := s.applyJobs(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyJobs: %w", err)
+	}
+	if err := s.applyPodDisruptionBudgets(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyPodDisruptionBudgets: %w", err)
+	}
+	// The ResourceQuotas and the LimitRanges are applied after `applyPods` finished as well,
+	// because the imported Pods have been admitted in the exported cluster.
+	if err := s.applyResourceQuotas(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyResourceQuotas: %w", err)
+	}
+	if err := s.applyLimitRanges(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyLimitRanges: %w", err)
+	}
+	if err := errgrp.Wait(); err != nil {
+		return xerrors.Errorf("apply workloads and policies: %w", err)
+	}
+
+	// `applyDeployments` should be called after `applyReplicaSets` finished for the same reason.
+	if err := s.applyDeployments(ctx, resources, errgrp, opts); err != nil {
+		return xerrors.Errorf("call applyDeployments: %w", err)
+	}
 	// `applyPvs` should be called after `applyPvcs` finished,
 	// because `applyPvs` look up PersistentVolumeClaim for `Spec.ClaimRef.UID` field.
 	if err := s.applyPvs(ctx, resources, errgrp, opts); err != nil {
 		return xerrors.Errorf("call applyPvs: %w", err)
 	}
 	if err := errgrp.Wait(); err != nil {
-		return xerrors.Errorf("apply PVs: %w", err)
+		return xerrors.Errorf("apply Deployments and PVs: %w", err)
 	}
 	return nil
 }
@@ -243,18 +439,20 @@ func (s *Service) apply(ctx context.Context, resources *ResourcesForImport, opts
 // (1) Restart scheduler based on the data.
 // (2) Apply each resource.
 //   - If UID is not nil, an error will occur. (This is because the api-server will try to find that in existing resources by UID)
+//   - ResourceVersion is cleared as well, because it's used as the precondition of the apply and conflicts with the one in the simulator.
 func (s *Service) Import(ctx context.Context, resources *ResourcesForImport, opts ...Option) error {
 	options := options{}
 	for _, o := range opts {
 		o.apply(&options)
 	}
-	if !options.ignoreSchedulerConfiguration {
-		if err := s.schedulerService.RestartScheduler(resources.SchedulerConfig); err != nil {
-			if !errors.Is(err, scheduler.ErrServiceDisabled) {
-				return xerrors.Errorf("restart scheduler with imported configuration: %w", err)
-			}
-			klog.Info("The scheduler configuration hasn't been imported because of an external scheduler is enabled.")
-		}
+	if options.report != nil {
+		options.report.DryRun = options.dryRun
+	}
+	if options.dryRun {
+		options.dryRunImported = newDryRunImported()
+	}
+	if err := s.restartSchedulerWithImportedConfig(resources.SchedulerConfig, options); err != nil {
+		return err
 	}
 	if err := s.apply(ctx, resources, options); err != nil {
 		return xerrors.Errorf("import resources all: %w", err)
@@ -262,6 +460,21 @@ func (s *Service) Import(ctx context.Context, resources *ResourcesForImport, opt
 	return nil
 }
 
+// restartSchedulerWithImportedConfig restarts the scheduler with the imported configuration unless the options prevent it.
+// The resources decoded from the manifests may not have the scheduler configuration, and then cfg is nil.
+func (s *Service) restartSchedulerWithImportedConfig(cfg *v1beta2config.KubeSchedulerConfiguration, opts options) error {
+	if opts.ignoreSchedulerConfiguration || opts.dryRun || cfg == nil {
+		return nil
+	}
+	if err := s.schedulerService.RestartScheduler(cfg); err != nil {
+		if !errors.Is(err, scheduler.ErrServiceDisabled) {
+			return xerrors.Errorf("restart scheduler with imported configuration: %w", err)
+		}
+		klog.Info("The scheduler configuration hasn't been imported because of an external scheduler is enabled.")
+	}
+	return nil
+}
+
 func (s *Service) listPods(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
 	if err := eg.Go(func() error {
 		pods, err := s.podService.List(ctx, metav1.NamespaceAll)
@@ -378,7 +591,10 @@ func (s *Service) listPcs(ctx context.Context, r *ResourcesForExport, eg *util.S
 
 func (s *Service) listNamespaces(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
 	if err := eg.Go(func() error {
-		nss, err := s.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		nss := &corev1.NamespaceList{}
+		err := util.ListAllPages(ctx, nss, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.CoreV1().Namespaces().List(ctx, listOpts)
+		})
 		if err != nil {
 			if !opts.ignoreErr {
 				return xerrors.Errorf("call list namespace: %w", err)
@@ -400,6 +616,258 @@ func (s *Service) listNamespaces(ctx context.Context, r *ResourcesForExport, eg
 	return nil
 }
 
+func (s *Service) listDeployments(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		deployments := &appsv1.DeploymentList{}
+		err := util.ListAllPages(ctx, deployments, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list Deployments: %w", err)
+			}
+			klog.Errorf("failed to call list Deployments: %v", err)
+			deployments = &appsv1.DeploymentList{Items: []appsv1.Deployment{}}
+		}
+		r.Deployments = deployments.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listReplicaSets(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		replicaSets := &appsv1.ReplicaSetList{}
+		err := util.ListAllPages(ctx, replicaSets, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.AppsV1().ReplicaSets(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list ReplicaSets: %w", err)
+			}
+			klog.Errorf("failed to call list ReplicaSets: %v", err)
+			replicaSets = &appsv1.ReplicaSetList{Items: []appsv1.ReplicaSet{}}
+		}
+		r.ReplicaSets = replicaSets.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listStatefulSets(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		statefulSets := &appsv1.StatefulSetList{}
+		err := util.ListAllPages(ctx, statefulSets, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list StatefulSets: %w", err)
+			}
+			klog.Errorf("failed to call list StatefulSets: %v", err)
+			statefulSets = &appsv1.StatefulSetList{Items: []appsv1.StatefulSet{}}
+		}
+		r.StatefulSets = statefulSets.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listDaemonSets(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		daemonSets := &appsv1.DaemonSetList{}
+		err := util.ListAllPages(ctx, daemonSets, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list DaemonSets: %w", err)
+			}
+			klog.Errorf("failed to call list DaemonSets: %v", err)
+			daemonSets = &appsv1.DaemonSetList{Items: []appsv1.DaemonSet{}}
+		}
+		r.DaemonSets = daemonSets.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listJobs(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		jobs := &batchv1.JobList{}
+		err := util.ListAllPages(ctx, jobs, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list Jobs: %w", err)
+			}
+			klog.Errorf("failed to call list Jobs: %v", err)
+			jobs = &batchv1.JobList{Items: []batchv1.Job{}}
+		}
+		r.Jobs = jobs.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listPodDisruptionBudgets(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		pdbs := &policyv1.PodDisruptionBudgetList{}
+		err := util.ListAllPages(ctx, pdbs, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list PodDisruptionBudgets: %w", err)
+			}
+			klog.Errorf("failed to call list PodDisruptionBudgets: %v", err)
+			pdbs = &policyv1.PodDisruptionBudgetList{Items: []policyv1.PodDisruptionBudget{}}
+		}
+		r.PodDisruptionBudgets = pdbs.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listResourceQuotas(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		quotas := &corev1.ResourceQuotaList{}
+		err := util.ListAllPages(ctx, quotas, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.CoreV1().ResourceQuotas(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list ResourceQuotas: %w", err)
+			}
+			klog.Errorf("failed to call list ResourceQuotas: %v", err)
+			quotas = &corev1.ResourceQuotaList{Items: []corev1.ResourceQuota{}}
+		}
+		r.ResourceQuotas = quotas.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listLimitRanges(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		lrs := &corev1.LimitRangeList{}
+		err := util.ListAllPages(ctx, lrs, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.CoreV1().LimitRanges(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list LimitRanges: %w", err)
+			}
+			klog.Errorf("failed to call list LimitRanges: %v", err)
+			lrs = &corev1.LimitRangeList{Items: []corev1.LimitRange{}}
+		}
+		r.LimitRanges = lrs.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listCSINodes(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		csiNodes := &storagev1.CSINodeList{}
+		err := util.ListAllPages(ctx, csiNodes, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.StorageV1().CSINodes().List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list CSINodes: %w", err)
+			}
+			klog.Errorf("failed to call list CSINodes: %v", err)
+			csiNodes = &storagev1.CSINodeList{Items: []storagev1.CSINode{}}
+		}
+		r.CSINodes = csiNodes.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listCSIDrivers(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		csiDrivers := &storagev1.CSIDriverList{}
+		err := util.ListAllPages(ctx, csiDrivers, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.StorageV1().CSIDrivers().List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list CSIDrivers: %w", err)
+			}
+			klog.Errorf("failed to call list CSIDrivers: %v", err)
+			csiDrivers = &storagev1.CSIDriverList{Items: []storagev1.CSIDriver{}}
+		}
+		r.CSIDrivers = csiDrivers.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listCSIStorageCapacities(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		capacities := &storagev1.CSIStorageCapacityList{}
+		err := util.ListAllPages(ctx, capacities, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.StorageV1().CSIStorageCapacities(metav1.NamespaceAll).List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list CSIStorageCapacities: %w", err)
+			}
+			klog.Errorf("failed to call list CSIStorageCapacities: %v", err)
+			capacities = &storagev1.CSIStorageCapacityList{Items: []storagev1.CSIStorageCapacity{}}
+		}
+		r.CSIStorageCapacities = capacities.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) listRuntimeClasses(ctx context.Context, r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
+	if err := eg.Go(func() error {
+		rcs := &nodev1.RuntimeClassList{}
+		err := util.ListAllPages(ctx, rcs, func(ctx context.Context, listOpts metav1.ListOptions) (runtime.Object, error) {
+			return s.client.NodeV1().RuntimeClasses().List(ctx, listOpts)
+		})
+		if err != nil {
+			if !opts.ignoreErr {
+				return xerrors.Errorf("call list RuntimeClasses: %w", err)
+			}
+			klog.Errorf("failed to call list RuntimeClasses: %v", err)
+			rcs = &nodev1.RuntimeClassList{Items: []nodev1.RuntimeClass{}}
+		}
+		r.RuntimeClasses = rcs.Items
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("start error group: %w", err)
+	}
+	return nil
+}
+
 func (s *Service) getSchedulerConfig(r *ResourcesForExport, eg *util.SemaphoredErrGroup, opts options) error {
 	if err := eg.Go(func() error {
 		ss, err := s.schedulerService.GetSchedulerConfig()
@@ -422,11 +890,20 @@ func (s *Service) applyPcs(ctx context.Context, r *ResourcesForImport, eg *util.
 	for i := range r.PriorityClasses {
 		pc := r.PriorityClasses[i]
 		if isSystemPriorityClass(*pc.Name) {
+			opts.report.recordSkipped("PriorityClass", pc.ObjectMetaApplyConfiguration, "the system PriorityClass is created by kube-apiserver")
 			continue
 		}
 		if err := eg.Go(func() error {
 			pc.ObjectMetaApplyConfiguration.UID = nil
-			_, err := s.priorityclassService.Apply(ctx, &pc)
+			pc.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			var err error
+			if opts.dryRun {
+				pc.WithAPIVersion("scheduling.k8s.io/v1").WithKind("PriorityClass")
+				_, err = s.client.SchedulingV1().PriorityClasses().Apply(ctx, &pc, opts.applyOptions())
+			} else {
+				_, err = s.priorityclassService.Apply(ctx, &pc)
+			}
+			err = opts.record("PriorityClass", pc.ObjectMetaApplyConfiguration, err)
 			if err != nil {
 				if !opts.ignoreErr {
 					return xerrors.Errorf("apply PriorityClass: %w", err)
@@ -446,7 +923,15 @@ func (s *Service) applyStorageClasses(ctx context.Context, r *ResourcesForImport
 		sc := r.StorageClasses[i]
 		if err := eg.Go(func() error {
 			sc.ObjectMetaApplyConfiguration.UID = nil
-			_, err := s.storageClassService.Apply(ctx, &sc)
+			sc.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			var err error
+			if opts.dryRun {
+				sc.WithAPIVersion("storage.k8s.io/v1").WithKind("StorageClass")
+				_, err = s.client.StorageV1().StorageClasses().Apply(ctx, &sc, opts.applyOptions())
+			} else {
+				_, err = s.storageClassService.Apply(ctx, &sc)
+			}
+			err = opts.record("StorageClass", sc.ObjectMetaApplyConfiguration, err)
 			if err != nil {
 				if !opts.ignoreErr {
 					return xerrors.Errorf("apply StorageClass: %w", err)
@@ -466,7 +951,15 @@ func (s *Service) applyPvcs(ctx context.Context, r *ResourcesForImport, eg *util
 		pvc := r.Pvcs[i]
 		if err := eg.Go(func() error {
 			pvc.ObjectMetaApplyConfiguration.UID = nil
-			_, err := s.pvcService.Apply(ctx, *pvc.Namespace, &pvc)
+			pvc.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			var err error
+			if opts.dryRun {
+				pvc.WithAPIVersion("v1").WithKind("PersistentVolumeClaim")
+				_, err = s.client.CoreV1().PersistentVolumeClaims(*pvc.Namespace).Apply(ctx, &pvc, opts.applyOptions())
+			} else {
+				_, err = s.pvcService.Apply(ctx, *pvc.Namespace, &pvc)
+			}
+			err = opts.record("PersistentVolumeClaim", pvc.ObjectMetaApplyConfiguration, err)
 			if err != nil {
 				if !opts.ignoreErr {
 					return xerrors.Errorf("apply PersistentVolumeClaims: %w", err)
@@ -486,6 +979,7 @@ func (s *Service) applyPvs(ctx context.Context, r *ResourcesForImport, eg *util.
 		pv := r.Pvs[i]
 		if err := eg.Go(func() error {
 			pv.ObjectMetaApplyConfiguration.UID = nil
+			pv.ObjectMetaApplyConfiguration.ResourceVersion = nil
 			if pv.Status != nil && pv.Status.Phase != nil {
 				if *pv.Status.Phase == "Bound" {
 					// PersistentVolumeClaims's UID has been changed to a new value.
@@ -498,7 +992,14 @@ func (s *Service) applyPvs(ctx context.Context, r *ResourcesForImport, eg *util.
 					}
 				}
 			}
-			_, err := s.pvService.Apply(ctx, &pv)
+			var err error
+			if opts.dryRun {
+				pv.WithAPIVersion("v1").WithKind("PersistentVolume")
+				_, err = s.client.CoreV1().PersistentVolumes().Apply(ctx, &pv, opts.applyOptions())
+			} else {
+				_, err = s.pvService.Apply(ctx, &pv)
+			}
+			err = opts.record("PersistentVolume", pv.ObjectMetaApplyConfiguration, err)
 			if err != nil {
 				if !opts.ignoreErr {
 					return xerrors.Errorf("apply PersistentVolume: %w", err)
@@ -518,7 +1019,15 @@ func (s *Service) applyNodes(ctx context.Context, r *ResourcesForImport, eg *uti
 		node := r.Nodes[i]
 		if err := eg.Go(func() error {
 			node.ObjectMetaApplyConfiguration.UID = nil
-			_, err := s.nodeService.Apply(ctx, &node)
+			node.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			var err error
+			if opts.dryRun {
+				node.WithAPIVersion("v1").WithKind("Node")
+				_, err = s.client.CoreV1().Nodes().Apply(ctx, &node, opts.applyOptions())
+			} else {
+				_, err = s.nodeService.Apply(ctx, &node)
+			}
+			err = opts.record("Node", node.ObjectMetaApplyConfiguration, err)
 			if err != nil {
 				if !opts.ignoreErr {
 					return xerrors.Errorf("apply Node: %w", err)
@@ -538,7 +1047,16 @@ func (s *Service) applyPods(ctx context.Context, r *ResourcesForImport, eg *util
 		pod := r.Pods[i]
 		if err := eg.Go(func() error {
 			pod.ObjectMetaApplyConfiguration.UID = nil
-			_, err := s.podService.Apply(ctx, *pod.Namespace, &pod)
+			pod.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			pod.ObjectMetaApplyConfiguration.OwnerReferences = removeWorkloadOwnerReferences(pod.OwnerReferences)
+			var err error
+			if opts.dryRun {
+				pod.WithAPIVersion("v1").WithKind("Pod")
+				_, err = s.client.CoreV1().Pods(*pod.Namespace).Apply(ctx, &pod, opts.applyOptions())
+			} else {
+				_, err = s.podService.Apply(ctx, *pod.Namespace, &pod)
+			}
+			err = opts.record("Pod", pod.ObjectMetaApplyConfiguration, err)
 			if err != nil {
 				if !opts.ignoreErr {
 					return xerrors.Errorf("apply Pod: %w", err)
@@ -557,12 +1075,15 @@ func (s *Service) applyNamespaces(ctx context.Context, r *ResourcesForImport, eg
 	for i := range r.Namespaces {
 		ns := r.Namespaces[i]
 		if isIgnoreNamespace(*ns.Name) {
+			opts.report.recordSkipped("Namespace", ns.ObjectMetaApplyConfiguration, "the system Namespace is created by kube-apiserver")
 			continue
 		}
 		if err := eg.Go(func() error {
 			ns.ObjectMetaApplyConfiguration.UID = nil
+			ns.ObjectMetaApplyConfiguration.ResourceVersion = nil
 			ns.WithAPIVersion("v1").WithKind("Namespace")
-			_, err := s.client.CoreV1().Namespaces().Apply(ctx, &ns, metav1.ApplyOptions{Force: true, FieldManager: "simulator"})
+			_, err := s.client.CoreV1().Namespaces().Apply(ctx, &ns, opts.applyOptions())
+			err = opts.record("Namespace", ns.ObjectMetaApplyConfiguration, err)
 			if err != nil {
 				if !opts.ignoreErr {
 					return xerrors.Errorf("apply Namespace: %w", err)
@@ -577,6 +1098,294 @@ func (s *Service) applyNamespaces(ctx context.Context, r *ResourcesForImport, eg
 	return nil
 }
 
+func (s *Service) applyDeployments(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.Deployments {
+		d := r.Deployments[i]
+		if err := eg.Go(func() error {
+			d.ObjectMetaApplyConfiguration.UID = nil
+			d.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			d.WithAPIVersion("apps/v1").WithKind("Deployment")
+			_, err := s.client.AppsV1().Deployments(*d.Namespace).Apply(ctx, &d, opts.applyOptions())
+			err = opts.record("Deployment", d.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply Deployment: %w", err)
+				}
+				klog.Errorf("failed to apply Deployment: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyReplicaSets(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.ReplicaSets {
+		rs := r.ReplicaSets[i]
+		if err := eg.Go(func() error {
+			rs.ObjectMetaApplyConfiguration.UID = nil
+			rs.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			rs.ObjectMetaApplyConfiguration.OwnerReferences = removeWorkloadOwnerReferences(rs.OwnerReferences)
+			rs.WithAPIVersion("apps/v1").WithKind("ReplicaSet")
+			_, err := s.client.AppsV1().ReplicaSets(*rs.Namespace).Apply(ctx, &rs, opts.applyOptions())
+			err = opts.record("ReplicaSet", rs.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply ReplicaSet: %w", err)
+				}
+				klog.Errorf("failed to apply ReplicaSet: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyStatefulSets(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.StatefulSets {
+		ss := r.StatefulSets[i]
+		if err := eg.Go(func() error {
+			ss.ObjectMetaApplyConfiguration.UID = nil
+			ss.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			ss.WithAPIVersion("apps/v1").WithKind("StatefulSet")
+			_, err := s.client.AppsV1().StatefulSets(*ss.Namespace).Apply(ctx, &ss, opts.applyOptions())
+			err = opts.record("StatefulSet", ss.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply StatefulSet: %w", err)
+				}
+				klog.Errorf("failed to apply StatefulSet: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyDaemonSets(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.DaemonSets {
+		ds := r.DaemonSets[i]
+		if err := eg.Go(func() error {
+			ds.ObjectMetaApplyConfiguration.UID = nil
+			ds.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			ds.WithAPIVersion("apps/v1").WithKind("DaemonSet")
+			_, err := s.client.AppsV1().DaemonSets(*ds.Namespace).Apply(ctx, &ds, opts.applyOptions())
+			err = opts.record("DaemonSet", ds.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply DaemonSet: %w", err)
+				}
+				klog.Errorf("failed to apply DaemonSet: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyJobs(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.Jobs {
+		job := r.Jobs[i]
+		if err := eg.Go(func() error {
+			job.ObjectMetaApplyConfiguration.UID = nil
+			job.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			if job.Spec != nil && job.Spec.Selector != nil {
+				// The selector has been generated with the UID in the exported cluster,
+				// so it's kept as a manual selector to select the imported Pods.
+				job.Spec.WithManualSelector(true)
+			}
+			job.WithAPIVersion("batch/v1").WithKind("Job")
+			_, err := s.client.BatchV1().Jobs(*job.Namespace).Apply(ctx, &job, opts.applyOptions())
+			err = opts.record("Job", job.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply Job: %w", err)
+				}
+				klog.Errorf("failed to apply Job: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyPodDisruptionBudgets(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.PodDisruptionBudgets {
+		pdb := r.PodDisruptionBudgets[i]
+		if err := eg.Go(func() error {
+			pdb.ObjectMetaApplyConfiguration.UID = nil
+			pdb.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			pdb.WithAPIVersion("policy/v1").WithKind("PodDisruptionBudget")
+			_, err := s.client.PolicyV1().PodDisruptionBudgets(*pdb.Namespace).Apply(ctx, &pdb, opts.applyOptions())
+			if err == nil && pdb.Status != nil {
+				// The status is applied separately because the disruption controller doesn't run in the simulator.
+				_, err = s.client.PolicyV1().PodDisruptionBudgets(*pdb.Namespace).ApplyStatus(ctx, &pdb, opts.applyOptions())
+			}
+			err = opts.record("PodDisruptionBudget", pdb.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply PodDisruptionBudget: %w", err)
+				}
+				klog.Errorf("failed to apply PodDisruptionBudget: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyResourceQuotas(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.ResourceQuotas {
+		quota := r.ResourceQuotas[i]
+		if err := eg.Go(func() error {
+			quota.ObjectMetaApplyConfiguration.UID = nil
+			quota.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			quota.WithAPIVersion("v1").WithKind("ResourceQuota")
+			_, err := s.client.CoreV1().ResourceQuotas(*quota.Namespace).Apply(ctx, &quota, opts.applyOptions())
+			err = opts.record("ResourceQuota", quota.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply ResourceQuota: %w", err)
+				}
+				klog.Errorf("failed to apply ResourceQuota: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyLimitRanges(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.LimitRanges {
+		lr := r.LimitRanges[i]
+		if err := eg.Go(func() error {
+			lr.ObjectMetaApplyConfiguration.UID = nil
+			lr.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			lr.WithAPIVersion("v1").WithKind("LimitRange")
+			_, err := s.client.CoreV1().LimitRanges(*lr.Namespace).Apply(ctx, &lr, opts.applyOptions())
+			err = opts.record("LimitRange", lr.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply LimitRange: %w", err)
+				}
+				klog.Errorf("failed to apply LimitRange: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyCSINodes(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.CSINodes {
+		csiNode := r.CSINodes[i]
+		if err := eg.Go(func() error {
+			csiNode.ObjectMetaApplyConfiguration.UID = nil
+			csiNode.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			// The CSINode is owned by the Node, whose UID changes when it's imported.
+			csiNode.ObjectMetaApplyConfiguration.OwnerReferences = nil
+			csiNode.WithAPIVersion("storage.k8s.io/v1").WithKind("CSINode")
+			_, err := s.client.StorageV1().CSINodes().Apply(ctx, &csiNode, opts.applyOptions())
+			err = opts.record("CSINode", csiNode.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply CSINode: %w", err)
+				}
+				klog.Errorf("failed to apply CSINode: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyCSIDrivers(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.CSIDrivers {
+		csiDriver := r.CSIDrivers[i]
+		if err := eg.Go(func() error {
+			csiDriver.ObjectMetaApplyConfiguration.UID = nil
+			csiDriver.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			csiDriver.WithAPIVersion("storage.k8s.io/v1").WithKind("CSIDriver")
+			_, err := s.client.StorageV1().CSIDrivers().Apply(ctx, &csiDriver, opts.applyOptions())
+			err = opts.record("CSIDriver", csiDriver.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply CSIDriver: %w", err)
+				}
+				klog.Errorf("failed to apply CSIDriver: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyCSIStorageCapacities(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.CSIStorageCapacities {
+		capacity := r.CSIStorageCapacities[i]
+		if err := eg.Go(func() error {
+			capacity.ObjectMetaApplyConfiguration.UID = nil
+			capacity.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			capacity.WithAPIVersion("storage.k8s.io/v1").WithKind("CSIStorageCapacity")
+			_, err := s.client.StorageV1().CSIStorageCapacities(*capacity.Namespace).Apply(ctx, &capacity, opts.applyOptions())
+			err = opts.record("CSIStorageCapacity", capacity.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply CSIStorageCapacity: %w", err)
+				}
+				klog.Errorf("failed to apply CSIStorageCapacity: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyRuntimeClasses(ctx context.Context, r *ResourcesForImport, eg *util.SemaphoredErrGroup, opts options) error {
+	for i := range r.RuntimeClasses {
+		rc := r.RuntimeClasses[i]
+		if err := eg.Go(func() error {
+			rc.ObjectMetaApplyConfiguration.UID = nil
+			rc.ObjectMetaApplyConfiguration.ResourceVersion = nil
+			rc.WithAPIVersion("node.k8s.io/v1").WithKind("RuntimeClass")
+			_, err := s.client.NodeV1().RuntimeClasses().Apply(ctx, &rc, opts.applyOptions())
+			err = opts.record("RuntimeClass", rc.ObjectMetaApplyConfiguration, err)
+			if err != nil {
+				if !opts.ignoreErr {
+					return xerrors.Errorf("apply RuntimeClass: %w", err)
+				}
+				klog.Errorf("failed to apply RuntimeClass: %v", err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return nil
+}
+
 // isSystemPriorityClass returns whether the given name of PriorityClass is prefixed with `system-` or not.
 // The `system-` prefix is reserved by Kubernetes, and users cannot create a PriorityClass with such a name.
 // See: https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/#priorityclass
@@ -600,3 +1409,35 @@ func isSystemNamespace(name string) bool {
 func isIgnoreNamespace(name string) bool {
 	return isSystemNamespace(name) || name == "default"
 }
+
+// workloadGroupKinds are the workload controllers which are exported and imported with their Pods.
+var workloadGroupKinds = map[schema.GroupKind]bool{
+	appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind():  true,
+	appsv1.SchemeGroupVersion.WithKind("ReplicaSet").GroupKind():  true,
+	appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind(): true,
+	appsv1.SchemeGroupVersion.WithKind("DaemonSet").GroupKind():   true,
+	batchv1.SchemeGroupVersion.WithKind("Job").GroupKind():        true,
+}
+
+// isWorkloadOwner returns whether the owner is one of the workload controllers.
+func isWorkloadOwner(apiVersion, kind string) bool {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return false
+	}
+	return workloadGroupKinds[gv.WithKind(kind).GroupKind()]
+}
+
+// removeWorkloadOwnerReferences removes the references to the workload controllers.
+// The UIDs of the controllers change when they're imported, so the references would point to nothing.
+// Instead, the controllers adopt the orphaned objects matching their selectors after they're imported.
+func removeWorkloadOwnerReferences(refs []cfgmetav1.OwnerReferenceApplyConfiguration) []cfgmetav1.OwnerReferenceApplyConfiguration {
+	var result []cfgmetav1.OwnerReferenceApplyConfiguration
+	for _, ref := range refs {
+		if ref.APIVersion != nil && ref.Kind != nil && isWorkloadOwner(*ref.APIVersion, *ref.Kind) {
+			continue
+		}
+		result = append(result, ref)
+	}
+	return result
+}
@@ -5,18 +5,28 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	cfgappsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
+	cfgbatchv1 "k8s.io/client-go/applyconfigurations/batch/v1"
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	cfgnodev1 "k8s.io/client-go/applyconfigurations/node/v1"
+	cfgpolicyv1 "k8s.io/client-go/applyconfigurations/policy/v1"
 	schedulingcfgv1 "k8s.io/client-go/applyconfigurations/scheduling/v1"
 	confstoragev1 "k8s.io/client-go/applyconfigurations/storage/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -1465,6 +1475,24 @@ func TestService_Import(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "the scheduler isn't restarted when the resources don't have the scheduler configuration",
+			prepareEachServiceMockFn: func(pods *mock_export.MockPodService, nodes *mock_export.MockNodeService, pvs *mock_export.MockPersistentVolumeService, pvcs *mock_export.MockPersistentVolumeClaimService, storageClasss *mock_export.MockStorageClassService, pcs *mock_export.MockPriorityClassService, schedulers *mock_export.MockSchedulerService) {
+				nodes.EXPECT().Apply(gomock.Any(), gomock.Any()).Return(&corev1.Node{}, nil).Do(func(_ context.Context, cfg *v1.NodeApplyConfiguration) {
+					assert.Equal(t, "Node1", *cfg.Name)
+				})
+			},
+			applyConfiguration: func() *ResourcesForImport {
+				return &ResourcesForImport{
+					Nodes: []v1.NodeApplyConfiguration{*v1.Node("Node1")},
+				}
+			},
+			prepareFakeClientSetFn: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				return c
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -2291,3 +2319,327 @@ func TestService_Import_WithIgnoreSchedulerConfigurationOption(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Export_Workloads(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	pods := mock_export.NewMockPodService(ctrl)
+	pods.EXPECT().List(gomock.Any(), gomock.Any()).Return(&corev1.PodList{Items: []corev1.Pod{}}, nil)
+	nodes := mock_export.NewMockNodeService(ctrl)
+	nodes.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{Items: []corev1.Node{}}, nil)
+	pvs := mock_export.NewMockPersistentVolumeService(ctrl)
+	pvs.EXPECT().List(gomock.Any()).Return(&corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{}}, nil)
+	pvcs := mock_export.NewMockPersistentVolumeClaimService(ctrl)
+	pvcs.EXPECT().List(gomock.Any(), gomock.Any()).Return(&corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{}}, nil)
+	storageClasss := mock_export.NewMockStorageClassService(ctrl)
+	storageClasss.EXPECT().List(gomock.Any()).Return(&storagev1.StorageClassList{Items: []storagev1.StorageClass{}}, nil)
+	pcs := mock_export.NewMockPriorityClassService(ctrl)
+	pcs.EXPECT().List(gomock.Any()).Return(&schedulingv1.PriorityClassList{Items: []schedulingv1.PriorityClass{}}, nil)
+	schedulers := mock_export.NewMockSchedulerService(ctrl)
+	schedulers.EXPECT().GetSchedulerConfig().Return(nil, scheduler.ErrServiceDisabled)
+
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment1", Namespace: testDefaultNamespaceName1}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "replicaset1", Namespace: testDefaultNamespaceName1}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "statefulset1", Namespace: testDefaultNamespaceName2}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "daemonset1", Namespace: testDefaultNamespaceName1}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: testDefaultNamespaceName2}},
+	)
+	s := NewExportService(c, pods, nodes, pvs, pvcs, storageClasss, pcs, schedulers)
+	r, err := s.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Name: "deployment1", Namespace: testDefaultNamespaceName1}}}, r.Deployments)
+	assert.Equal(t, []appsv1.ReplicaSet{{ObjectMeta: metav1.ObjectMeta{Name: "replicaset1", Namespace: testDefaultNamespaceName1}}}, r.ReplicaSets)
+	assert.Equal(t, []appsv1.StatefulSet{{ObjectMeta: metav1.ObjectMeta{Name: "statefulset1", Namespace: testDefaultNamespaceName2}}}, r.StatefulSets)
+	assert.Equal(t, []appsv1.DaemonSet{{ObjectMeta: metav1.ObjectMeta{Name: "daemonset1", Namespace: testDefaultNamespaceName1}}}, r.DaemonSets)
+	assert.Equal(t, []batchv1.Job{{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: testDefaultNamespaceName2}}}, r.Jobs)
+}
+
+func TestService_Import_Workloads(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	var mu sync.Mutex
+	applied := []string{}
+	patches := map[string]map[string]interface{}{}
+	record := func(resource string, patch []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		applied = append(applied, resource)
+		if patch != nil {
+			obj := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(patch, &obj))
+			patches[resource] = obj
+		}
+	}
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		a, ok := action.(k8stesting.PatchAction)
+		assert.True(t, ok)
+		record(a.GetResource().Resource, a.GetPatch())
+		return true, nil, nil
+	})
+
+	pods := mock_export.NewMockPodService(ctrl)
+	pods.EXPECT().Apply(gomock.Any(), testDefaultNamespaceName1, gomock.Any()).Return(&corev1.Pod{}, nil).Do(func(_ context.Context, _ string, cfg *v1.PodApplyConfiguration) {
+		// The reference to the ReplicaSet is removed, and the other one is kept.
+		assert.Len(t, cfg.OwnerReferences, 1)
+		assert.Equal(t, "Custom", *cfg.OwnerReferences[0].Kind)
+		record("pods", nil)
+	})
+	s := NewExportService(c, pods, mock_export.NewMockNodeService(ctrl), mock_export.NewMockPersistentVolumeService(ctrl), mock_export.NewMockPersistentVolumeClaimService(ctrl), mock_export.NewMockStorageClassService(ctrl), mock_export.NewMockPriorityClassService(ctrl), mock_export.NewMockSchedulerService(ctrl))
+
+	resources := &ResourcesForImport{
+		Pods: []v1.PodApplyConfiguration{
+			*v1.Pod("pod1", testDefaultNamespaceName1).WithOwnerReferences(
+				metav1ac.OwnerReference().WithAPIVersion("apps/v1").WithKind("ReplicaSet").WithName("replicaset1").WithUID("old-uid"),
+				metav1ac.OwnerReference().WithAPIVersion("example.com/v1").WithKind("Custom").WithName("custom1").WithUID("custom-uid"),
+			),
+		},
+		Deployments: []cfgappsv1.DeploymentApplyConfiguration{*cfgappsv1.Deployment("deployment1", testDefaultNamespaceName1)},
+		ReplicaSets: []cfgappsv1.ReplicaSetApplyConfiguration{
+			*cfgappsv1.ReplicaSet("replicaset1", testDefaultNamespaceName1).WithOwnerReferences(
+				metav1ac.OwnerReference().WithAPIVersion("apps/v1").WithKind("Deployment").WithName("deployment1").WithUID("old-uid"),
+			),
+		},
+		StatefulSets: []cfgappsv1.StatefulSetApplyConfiguration{*cfgappsv1.StatefulSet("statefulset1", testDefaultNamespaceName1)},
+		DaemonSets:   []cfgappsv1.DaemonSetApplyConfiguration{*cfgappsv1.DaemonSet("daemonset1", testDefaultNamespaceName1)},
+		Jobs: []cfgbatchv1.JobApplyConfiguration{
+			*cfgbatchv1.Job("job1", testDefaultNamespaceName1).WithSpec(cfgbatchv1.JobSpec().WithSelector(metav1ac.LabelSelector().WithMatchLabels(map[string]string{"controller-uid": "old-uid"}))),
+		},
+	}
+	assert.NoError(t, s.Import(context.Background(), resources))
+
+	// The Pods are applied first, and the Deployments are applied last.
+	assert.Len(t, applied, 6)
+	assert.Equal(t, "pods", applied[0])
+	assert.ElementsMatch(t, []string{"replicasets", "statefulsets", "daemonsets", "jobs"}, applied[1:5])
+	assert.Equal(t, "deployments", applied[5])
+
+	assert.Equal(t, "Deployment", patches["deployments"]["kind"])
+	assert.NotContains(t, patches["replicasets"]["metadata"], "ownerReferences")
+	assert.Equal(t, true, patches["jobs"]["spec"].(map[string]interface{})["manualSelector"])
+}
+
+func TestService_Export_Policies(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	pods := mock_export.NewMockPodService(ctrl)
+	pods.EXPECT().List(gomock.Any(), gomock.Any()).Return(&corev1.PodList{Items: []corev1.Pod{}}, nil)
+	nodes := mock_export.NewMockNodeService(ctrl)
+	nodes.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{Items: []corev1.Node{}}, nil)
+	pvs := mock_export.NewMockPersistentVolumeService(ctrl)
+	pvs.EXPECT().List(gomock.Any()).Return(&corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{}}, nil)
+	pvcs := mock_export.NewMockPersistentVolumeClaimService(ctrl)
+	pvcs.EXPECT().List(gomock.Any(), gomock.Any()).Return(&corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{}}, nil)
+	storageClasss := mock_export.NewMockStorageClassService(ctrl)
+	storageClasss.EXPECT().List(gomock.Any()).Return(&storagev1.StorageClassList{Items: []storagev1.StorageClass{}}, nil)
+	pcs := mock_export.NewMockPriorityClassService(ctrl)
+	pcs.EXPECT().List(gomock.Any()).Return(&schedulingv1.PriorityClassList{Items: []schedulingv1.PriorityClass{}}, nil)
+	schedulers := mock_export.NewMockSchedulerService(ctrl)
+	schedulers.EXPECT().GetSchedulerConfig().Return(nil, scheduler.ErrServiceDisabled)
+
+	c := fake.NewSimpleClientset(
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "pdb1", Namespace: testDefaultNamespaceName1}},
+		&corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "quota1", Namespace: testDefaultNamespaceName1}},
+		&corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: "limitrange1", Namespace: testDefaultNamespaceName2}},
+		&storagev1.CSINode{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+		&storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "driver1"}},
+		&storagev1.CSIStorageCapacity{ObjectMeta: metav1.ObjectMeta{Name: "capacity1", Namespace: testDefaultNamespaceName1}},
+		&nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "runtimeclass1"}, Handler: "runc"},
+	)
+	s := NewExportService(c, pods, nodes, pvs, pvcs, storageClasss, pcs, schedulers)
+	r, err := s.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []policyv1.PodDisruptionBudget{{ObjectMeta: metav1.ObjectMeta{Name: "pdb1", Namespace: testDefaultNamespaceName1}}}, r.PodDisruptionBudgets)
+	assert.Equal(t, []corev1.ResourceQuota{{ObjectMeta: metav1.ObjectMeta{Name: "quota1", Namespace: testDefaultNamespaceName1}}}, r.ResourceQuotas)
+	assert.Equal(t, []corev1.LimitRange{{ObjectMeta: metav1.ObjectMeta{Name: "limitrange1", Namespace: testDefaultNamespaceName2}}}, r.LimitRanges)
+	assert.Equal(t, []storagev1.CSINode{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}}, r.CSINodes)
+	assert.Equal(t, []storagev1.CSIDriver{{ObjectMeta: metav1.ObjectMeta{Name: "driver1"}}}, r.CSIDrivers)
+	assert.Equal(t, []storagev1.CSIStorageCapacity{{ObjectMeta: metav1.ObjectMeta{Name: "capacity1", Namespace: testDefaultNamespaceName1}}}, r.CSIStorageCapacities)
+	assert.Equal(t, []nodev1.RuntimeClass{{ObjectMeta: metav1.ObjectMeta{Name: "runtimeclass1"}, Handler: "runc"}}, r.RuntimeClasses)
+}
+
+func TestService_Import_Policies(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	var mu sync.Mutex
+	applied := []string{}
+	patches := map[string]map[string]interface{}{}
+	record := func(resource string, patch []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		applied = append(applied, resource)
+		if patch != nil {
+			obj := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(patch, &obj))
+			patches[resource] = obj
+		}
+	}
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		a, ok := action.(k8stesting.PatchAction)
+		assert.True(t, ok)
+		resource := a.GetResource().Resource
+		if a.GetSubresource() != "" {
+			resource += "/" + a.GetSubresource()
+		}
+		record(resource, a.GetPatch())
+		return true, nil, nil
+	})
+
+	pods := mock_export.NewMockPodService(ctrl)
+	pods.EXPECT().Apply(gomock.Any(), testDefaultNamespaceName1, gomock.Any()).Return(&corev1.Pod{}, nil).Do(func(_ context.Context, _ string, _ *v1.PodApplyConfiguration) {
+		record("pods", nil)
+	})
+	s := NewExportService(c, pods, mock_export.NewMockNodeService(ctrl), mock_export.NewMockPersistentVolumeService(ctrl), mock_export.NewMockPersistentVolumeClaimService(ctrl), mock_export.NewMockStorageClassService(ctrl), mock_export.NewMockPriorityClassService(ctrl), mock_export.NewMockSchedulerService(ctrl))
+
+	resources := &ResourcesForImport{
+		Pods: []v1.PodApplyConfiguration{*v1.Pod("pod1", testDefaultNamespaceName1)},
+		PodDisruptionBudgets: []cfgpolicyv1.PodDisruptionBudgetApplyConfiguration{
+			*cfgpolicyv1.PodDisruptionBudget("pdb1", testDefaultNamespaceName1).WithStatus(cfgpolicyv1.PodDisruptionBudgetStatus().WithDisruptionsAllowed(1)),
+		},
+		ResourceQuotas: []v1.ResourceQuotaApplyConfiguration{*v1.ResourceQuota("quota1", testDefaultNamespaceName1)},
+		LimitRanges:    []v1.LimitRangeApplyConfiguration{*v1.LimitRange("limitrange1", testDefaultNamespaceName1)},
+		CSINodes: []confstoragev1.CSINodeApplyConfiguration{
+			*confstoragev1.CSINode("node1").WithOwnerReferences(metav1ac.OwnerReference().WithAPIVersion("v1").WithKind("Node").WithName("node1").WithUID("old-uid")),
+		},
+		CSIDrivers:           []confstoragev1.CSIDriverApplyConfiguration{*confstoragev1.CSIDriver("driver1")},
+		CSIStorageCapacities: []confstoragev1.CSIStorageCapacityApplyConfiguration{*confstoragev1.CSIStorageCapacity("capacity1", testDefaultNamespaceName1)},
+		RuntimeClasses:       []cfgnodev1.RuntimeClassApplyConfiguration{*cfgnodev1.RuntimeClass("runtimeclass1").WithHandler("runc")},
+	}
+	assert.NoError(t, s.Import(context.Background(), resources))
+
+	assert.Len(t, applied, 9)
+	indexOf := func(resource string) int {
+		for i, r := range applied {
+			if r == resource {
+				return i
+			}
+		}
+		t.Fatalf("%s isn't applied", resource)
+		return -1
+	}
+	// The RuntimeClasses are applied before the Pods, and the ResourceQuotas and the LimitRanges are applied after the Pods.
+	assert.Less(t, indexOf("runtimeclasses"), indexOf("pods"))
+	assert.Less(t, indexOf("pods"), indexOf("resourcequotas"))
+	assert.Less(t, indexOf("pods"), indexOf("limitranges"))
+	// The status of the PodDisruptionBudget is applied too.
+	assert.Less(t, indexOf("poddisruptionbudgets"), indexOf("poddisruptionbudgets/status"))
+	assert.Equal(t, float64(1), patches["poddisruptionbudgets/status"]["status"].(map[string]interface{})["disruptionsAllowed"])
+	assert.NotContains(t, patches["csinodes"]["metadata"], "ownerReferences")
+	assert.Equal(t, "CSIStorageCapacity", patches["csistoragecapacities"]["kind"])
+	assert.Contains(t, applied, "csidrivers")
+}
+
+func TestService_Import_WithImportReportOption(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                     string
+		dryRun                   bool
+		prepareEachServiceMockFn func(pods *mock_export.MockPodService, schedulers *mock_export.MockSchedulerService)
+		wantPatched              []string
+		wantReport               *ImportReport
+	}{
+		{
+			name: "record the result of each object",
+			prepareEachServiceMockFn: func(pods *mock_export.MockPodService, schedulers *mock_export.MockSchedulerService) {
+				schedulers.EXPECT().RestartScheduler(gomock.Any()).Return(nil)
+				pods.EXPECT().Apply(gomock.Any(), testDefaultNamespaceName1, gomock.Any()).Return(&corev1.Pod{}, nil)
+				pods.EXPECT().Apply(gomock.Any(), testDefaultNamespaceName2, gomock.Any()).Return(nil, xerrors.New("pod is invalid"))
+			},
+			wantPatched: []string{"deployments"},
+			wantReport: &ImportReport{
+				Succeeded: 1,
+				Skipped:   1,
+				Failed:    2,
+				Objects: []ObjectResult{
+					{Kind: "Namespace", Name: "kube-system", Result: ImportResultSkipped, Message: "the system Namespace is created by kube-apiserver"},
+					{Kind: "Pod", Namespace: testDefaultNamespaceName1, Name: "pod1", Result: ImportResultSucceeded},
+					{Kind: "Pod", Namespace: testDefaultNamespaceName2, Name: "pod2", Result: ImportResultFailed, Message: "pod is invalid"},
+					{Kind: "Deployment", Namespace: testDefaultNamespaceName1, Name: "deployment1", Result: ImportResultFailed, Message: "deployment is invalid"},
+				},
+			},
+		},
+		{
+			name:   "validate the objects with the client and don't restart the scheduler in the dry-run mode",
+			dryRun: true,
+			prepareEachServiceMockFn: func(pods *mock_export.MockPodService, schedulers *mock_export.MockSchedulerService) {
+				schedulers.EXPECT().RestartScheduler(gomock.Any()).Times(0)
+				pods.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantPatched: []string{"pods", "pods", "deployments"},
+			wantReport: &ImportReport{
+				DryRun:    true,
+				Succeeded: 2,
+				Skipped:   1,
+				Failed:    1,
+				Objects: []ObjectResult{
+					{Kind: "Namespace", Name: "kube-system", Result: ImportResultSkipped, Message: "the system Namespace is created by kube-apiserver"},
+					{Kind: "Pod", Namespace: testDefaultNamespaceName1, Name: "pod1", Result: ImportResultSucceeded},
+					{Kind: "Pod", Namespace: testDefaultNamespaceName2, Name: "pod2", Result: ImportResultSucceeded},
+					{Kind: "Deployment", Namespace: testDefaultNamespaceName1, Name: "deployment1", Result: ImportResultFailed, Message: "deployment is invalid"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			var mu sync.Mutex
+			patched := []string{}
+			c := fake.NewSimpleClientset()
+			c.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				patched = append(patched, action.GetResource().Resource)
+				if action.GetResource().Resource == "deployments" {
+					return true, nil, xerrors.New("deployment is invalid")
+				}
+				return true, nil, nil
+			})
+			pods := mock_export.NewMockPodService(ctrl)
+			schedulers := mock_export.NewMockSchedulerService(ctrl)
+			tt.prepareEachServiceMockFn(pods, schedulers)
+			s := NewExportService(c, pods, mock_export.NewMockNodeService(ctrl), mock_export.NewMockPersistentVolumeService(ctrl), mock_export.NewMockPersistentVolumeClaimService(ctrl), mock_export.NewMockStorageClassService(ctrl), mock_export.NewMockPriorityClassService(ctrl), schedulers)
+
+			config, err := schedulerCfg.DefaultSchedulerConfig()
+			assert.NoError(t, err)
+			resources := &ResourcesForImport{
+				Namespaces:      []v1.NamespaceApplyConfiguration{*v1.Namespace("kube-system")},
+				Pods:            []v1.PodApplyConfiguration{*v1.Pod("pod1", testDefaultNamespaceName1), *v1.Pod("pod2", testDefaultNamespaceName2)},
+				Deployments:     []cfgappsv1.DeploymentApplyConfiguration{*cfgappsv1.Deployment("deployment1", testDefaultNamespaceName1)},
+				SchedulerConfig: config,
+			}
+			report := &ImportReport{}
+			opts := []Option{s.IgnoreErr(), s.WithImportReport(report)}
+			if tt.dryRun {
+				opts = append(opts, s.DryRun())
+			}
+			assert.NoError(t, s.Import(context.Background(), resources, opts...))
+
+			assert.ElementsMatch(t, tt.wantPatched, patched)
+			assert.Equal(t, tt.wantReport.DryRun, report.DryRun)
+			assert.Equal(t, tt.wantReport.Succeeded, report.Succeeded)
+			assert.Equal(t, tt.wantReport.Skipped, report.Skipped)
+			assert.Equal(t, tt.wantReport.Failed, report.Failed)
+			assert.True(t, report.HasFailure())
+			assert.Len(t, report.Objects, len(tt.wantReport.Objects))
+			for _, want := range tt.wantReport.Objects {
+				found := false
+				for _, got := range report.Objects {
+					if got.Kind == want.Kind && got.Namespace == want.Namespace && got.Name == want.Name {
+						found = true
+						assert.Equal(t, want.Result, got.Result)
+						assert.Contains(t, got.Message, want.Message)
+					}
+				}
+				assert.True(t, found, "%s %s/%s isn't in the report", want.Kind, want.Namespace, want.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,644 @@
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	cfgappsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
+	cfgbatchv1 "k8s.io/client-go/applyconfigurations/batch/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	cfgnodev1 "k8s.io/client-go/applyconfigurations/node/v1"
+	cfgpolicyv1 "k8s.io/client-go/applyconfigurations/policy/v1"
+	schedulingcfgv1 "k8s.io/client-go/applyconfigurations/scheduling/v1"
+	confstoragev1 "k8s.io/client-go/applyconfigurations/storage/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
+)
+
+// ManifestFormat is the format of the exported manifests.
+type ManifestFormat string
+
+const (
+	// ManifestFormatYAML is multi-document YAML.
+	ManifestFormatYAML ManifestFormat = "yaml"
+	// ManifestFormatList is v1.List in JSON.
+	ManifestFormatList ManifestFormat = "list"
+	// ManifestFormatNDJSON is newline-delimited JSON, that is, an object in JSON per line.
+	ManifestFormatNDJSON ManifestFormat = "ndjson"
+)
+
+// ErrUnsupportedManifestFormat represents the manifest format isn't supported.
+var ErrUnsupportedManifestFormat = errors.New("unsupported manifest format")
+
+const schedulerConfigurationKind = "KubeSchedulerConfiguration"
+
+// decodeBufferSize is the size of the buffer to sniff whether the manifests are in JSON or YAML.
+const decodeBufferSize = 4096
+
+// manifestExtensions are the extensions of the files which are read as manifests in a directory or a tarball.
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// WriteManifests writes the resources as the manifests that `kubectl apply` understands.
+// The fields populated by kube-apiserver (e.g., uid and resourceVersion) are removed,
+// and the system PriorityClasses and Namespaces are excluded as well as Import does.
+// The references to the workload controllers are removed too because the controllers get new UIDs when they're applied,
+// and they adopt the Pods and the ReplicaSets again.
+// The scheduler configuration isn't written because kube-apiserver doesn't know it.
+func WriteManifests(w io.Writer, r *ResourcesForExport, format ManifestFormat) error {
+	mw, err := newManifestWriter(w, format)
+	if err != nil {
+		return err
+	}
+	for _, obj := range r.manifestObjects() {
+		if err := mw.write(obj); err != nil {
+			return err
+		}
+	}
+	return mw.close()
+}
+
+// manifestWriter writes the objects one by one as the manifests in the format.
+type manifestWriter struct {
+	w      io.Writer
+	format ManifestFormat
+	// written is the number of the written objects.
+	written int
+}
+
+func newManifestWriter(w io.Writer, format ManifestFormat) (*manifestWriter, error) {
+	switch format {
+	case ManifestFormatYAML, ManifestFormatNDJSON:
+	case ManifestFormatList:
+		// The items are written one by one between the header and the footer written by close.
+		if _, err := io.WriteString(w, `{"kind":"List","apiVersion":"v1","metadata":{},"items":[`); err != nil {
+			return nil, xerrors.Errorf("write the header of v1.List: %w", err)
+		}
+	default:
+		return nil, xerrors.Errorf("write manifests in %q: %w", format, ErrUnsupportedManifestFormat)
+	}
+	return &manifestWriter{w: w, format: format}, nil
+}
+
+// write writes the object.
+func (m *manifestWriter) write(obj runtime.Object) error {
+	var (
+		b   []byte
+		sep string
+		err error
+	)
+	switch m.format {
+	case ManifestFormatYAML:
+		b, err = yaml.Marshal(obj)
+		sep = "---\n"
+	case ManifestFormatList:
+		b, err = json.Marshal(obj)
+		sep = ","
+	case ManifestFormatNDJSON:
+		b, err = json.Marshal(obj)
+		b = append(b, '\n')
+	}
+	if err != nil {
+		return xerrors.Errorf("encode %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, err)
+	}
+	if m.written != 0 && sep != "" {
+		if _, err := io.WriteString(m.w, sep); err != nil {
+			return xerrors.Errorf("write the separator: %w", err)
+		}
+	}
+	if _, err := m.w.Write(b); err != nil {
+		return xerrors.Errorf("write manifest: %w", err)
+	}
+	m.written++
+	return nil
+}
+
+// close finishes writing the manifests. It doesn't close the underlying writer.
+func (m *manifestWriter) close() error {
+	if m.format != ManifestFormatList {
+		return nil
+	}
+	if _, err := io.WriteString(m.w, "]}\n"); err != nil {
+		return xerrors.Errorf("write the footer of v1.List: %w", err)
+	}
+	return nil
+}
+
+// manifestObjects returns the resources to be written as manifests in the order they should be applied.
+//
+//nolint:funlen,cyclop,gocyclo,gocognit // For readability.
+func (r *ResourcesForExport) manifestObjects() []runtime.Object {
+	objs := []runtime.Object{}
+	for i := range r.Namespaces {
+		ns := r.Namespaces[i].DeepCopy()
+		if isIgnoreNamespace(ns.Name) {
+			continue
+		}
+		ns.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"}
+		stripServerPopulatedFields(ns)
+		objs = append(objs, ns)
+	}
+	for i := range r.RuntimeClasses {
+		rc := r.RuntimeClasses[i].DeepCopy()
+		rc.TypeMeta = metav1.TypeMeta{APIVersion: nodev1.SchemeGroupVersion.String(), Kind: "RuntimeClass"}
+		stripServerPopulatedFields(rc)
+		objs = append(objs, rc)
+	}
+	for i := range r.PriorityClasses {
+		pc := r.PriorityClasses[i].DeepCopy()
+		if isSystemPriorityClass(pc.Name) {
+			continue
+		}
+		pc.TypeMeta = metav1.TypeMeta{APIVersion: schedulingv1.SchemeGroupVersion.String(), Kind: "PriorityClass"}
+		stripServerPopulatedFields(pc)
+		objs = append(objs, pc)
+	}
+	for i := range r.StorageClasses {
+		sc := r.StorageClasses[i].DeepCopy()
+		sc.TypeMeta = metav1.TypeMeta{APIVersion: storagev1.SchemeGroupVersion.String(), Kind: "StorageClass"}
+		stripServerPopulatedFields(sc)
+		objs = append(objs, sc)
+	}
+	for i := range r.Pvcs {
+		pvc := r.Pvcs[i].DeepCopy()
+		pvc.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"}
+		stripServerPopulatedFields(pvc)
+		objs = append(objs, pvc)
+	}
+	for i := range r.Pvs {
+		pv := r.Pvs[i].DeepCopy()
+		pv.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolume"}
+		if pv.Spec.ClaimRef != nil {
+			// The UID of the PersistentVolumeClaim changes when it's applied.
+			pv.Spec.ClaimRef.UID = ""
+			pv.Spec.ClaimRef.ResourceVersion = ""
+		}
+		stripServerPopulatedFields(pv)
+		objs = append(objs, pv)
+	}
+	for i := range r.Nodes {
+		node := r.Nodes[i].DeepCopy()
+		node.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Node"}
+		stripServerPopulatedFields(node)
+		objs = append(objs, node)
+	}
+	for i := range r.CSIDrivers {
+		d := r.CSIDrivers[i].DeepCopy()
+		d.TypeMeta = metav1.TypeMeta{APIVersion: storagev1.SchemeGroupVersion.String(), Kind: "CSIDriver"}
+		stripServerPopulatedFields(d)
+		objs = append(objs, d)
+	}
+	for i := range r.CSINodes {
+		n := r.CSINodes[i].DeepCopy()
+		n.TypeMeta = metav1.TypeMeta{APIVersion: storagev1.SchemeGroupVersion.String(), Kind: "CSINode"}
+		stripServerPopulatedFields(n)
+		// The CSINode is owned by the Node, whose UID changes when it's applied.
+		n.OwnerReferences = nil
+		objs = append(objs, n)
+	}
+	for i := range r.CSIStorageCapacities {
+		c := r.CSIStorageCapacities[i].DeepCopy()
+		c.TypeMeta = metav1.TypeMeta{APIVersion: storagev1.SchemeGroupVersion.String(), Kind: "CSIStorageCapacity"}
+		stripServerPopulatedFields(c)
+		objs = append(objs, c)
+	}
+	for i := range r.Deployments {
+		d := r.Deployments[i].DeepCopy()
+		d.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"}
+		stripServerPopulatedFields(d)
+		objs = append(objs, d)
+	}
+	for i := range r.ReplicaSets {
+		rs := r.ReplicaSets[i].DeepCopy()
+		rs.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "ReplicaSet"}
+		stripServerPopulatedFields(rs)
+		stripWorkloadOwnerReferences(rs)
+		objs = append(objs, rs)
+	}
+	for i := range r.StatefulSets {
+		ss := r.StatefulSets[i].DeepCopy()
+		ss.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "StatefulSet"}
+		stripServerPopulatedFields(ss)
+		objs = append(objs, ss)
+	}
+	for i := range r.DaemonSets {
+		ds := r.DaemonSets[i].DeepCopy()
+		ds.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "DaemonSet"}
+		stripServerPopulatedFields(ds)
+		objs = append(objs, ds)
+	}
+	for i := range r.Jobs {
+		job := r.Jobs[i].DeepCopy()
+		job.TypeMeta = metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"}
+		if job.Spec.Selector != nil {
+			// The selector has been generated with the UID of the Job, which changes when it's applied.
+			job.Spec.ManualSelector = pointer.Bool(true)
+		}
+		stripServerPopulatedFields(job)
+		objs = append(objs, job)
+	}
+	for i := range r.Pods {
+		pod := r.Pods[i].DeepCopy()
+		pod.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}
+		stripServerPopulatedFields(pod)
+		stripWorkloadOwnerReferences(pod)
+		objs = append(objs, pod)
+	}
+	// The policies are written after the Pods because the Pods have been admitted in the exported cluster.
+	for i := range r.PodDisruptionBudgets {
+		pdb := r.PodDisruptionBudgets[i].DeepCopy()
+		pdb.TypeMeta = metav1.TypeMeta{APIVersion: policyv1.SchemeGroupVersion.String(), Kind: "PodDisruptionBudget"}
+		stripServerPopulatedFields(pdb)
+		objs = append(objs, pdb)
+	}
+	for i := range r.ResourceQuotas {
+		quota := r.ResourceQuotas[i].DeepCopy()
+		quota.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuota"}
+		stripServerPopulatedFields(quota)
+		objs = append(objs, quota)
+	}
+	for i := range r.LimitRanges {
+		lr := r.LimitRanges[i].DeepCopy()
+		lr.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "LimitRange"}
+		stripServerPopulatedFields(lr)
+		objs = append(objs, lr)
+	}
+	return objs
+}
+
+// stripServerPopulatedFields removes the metadata fields which are populated by kube-apiserver
+// and can't be applied to another cluster.
+func stripServerPopulatedFields(obj metav1.Object) {
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+	obj.SetSelfLink("")
+}
+
+// stripWorkloadOwnerReferences removes the references to the workload controllers.
+func stripWorkloadOwnerReferences(obj metav1.Object) {
+	var refs []metav1.OwnerReference
+	for _, ref := range obj.GetOwnerReferences() {
+		if !isWorkloadOwner(ref.APIVersion, ref.Kind) {
+			refs = append(refs, ref)
+		}
+	}
+	obj.SetOwnerReferences(refs)
+}
+
+// ReadResourcesForImport reads the resources for import from the file or the directory.
+// The file can be anything that DecodeResourcesForImport accepts.
+// In a directory, all files with .yaml, .yml or .json extension are read as manifests recursively.
+func ReadResourcesForImport(path string) (*ResourcesForImport, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, xerrors.Errorf("stat %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, xerrors.Errorf("read %s: %w", path, err)
+		}
+		return DecodeResourcesForImport(data)
+	}
+
+	r := &ResourcesForImport{}
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !manifestExtensions[strings.ToLower(filepath.Ext(p))] {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return xerrors.Errorf("read %s: %w", p, err)
+		}
+		if err := r.addManifests(data); err != nil {
+			return xerrors.Errorf("decode manifests in %s: %w", p, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("read manifests in %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// DecodeResourcesForImport decodes the resources for import in any of the following formats.
+//   - ResourcesForImport in JSON.
+//   - Kubernetes manifests in YAML or JSON. It can have multiple documents and Lists (e.g., v1.List.)
+//     KubeSchedulerConfiguration in the manifests is decoded as the scheduler configuration.
+//   - A tarball (optionally gzipped) of the manifests. All files with .yaml, .yml or .json extension are read.
+//
+// The manifests of unsupported kinds are skipped.
+func DecodeResourcesForImport(data []byte) (*ResourcesForImport, error) {
+	switch {
+	case isGzip(data):
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, xerrors.Errorf("open gzip: %w", err)
+		}
+		defer gr.Close()
+		return decodeTarball(gr)
+	case isTar(data):
+		return decodeTarball(bytes.NewReader(data))
+	case isManifest(data):
+		r := &ResourcesForImport{}
+		if err := r.addManifests(data); err != nil {
+			return nil, xerrors.Errorf("decode manifests: %w", err)
+		}
+		return r, nil
+	default:
+		r := &ResourcesForImport{}
+		if err := json.Unmarshal(data, r); err != nil {
+			return nil, xerrors.Errorf("decode ResourcesForImport: %w", err)
+		}
+		return r, nil
+	}
+}
+
+func decodeTarball(reader io.Reader) (*ResourcesForImport, error) {
+	r := &ResourcesForImport{}
+	err := eachManifestFileInTarball(reader, func(name string, file io.Reader) error {
+		if err := decodeDocuments(file, r.addManifest); err != nil {
+			return xerrors.Errorf("decode manifests in %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// eachManifestFileInTarball calls fn with each file with .yaml, .yml or .json extension in the tarball.
+func eachManifestFileInTarball(reader io.Reader, fn func(name string, file io.Reader) error) error {
+	tr := tar.NewReader(reader)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("read tarball: %w", err)
+		}
+		if h.Typeflag != tar.TypeReg || !manifestExtensions[strings.ToLower(filepath.Ext(h.Name))] {
+			continue
+		}
+		if err := fn(h.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// addManifests decodes all documents in data and adds them to r.
+func (r *ResourcesForImport) addManifests(data []byte) error {
+	return decodeDocuments(bytes.NewReader(data), r.addManifest)
+}
+
+// decodeDocuments decodes the documents in YAML or JSON one by one, and calls fn with each document in JSON.
+// The documents can be separated by "---" in YAML, or be concatenated (e.g., one per line) in JSON.
+// The empty documents are skipped.
+func decodeDocuments(reader io.Reader, fn func(doc []byte) error) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, decodeBufferSize)
+	for {
+		var doc json.RawMessage
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("read document: %w", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 || string(bytes.TrimSpace(doc)) == "null" {
+			// empty document.
+			continue
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+}
+
+// addManifest adds the object in JSON to r. If the object is a List, all items are added.
+//
+//nolint:funlen,cyclop,gocyclo,gocognit // For readability.
+func (r *ResourcesForImport) addManifest(doc []byte) error {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(doc, &typeMeta); err != nil {
+		return xerrors.Errorf("decode apiVersion and kind: %w", err)
+	}
+	gvk := typeMeta.GroupVersionKind()
+	if gvk.Kind == "" {
+		return xerrors.New("decode manifest: kind is empty")
+	}
+
+	if strings.HasSuffix(gvk.Kind, "List") {
+		list := struct {
+			Items []json.RawMessage `json:"items"`
+		}{}
+		if err := json.Unmarshal(doc, &list); err != nil {
+			return xerrors.Errorf("decode %s: %w", gvk.Kind, err)
+		}
+		for _, item := range list.Items {
+			if err := r.addManifest(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var err error
+	switch gvk {
+	case corev1.SchemeGroupVersion.WithKind("Pod"):
+		var pod v1.PodApplyConfiguration
+		if err = decodeManifest(doc, &pod, true); err == nil {
+			r.Pods = append(r.Pods, pod)
+		}
+	case corev1.SchemeGroupVersion.WithKind("Node"):
+		var node v1.NodeApplyConfiguration
+		if err = decodeManifest(doc, &node, false); err == nil {
+			r.Nodes = append(r.Nodes, node)
+		}
+	case corev1.SchemeGroupVersion.WithKind("PersistentVolume"):
+		var pv v1.PersistentVolumeApplyConfiguration
+		if err = decodeManifest(doc, &pv, false); err == nil {
+			r.Pvs = append(r.Pvs, pv)
+		}
+	case corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"):
+		var pvc v1.PersistentVolumeClaimApplyConfiguration
+		if err = decodeManifest(doc, &pvc, true); err == nil {
+			r.Pvcs = append(r.Pvcs, pvc)
+		}
+	case corev1.SchemeGroupVersion.WithKind("Namespace"):
+		var ns v1.NamespaceApplyConfiguration
+		if err = decodeManifest(doc, &ns, false); err == nil {
+			r.Namespaces = append(r.Namespaces, ns)
+		}
+	case storagev1.SchemeGroupVersion.WithKind("StorageClass"):
+		var sc confstoragev1.StorageClassApplyConfiguration
+		if err = decodeManifest(doc, &sc, false); err == nil {
+			r.StorageClasses = append(r.StorageClasses, sc)
+		}
+	case schedulingv1.SchemeGroupVersion.WithKind("PriorityClass"):
+		var pc schedulingcfgv1.PriorityClassApplyConfiguration
+		if err = decodeManifest(doc, &pc, false); err == nil {
+			r.PriorityClasses = append(r.PriorityClasses, pc)
+		}
+	case appsv1.SchemeGroupVersion.WithKind("Deployment"):
+		var d cfgappsv1.DeploymentApplyConfiguration
+		if err = decodeManifest(doc, &d, true); err == nil {
+			r.Deployments = append(r.Deployments, d)
+		}
+	case appsv1.SchemeGroupVersion.WithKind("ReplicaSet"):
+		var rs cfgappsv1.ReplicaSetApplyConfiguration
+		if err = decodeManifest(doc, &rs, true); err == nil {
+			r.ReplicaSets = append(r.ReplicaSets, rs)
+		}
+	case appsv1.SchemeGroupVersion.WithKind("StatefulSet"):
+		var ss cfgappsv1.StatefulSetApplyConfiguration
+		if err = decodeManifest(doc, &ss, true); err == nil {
+			r.StatefulSets = append(r.StatefulSets, ss)
+		}
+	case appsv1.SchemeGroupVersion.WithKind("DaemonSet"):
+		var ds cfgappsv1.DaemonSetApplyConfiguration
+		if err = decodeManifest(doc, &ds, true); err == nil {
+			r.DaemonSets = append(r.DaemonSets, ds)
+		}
+	case batchv1.SchemeGroupVersion.WithKind("Job"):
+		var job cfgbatchv1.JobApplyConfiguration
+		if err = decodeManifest(doc, &job, true); err == nil {
+			r.Jobs = append(r.Jobs, job)
+		}
+	case policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"):
+		var pdb cfgpolicyv1.PodDisruptionBudgetApplyConfiguration
+		if err = decodeManifest(doc, &pdb, true); err == nil {
+			r.PodDisruptionBudgets = append(r.PodDisruptionBudgets, pdb)
+		}
+	case corev1.SchemeGroupVersion.WithKind("ResourceQuota"):
+		var quota v1.ResourceQuotaApplyConfiguration
+		if err = decodeManifest(doc, &quota, true); err == nil {
+			r.ResourceQuotas = append(r.ResourceQuotas, quota)
+		}
+	case corev1.SchemeGroupVersion.WithKind("LimitRange"):
+		var lr v1.LimitRangeApplyConfiguration
+		if err = decodeManifest(doc, &lr, true); err == nil {
+			r.LimitRanges = append(r.LimitRanges, lr)
+		}
+	case storagev1.SchemeGroupVersion.WithKind("CSINode"):
+		var n confstoragev1.CSINodeApplyConfiguration
+		if err = decodeManifest(doc, &n, false); err == nil {
+			r.CSINodes = append(r.CSINodes, n)
+		}
+	case storagev1.SchemeGroupVersion.WithKind("CSIDriver"):
+		var d confstoragev1.CSIDriverApplyConfiguration
+		if err = decodeManifest(doc, &d, false); err == nil {
+			r.CSIDrivers = append(r.CSIDrivers, d)
+		}
+	case storagev1.SchemeGroupVersion.WithKind("CSIStorageCapacity"):
+		var c confstoragev1.CSIStorageCapacityApplyConfiguration
+		if err = decodeManifest(doc, &c, true); err == nil {
+			r.CSIStorageCapacities = append(r.CSIStorageCapacities, c)
+		}
+	case nodev1.SchemeGroupVersion.WithKind("RuntimeClass"):
+		var rc cfgnodev1.RuntimeClassApplyConfiguration
+		if err = decodeManifest(doc, &rc, false); err == nil {
+			r.RuntimeClasses = append(r.RuntimeClasses, rc)
+		}
+	default:
+		if gvk.Kind == schedulerConfigurationKind {
+			r.SchedulerConfig, err = config.DecodeSchedulerCfg(doc)
+			break
+		}
+		klog.Warningf("skip the manifest of unsupported kind: %s", gvk)
+	}
+	if err != nil {
+		return xerrors.Errorf("decode %s: %w", gvk.Kind, err)
+	}
+	return nil
+}
+
+// decodeManifest decodes the object in JSON into out, which should be the pointer of XXXXApplyConfiguration.
+// The namespace of the namespaced object is defaulted to "default".
+func decodeManifest(doc []byte, out interface{}, namespaced bool) error {
+	if namespaced {
+		obj := struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+		}{}
+		if err := json.Unmarshal(doc, &obj); err != nil {
+			return xerrors.Errorf("decode metadata: %w", err)
+		}
+		if obj.Metadata.Namespace == "" {
+			var err error
+			doc, err = withNamespace(doc, metav1.NamespaceDefault)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := json.Unmarshal(doc, out); err != nil {
+		return xerrors.Errorf("decode into apply configuration: %w", err)
+	}
+	return nil
+}
+
+// withNamespace sets the namespace to the object in JSON.
+func withNamespace(doc []byte, namespace string) ([]byte, error) {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(doc, &obj); err != nil {
+		return nil, xerrors.Errorf("decode object: %w", err)
+	}
+	md, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		md = map[string]interface{}{}
+		obj["metadata"] = md
+	}
+	md["namespace"] = namespace
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, xerrors.Errorf("encode object: %w", err)
+	}
+	return b, nil
+}
+
+// isManifest checks whether data is Kubernetes manifests, that is, the first document has apiVersion and kind.
+func isManifest(data []byte) bool {
+	typeMeta := metav1.TypeMeta{}
+	if err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), decodeBufferSize).Decode(&typeMeta); err != nil {
+		return false
+	}
+	return typeMeta.APIVersion != "" && typeMeta.Kind != ""
+}
+
+func isGzip(data []byte) bool {
+	return len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+func isTar(data []byte) bool {
+	// The magic of the ustar format is at 257.
+	return len(data) > 262 && string(data[257:262]) == "ustar"
+}
@@ -0,0 +1,313 @@
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	cfgnodev1 "k8s.io/client-go/applyconfigurations/node/v1"
+	cfgpolicyv1 "k8s.io/client-go/applyconfigurations/policy/v1"
+	confstoragev1 "k8s.io/client-go/applyconfigurations/storage/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestWriteManifests(t *testing.T) {
+	t.Parallel()
+	resources := &ResourcesForExport{
+		Namespaces: []corev1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "ns1", UID: "uid-ns1", ResourceVersion: "1"}},
+		},
+		PriorityClasses: []schedulingv1.PriorityClass{
+			{ObjectMeta: metav1.ObjectMeta{Name: "system-node-critical"}, Value: 2000001000},
+		},
+		Nodes: []corev1.Node{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node1", UID: "uid-node1", ResourceVersion: "2", CreationTimestamp: metav1.Unix(1, 0)}},
+		},
+		Pods: []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1", UID: "uid-pod1", ResourceVersion: "3", ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "simulator"}}},
+				Spec:       corev1.PodSpec{NodeName: "node1"},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		format  ManifestFormat
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "write multi-document YAML",
+			format: ManifestFormatYAML,
+			want: `apiVersion: v1
+kind: Namespace
+metadata:
+  creationTimestamp: null
+  name: ns1
+spec: {}
+status: {}
+---
+apiVersion: v1
+kind: Node
+metadata:
+  creationTimestamp: null
+  name: node1
+spec: {}
+status:
+  daemonEndpoints:
+    kubeletEndpoint:
+      Port: 0
+  nodeInfo:
+    architecture: ""
+    bootID: ""
+    containerRuntimeVersion: ""
+    kernelVersion: ""
+    kubeProxyVersion: ""
+    kubeletVersion: ""
+    machineID: ""
+    operatingSystem: ""
+    osImage: ""
+    systemUUID: ""
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  creationTimestamp: null
+  name: pod1
+  namespace: ns1
+spec:
+  containers: null
+  nodeName: node1
+status: {}
+`,
+		},
+		{
+			name:   "write v1.List",
+			format: ManifestFormatList,
+			want: `{"kind":"List","apiVersion":"v1","metadata":{},"items":[` +
+				`{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"ns1","creationTimestamp":null},"spec":{},"status":{}},` +
+				`{"kind":"Node","apiVersion":"v1","metadata":{"name":"node1","creationTimestamp":null},"spec":{},"status":{"daemonEndpoints":{"kubeletEndpoint":{"Port":0}},"nodeInfo":{"machineID":"","systemUUID":"","bootID":"","kernelVersion":"","osImage":"","containerRuntimeVersion":"","kubeletVersion":"","kubeProxyVersion":"","operatingSystem":"","architecture":""}}},` +
+				`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"pod1","namespace":"ns1","creationTimestamp":null},"spec":{"containers":null,"nodeName":"node1"},"status":{}}` +
+				"]}\n",
+		},
+		{
+			name:    "return error if the format is unsupported",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			buf := &bytes.Buffer{}
+			err := WriteManifests(buf, resources, tt.format)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestDecodeResourcesForImport(t *testing.T) {
+	t.Parallel()
+	manifests := `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod1
+spec:
+  containers:
+  - name: container1
+    image: k8s.gcr.io/pause:3.5
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: node1
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: skipped
+---
+apiVersion: kubescheduler.config.k8s.io/v1
+kind: KubeSchedulerConfiguration
+parallelism: 8
+---
+`
+	wantManifests := &ResourcesForImport{
+		Pods: []v1.PodApplyConfiguration{
+			*v1.Pod("pod1", "default").WithSpec(v1.PodSpec().WithContainers(v1.Container().WithName("container1").WithImage("k8s.gcr.io/pause:3.5"))),
+		},
+		Nodes: []v1.NodeApplyConfiguration{*v1.Node("node1")},
+	}
+	tests := []struct {
+		name            string
+		data            func() []byte
+		want            *ResourcesForImport
+		wantParallelism *int32
+		wantErr         bool
+	}{
+		{
+			name: "decode ResourcesForImport in JSON",
+			data: func() []byte {
+				return []byte(`{"nodes":[{"metadata":{"name":"node1"}}]}`)
+			},
+			want: &ResourcesForImport{Nodes: []v1.NodeApplyConfiguration{{ObjectMetaApplyConfiguration: metav1ac.ObjectMeta().WithName("node1")}}},
+		},
+		{
+			name: "decode multi-document YAML",
+			data: func() []byte {
+				return []byte(manifests)
+			},
+			want:            wantManifests,
+			wantParallelism: pointer.Int32(8),
+		},
+		{
+			name: "decode gzipped tarball",
+			data: func() []byte {
+				buf := &bytes.Buffer{}
+				gw := gzip.NewWriter(buf)
+				tw := tar.NewWriter(gw)
+				for name, content := range map[string]string{"manifests/all.yaml": manifests, "README.md": "not a manifest"} {
+					assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+					_, err := tw.Write([]byte(content))
+					assert.NoError(t, err)
+				}
+				assert.NoError(t, tw.Close())
+				assert.NoError(t, gw.Close())
+				return buf.Bytes()
+			},
+			want:            wantManifests,
+			wantParallelism: pointer.Int32(8),
+		},
+		{
+			name: "decode the policy objects",
+			data: func() []byte {
+				return []byte(`apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: pdb1
+---
+apiVersion: node.k8s.io/v1
+kind: RuntimeClass
+metadata:
+  name: runtimeclass1
+handler: runc
+---
+apiVersion: storage.k8s.io/v1
+kind: CSINode
+metadata:
+  name: node1
+`)
+			},
+			want: &ResourcesForImport{
+				PodDisruptionBudgets: []cfgpolicyv1.PodDisruptionBudgetApplyConfiguration{*cfgpolicyv1.PodDisruptionBudget("pdb1", "default")},
+				RuntimeClasses:       []cfgnodev1.RuntimeClassApplyConfiguration{*cfgnodev1.RuntimeClass("runtimeclass1").WithHandler("runc")},
+				CSINodes:             []confstoragev1.CSINodeApplyConfiguration{*confstoragev1.CSINode("node1")},
+			},
+		},
+		{
+			name: "return error if the manifest doesn't have kind",
+			data: func() []byte {
+				return []byte("apiVersion: v1\nkind: Pod\n---\napiVersion: v1\nmetadata:\n  name: pod1\n")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := DecodeResourcesForImport(tt.data())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantParallelism != nil {
+				assert.Equal(t, tt.wantParallelism, got.SchedulerConfig.Parallelism)
+				got.SchedulerConfig = nil
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadResourcesForImport(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "nodes"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "nodes", "node1.yaml"), []byte("apiVersion: v1\nkind: Node\nmetadata:\n  name: node1\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pod1.json"), []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod1","namespace":"ns1"}}`), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0o600))
+
+	got, err := ReadResourcesForImport(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, &ResourcesForImport{
+		Pods:  []v1.PodApplyConfiguration{*v1.Pod("pod1", "ns1")},
+		Nodes: []v1.NodeApplyConfiguration{*v1.Node("node1")},
+	}, got)
+}
+
+func TestWriteManifests_Workloads(t *testing.T) {
+	t.Parallel()
+	resources := &ResourcesForExport{
+		Deployments: []appsv1.Deployment{
+			{ObjectMeta: metav1.ObjectMeta{Name: "deployment1", Namespace: "ns1", UID: "uid-deployment1"}},
+		},
+		ReplicaSets: []appsv1.ReplicaSet{
+			{ObjectMeta: metav1.ObjectMeta{
+				Name:            "replicaset1",
+				Namespace:       "ns1",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "deployment1", UID: "uid-deployment1"}},
+			}},
+		},
+		Jobs: []batchv1.Job{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "ns1"},
+				Spec:       batchv1.JobSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "uid-job1"}}},
+			},
+		},
+		Pods: []corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{
+				Name:            "pod1",
+				Namespace:       "ns1",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "replicaset1", UID: "uid-replicaset1"}},
+			}},
+		},
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteManifests(buf, resources, ManifestFormatYAML))
+
+	got, err := DecodeResourcesForImport(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, got.Deployments, 1)
+	assert.Nil(t, got.Deployments[0].UID)
+	// The references to the workload controllers are removed.
+	assert.Len(t, got.ReplicaSets, 1)
+	assert.Empty(t, got.ReplicaSets[0].OwnerReferences)
+	assert.Len(t, got.Pods, 1)
+	assert.Empty(t, got.Pods[0].OwnerReferences)
+	// The generated selector of the Job is kept as a manual selector.
+	assert.Len(t, got.Jobs, 1)
+	assert.Equal(t, map[string]string{"controller-uid": "uid-job1"}, got.Jobs[0].Spec.Selector.MatchLabels)
+	assert.Equal(t, pointer.Bool(true), got.Jobs[0].Spec.ManualSelector)
+}
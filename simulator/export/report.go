@@ -0,0 +1,89 @@
+package export
+
+import (
+	"sync"
+
+	cfgmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ImportResult is the result of importing an object.
+type ImportResult string
+
+const (
+	// ImportResultSucceeded means the object is applied. (or is validated in the dry-run mode.)
+	ImportResultSucceeded ImportResult = "Succeeded"
+	// ImportResultSkipped means the object isn't applied on purpose. (e.g., the system PriorityClasses.)
+	ImportResultSkipped ImportResult = "Skipped"
+	// ImportResultFailed means kube-apiserver rejected the object.
+	ImportResultFailed ImportResult = "Failed"
+)
+
+// ImportReport is the per-object result of Import.
+// It's filled only when it's given to Import with the WithImportReport option.
+type ImportReport struct {
+	// DryRun is true when the objects are only validated and not persisted.
+	DryRun    bool           `json:"dryRun"`
+	Succeeded int            `json:"succeeded"`
+	Skipped   int            `json:"skipped"`
+	Failed    int            `json:"failed"`
+	Objects   []ObjectResult `json:"objects"`
+
+	mu sync.Mutex
+}
+
+// ObjectResult is the result of importing an object.
+type ObjectResult struct {
+	Kind      string       `json:"kind"`
+	Namespace string       `json:"namespace,omitempty"`
+	Name      string       `json:"name"`
+	Result    ImportResult `json:"result"`
+	// Message is the error from kube-apiserver when the result is Failed, or the reason when it's Skipped.
+	// In the dry-run mode, it may tell which object imported together the Succeeded object is validated without.
+	Message string `json:"message,omitempty"`
+}
+
+// HasFailure returns true if some objects failed to be imported.
+func (r *ImportReport) HasFailure() bool {
+	return r.Failed != 0
+}
+
+// record records the result of applying the object. It does nothing if r is nil.
+func (r *ImportReport) record(kind string, meta *cfgmetav1.ObjectMetaApplyConfiguration, err error) {
+	if err != nil {
+		r.add(kind, meta, ImportResultFailed, err.Error())
+		return
+	}
+	r.add(kind, meta, ImportResultSucceeded, "")
+}
+
+// recordSkipped records the object which isn't applied. It does nothing if r is nil.
+func (r *ImportReport) recordSkipped(kind string, meta *cfgmetav1.ObjectMetaApplyConfiguration, reason string) {
+	r.add(kind, meta, ImportResultSkipped, reason)
+}
+
+func (r *ImportReport) add(kind string, meta *cfgmetav1.ObjectMetaApplyConfiguration, result ImportResult, message string) {
+	if r == nil {
+		return
+	}
+	o := ObjectResult{Kind: kind, Result: result, Message: message}
+	if meta != nil {
+		if meta.Namespace != nil {
+			o.Namespace = *meta.Namespace
+		}
+		if meta.Name != nil {
+			o.Name = *meta.Name
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Objects = append(r.Objects, o)
+	switch result {
+	case ImportResultSucceeded:
+		r.Succeeded++
+	case ImportResultSkipped:
+		r.Skipped++
+	case ImportResultFailed:
+		r.Failed++
+	}
+}
@@ -0,0 +1,411 @@
+package export
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+
+	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+// importBatchSize is the number of the objects which ImportStream applies at once.
+const importBatchSize = 500
+
+// ImportProgress is the progress of ImportStream.
+type ImportProgress struct {
+	// Decoded is the number of the objects decoded so far.
+	Decoded int `json:"decoded"`
+	// Applied is the number of the objects which have been tried to be applied so far.
+	Applied int `json:"applied"`
+	// Failed is the number of the objects which failed to be applied so far.
+	// It's counted only when the ImportReport is given with the WithImportReport option.
+	Failed int `json:"failed"`
+}
+
+// pageLister lists the objects of a kind page by page for ExportManifests.
+type pageLister struct {
+	kind string
+	list util.ListFunc
+	// resources returns ResourcesForExport which has the objects in the page returned from list.
+	resources func(page runtime.Object) *ResourcesForExport
+}
+
+// pageListers returns pageListers in the order the objects should be applied, which is the same as manifestObjects.
+//
+//nolint:funlen // For readability.
+func (s *Service) pageListers() []pageLister {
+	return []pageLister{
+		{
+			kind: "Namespace",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.CoreV1().Namespaces().List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{Namespaces: page.(*corev1.NamespaceList).Items}
+			},
+		},
+		{
+			kind: "RuntimeClass",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.NodeV1().RuntimeClasses().List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{RuntimeClasses: page.(*nodev1.RuntimeClassList).Items}
+			},
+		},
+		{
+			kind: "PriorityClass",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.SchedulingV1().PriorityClasses().List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{PriorityClasses: page.(*schedulingv1.PriorityClassList).Items}
+			},
+		},
+		{
+			kind: "StorageClass",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.StorageV1().StorageClasses().List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{StorageClasses: page.(*storagev1.StorageClassList).Items}
+			},
+		},
+		{
+			kind: "PersistentVolumeClaim",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{Pvcs: page.(*corev1.PersistentVolumeClaimList).Items}
+			},
+		},
+		{
+			kind: "PersistentVolume",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.CoreV1().PersistentVolumes().List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{Pvs: page.(*corev1.PersistentVolumeList).Items}
+			},
+		},
+		{
+			kind: "Node",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.CoreV1().Nodes().List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{Nodes: page.(*corev1.NodeList).Items}
+			},
+		},
+		{
+			kind: "CSIDriver",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.StorageV1().CSIDrivers().List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{CSIDrivers: page.(*storagev1.CSIDriverList).Items}
+			},
+		},
+		{
+			kind: "CSINode",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.StorageV1().CSINodes().List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{CSINodes: page.(*storagev1.CSINodeList).Items}
+			},
+		},
+		{
+			kind: "CSIStorageCapacity",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.StorageV1().CSIStorageCapacities(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{CSIStorageCapacities: page.(*storagev1.CSIStorageCapacityList).Items}
+			},
+		},
+		{
+			kind: "Deployment",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{Deployments: page.(*appsv1.DeploymentList).Items}
+			},
+		},
+		{
+			kind: "ReplicaSet",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.AppsV1().ReplicaSets(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{ReplicaSets: page.(*appsv1.ReplicaSetList).Items}
+			},
+		},
+		{
+			kind: "StatefulSet",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{StatefulSets: page.(*appsv1.StatefulSetList).Items}
+			},
+		},
+		{
+			kind: "DaemonSet",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{DaemonSets: page.(*appsv1.DaemonSetList).Items}
+			},
+		},
+		{
+			kind: "Job",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{Jobs: page.(*batchv1.JobList).Items}
+			},
+		},
+		{
+			kind: "Pod",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{Pods: page.(*corev1.PodList).Items}
+			},
+		},
+		{
+			kind: "PodDisruptionBudget",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{PodDisruptionBudgets: page.(*policyv1.PodDisruptionBudgetList).Items}
+			},
+		},
+		{
+			kind: "ResourceQuota",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.CoreV1().ResourceQuotas(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{ResourceQuotas: page.(*corev1.ResourceQuotaList).Items}
+			},
+		},
+		{
+			kind: "LimitRange",
+			list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				return s.client.CoreV1().LimitRanges(metav1.NamespaceAll).List(ctx, opts)
+			},
+			resources: func(page runtime.Object) *ResourcesForExport {
+				return &ResourcesForExport{LimitRanges: page.(*corev1.LimitRangeList).Items}
+			},
+		},
+	}
+}
+
+// ExportManifests writes all resources to w as the manifests in the format, in the same way as WriteManifests.
+// Unlike Export, the resources are listed page by page and each page is written as soon as it's got,
+// so that the memory usage doesn't grow with the size of the cluster.
+// The scheduler configuration isn't written.
+func (s *Service) ExportManifests(ctx context.Context, w io.Writer, format ManifestFormat, opts ...Option) error {
+	options := options{}
+	for _, o := range opts {
+		o.apply(&options)
+	}
+	mw, err := newManifestWriter(w, format)
+	if err != nil {
+		return err
+	}
+	listers := s.pageListers()
+	var anonymizer *Anonymizer
+	if options.anonymize {
+		// The same Anonymizer is used for all pages so that the references between the objects still match.
+		anonymizer = NewAnonymizer()
+		// The StatefulSets are written after the PVCs and the Pods, but the Anonymizer needs them first
+		// to keep the names of their PVCs and Pods.
+		if err := s.addStatefulSets(ctx, anonymizer, listers); err != nil {
+			if !options.ignoreErr {
+				return err
+			}
+			klog.Errorf("failed to add StatefulSets to the anonymizer: %v", err)
+		}
+	}
+
+	for _, l := range listers {
+		l := l
+		var writeErr error
+		err := util.EachListPage(ctx, l.list, func(page runtime.Object) error {
+			r := l.resources(page)
+			if anonymizer != nil {
+				anonymizer.Anonymize(r)
+			}
+			for _, obj := range r.manifestObjects() {
+				if writeErr = mw.write(obj); writeErr != nil {
+					return writeErr
+				}
+			}
+			return nil
+		})
+		if writeErr != nil {
+			return xerrors.Errorf("write %s: %w", l.kind, writeErr)
+		}
+		if err != nil {
+			if !options.ignoreErr {
+				return xerrors.Errorf("call list %s: %w", l.kind, err)
+			}
+			klog.Errorf("failed to call list %s: %v", l.kind, err)
+		}
+	}
+	return mw.close()
+}
+
+// addStatefulSets adds all StatefulSets to the Anonymizer.
+func (s *Service) addStatefulSets(ctx context.Context, a *Anonymizer, listers []pageLister) error {
+	for _, l := range listers {
+		if l.kind != "StatefulSet" {
+			continue
+		}
+		err := util.EachListPage(ctx, l.list, func(page runtime.Object) error {
+			r := l.resources(page)
+			for i := range r.StatefulSets {
+				a.addStatefulSet(&r.StatefulSets[i])
+			}
+			return nil
+		})
+		if err != nil {
+			return xerrors.Errorf("call list %s: %w", l.kind, err)
+		}
+	}
+	return nil
+}
+
+// ImportStream imports the resources in the manifests read from reader.
+// The reader can have anything that DecodeResourcesForImport accepts except ResourcesForImport in JSON.
+//
+// The objects are decoded one by one, and applied in batches as Import does.
+// The dependency order is honored within a batch. It's honored across the batches as well
+// if the manifests are in the order that ExportManifests and WriteManifests write them.
+// The v1.List is decoded at once, so you should use the NDJSON or YAML manifests for a large cluster.
+//
+// progress is called after each batch is applied. It can be nil.
+// The scheduler configuration in the manifests is applied after all resources are applied.
+func (s *Service) ImportStream(ctx context.Context, reader io.Reader, progress func(ImportProgress), opts ...Option) error {
+	options := options{}
+	for _, o := range opts {
+		o.apply(&options)
+	}
+	if options.report != nil {
+		options.report.DryRun = options.dryRun
+	}
+	if options.dryRun {
+		// the objects in the earlier batches aren't persisted either.
+		options.dryRunImported = newDryRunImported()
+	}
+
+	var (
+		batch           = &ResourcesForImport{}
+		current         ImportProgress
+		schedulerConfig *v1beta2config.KubeSchedulerConfiguration
+	)
+	flush := func() error {
+		n := batch.objectCount()
+		if n == 0 {
+			return nil
+		}
+		if err := s.apply(ctx, batch, options); err != nil {
+			return xerrors.Errorf("import resources: %w", err)
+		}
+		batch = &ResourcesForImport{}
+		current.Applied += n
+		if options.report != nil {
+			current.Failed = options.report.Failed
+		}
+		if progress != nil {
+			progress(current)
+		}
+		return nil
+	}
+	err := decodeManifestStream(reader, func(doc []byte) error {
+		n := batch.objectCount()
+		if err := batch.addManifest(doc); err != nil {
+			return err
+		}
+		current.Decoded += batch.objectCount() - n
+		if batch.SchedulerConfig != nil {
+			schedulerConfig, batch.SchedulerConfig = batch.SchedulerConfig, nil
+		}
+		if batch.objectCount() >= importBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("decode manifests: %w", err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if err := s.restartSchedulerWithImportedConfig(schedulerConfig, options); err != nil {
+		return err
+	}
+	return nil
+}
+
+// decodeManifestStream decodes the manifests read from reader one by one, and calls fn with each document in JSON.
+// The manifests can be gzipped and can be in a tarball.
+func decodeManifestStream(reader io.Reader, fn func(doc []byte) error) error {
+	br := bufio.NewReader(reader)
+	// The magic of the ustar format is at 257, and the 262 bytes are enough to know whether it's gzip or tar.
+	head, err := br.Peek(262)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return xerrors.Errorf("read the head: %w", err)
+	}
+	switch {
+	case isGzip(head):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return xerrors.Errorf("open gzip: %w", err)
+		}
+		defer gr.Close()
+		return decodeManifestStream(gr, fn)
+	case isTar(head):
+		return eachManifestFileInTarball(br, func(name string, file io.Reader) error {
+			if err := decodeDocuments(file, fn); err != nil {
+				return xerrors.Errorf("decode manifests in %s: %w", name, err)
+			}
+			return nil
+		})
+	default:
+		return decodeDocuments(br, fn)
+	}
+}
+
+// objectCount returns the number of the objects in r. The scheduler configuration isn't counted.
+func (r *ResourcesForImport) objectCount() int {
+	return len(r.Pods) + len(r.Nodes) + len(r.Pvs) + len(r.Pvcs) + len(r.StorageClasses) + len(r.PriorityClasses) +
+		len(r.Namespaces) + len(r.Deployments) + len(r.ReplicaSets) + len(r.StatefulSets) + len(r.DaemonSets) + len(r.Jobs) +
+		len(r.PodDisruptionBudgets) + len(r.ResourceQuotas) + len(r.LimitRanges) +
+		len(r.CSINodes) + len(r.CSIDrivers) + len(r.CSIStorageCapacities) + len(r.RuntimeClasses)
+}
@@ -0,0 +1,152 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/export/mock_export"
+)
+
+func newTestServiceWithClient(ctrl *gomock.Controller, c *fake.Clientset) *Service {
+	return NewExportService(c, mock_export.NewMockPodService(ctrl), mock_export.NewMockNodeService(ctrl), mock_export.NewMockPersistentVolumeService(ctrl), mock_export.NewMockPersistentVolumeClaimService(ctrl), mock_export.NewMockStorageClassService(ctrl), mock_export.NewMockPriorityClassService(ctrl), mock_export.NewMockSchedulerService(ctrl))
+}
+
+func TestService_ExportManifests(t *testing.T) {
+	t.Parallel()
+	objs := []runtime.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1", UID: "uid-pod1"}, Spec: corev1.PodSpec{NodeName: "node1"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment1", Namespace: "ns1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns1"},
+			Spec:       appsv1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}}},
+		},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data-web-0", Namespace: "ns1"}},
+	}
+	tests := []struct {
+		name      string
+		opts      func(s *Service) []Option
+		wantKinds []string
+		check     func(t *testing.T, lines []map[string]interface{})
+	}{
+		{
+			name:      "write the objects in NDJSON in the order to be applied",
+			opts:      func(s *Service) []Option { return nil },
+			wantKinds: []string{"Namespace", "PersistentVolumeClaim", "Node", "Deployment", "StatefulSet", "Pod"},
+			check: func(t *testing.T, lines []map[string]interface{}) {
+				t.Helper()
+				// The server-populated fields are removed as WriteManifests does.
+				assert.NotContains(t, lines[5]["metadata"], "uid")
+			},
+		},
+		{
+			name:      "anonymize all pages with the same Anonymizer",
+			opts:      func(s *Service) []Option { return []Option{s.Anonymize()} },
+			wantKinds: []string{"Namespace", "PersistentVolumeClaim", "Node", "Deployment", "StatefulSet", "Pod"},
+			check: func(t *testing.T, lines []map[string]interface{}) {
+				t.Helper()
+				nodeName := lines[2]["metadata"].(map[string]interface{})["name"]
+				assert.NotEqual(t, "node1", nodeName)
+				assert.Equal(t, nodeName, lines[5]["spec"].(map[string]interface{})["nodeName"])
+				assert.Equal(t, lines[0]["metadata"].(map[string]interface{})["name"], lines[5]["metadata"].(map[string]interface{})["namespace"])
+				// The PVC written before the StatefulSet is named after the anonymized StatefulSet.
+				ss := lines[4]["metadata"].(map[string]interface{})["name"].(string)
+				tmpl := lines[4]["spec"].(map[string]interface{})["volumeClaimTemplates"].([]interface{})[0].(map[string]interface{})["metadata"].(map[string]interface{})["name"].(string)
+				assert.Equal(t, tmpl+"-"+ss+"-0", lines[1]["metadata"].(map[string]interface{})["name"])
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := newTestServiceWithClient(gomock.NewController(t), fake.NewSimpleClientset(objs...))
+			buf := &bytes.Buffer{}
+			assert.NoError(t, s.ExportManifests(context.Background(), buf, ManifestFormatNDJSON, tt.opts(s)...))
+
+			lines := []map[string]interface{}{}
+			kinds := []string{}
+			for _, l := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+				obj := map[string]interface{}{}
+				assert.NoError(t, json.Unmarshal([]byte(l), &obj))
+				lines = append(lines, obj)
+				kinds = append(kinds, obj["kind"].(string))
+			}
+			assert.Equal(t, tt.wantKinds, kinds)
+			tt.check(t, lines)
+		})
+	}
+}
+
+func TestService_ImportStream(t *testing.T) {
+	t.Parallel()
+	manifests := `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"ns1"}}
+{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod1","namespace":"ns1"}}
+{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod2","namespace":"ns1"}}
+`
+	gzipped := &bytes.Buffer{}
+	gw := gzip.NewWriter(gzipped)
+	_, err := gw.Write([]byte(manifests))
+	assert.NoError(t, err)
+	assert.NoError(t, gw.Close())
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "import NDJSON",
+			data: []byte(manifests),
+		},
+		{
+			name: "import gzipped NDJSON",
+			data: gzipped.Bytes(),
+		},
+		{
+			name: "import multi-document YAML",
+			data: []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: ns1\n---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: pod1\n  namespace: ns1\n---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: pod2\n  namespace: ns1\n"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			patched := []string{}
+			c := fake.NewSimpleClientset()
+			c.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				patched = append(patched, action.GetResource().Resource)
+				return true, nil, nil
+			})
+			s := newTestServiceWithClient(gomock.NewController(t), c)
+
+			report := &ImportReport{}
+			progresses := []ImportProgress{}
+			err := s.ImportStream(context.Background(), bytes.NewReader(tt.data), func(p ImportProgress) {
+				progresses = append(progresses, p)
+			}, s.DryRun(), s.WithImportReport(report))
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"namespaces", "pods", "pods"}, patched)
+			assert.Equal(t, []ImportProgress{{Decoded: 3, Applied: 3}}, progresses)
+			assert.Equal(t, 3, report.Succeeded)
+		})
+	}
+}
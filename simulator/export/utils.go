@@ -4,14 +4,23 @@ import (
 	"encoding/json"
 
 	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	cfgappsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
+	cfgbatchv1 "k8s.io/client-go/applyconfigurations/batch/v1"
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	cfgnodev1 "k8s.io/client-go/applyconfigurations/node/v1"
+	cfgpolicyv1 "k8s.io/client-go/applyconfigurations/policy/v1"
 	schedulingcfgv1 "k8s.io/client-go/applyconfigurations/scheduling/v1"
 	cfgstoragev1 "k8s.io/client-go/applyconfigurations/storage/v1"
 )
 
+//nolint:funlen,cyclop // For readability.
 func ConvertResourcesForImportToResourcesForExport(expRes *ResourcesForExport) (*ResourcesForImport, error) {
 	pods, err := convertPodListToApplyConfigurationList(expRes.Pods)
 	if err != nil {
@@ -41,15 +50,75 @@ func ConvertResourcesForImportToResourcesForExport(expRes *ResourcesForExport) (
 	if err != nil {
 		return nil, xerrors.Errorf("call convertNamespaceListToApplyConfigurationList: %w", err)
 	}
+	deployments, err := convertDeploymentListToApplyConfigurationList(expRes.Deployments)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertDeploymentListToApplyConfigurationList: %w", err)
+	}
+	replicaSets, err := convertReplicaSetListToApplyConfigurationList(expRes.ReplicaSets)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertReplicaSetListToApplyConfigurationList: %w", err)
+	}
+	statefulSets, err := convertStatefulSetListToApplyConfigurationList(expRes.StatefulSets)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertStatefulSetListToApplyConfigurationList: %w", err)
+	}
+	daemonSets, err := convertDaemonSetListToApplyConfigurationList(expRes.DaemonSets)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertDaemonSetListToApplyConfigurationList: %w", err)
+	}
+	jobs, err := convertJobListToApplyConfigurationList(expRes.Jobs)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertJobListToApplyConfigurationList: %w", err)
+	}
+	pdbs, err := convertPodDisruptionBudgetListToApplyConfigurationList(expRes.PodDisruptionBudgets)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertPodDisruptionBudgetListToApplyConfigurationList: %w", err)
+	}
+	quotas, err := convertResourceQuotaListToApplyConfigurationList(expRes.ResourceQuotas)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertResourceQuotaListToApplyConfigurationList: %w", err)
+	}
+	lrs, err := convertLimitRangeListToApplyConfigurationList(expRes.LimitRanges)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertLimitRangeListToApplyConfigurationList: %w", err)
+	}
+	csiNodes, err := convertCSINodeListToApplyConfigurationList(expRes.CSINodes)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertCSINodeListToApplyConfigurationList: %w", err)
+	}
+	csiDrivers, err := convertCSIDriverListToApplyConfigurationList(expRes.CSIDrivers)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertCSIDriverListToApplyConfigurationList: %w", err)
+	}
+	capacities, err := convertCSIStorageCapacityListToApplyConfigurationList(expRes.CSIStorageCapacities)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertCSIStorageCapacityListToApplyConfigurationList: %w", err)
+	}
+	rcs, err := convertRuntimeClassListToApplyConfigurationList(expRes.RuntimeClasses)
+	if err != nil {
+		return nil, xerrors.Errorf("call convertRuntimeClassListToApplyConfigurationList: %w", err)
+	}
 	return &ResourcesForImport{
-		Pods:            pods,
-		Nodes:           nodes,
-		Pvs:             pvs,
-		Pvcs:            pvcs,
-		StorageClasses:  scs,
-		PriorityClasses: pcs,
-		SchedulerConfig: expRes.SchedulerConfig,
-		Namespaces:      nss,
+		Pods:                 pods,
+		Nodes:                nodes,
+		Pvs:                  pvs,
+		Pvcs:                 pvcs,
+		StorageClasses:       scs,
+		PriorityClasses:      pcs,
+		SchedulerConfig:      expRes.SchedulerConfig,
+		Namespaces:           nss,
+		Deployments:          deployments,
+		ReplicaSets:          replicaSets,
+		StatefulSets:         statefulSets,
+		DaemonSets:           daemonSets,
+		Jobs:                 jobs,
+		PodDisruptionBudgets: pdbs,
+		ResourceQuotas:       quotas,
+		LimitRanges:          lrs,
+		CSINodes:             csiNodes,
+		CSIDrivers:           csiDrivers,
+		CSIStorageCapacities: capacities,
+		RuntimeClasses:       rcs,
 	}, nil
 }
 
@@ -123,10 +192,130 @@ func convertNamespaceListToApplyConfigurationList(nss []corev1.Namespace) ([]v1.
 	return rto, nil
 }
 
+func convertDeploymentListToApplyConfigurationList(deployments []appsv1.Deployment) ([]cfgappsv1.DeploymentApplyConfiguration, error) {
+	rto := make([]cfgappsv1.DeploymentApplyConfiguration, len(deployments))
+	for i, d := range deployments {
+		if err := convertToApplyConfiguration(d, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert Deployment to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertReplicaSetListToApplyConfigurationList(rss []appsv1.ReplicaSet) ([]cfgappsv1.ReplicaSetApplyConfiguration, error) {
+	rto := make([]cfgappsv1.ReplicaSetApplyConfiguration, len(rss))
+	for i, rs := range rss {
+		if err := convertToApplyConfiguration(rs, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert ReplicaSet to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertStatefulSetListToApplyConfigurationList(sss []appsv1.StatefulSet) ([]cfgappsv1.StatefulSetApplyConfiguration, error) {
+	rto := make([]cfgappsv1.StatefulSetApplyConfiguration, len(sss))
+	for i, ss := range sss {
+		if err := convertToApplyConfiguration(ss, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert StatefulSet to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertDaemonSetListToApplyConfigurationList(dss []appsv1.DaemonSet) ([]cfgappsv1.DaemonSetApplyConfiguration, error) {
+	rto := make([]cfgappsv1.DaemonSetApplyConfiguration, len(dss))
+	for i, ds := range dss {
+		if err := convertToApplyConfiguration(ds, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert DaemonSet to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertJobListToApplyConfigurationList(jobs []batchv1.Job) ([]cfgbatchv1.JobApplyConfiguration, error) {
+	rto := make([]cfgbatchv1.JobApplyConfiguration, len(jobs))
+	for i, j := range jobs {
+		if err := convertToApplyConfiguration(j, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert Job to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertPodDisruptionBudgetListToApplyConfigurationList(pdbs []policyv1.PodDisruptionBudget) ([]cfgpolicyv1.PodDisruptionBudgetApplyConfiguration, error) {
+	rto := make([]cfgpolicyv1.PodDisruptionBudgetApplyConfiguration, len(pdbs))
+	for i, p := range pdbs {
+		if err := convertToApplyConfiguration(p, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert PodDisruptionBudget to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertResourceQuotaListToApplyConfigurationList(quotas []corev1.ResourceQuota) ([]v1.ResourceQuotaApplyConfiguration, error) {
+	rto := make([]v1.ResourceQuotaApplyConfiguration, len(quotas))
+	for i, q := range quotas {
+		if err := convertToApplyConfiguration(q, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert ResourceQuota to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertLimitRangeListToApplyConfigurationList(lrs []corev1.LimitRange) ([]v1.LimitRangeApplyConfiguration, error) {
+	rto := make([]v1.LimitRangeApplyConfiguration, len(lrs))
+	for i, l := range lrs {
+		if err := convertToApplyConfiguration(l, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert LimitRange to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertCSINodeListToApplyConfigurationList(csiNodes []storagev1.CSINode) ([]cfgstoragev1.CSINodeApplyConfiguration, error) {
+	rto := make([]cfgstoragev1.CSINodeApplyConfiguration, len(csiNodes))
+	for i, n := range csiNodes {
+		if err := convertToApplyConfiguration(n, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert CSINode to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertCSIDriverListToApplyConfigurationList(csiDrivers []storagev1.CSIDriver) ([]cfgstoragev1.CSIDriverApplyConfiguration, error) {
+	rto := make([]cfgstoragev1.CSIDriverApplyConfiguration, len(csiDrivers))
+	for i, d := range csiDrivers {
+		if err := convertToApplyConfiguration(d, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert CSIDriver to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertCSIStorageCapacityListToApplyConfigurationList(capacities []storagev1.CSIStorageCapacity) ([]cfgstoragev1.CSIStorageCapacityApplyConfiguration, error) {
+	rto := make([]cfgstoragev1.CSIStorageCapacityApplyConfiguration, len(capacities))
+	for i, c := range capacities {
+		if err := convertToApplyConfiguration(c, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert CSIStorageCapacity to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
+func convertRuntimeClassListToApplyConfigurationList(rcs []nodev1.RuntimeClass) ([]cfgnodev1.RuntimeClassApplyConfiguration, error) {
+	rto := make([]cfgnodev1.RuntimeClassApplyConfiguration, len(rcs))
+	for i, r := range rcs {
+		if err := convertToApplyConfiguration(r, &rto[i]); err != nil {
+			return nil, xerrors.Errorf("convert RuntimeClass to apply configuration: %w", err)
+		}
+	}
+	return rto, nil
+}
+
 // convertToApplyConfiguration is convert some object to XXXXApplyConfiguration.
 // out should be the pointer of XXXXApplyConfiguration, otherwise, you can not get the result of conversion.
 //
-//nolint:funlen,cyclop // For readability.
+//nolint:funlen,cyclop,gocyclo // For readability.
 func convertToApplyConfiguration(in interface{}, out interface{}) error {
 	_in, err := json.Marshal(in)
 	if err != nil {
@@ -196,6 +385,114 @@ func convertToApplyConfiguration(in interface{}, out interface{}) error {
 			return xerrors.Errorf("call Unmarshal to convert Namespace: %w", err)
 		}
 		return nil
+	case appsv1.Deployment:
+		typedout, ok := out.(*cfgappsv1.DeploymentApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert Deployment: %w", err)
+		}
+		return nil
+	case appsv1.ReplicaSet:
+		typedout, ok := out.(*cfgappsv1.ReplicaSetApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert ReplicaSet: %w", err)
+		}
+		return nil
+	case appsv1.StatefulSet:
+		typedout, ok := out.(*cfgappsv1.StatefulSetApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert StatefulSet: %w", err)
+		}
+		return nil
+	case appsv1.DaemonSet:
+		typedout, ok := out.(*cfgappsv1.DaemonSetApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert DaemonSet: %w", err)
+		}
+		return nil
+	case batchv1.Job:
+		typedout, ok := out.(*cfgbatchv1.JobApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert Job: %w", err)
+		}
+		return nil
+	case policyv1.PodDisruptionBudget:
+		typedout, ok := out.(*cfgpolicyv1.PodDisruptionBudgetApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert PodDisruptionBudget: %w", err)
+		}
+		return nil
+	case corev1.ResourceQuota:
+		typedout, ok := out.(*v1.ResourceQuotaApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert ResourceQuota: %w", err)
+		}
+		return nil
+	case corev1.LimitRange:
+		typedout, ok := out.(*v1.LimitRangeApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert LimitRange: %w", err)
+		}
+		return nil
+	case storagev1.CSINode:
+		typedout, ok := out.(*cfgstoragev1.CSINodeApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert CSINode: %w", err)
+		}
+		return nil
+	case storagev1.CSIDriver:
+		typedout, ok := out.(*cfgstoragev1.CSIDriverApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert CSIDriver: %w", err)
+		}
+		return nil
+	case storagev1.CSIStorageCapacity:
+		typedout, ok := out.(*cfgstoragev1.CSIStorageCapacityApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert CSIStorageCapacity: %w", err)
+		}
+		return nil
+	case nodev1.RuntimeClass:
+		typedout, ok := out.(*cfgnodev1.RuntimeClassApplyConfiguration)
+		if !ok {
+			return xerrors.New("unexpected type was given as out")
+		}
+		if err := json.Unmarshal(_in, &typedout); err != nil {
+			return xerrors.Errorf("call Unmarshal to convert RuntimeClass: %w", err)
+		}
+		return nil
 	default:
 		return xerrors.Errorf("unknown type")
 	}
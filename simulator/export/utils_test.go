@@ -4,11 +4,19 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cfgappsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
+	cfgbatchv1 "k8s.io/client-go/applyconfigurations/batch/v1"
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	cfgnodev1 "k8s.io/client-go/applyconfigurations/node/v1"
+	cfgpolicyv1 "k8s.io/client-go/applyconfigurations/policy/v1"
 	schedulingcfgv1 "k8s.io/client-go/applyconfigurations/scheduling/v1"
 	cfgstoragev1 "k8s.io/client-go/applyconfigurations/storage/v1"
 )
@@ -375,3 +383,642 @@ func Test_convertNamespaceListToApplyConfigurationList(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertDeploymentListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []appsv1.Deployment
+		wantReturn func() []cfgappsv1.DeploymentApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert Deployment list to DeploymentApplyConfiguration list",
+			input: func() []appsv1.Deployment {
+				return []appsv1.Deployment{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "deployment1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "deployment2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgappsv1.DeploymentApplyConfiguration {
+				return []cfgappsv1.DeploymentApplyConfiguration{
+					*new(cfgappsv1.DeploymentApplyConfiguration).WithName("deployment1").WithNamespace(defaultNamespaceName),
+					*new(cfgappsv1.DeploymentApplyConfiguration).WithName("deployment2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertDeploymentListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertDeploymentListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertReplicaSetListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []appsv1.ReplicaSet
+		wantReturn func() []cfgappsv1.ReplicaSetApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert ReplicaSet list to ReplicaSetApplyConfiguration list",
+			input: func() []appsv1.ReplicaSet {
+				return []appsv1.ReplicaSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "replicaset1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "replicaset2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgappsv1.ReplicaSetApplyConfiguration {
+				return []cfgappsv1.ReplicaSetApplyConfiguration{
+					*new(cfgappsv1.ReplicaSetApplyConfiguration).WithName("replicaset1").WithNamespace(defaultNamespaceName),
+					*new(cfgappsv1.ReplicaSetApplyConfiguration).WithName("replicaset2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertReplicaSetListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertReplicaSetListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertStatefulSetListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []appsv1.StatefulSet
+		wantReturn func() []cfgappsv1.StatefulSetApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert StatefulSet list to StatefulSetApplyConfiguration list",
+			input: func() []appsv1.StatefulSet {
+				return []appsv1.StatefulSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "statefulset1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "statefulset2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgappsv1.StatefulSetApplyConfiguration {
+				return []cfgappsv1.StatefulSetApplyConfiguration{
+					*new(cfgappsv1.StatefulSetApplyConfiguration).WithName("statefulset1").WithNamespace(defaultNamespaceName),
+					*new(cfgappsv1.StatefulSetApplyConfiguration).WithName("statefulset2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertStatefulSetListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertStatefulSetListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertDaemonSetListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []appsv1.DaemonSet
+		wantReturn func() []cfgappsv1.DaemonSetApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert DaemonSet list to DaemonSetApplyConfiguration list",
+			input: func() []appsv1.DaemonSet {
+				return []appsv1.DaemonSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "daemonset1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "daemonset2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgappsv1.DaemonSetApplyConfiguration {
+				return []cfgappsv1.DaemonSetApplyConfiguration{
+					*new(cfgappsv1.DaemonSetApplyConfiguration).WithName("daemonset1").WithNamespace(defaultNamespaceName),
+					*new(cfgappsv1.DaemonSetApplyConfiguration).WithName("daemonset2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertDaemonSetListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertDaemonSetListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertJobListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []batchv1.Job
+		wantReturn func() []cfgbatchv1.JobApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert Job list to JobApplyConfiguration list",
+			input: func() []batchv1.Job {
+				return []batchv1.Job{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "job1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "job2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgbatchv1.JobApplyConfiguration {
+				return []cfgbatchv1.JobApplyConfiguration{
+					*new(cfgbatchv1.JobApplyConfiguration).WithName("job1").WithNamespace(defaultNamespaceName),
+					*new(cfgbatchv1.JobApplyConfiguration).WithName("job2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertJobListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertJobListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertPodDisruptionBudgetListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []policyv1.PodDisruptionBudget
+		wantReturn func() []cfgpolicyv1.PodDisruptionBudgetApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert PodDisruptionBudget list to PodDisruptionBudgetApplyConfiguration list",
+			input: func() []policyv1.PodDisruptionBudget {
+				return []policyv1.PodDisruptionBudget{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "pdb1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "pdb2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgpolicyv1.PodDisruptionBudgetApplyConfiguration {
+				return []cfgpolicyv1.PodDisruptionBudgetApplyConfiguration{
+					*new(cfgpolicyv1.PodDisruptionBudgetApplyConfiguration).WithName("pdb1").WithNamespace(defaultNamespaceName),
+					*new(cfgpolicyv1.PodDisruptionBudgetApplyConfiguration).WithName("pdb2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertPodDisruptionBudgetListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertPodDisruptionBudgetListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertResourceQuotaListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []corev1.ResourceQuota
+		wantReturn func() []v1.ResourceQuotaApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert ResourceQuota list to ResourceQuotaApplyConfiguration list",
+			input: func() []corev1.ResourceQuota {
+				return []corev1.ResourceQuota{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "quota1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "quota2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []v1.ResourceQuotaApplyConfiguration {
+				return []v1.ResourceQuotaApplyConfiguration{
+					*new(v1.ResourceQuotaApplyConfiguration).WithName("quota1").WithNamespace(defaultNamespaceName),
+					*new(v1.ResourceQuotaApplyConfiguration).WithName("quota2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertResourceQuotaListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertResourceQuotaListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertLimitRangeListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []corev1.LimitRange
+		wantReturn func() []v1.LimitRangeApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert LimitRange list to LimitRangeApplyConfiguration list",
+			input: func() []corev1.LimitRange {
+				return []corev1.LimitRange{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "limitrange1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "limitrange2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []v1.LimitRangeApplyConfiguration {
+				return []v1.LimitRangeApplyConfiguration{
+					*new(v1.LimitRangeApplyConfiguration).WithName("limitrange1").WithNamespace(defaultNamespaceName),
+					*new(v1.LimitRangeApplyConfiguration).WithName("limitrange2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertLimitRangeListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertLimitRangeListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertCSINodeListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		input      func() []storagev1.CSINode
+		wantReturn func() []cfgstoragev1.CSINodeApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert CSINode list to CSINodeApplyConfiguration list",
+			input: func() []storagev1.CSINode {
+				return []storagev1.CSINode{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "node1",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "node2",
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgstoragev1.CSINodeApplyConfiguration {
+				return []cfgstoragev1.CSINodeApplyConfiguration{
+					*new(cfgstoragev1.CSINodeApplyConfiguration).WithName("node1"),
+					*new(cfgstoragev1.CSINodeApplyConfiguration).WithName("node2"),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertCSINodeListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertCSINodeListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertCSIDriverListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		input      func() []storagev1.CSIDriver
+		wantReturn func() []cfgstoragev1.CSIDriverApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert CSIDriver list to CSIDriverApplyConfiguration list",
+			input: func() []storagev1.CSIDriver {
+				return []storagev1.CSIDriver{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "driver1",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "driver2",
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgstoragev1.CSIDriverApplyConfiguration {
+				return []cfgstoragev1.CSIDriverApplyConfiguration{
+					*new(cfgstoragev1.CSIDriverApplyConfiguration).WithName("driver1"),
+					*new(cfgstoragev1.CSIDriverApplyConfiguration).WithName("driver2"),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertCSIDriverListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertCSIDriverListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertCSIStorageCapacityListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	const defaultNamespaceName = "default"
+	tests := []struct {
+		name       string
+		input      func() []storagev1.CSIStorageCapacity
+		wantReturn func() []cfgstoragev1.CSIStorageCapacityApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert CSIStorageCapacity list to CSIStorageCapacityApplyConfiguration list",
+			input: func() []storagev1.CSIStorageCapacity {
+				return []storagev1.CSIStorageCapacity{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "capacity1",
+							Namespace: defaultNamespaceName,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "capacity2",
+							Namespace: defaultNamespaceName,
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgstoragev1.CSIStorageCapacityApplyConfiguration {
+				return []cfgstoragev1.CSIStorageCapacityApplyConfiguration{
+					*new(cfgstoragev1.CSIStorageCapacityApplyConfiguration).WithName("capacity1").WithNamespace(defaultNamespaceName),
+					*new(cfgstoragev1.CSIStorageCapacityApplyConfiguration).WithName("capacity2").WithNamespace(defaultNamespaceName),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertCSIStorageCapacityListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertCSIStorageCapacityListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
+
+func Test_convertRuntimeClassListToApplyConfigurationList(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		input      func() []nodev1.RuntimeClass
+		wantReturn func() []cfgnodev1.RuntimeClassApplyConfiguration
+		wantErr    bool
+	}{
+		{
+			name: "convert RuntimeClass list to RuntimeClassApplyConfiguration list",
+			input: func() []nodev1.RuntimeClass {
+				return []nodev1.RuntimeClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "runtimeclass1",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "runtimeclass2",
+						},
+					},
+				}
+			},
+			wantReturn: func() []cfgnodev1.RuntimeClassApplyConfiguration {
+				return []cfgnodev1.RuntimeClassApplyConfiguration{
+					*new(cfgnodev1.RuntimeClassApplyConfiguration).WithName("runtimeclass1"),
+					*new(cfgnodev1.RuntimeClassApplyConfiguration).WithName("runtimeclass2"),
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := tt.input()
+			out, err := convertRuntimeClassListToApplyConfigurationList(input)
+			want := tt.wantReturn()
+			for i, o := range out {
+				diffResponse := cmp.Diff(o.Name, want[i].Name)
+				if diffResponse != "" || (err != nil) != tt.wantErr {
+					t.Fatalf("convertRuntimeClassListToApplyConfigurationList() %v test, \nerror = %v,\n%s", tt.name, err, diffResponse)
+				}
+			}
+		})
+	}
+}
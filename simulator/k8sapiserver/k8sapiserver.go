@@ -160,6 +160,14 @@ func createK8SAPIServerOpts(etcdURL string, corsAllowedOriginList []string) (*ap
 		// This plugin is needed to use PriorityClass.
 		// https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#priority
 		"Priority",
+		// These plugins are needed to apply the defaults and the limits of LimitRange and ResourceQuota to Pods.
+		// https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#limitranger
+		// https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#resourcequota
+		"LimitRanger",
+		"ResourceQuota",
+		// This plugin is needed to set the overhead of RuntimeClass to Pods.
+		// https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#runtimeclass
+		"RuntimeClass",
 	}
 
 	// add cert directory to avoid permission error
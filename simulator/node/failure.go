@@ -0,0 +1,172 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// FailureAnnotationKey is the annotation of the Node which has the failures injected to it, separated by commas.
+// The fake node agent reports the conditions and renews the Lease of the Node according to it.
+const FailureAnnotationKey = "scheduler-simulator/node-failures"
+
+// FailureType is the type of a failure injected to a Node.
+type FailureType string
+
+const (
+	// FailureNotReady makes the Node report the Ready condition as False.
+	FailureNotReady FailureType = "NotReady"
+	// FailureUnreachable stops the heartbeats of the Node,
+	// so that the node-lifecycle controller changes the Ready condition to Unknown after the grace period.
+	FailureUnreachable FailureType = "Unreachable"
+	// FailureMemoryPressure makes the Node report the MemoryPressure condition as True.
+	FailureMemoryPressure FailureType = "MemoryPressure"
+	// FailureDiskPressure makes the Node report the DiskPressure condition as True.
+	FailureDiskPressure FailureType = "DiskPressure"
+	// FailurePIDPressure makes the Node report the PIDPressure condition as True.
+	FailurePIDPressure FailureType = "PIDPressure"
+	// FailureNetworkUnavailable makes the Node report the NetworkUnavailable condition as True.
+	FailureNetworkUnavailable FailureType = "NetworkUnavailable"
+	// FailureDelete deletes the Node, but not its Pods unlike Service.Delete, as if the machine is lost.
+	// The Pods are deleted by the pod garbage collector later.
+	FailureDelete FailureType = "Delete"
+)
+
+var (
+	ErrNodeNotFound   = errors.New("node not found")
+	ErrInvalidFailure = errors.New("invalid failure")
+)
+
+// Validate returns ErrInvalidFailure if the failure type is unknown.
+func (f FailureType) Validate() error {
+	switch f {
+	case FailureNotReady, FailureUnreachable, FailureMemoryPressure, FailureDiskPressure,
+		FailurePIDPressure, FailureNetworkUnavailable, FailureDelete:
+		return nil
+	}
+	return xerrors.Errorf("unknown failure type %q: %w", f, ErrInvalidFailure)
+}
+
+// Failures returns the failures injected to the Node.
+func Failures(n *corev1.Node) []FailureType {
+	v := n.Annotations[FailureAnnotationKey]
+	if v == "" {
+		return nil
+	}
+	var failures []FailureType
+	for _, f := range strings.Split(v, ",") {
+		failures = append(failures, FailureType(strings.TrimSpace(f)))
+	}
+	return failures
+}
+
+// HasFailure returns whether the failure is injected to the Node.
+func HasFailure(n *corev1.Node, f FailureType) bool {
+	for _, got := range Failures(n) {
+		if got == f {
+			return true
+		}
+	}
+	return false
+}
+
+// FailuresOf returns the failures which make the fake node agent report the same conditions as the Node has.
+// The Ready condition which is Unknown is regarded as FailureUnreachable.
+// It's used to keep the conditions of the Nodes imported from the existing cluster, since the fake node agent resets the others.
+func FailuresOf(n *corev1.Node) []FailureType {
+	var failures []FailureType
+	for _, c := range n.Status.Conditions {
+		switch {
+		case c.Type == corev1.NodeReady && c.Status == corev1.ConditionFalse:
+			failures = append(failures, FailureNotReady)
+		case c.Type == corev1.NodeReady && c.Status == corev1.ConditionUnknown:
+			failures = append(failures, FailureUnreachable)
+		case c.Type == corev1.NodeMemoryPressure && c.Status == corev1.ConditionTrue:
+			failures = append(failures, FailureMemoryPressure)
+		case c.Type == corev1.NodeDiskPressure && c.Status == corev1.ConditionTrue:
+			failures = append(failures, FailureDiskPressure)
+		case c.Type == corev1.NodePIDPressure && c.Status == corev1.ConditionTrue:
+			failures = append(failures, FailurePIDPressure)
+		case c.Type == corev1.NodeNetworkUnavailable && c.Status == corev1.ConditionTrue:
+			failures = append(failures, FailureNetworkUnavailable)
+		}
+	}
+	return failures
+}
+
+// SetFailures sets the failures to the annotation of the Node. The annotation is removed if failures is empty.
+func SetFailures(n *corev1.Node, failures []FailureType) {
+	if len(failures) == 0 {
+		delete(n.Annotations, FailureAnnotationKey)
+		return
+	}
+	strs := make([]string, 0, len(failures))
+	for _, f := range failures {
+		strs = append(strs, string(f))
+	}
+	metav1.SetMetaDataAnnotation(&n.ObjectMeta, FailureAnnotationKey, strings.Join(strs, ","))
+}
+
+// InjectFailure injects the failure to the Node in addition to the ones already injected.
+func (s *Service) InjectFailure(ctx context.Context, name string, f FailureType) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	n, err := s.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return xerrors.Errorf("get node %s: %w", name, ErrNodeNotFound)
+	}
+	if err != nil {
+		return xerrors.Errorf("get node: %w", err)
+	}
+
+	if f == FailureDelete {
+		if err := s.client.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+			return xerrors.Errorf("delete node: %w", err)
+		}
+		return nil
+	}
+	if HasFailure(n, f) {
+		return nil
+	}
+	failures := make([]string, 0, len(Failures(n))+1)
+	for _, got := range Failures(n) {
+		failures = append(failures, string(got))
+	}
+	failures = append(failures, string(f))
+	return s.patchFailures(ctx, name, strings.Join(failures, ","))
+}
+
+// Recover removes all failures injected to the Node.
+func (s *Service) Recover(ctx context.Context, name string) error {
+	if _, err := s.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return xerrors.Errorf("get node %s: %w", name, ErrNodeNotFound)
+		}
+		return xerrors.Errorf("get node: %w", err)
+	}
+	return s.patchFailures(ctx, name, nil)
+}
+
+// patchFailures sets the failures annotation of the Node. It's removed if the failures are nil.
+func (s *Service) patchFailures(ctx context.Context, name string, failures interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{FailureAnnotationKey: failures},
+		},
+	})
+	if err != nil {
+		return xerrors.Errorf("marshal patch: %w", err)
+	}
+	if _, err := s.client.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return xerrors.Errorf("patch node: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestService_InjectFailure(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		nodeName     string
+		failure      FailureType
+		wantFailures []FailureType
+		wantDeleted  bool
+		wantErr      error
+	}{
+		{
+			name:         "inject the failure",
+			nodeName:     "node1",
+			failure:      FailureNotReady,
+			wantFailures: []FailureType{FailureNotReady},
+		},
+		{
+			name:         "inject the failure in addition to the ones already injected",
+			annotations:  map[string]string{FailureAnnotationKey: "MemoryPressure"},
+			nodeName:     "node1",
+			failure:      FailureDiskPressure,
+			wantFailures: []FailureType{FailureMemoryPressure, FailureDiskPressure},
+		},
+		{
+			name:         "do nothing if the failure is already injected",
+			annotations:  map[string]string{FailureAnnotationKey: "MemoryPressure,Unreachable"},
+			nodeName:     "node1",
+			failure:      FailureUnreachable,
+			wantFailures: []FailureType{FailureMemoryPressure, FailureUnreachable},
+		},
+		{
+			name:        "delete the node",
+			nodeName:    "node1",
+			failure:     FailureDelete,
+			wantDeleted: true,
+		},
+		{
+			name:     "return error if the failure is unknown",
+			nodeName: "node1",
+			failure:  "Broken",
+			wantErr:  ErrInvalidFailure,
+		},
+		{
+			name:     "return error if the node doesn't exist",
+			nodeName: "node2",
+			failure:  FailureNotReady,
+			wantErr:  ErrNodeNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: tt.annotations}})
+			s := NewNodeService(client, nil)
+
+			err := s.InjectFailure(ctx, tt.nodeName, tt.failure)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+
+			n, err := client.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+			if tt.wantDeleted {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFailures, Failures(n))
+		})
+	}
+}
+
+func TestService_Recover(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "node1",
+		Annotations: map[string]string{FailureAnnotationKey: "NotReady,DiskPressure", "other": "kept"},
+	}})
+	s := NewNodeService(client, nil)
+
+	assert.NoError(t, s.Recover(ctx, "node1"))
+	n, err := client.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, Failures(n))
+	assert.Equal(t, map[string]string{"other": "kept"}, n.Annotations)
+
+	assert.True(t, errors.Is(s.Recover(ctx, "node2"), ErrNodeNotFound))
+}
+
+func TestFailuresOf(t *testing.T) {
+	t.Parallel()
+	n := &corev1.Node{
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionUnknown},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+	failures := FailuresOf(n)
+	assert.Equal(t, []FailureType{FailureUnreachable, FailureMemoryPressure}, failures)
+
+	SetFailures(n, failures)
+	assert.Equal(t, failures, Failures(n))
+	SetFailures(n, nil)
+	assert.NotContains(t, n.Annotations, FailureAnnotationKey)
+}
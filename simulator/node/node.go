@@ -8,8 +8,11 @@ import (
 	"golang.org/x/xerrors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
 )
 
 // Service manages node.
@@ -44,7 +47,10 @@ func (s *Service) Get(ctx context.Context, name string) (*corev1.Node, error) {
 
 // List lists all nodes.
 func (s *Service) List(ctx context.Context) (*corev1.NodeList, error) {
-	nl, err := s.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	nl := &corev1.NodeList{}
+	err := util.ListAllPages(ctx, nl, func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.CoreV1().Nodes().List(ctx, opts)
+	})
 	if err != nil {
 		return nil, xerrors.Errorf("list nodes: %w", err)
 	}
@@ -65,6 +71,19 @@ func (s *Service) Apply(ctx context.Context, nac *v1.NodeApplyConfiguration) (*c
 	return newnode, nil
 }
 
+// ApplyStatus applies the status of the node via the status subresource, since Apply doesn't update the status of the existing node.
+func (s *Service) ApplyStatus(ctx context.Context, nac *v1.NodeApplyConfiguration) (*corev1.Node, error) {
+	nac.WithAPIVersion("v1")
+	nac.WithKind("Node")
+
+	newnode, err := s.client.CoreV1().Nodes().ApplyStatus(ctx, nac, metav1.ApplyOptions{Force: true, FieldManager: "simulator"})
+	if err != nil {
+		return nil, xerrors.Errorf("apply node status: %w", err)
+	}
+
+	return newnode, nil
+}
+
 // Delete deletes the node has given name.
 func (s *Service) Delete(ctx context.Context, name string) error {
 	pl, err := s.podService.List(ctx, metav1.NamespaceAll)
@@ -0,0 +1,480 @@
+package nodegroup
+
+//go:generate mockgen -destination=./mock_$GOPACKAGE/$GOFILE . NodeService
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+const (
+	// GroupLabel is the label which has the name of the node group that the Node belongs to.
+	GroupLabel = "scheduler-simulator/node-group"
+	// specAnnotation has the NodeGroup in JSON which the Node is generated from.
+	// It's used to generate the new Nodes when the group is scaled up.
+	specAnnotation = "scheduler-simulator/node-group-spec"
+)
+
+var (
+	ErrNodeGroupNotFound = errors.New("node group not found")
+	ErrInvalidNodeGroup  = errors.New("invalid node group")
+)
+
+// NodeService represents service for manage Nodes.
+type NodeService interface {
+	List(ctx context.Context) (*corev1.NodeList, error)
+	Apply(ctx context.Context, nac *v1.NodeApplyConfiguration) (*corev1.Node, error)
+	ApplyStatus(ctx context.Context, nac *v1.NodeApplyConfiguration) (*corev1.Node, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// Service generates the groups of Nodes from the templates.
+type Service struct {
+	nodeService NodeService
+}
+
+// NodeGroup is a group of Nodes generated from the same template.
+type NodeGroup struct {
+	// Name is the name of the group. The Nodes are named "<name>-<index>" and have GroupLabel.
+	Name string `json:"name"`
+	// Count is the number of the Nodes.
+	Count int `json:"count"`
+	// Seed is the seed of the random values in the template.
+	// The Node with the same index always gets the same values, so the Nodes are kept as they are when the group is scaled.
+	Seed     int64    `json:"seed,omitempty"`
+	Template Template `json:"template"`
+}
+
+// Template describes the Nodes in a group.
+type Template struct {
+	// Resources is the distribution of the capacity of each resource, including the extended resources.
+	// The allocatable is the same as the capacity. The pods defaults to 110.
+	Resources map[corev1.ResourceName]util.Distribution `json:"resources"`
+	// Labels are added to all Nodes.
+	// kubernetes.io/os and kubernetes.io/arch default to linux and amd64.
+	Labels map[string]string `json:"labels,omitempty"`
+	// LabelSets are the sets of labels, one of which is added to each Node. It's chosen randomly by the weights.
+	LabelSets []WeightedLabels `json:"labelSets,omitempty"`
+	// Taints are added to all Nodes.
+	Taints []corev1.Taint `json:"taints,omitempty"`
+	// TaintSets are the sets of taints, one of which is added to each Node. It's chosen randomly by the weights.
+	TaintSets []WeightedTaints `json:"taintSets,omitempty"`
+	// Topology spreads the Nodes across the zones.
+	Topology *Topology `json:"topology,omitempty"`
+}
+
+type WeightedLabels struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Weight int               `json:"weight"`
+}
+
+type WeightedTaints struct {
+	Taints []corev1.Taint `json:"taints,omitempty"`
+	Weight int            `json:"weight"`
+}
+
+// Topology spreads the Nodes across the zones evenly in the order of the zones.
+// The Nodes get topology.kubernetes.io/region and topology.kubernetes.io/zone.
+type Topology struct {
+	Regions []Region `json:"regions"`
+}
+
+type Region struct {
+	Name  string   `json:"name"`
+	Zones []string `json:"zones"`
+}
+
+// NewNodeGroupService initializes Service.
+func NewNodeGroupService(ns NodeService) *Service {
+	return &Service{nodeService: ns}
+}
+
+// List lists all node groups. The Count of each group is the number of its Nodes.
+func (s *Service) List(ctx context.Context) ([]NodeGroup, error) {
+	groups, err := s.listGroupNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ngs := make([]NodeGroup, 0, len(groups))
+	for name, nodes := range groups {
+		g, err := groupFromNodes(name, nodes)
+		if err != nil {
+			return nil, err
+		}
+		ngs = append(ngs, *g)
+	}
+	sort.Slice(ngs, func(i, j int) bool { return ngs[i].Name < ngs[j].Name })
+	return ngs, nil
+}
+
+// Generate creates or updates the Nodes of the group with the template.
+// The Nodes with the index equal to or greater than the Count are deleted with the Pods on them.
+func (s *Service) Generate(ctx context.Context, g NodeGroup) error {
+	if err := g.Validate(); err != nil {
+		return xerrors.Errorf("%s: %v: %w", g.Name, err, ErrInvalidNodeGroup)
+	}
+	nodes, err := s.groupNodes(ctx, g.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.apply(ctx, &g, nodes, true); err != nil {
+		return xerrors.Errorf("generate node group %s: %w", g.Name, err)
+	}
+	return nil
+}
+
+// Scale changes the number of the Nodes in the group.
+// The new Nodes are generated with the template which the group is generated with, and the existing Nodes aren't changed.
+// The Nodes with the largest indexes are deleted first when it's scaled down.
+func (s *Service) Scale(ctx context.Context, name string, count int) error {
+	nodes, err := s.groupNodes(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return xerrors.Errorf("scale node group %s: %w", name, ErrNodeGroupNotFound)
+	}
+	g, err := groupFromNodes(name, nodes)
+	if err != nil {
+		return err
+	}
+	g.Count = count
+	if err := g.Validate(); err != nil {
+		return xerrors.Errorf("%s: %v: %w", g.Name, err, ErrInvalidNodeGroup)
+	}
+	if err := s.apply(ctx, g, nodes, false); err != nil {
+		return xerrors.Errorf("scale node group %s: %w", name, err)
+	}
+	return nil
+}
+
+// Add creates the Nodes of the group at the indexes, and returns their names.
+// Unlike Scale, it never changes nor deletes the existing Nodes, so it can be used after the Nodes in the middle are deleted.
+// The caller decides the indexes so that the Nodes are the same as the ones it has generated with Node.
+func (s *Service) Add(ctx context.Context, g NodeGroup, indexes []int) ([]string, error) {
+	if err := g.Validate(); err != nil {
+		return nil, xerrors.Errorf("%s: %v: %w", g.Name, err, ErrInvalidNodeGroup)
+	}
+	nodes, err := s.groupNodes(ctx, g.Name)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[int]bool{}
+	for i := range nodes {
+		if idx, ok := NodeIndex(g.Name, nodes[i].Name); ok {
+			existing[idx] = true
+		}
+	}
+	for _, idx := range indexes {
+		if idx < 0 || existing[idx] {
+			return nil, xerrors.Errorf("%s: node %s already exists or has an invalid index: %w", g.Name, nodeName(g.Name, idx), ErrInvalidNodeGroup)
+		}
+		existing[idx] = true
+	}
+	g.Count = len(nodes) + len(indexes)
+	spec, err := json.Marshal(g)
+	if err != nil {
+		return nil, xerrors.Errorf("encode node group: %w", err)
+	}
+
+	names := make([]string, 0, len(indexes))
+	eg := util.NewErrGroupWithSemaphore(ctx)
+	for _, idx := range indexes {
+		nac := g.node(idx, string(spec))
+		names = append(names, *nac.Name)
+		if err := eg.Go(func() error {
+			if _, err := s.nodeService.Apply(ctx, nac); err != nil {
+				return xerrors.Errorf("apply node %s: %w", *nac.Name, err)
+			}
+			return nil
+		}); err != nil {
+			return nil, xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, xerrors.Errorf("add nodes to node group %s: %w", g.Name, err)
+	}
+	return names, nil
+}
+
+// Delete deletes all Nodes in the group with the Pods on them.
+func (s *Service) Delete(ctx context.Context, name string) error {
+	nodes, err := s.groupNodes(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return xerrors.Errorf("delete node group %s: %w", name, ErrNodeGroupNotFound)
+	}
+	g := &NodeGroup{Name: name}
+	if err := s.apply(ctx, g, nodes, false); err != nil {
+		return xerrors.Errorf("delete node group %s: %w", name, err)
+	}
+	return nil
+}
+
+// apply applies the Nodes with the index less than the Count, and deletes the other Nodes in nodes.
+// If updateExisting is false, the Nodes which already exist aren't applied.
+func (s *Service) apply(ctx context.Context, g *NodeGroup, nodes []corev1.Node, updateExisting bool) error {
+	spec, err := json.Marshal(g)
+	if err != nil {
+		return xerrors.Errorf("encode node group: %w", err)
+	}
+	existing := map[int]bool{}
+	for i := range nodes {
+		idx, ok := NodeIndex(g.Name, nodes[i].Name)
+		if ok {
+			existing[idx] = true
+		}
+	}
+
+	eg := util.NewErrGroupWithSemaphore(ctx)
+	for i := 0; i < g.Count; i++ {
+		if existing[i] && !updateExisting {
+			continue
+		}
+		nac := g.node(i, string(spec))
+		update := existing[i]
+		if err := eg.Go(func() error {
+			if _, err := s.nodeService.Apply(ctx, nac); err != nil {
+				return xerrors.Errorf("apply node %s: %w", *nac.Name, err)
+			}
+			if !update {
+				return nil
+			}
+			// The status of the existing Node is ignored by Apply.
+			if _, err := s.nodeService.ApplyStatus(ctx, v1.Node(*nac.Name).WithStatus(nac.Status)); err != nil {
+				return xerrors.Errorf("apply status of node %s: %w", *nac.Name, err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	for i := range nodes {
+		name := nodes[i].Name
+		if idx, ok := NodeIndex(g.Name, name); ok && idx < g.Count {
+			continue
+		}
+		if err := eg.Go(func() error {
+			if err := s.nodeService.Delete(ctx, name); err != nil {
+				return xerrors.Errorf("delete node %s: %w", name, err)
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("start error group: %w", err)
+		}
+	}
+	return eg.Wait()
+}
+
+// listGroupNodes lists the Nodes which have GroupLabel, grouped by its value.
+func (s *Service) listGroupNodes(ctx context.Context) (map[string][]corev1.Node, error) {
+	nl, err := s.nodeService.List(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("list nodes: %w", err)
+	}
+	groups := map[string][]corev1.Node{}
+	for i := range nl.Items {
+		if name, ok := nl.Items[i].Labels[GroupLabel]; ok {
+			groups[name] = append(groups[name], nl.Items[i])
+		}
+	}
+	return groups, nil
+}
+
+// groupNodes lists the Nodes in the group.
+func (s *Service) groupNodes(ctx context.Context, name string) ([]corev1.Node, error) {
+	groups, err := s.listGroupNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return groups[name], nil
+}
+
+// groupFromNodes decodes the NodeGroup from specAnnotation of the Nodes, and sets the Count to the number of them.
+func groupFromNodes(name string, nodes []corev1.Node) (*NodeGroup, error) {
+	for i := range nodes {
+		spec, ok := nodes[i].Annotations[specAnnotation]
+		if !ok {
+			continue
+		}
+		g := &NodeGroup{}
+		if err := json.Unmarshal([]byte(spec), g); err != nil {
+			return nil, xerrors.Errorf("decode node group %s from node %s: %w", name, nodes[i].Name, err)
+		}
+		g.Name = name
+		g.Count = len(nodes)
+		return g, nil
+	}
+	return nil, xerrors.Errorf("node group %s doesn't have the Node with %s", name, specAnnotation)
+}
+
+// nodeName returns the name of the Node at the index in the group.
+func nodeName(group string, index int) string {
+	return fmt.Sprintf("%s-%d", group, index)
+}
+
+// NodeIndex returns the index of the Node in the group.
+func NodeIndex(group, name string) (int, bool) {
+	suffix := strings.TrimPrefix(name, group+"-")
+	if suffix == name {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(suffix)
+	if err != nil || nodeName(group, idx) != name {
+		return 0, false
+	}
+	return idx, true
+}
+
+// node generates the Node at the index. The random values depend only on the Seed and the index.
+func (g *NodeGroup) node(index int, spec string) *v1.NodeApplyConfiguration {
+	//nolint:gosec // The values don't have to be secure, but have to be reproducible.
+	rng := rand.New(rand.NewSource(g.Seed + int64(index)))
+	t := &g.Template
+	name := nodeName(g.Name, index)
+
+	labels := map[string]string{
+		corev1.LabelOSStable:   "linux",
+		corev1.LabelArchStable: "amd64",
+	}
+	for k, v := range t.Labels {
+		labels[k] = v
+	}
+	if len(t.LabelSets) > 0 {
+		weights := make([]int, len(t.LabelSets))
+		for i := range t.LabelSets {
+			weights[i] = t.LabelSets[i].Weight
+		}
+		for k, v := range t.LabelSets[util.WeightedChoice(rng, weights)].Labels {
+			labels[k] = v
+		}
+	}
+	if t.Topology != nil {
+		region, zone := t.Topology.zone(index)
+		labels[corev1.LabelTopologyRegion] = region
+		labels[corev1.LabelTopologyZone] = zone
+	}
+	labels[corev1.LabelHostname] = name
+	labels[GroupLabel] = g.Name
+
+	taints := append([]corev1.Taint{}, t.Taints...)
+	if len(t.TaintSets) > 0 {
+		weights := make([]int, len(t.TaintSets))
+		for i := range t.TaintSets {
+			weights[i] = t.TaintSets[i].Weight
+		}
+		taints = append(taints, t.TaintSets[util.WeightedChoice(rng, weights)].Taints...)
+	}
+	nodeSpec := v1.NodeSpec()
+	for i := range taints {
+		nodeSpec.WithTaints(v1.Taint().WithKey(taints[i].Key).WithValue(taints[i].Value).WithEffect(taints[i].Effect))
+	}
+
+	resources := corev1.ResourceList{corev1.ResourcePods: resource.MustParse(util.DefaultNodePods)}
+	// The resources are generated in the order of the names so that the same random values are used for them.
+	names := make([]string, 0, len(t.Resources))
+	for rn := range t.Resources {
+		names = append(names, string(rn))
+	}
+	sort.Strings(names)
+	for _, rn := range names {
+		d := t.Resources[corev1.ResourceName(rn)]
+		resources[corev1.ResourceName(rn)] = d.Sample(rng)
+	}
+
+	return v1.Node(name).
+		WithLabels(labels).
+		WithAnnotations(map[string]string{specAnnotation: spec}).
+		WithSpec(nodeSpec).
+		WithStatus(v1.NodeStatus().
+			WithCapacity(resources).
+			WithAllocatable(resources).
+			WithConditions(v1.NodeCondition().
+				WithType(corev1.NodeReady).
+				WithStatus(corev1.ConditionTrue).
+				WithReason("KubeletReady").
+				WithMessage("generated by the node group " + g.Name)))
+}
+
+// Node returns the Node at the index which the group generates, without creating it.
+func (g *NodeGroup) Node(index int) (*corev1.Node, error) {
+	nac := g.node(index, "")
+	delete(nac.Annotations, specAnnotation)
+	b, err := json.Marshal(nac)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal node: %w", err)
+	}
+	n := &corev1.Node{}
+	if err := json.Unmarshal(b, n); err != nil {
+		return nil, xerrors.Errorf("unmarshal node: %w", err)
+	}
+	return n, nil
+}
+
+// zone returns the region and the zone of the Node at the index.
+func (t *Topology) zone(index int) (string, string) {
+	type zone struct{ region, name string }
+	var zones []zone
+	for _, r := range t.Regions {
+		for _, z := range r.Zones {
+			zones = append(zones, zone{region: r.Name, name: z})
+		}
+	}
+	z := zones[index%len(zones)]
+	return z.region, z.name
+}
+
+// Validate returns the error if the group is invalid.
+func (g *NodeGroup) Validate() error {
+	var errs []string
+	errs = append(errs, validation.IsDNS1123Label(g.Name)...)
+	if g.Count < 0 {
+		errs = append(errs, "count must not be negative")
+	}
+	t := &g.Template
+	for rn, d := range t.Resources {
+		if err := d.Validate(); err != nil {
+			errs = append(errs, fmt.Sprintf("resources[%s]: %v", rn, err))
+		}
+	}
+	for i := range t.LabelSets {
+		if t.LabelSets[i].Weight <= 0 {
+			errs = append(errs, fmt.Sprintf("labelSets[%d]: weight must be positive", i))
+		}
+	}
+	for i := range t.TaintSets {
+		if t.TaintSets[i].Weight <= 0 {
+			errs = append(errs, fmt.Sprintf("taintSets[%d]: weight must be positive", i))
+		}
+	}
+	if t.Topology != nil {
+		zones := 0
+		for _, r := range t.Topology.Regions {
+			zones += len(r.Zones)
+		}
+		if zones == 0 {
+			errs = append(errs, "topology must have at least one zone")
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package nodegroup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/nodegroup/mock_nodegroup"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+func quantity(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func testNodeGroup(count int) NodeGroup {
+	return NodeGroup{
+		Name:  "worker",
+		Count: count,
+		Seed:  1,
+		Template: Template{
+			Resources: map[corev1.ResourceName]util.Distribution{
+				corev1.ResourceCPU:    {Min: quantity("2"), Max: quantity("8"), Step: quantity("2")},
+				corev1.ResourceMemory: {Choices: []util.WeightedQuantity{{Value: resource.MustParse("16Gi"), Weight: 1}, {Value: resource.MustParse("32Gi"), Weight: 3}}},
+				"nvidia.com/gpu":      {Value: quantity("4")},
+			},
+			Labels:    map[string]string{"team": "a"},
+			LabelSets: []WeightedLabels{{Labels: map[string]string{"spot": "true"}, Weight: 1}, {Weight: 1}},
+			TaintSets: []WeightedTaints{{Taints: []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}, Weight: 1}, {Weight: 1}},
+			Topology:  &Topology{Regions: []Region{{Name: "r1", Zones: []string{"r1-a", "r1-b"}}, {Name: "r2", Zones: []string{"r2-a"}}}},
+		},
+	}
+}
+
+// existingNodes generates the Nodes of the group as they are applied.
+func existingNodes(t *testing.T, g NodeGroup) []corev1.Node {
+	t.Helper()
+	spec, err := json.Marshal(g)
+	assert.NoError(t, err)
+	nodes := make([]corev1.Node, 0, g.Count)
+	for i := 0; i < g.Count; i++ {
+		nac := g.node(i, string(spec))
+		nodes = append(nodes, corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: *nac.Name, Labels: nac.Labels, Annotations: nac.Annotations}})
+	}
+	return nodes
+}
+
+// recorder records the Nodes applied and deleted via the mock.
+type recorder struct {
+	mu      sync.Mutex
+	applied map[string]*v1.NodeApplyConfiguration
+	// statuses are the statuses applied via the status subresource.
+	statuses map[string]*v1.NodeStatusApplyConfiguration
+	deleted  []string
+}
+
+func newRecorder(m *mock_nodegroup.MockNodeService) *recorder {
+	r := &recorder{applied: map[string]*v1.NodeApplyConfiguration{}, statuses: map[string]*v1.NodeStatusApplyConfiguration{}}
+	m.EXPECT().Apply(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, nac *v1.NodeApplyConfiguration) (*corev1.Node, error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.applied[*nac.Name] = nac
+		return &corev1.Node{}, nil
+	}).AnyTimes()
+	m.EXPECT().ApplyStatus(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, nac *v1.NodeApplyConfiguration) (*corev1.Node, error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.statuses[*nac.Name] = nac.Status
+		return &corev1.Node{}, nil
+	}).AnyTimes()
+	m.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, name string) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.deleted = append(r.deleted, name)
+		return nil
+	}).AnyTimes()
+	return r
+}
+
+func (r *recorder) appliedNames() []string {
+	names := []string{}
+	for name := range r.applied {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestService_Generate(t *testing.T) {
+	t.Parallel()
+	t.Run("generate the Nodes from the template", func(t *testing.T) {
+		t.Parallel()
+		m := mock_nodegroup.NewMockNodeService(gomock.NewController(t))
+		m.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{}, nil)
+		r := newRecorder(m)
+
+		s := NewNodeGroupService(m)
+		assert.NoError(t, s.Generate(context.Background(), testNodeGroup(6)))
+
+		assert.Equal(t, []string{"worker-0", "worker-1", "worker-2", "worker-3", "worker-4", "worker-5"}, r.appliedNames())
+		zones := map[string]int{}
+		for name, nac := range r.applied {
+			assert.Equal(t, name, nac.Labels[corev1.LabelHostname])
+			assert.Equal(t, "worker", nac.Labels[GroupLabel])
+			assert.Equal(t, "a", nac.Labels["team"])
+			assert.Equal(t, "linux", nac.Labels[corev1.LabelOSStable])
+			zones[nac.Labels[corev1.LabelTopologyZone]]++
+
+			capacity := *nac.Status.Capacity
+			assert.Equal(t, capacity, *nac.Status.Allocatable)
+			cpu := capacity[corev1.ResourceCPU]
+			assert.Contains(t, []int64{2, 4, 6, 8}, cpu.Value())
+			memory := capacity[corev1.ResourceMemory]
+			assert.Contains(t, []string{"16Gi", "32Gi"}, memory.String())
+			pods := capacity[corev1.ResourcePods]
+			assert.Equal(t, "110", pods.String())
+			gpu := capacity["nvidia.com/gpu"]
+			assert.Equal(t, "4", gpu.String())
+		}
+		assert.Equal(t, map[string]int{"r1-a": 2, "r1-b": 2, "r2-a": 2}, zones)
+		assert.Equal(t, "r2", r.applied["worker-2"].Labels[corev1.LabelTopologyRegion])
+	})
+	t.Run("generate the same Nodes with the same seed", func(t *testing.T) {
+		t.Parallel()
+		g := testNodeGroup(1)
+		a, b := g.node(0, ""), g.node(0, "")
+		assert.Equal(t, a, b)
+		g.Seed = 2
+		original := testNodeGroup(10)
+		var differs bool
+		for i := 0; i < 10 && !differs; i++ {
+			differs = !assert.ObjectsAreEqual(original.node(i, ""), g.node(i, ""))
+		}
+		assert.True(t, differs)
+	})
+	t.Run("delete the Nodes out of the count", func(t *testing.T) {
+		t.Parallel()
+		m := mock_nodegroup.NewMockNodeService(gomock.NewController(t))
+		m.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{Items: existingNodes(t, testNodeGroup(4))}, nil)
+		r := newRecorder(m)
+
+		s := NewNodeGroupService(m)
+		assert.NoError(t, s.Generate(context.Background(), testNodeGroup(2)))
+
+		assert.Equal(t, []string{"worker-0", "worker-1"}, r.appliedNames())
+		sort.Strings(r.deleted)
+		assert.Equal(t, []string{"worker-2", "worker-3"}, r.deleted)
+	})
+	t.Run("update the status of the existing Nodes with the template", func(t *testing.T) {
+		t.Parallel()
+		m := mock_nodegroup.NewMockNodeService(gomock.NewController(t))
+		m.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{Items: existingNodes(t, testNodeGroup(1))}, nil)
+		r := newRecorder(m)
+
+		g := testNodeGroup(2)
+		g.Template.Resources[corev1.ResourceCPU] = util.Distribution{Value: quantity("16")}
+		s := NewNodeGroupService(m)
+		assert.NoError(t, s.Generate(context.Background(), g))
+
+		assert.Equal(t, []string{"worker-0", "worker-1"}, r.appliedNames())
+		// The status of the new Node is applied on creation, so only the existing Node's status is applied via the status subresource.
+		assert.Len(t, r.statuses, 1)
+		status, ok := r.statuses["worker-0"]
+		assert.True(t, ok)
+		cpu := (*status.Capacity)[corev1.ResourceCPU]
+		assert.Equal(t, "16", cpu.String())
+		cpu = (*status.Allocatable)[corev1.ResourceCPU]
+		assert.Equal(t, "16", cpu.String())
+	})
+	t.Run("return ErrInvalidNodeGroup if the template is invalid", func(t *testing.T) {
+		t.Parallel()
+		m := mock_nodegroup.NewMockNodeService(gomock.NewController(t))
+		g := testNodeGroup(1)
+		g.Template.Resources[corev1.ResourceCPU] = util.Distribution{Value: quantity("1"), Min: quantity("1"), Max: quantity("2")}
+
+		s := NewNodeGroupService(m)
+		err := s.Generate(context.Background(), g)
+		assert.True(t, errors.Is(err, ErrInvalidNodeGroup))
+	})
+}
+
+func TestService_Scale(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		existing    int
+		count       int
+		wantApplied []string
+		wantDeleted []string
+		wantErr     error
+	}{
+		{
+			name:        "generate only the new Nodes when it's scaled up",
+			existing:    2,
+			count:       4,
+			wantApplied: []string{"worker-2", "worker-3"},
+			wantDeleted: nil,
+		},
+		{
+			name:        "delete the Nodes with the largest indexes when it's scaled down",
+			existing:    4,
+			count:       1,
+			wantApplied: []string{},
+			wantDeleted: []string{"worker-1", "worker-2", "worker-3"},
+		},
+		{
+			name:     "return ErrNodeGroupNotFound if the group doesn't exist",
+			existing: 0,
+			count:    1,
+			wantErr:  ErrNodeGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := mock_nodegroup.NewMockNodeService(gomock.NewController(t))
+			m.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{Items: existingNodes(t, testNodeGroup(tt.existing))}, nil)
+			r := newRecorder(m)
+
+			s := NewNodeGroupService(m)
+			err := s.Scale(context.Background(), "worker", tt.count)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantApplied, r.appliedNames())
+			sort.Strings(r.deleted)
+			assert.Equal(t, tt.wantDeleted, r.deleted)
+			// The new Nodes are the same as the ones generated with the original template.
+			original := testNodeGroup(tt.count)
+			for name, nac := range r.applied {
+				idx, _ := NodeIndex("worker", name)
+				assert.Equal(t, original.node(idx, "").Status, nac.Status)
+			}
+		})
+	}
+}
+
+func TestService_Add(t *testing.T) {
+	t.Parallel()
+	m := mock_nodegroup.NewMockNodeService(gomock.NewController(t))
+	// worker-1 was deleted.
+	nodes := existingNodes(t, testNodeGroup(3))
+	nodes = append(nodes[:1], nodes[2:]...)
+	m.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{Items: nodes}, nil)
+	r := newRecorder(m)
+
+	s := NewNodeGroupService(m)
+	got, err := s.Add(context.Background(), testNodeGroup(0), []int{1, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"worker-1", "worker-4"}, got)
+	assert.Equal(t, []string{"worker-1", "worker-4"}, r.appliedNames())
+	assert.Empty(t, r.deleted)
+
+	// The existing Node isn't overwritten.
+	m.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{Items: nodes}, nil)
+	_, err = s.Add(context.Background(), testNodeGroup(0), []int{2})
+	assert.True(t, errors.Is(err, ErrInvalidNodeGroup))
+
+	g := testNodeGroup(0)
+	n, err := g.Node(4)
+	assert.NoError(t, err)
+	assert.Equal(t, "worker-4", n.Name)
+	assert.Equal(t, "worker", n.Labels[GroupLabel])
+	assert.Equal(t, r.applied["worker-4"].Status.Allocatable.Cpu().String(), n.Status.Allocatable.Cpu().String())
+	assert.NotContains(t, n.Annotations, specAnnotation)
+}
+
+func TestService_List(t *testing.T) {
+	t.Parallel()
+	m := mock_nodegroup.NewMockNodeService(gomock.NewController(t))
+	nodes := append(existingNodes(t, testNodeGroup(3)), corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-not-in-group"}})
+	m.EXPECT().List(gomock.Any()).Return(&corev1.NodeList{Items: nodes}, nil)
+
+	s := NewNodeGroupService(m)
+	got, err := s.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeGroup{testNodeGroup(3)}, got)
+}
@@ -6,8 +6,11 @@ import (
 	"golang.org/x/xerrors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
 )
 
 // Service manages persistentVolumeClaims.
@@ -33,7 +36,10 @@ func (s *Service) Get(ctx context.Context, name string, namespace string) (*core
 
 // List list all persistentVolumeClaims.
 func (s *Service) List(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaimList, error) {
-	pl, err := s.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	pl := &corev1.PersistentVolumeClaimList{}
+	err := util.ListAllPages(ctx, pl, func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	})
 	if err != nil {
 		return nil, xerrors.Errorf("list persistentVolumeClaims: %w", err)
 	}
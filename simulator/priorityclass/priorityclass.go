@@ -6,8 +6,11 @@ import (
 	"golang.org/x/xerrors"
 	v1 "k8s.io/api/scheduling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	schedulingv1 "k8s.io/client-go/applyconfigurations/scheduling/v1"
 	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
 )
 
 // Service manages priorityClass.
@@ -31,7 +34,10 @@ func (s *Service) Get(ctx context.Context, name string) (*v1.PriorityClass, erro
 }
 
 func (s *Service) List(ctx context.Context) (*v1.PriorityClassList, error) {
-	pl, err := s.client.SchedulingV1().PriorityClasses().List(ctx, metav1.ListOptions{})
+	pl := &v1.PriorityClassList{}
+	err := util.ListAllPages(ctx, pl, func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.SchedulingV1().PriorityClasses().List(ctx, opts)
+	})
 	if err != nil {
 		return nil, xerrors.Errorf("list priorityClass: %w", err)
 	}
@@ -0,0 +1,349 @@
+package replay
+
+//go:generate mockgen -destination=./mock_$GOPACKAGE/pod.go . PodService
+//go:generate mockgen -destination=./mock_$GOPACKAGE/node.go . NodeService
+//go:generate mockgen -destination=./mock_$GOPACKAGE/schedulingresult.go . SchedulingResultService
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"time"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/batch"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/schedulingresult"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+const (
+	defaultPollInterval = time.Second
+)
+
+type PodService interface {
+	Get(ctx context.Context, name string, namespace string) (*corev1.Pod, error)
+	List(ctx context.Context, namespace string) (*corev1.PodList, error)
+	Apply(ctx context.Context, namespace string, pod *v1.PodApplyConfiguration) (*corev1.Pod, error)
+	Delete(ctx context.Context, name string, namespace string) error
+}
+
+type NodeService interface {
+	Apply(ctx context.Context, nac *v1.NodeApplyConfiguration) (*corev1.Node, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type ExportService interface {
+	Import(ctx context.Context, resources *export.ResourcesForImport, opts ...export.Option) error
+}
+
+type SchedulingResultService interface {
+	List(ctx context.Context, q schedulingresult.Query) ([]schedulingresult.Result, error)
+}
+
+// Service replays the traces of the Pods and the Nodes on the simulator.
+type Service struct {
+	podService              PodService
+	nodeService             NodeService
+	exportService           ExportService
+	schedulingResultService SchedulingResultService
+	pollInterval            time.Duration
+}
+
+// Options is the options of a replay.
+type Options struct {
+	// TracePath is the path to the trace file. (See ReadTrace.)
+	TracePath string
+	// ResourcesPath is the path to the resources imported before the replay. It's optional.
+	// It can be anything that export.ReadResourcesForImport accepts.
+	ResourcesPath string
+	// SchedulerConfigPath is the path to the file of KubeSchedulerConfiguration in any supported version. It's optional.
+	// If it's empty, the scheduler configuration in the resources file is used,
+	// and the current scheduler configuration is kept if the resources file doesn't have it either.
+	SchedulerConfigPath string
+	// SpeedUp is how many times faster than the trace the events are replayed. It defaults to 1.
+	SpeedUp float64
+	// Timeout is the time to wait for the Pods to be bound or unschedulable after the last event. It waits forever if it's 0.
+	Timeout time.Duration
+}
+
+// Report is the result of a replay.
+type Report struct {
+	Summary Summary     `json:"summary"`
+	Pods    []PodResult `json:"pods"`
+}
+
+// Summary is the number of the events and the Pods in each status.
+type Summary struct {
+	Events int `json:"events"`
+	// FailedEvents is the number of the events which failed to be applied. (e.g., deleting a Pod which doesn't exist.)
+	FailedEvents int `json:"failedEvents"`
+	Pods         int `json:"pods"`
+	batch.StatusCounts
+	// Attempts is the number of the scheduling attempts of all Pods.
+	Attempts int `json:"attempts"`
+	// Duration is the time that the replay took in seconds, excluding the time waiting for the Pods after the last event.
+	Duration float64 `json:"duration"`
+	// TimedOut is true when some Pods are still pending after the timeout.
+	TimedOut bool `json:"timedOut"`
+}
+
+// PodResult is the result of a Pod created in the trace.
+// The status of the Pod deleted in the trace is the one just before it's deleted.
+type PodResult struct {
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	NodeName  string          `json:"nodeName,omitempty"`
+	Status    batch.PodStatus `json:"status"`
+	// Attempts is the number of the scheduling attempts recorded in the scheduling results.
+	Attempts int  `json:"attempts"`
+	Deleted  bool `json:"deleted,omitempty"`
+}
+
+// NewReplayService initializes Service.
+func NewReplayService(ps PodService, ns NodeService, es ExportService, rs SchedulingResultService) *Service {
+	return &Service{
+		podService:              ps,
+		nodeService:             ns,
+		exportService:           es,
+		schedulingResultService: rs,
+		pollInterval:            defaultPollInterval,
+	}
+}
+
+// Run imports the resources with the scheduler configuration, and replays the trace.
+// Then, it waits until all Pods created in the trace are bound or unschedulable, or the timeout passes,
+// and returns the report which has their status and the number of their scheduling attempts.
+func (s *Service) Run(ctx context.Context, opts Options) (*Report, error) {
+	events, err := ReadTrace(opts.TracePath)
+	if err != nil {
+		return nil, xerrors.Errorf("read trace: %w", err)
+	}
+	if err := s.importResources(ctx, opts); err != nil {
+		return nil, err
+	}
+	return s.Replay(ctx, events, opts.SpeedUp, opts.Timeout)
+}
+
+// Replay replays the events, which must be sorted by their times, and returns the report.
+// The events failing to be applied are skipped.
+//
+//nolint:cyclop // For readability.
+func (s *Service) Replay(ctx context.Context, events []Event, speedUp float64, timeout time.Duration) (*Report, error) {
+	if speedUp <= 0 {
+		speedUp = 1
+	}
+	r := &Report{Summary: Summary{Events: len(events)}}
+	// pods has the results of the Pods created in the trace by their keys.
+	pods := map[string]*PodResult{}
+
+	start := time.Now()
+	for i := range events {
+		e := &events[i]
+		if e.Namespace == "" {
+			e.Namespace = metav1.NamespaceDefault
+		}
+		at := start.Add(time.Duration((e.Time - events[0].Time) / speedUp * float64(time.Second)))
+		select {
+		case <-ctx.Done():
+			return nil, xerrors.Errorf("replay event %d: %w", i, ctx.Err())
+		case <-time.After(time.Until(at)):
+		}
+
+		if err := s.apply(ctx, e, pods); err != nil {
+			klog.Warningf("skip event %d (%s %s): %v", i, e.Type, e.Name, err)
+			r.Summary.FailedEvents++
+		}
+	}
+	r.Summary.Duration = time.Since(start).Seconds()
+
+	if err := wait.PollImmediateWithContext(ctx, s.pollInterval, timeout, func(ctx context.Context) (bool, error) {
+		return s.updatePodResults(ctx, pods)
+	}); err != nil {
+		if !errors.Is(err, wait.ErrWaitTimeout) {
+			return nil, xerrors.Errorf("wait for Pods to be scheduled: %w", err)
+		}
+		r.Summary.TimedOut = true
+	}
+
+	results, err := s.schedulingResultService.List(ctx, schedulingresult.Query{Since: start})
+	if err != nil {
+		return nil, xerrors.Errorf("list scheduling results: %w", err)
+	}
+	for i := range results {
+		if p, ok := pods[podKey(results[i].Namespace, results[i].PodName)]; ok {
+			p.Attempts++
+			r.Summary.Attempts++
+		}
+	}
+
+	r.Pods = make([]PodResult, 0, len(pods))
+	for _, p := range pods {
+		r.Pods = append(r.Pods, *p)
+		r.Summary.Pods++
+		r.Summary.Add(p.Status)
+	}
+	sort.Slice(r.Pods, func(i, j int) bool {
+		if r.Pods[i].Namespace != r.Pods[j].Namespace {
+			return r.Pods[i].Namespace < r.Pods[j].Namespace
+		}
+		return r.Pods[i].Name < r.Pods[j].Name
+	})
+	return r, nil
+}
+
+// importResources imports the resources and the scheduler configuration in the options if they are given.
+func (s *Service) importResources(ctx context.Context, opts Options) error {
+	resources := &export.ResourcesForImport{}
+	if opts.ResourcesPath != "" {
+		var err error
+		resources, err = export.ReadResourcesForImport(opts.ResourcesPath)
+		if err != nil {
+			return xerrors.Errorf("read resources: %w", err)
+		}
+	}
+	if opts.SchedulerConfigPath != "" {
+		data, err := os.ReadFile(opts.SchedulerConfigPath)
+		if err != nil {
+			return xerrors.Errorf("read scheduler config file: %w", err)
+		}
+		resources.SchedulerConfig, err = config.DecodeSchedulerCfg(data)
+		if err != nil {
+			return xerrors.Errorf("decode scheduler config file: %w", err)
+		}
+	}
+	if opts.ResourcesPath == "" && opts.SchedulerConfigPath == "" {
+		return nil
+	}
+	if err := s.exportService.Import(ctx, resources); err != nil {
+		return xerrors.Errorf("import resources: %w", err)
+	}
+	return nil
+}
+
+// apply applies the event to the simulator.
+func (s *Service) apply(ctx context.Context, e *Event, pods map[string]*PodResult) error {
+	switch e.Type {
+	case EventCreatePod:
+		pac, err := podApplyConfiguration(e)
+		if err != nil {
+			return err
+		}
+		if _, err := s.podService.Apply(ctx, e.Namespace, pac); err != nil {
+			return xerrors.Errorf("apply pod: %w", err)
+		}
+		pods[podKey(e.Namespace, e.Name)] = &PodResult{Namespace: e.Namespace, Name: e.Name, Status: batch.PodStatusPending}
+	case EventDeletePod:
+		// The status is recorded before the Pod is deleted.
+		if p, ok := pods[podKey(e.Namespace, e.Name)]; ok {
+			pod, err := s.podService.Get(ctx, e.Name, e.Namespace)
+			if err != nil {
+				return xerrors.Errorf("get pod: %w", err)
+			}
+			p.Status, _ = batch.PodStatusOf(pod)
+			p.NodeName = pod.Spec.NodeName
+			p.Deleted = true
+		}
+		if err := s.podService.Delete(ctx, e.Name, e.Namespace); err != nil {
+			return xerrors.Errorf("delete pod: %w", err)
+		}
+	case EventAddNode:
+		if _, err := s.nodeService.Apply(ctx, nodeApplyConfiguration(e)); err != nil {
+			return xerrors.Errorf("apply node: %w", err)
+		}
+	case EventRemoveNode:
+		if err := s.nodeService.Delete(ctx, e.Name); err != nil {
+			return xerrors.Errorf("delete node: %w", err)
+		}
+	default:
+		return xerrors.Errorf("unknown event type %q", e.Type)
+	}
+	return nil
+}
+
+// updatePodResults updates the status of the Pods which aren't deleted,
+// and returns true if all of them are bound or unschedulable.
+func (s *Service) updatePodResults(ctx context.Context, pods map[string]*PodResult) (bool, error) {
+	pl, err := s.podService.List(ctx, metav1.NamespaceAll)
+	if err != nil {
+		return false, xerrors.Errorf("list pods: %w", err)
+	}
+	current := make(map[string]*corev1.Pod, len(pl.Items))
+	for i := range pl.Items {
+		current[podKey(pl.Items[i].Namespace, pl.Items[i].Name)] = &pl.Items[i]
+	}
+
+	done := true
+	for key, p := range pods {
+		if p.Deleted {
+			continue
+		}
+		pod, ok := current[key]
+		if !ok {
+			// The Pod is deleted by others. (e.g., the Node is removed in the trace.)
+			p.Deleted = true
+			continue
+		}
+		p.Status, _ = batch.PodStatusOf(pod)
+		p.NodeName = pod.Spec.NodeName
+		if p.Status == batch.PodStatusPending {
+			done = false
+		}
+	}
+	return done, nil
+}
+
+func podKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func podApplyConfiguration(e *Event) (*v1.PodApplyConfiguration, error) {
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: e.Name, Namespace: e.Namespace, Labels: e.Labels},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:      "app",
+				Image:     util.PauseImage,
+				Resources: corev1.ResourceRequirements{Requests: e.Requests},
+			}},
+			PriorityClassName: e.PriorityClassName,
+		},
+	}
+	pac, err := util.PodApplyConfiguration(pod)
+	if err != nil {
+		return nil, xerrors.Errorf("convert Pod: %w", err)
+	}
+	return pac, nil
+}
+
+// nodeApplyConfiguration returns the Node for AddNode.
+// The Node allows util.DefaultNodePods Pods unless the capacity in the trace has the number of Pods.
+func nodeApplyConfiguration(e *Event) *v1.NodeApplyConfiguration {
+	labels := map[string]string{corev1.LabelHostname: e.Name}
+	for k, v := range e.Labels {
+		labels[k] = v
+	}
+	capacity := corev1.ResourceList{corev1.ResourcePods: resource.MustParse(util.DefaultNodePods)}
+	for rn, q := range e.Capacity {
+		capacity[rn] = q
+	}
+	return v1.Node(e.Name).
+		WithLabels(labels).
+		WithStatus(v1.NodeStatus().
+			WithCapacity(capacity).
+			WithAllocatable(capacity).
+			WithConditions(v1.NodeCondition().
+				WithType(corev1.NodeReady).
+				WithStatus(corev1.ConditionTrue).
+				WithReason("KubeletReady")))
+}
@@ -0,0 +1,111 @@
+package replay
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/batch"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/replay/mock_replay"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/schedulingresult"
+)
+
+//nolint:funlen // For readability.
+func TestService_Replay(t *testing.T) {
+	t.Parallel()
+	events := []Event{
+		{Time: 100, Type: EventAddNode, Name: "node1", Capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}},
+		{Time: 110, Type: EventCreatePod, Name: "pod1", Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}, PriorityClassName: "high"},
+		{Time: 120, Type: EventCreatePod, Name: "pod2", Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}},
+		{Time: 130, Type: EventDeletePod, Name: "pod1"},
+		{Time: 140, Type: EventDeletePod, Name: "not-found"},
+		{Time: 150, Type: EventRemoveNode, Name: "node1"},
+	}
+	boundPod1 := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node1"}}
+	unschedulablePod2 := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "default"},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+			{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable},
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	ps := mock_replay.NewMockPodService(ctrl)
+	ns := mock_replay.NewMockNodeService(ctrl)
+	rs := mock_replay.NewMockSchedulingResultService(ctrl)
+
+	var mu sync.Mutex
+	var applied []time.Time
+	record := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		applied = append(applied, time.Now())
+	}
+	gomock.InOrder(
+		ns.EXPECT().Apply(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, nac *v1.NodeApplyConfiguration) (*corev1.Node, error) {
+			record()
+			assert.Equal(t, "node1", *nac.Name)
+			cpu := (*nac.Status.Allocatable)[corev1.ResourceCPU]
+			assert.Equal(t, "4", cpu.String())
+			return &corev1.Node{}, nil
+		}),
+		ps.EXPECT().Apply(gomock.Any(), "default", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, pac *v1.PodApplyConfiguration) (*corev1.Pod, error) {
+			record()
+			assert.Equal(t, "pod1", *pac.Name)
+			assert.Equal(t, "high", *pac.Spec.PriorityClassName)
+			return &corev1.Pod{}, nil
+		}),
+		ps.EXPECT().Apply(gomock.Any(), "default", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, pac *v1.PodApplyConfiguration) (*corev1.Pod, error) {
+			record()
+			assert.Equal(t, "pod2", *pac.Name)
+			return &corev1.Pod{}, nil
+		}),
+		// The status of pod1 is recorded before it's deleted.
+		ps.EXPECT().Get(gomock.Any(), "pod1", "default").Return(&boundPod1, nil),
+		ps.EXPECT().Delete(gomock.Any(), "pod1", "default").DoAndReturn(func(_ context.Context, _, _ string) error {
+			record()
+			return nil
+		}),
+		ps.EXPECT().Delete(gomock.Any(), "not-found", "default").Return(assert.AnError),
+		ns.EXPECT().Delete(gomock.Any(), "node1").Return(nil),
+		ps.EXPECT().List(gomock.Any(), metav1.NamespaceAll).Return(&corev1.PodList{Items: []corev1.Pod{unschedulablePod2}}, nil),
+		rs.EXPECT().List(gomock.Any(), gomock.Any()).Return([]schedulingresult.Result{
+			{Namespace: "default", PodName: "pod1", NodeName: "node1"},
+			{Namespace: "default", PodName: "pod2"},
+			{Namespace: "default", PodName: "pod2"},
+			{Namespace: "default", PodName: "pod-not-in-trace"},
+		}, nil),
+	)
+
+	s := NewReplayService(ps, ns, nil, rs)
+	s.pollInterval = 10 * time.Millisecond
+	// 10 seconds in the trace are 10 milliseconds.
+	got, err := s.Replay(context.Background(), events, 1000, time.Second)
+	assert.NoError(t, err)
+
+	assert.Equal(t, Summary{
+		Events:       6,
+		FailedEvents: 1,
+		Pods:         2,
+		StatusCounts: batch.StatusCounts{Bound: 1, Unschedulable: 1},
+		Attempts:     3,
+		Duration:     got.Summary.Duration,
+	}, got.Summary)
+	assert.GreaterOrEqual(t, got.Summary.Duration, 0.05)
+	assert.Equal(t, []PodResult{
+		{Namespace: "default", Name: "pod1", NodeName: "node1", Status: batch.PodStatusBound, Attempts: 1, Deleted: true},
+		{Namespace: "default", Name: "pod2", Status: batch.PodStatusUnschedulable, Attempts: 2},
+	}, got.Pods)
+	// The events are replayed at the intervals in the trace. (with the margin for the delay of the first event.)
+	for i := 1; i < len(applied); i++ {
+		assert.GreaterOrEqual(t, applied[i].Sub(applied[0]), time.Duration(i)*10*time.Millisecond-5*time.Millisecond)
+	}
+}
@@ -0,0 +1,180 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// EventType is the type of an event in a trace.
+type EventType string
+
+const (
+	EventCreatePod  EventType = "CreatePod"
+	EventDeletePod  EventType = "DeletePod"
+	EventAddNode    EventType = "AddNode"
+	EventRemoveNode EventType = "RemoveNode"
+)
+
+// Event is an event in a trace.
+type Event struct {
+	// Time is the time of the event in seconds. The origin is arbitrary (e.g., the Unix epoch or the start of the trace),
+	// and the events are replayed at the intervals between their times.
+	Time float64   `json:"time"`
+	Type EventType `json:"type"`
+	// Namespace is the namespace of the Pod. It defaults to "default".
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// Requests is the requests of the Pod for CreatePod.
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// PriorityClassName is the PriorityClass of the Pod for CreatePod.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Capacity is the capacity (and the allocatable) of the Node for AddNode.
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+}
+
+// The columns of a trace in CSV. The other columns are the resources. (e.g., "nvidia.com/gpu".)
+const (
+	columnTime              = "time"
+	columnType              = "type"
+	columnNamespace         = "namespace"
+	columnName              = "name"
+	columnLabels            = "labels"
+	columnPriorityClassName = "priorityClassName"
+)
+
+// ReadTrace reads the trace from the file, and returns the events sorted by their times.
+// The file is CSV if its extension is ".csv", or a JSON array or newline-delimited JSON of Event otherwise.
+func ReadTrace(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("open trace file: %w", err)
+	}
+	defer f.Close()
+
+	var events []Event
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		events, err = decodeCSVTrace(f)
+	} else {
+		events, err = decodeJSONTrace(f)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("decode trace %s: %w", path, err)
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time < events[j].Time })
+	return events, nil
+}
+
+// decodeJSONTrace decodes a JSON array or newline-delimited JSON of Event.
+func decodeJSONTrace(r io.Reader) ([]Event, error) {
+	br := bufio.NewReader(r)
+	d := json.NewDecoder(br)
+	first, err := br.Peek(1)
+	for err == nil && len(bytes.TrimSpace(first)) == 0 {
+		// skip the leading spaces to find whether it's an array.
+		if _, err = br.ReadByte(); err == nil {
+			first, err = br.Peek(1)
+		}
+	}
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("read trace: %w", err)
+	}
+
+	var events []Event
+	if first[0] == '[' {
+		if err := d.Decode(&events); err != nil {
+			return nil, xerrors.Errorf("decode events: %w", err)
+		}
+		return events, nil
+	}
+	for {
+		var e Event
+		err := d.Decode(&e)
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("decode event %d: %w", len(events), err)
+		}
+		events = append(events, e)
+	}
+}
+
+// decodeCSVTrace decodes a trace in CSV with the header.
+// The labels are in the same format as the label selectors, e.g., "app=web,tier=frontend".
+// The resources are the requests for CreatePod and the capacity for AddNode, and the empty ones are ignored.
+func decodeCSVTrace(r io.Reader) ([]Event, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("read header: %w", err)
+	}
+
+	var events []Event
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("read line %d: %w", line, err)
+		}
+		e := Event{}
+		resources := corev1.ResourceList{}
+		for i, column := range header {
+			v := strings.TrimSpace(record[i])
+			if v == "" {
+				continue
+			}
+			switch column {
+			case columnTime:
+				e.Time, err = strconv.ParseFloat(v, 64)
+			case columnType:
+				e.Type = EventType(v)
+			case columnNamespace:
+				e.Namespace = v
+			case columnName:
+				e.Name = v
+			case columnLabels:
+				e.Labels, err = labels.ConvertSelectorToLabelsMap(v)
+			case columnPriorityClassName:
+				e.PriorityClassName = v
+			default:
+				var q resource.Quantity
+				q, err = resource.ParseQuantity(v)
+				resources[corev1.ResourceName(column)] = q
+			}
+			if err != nil {
+				return nil, xerrors.Errorf("parse %s at line %d: %w", column, line, err)
+			}
+		}
+		if len(resources) > 0 {
+			if e.Type == EventAddNode {
+				e.Capacity = resources
+			} else {
+				e.Requests = resources
+			}
+		}
+		events = append(events, e)
+	}
+}
@@ -0,0 +1,146 @@
+package replay
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/noderesources"
+)
+
+func TestReadTrace(t *testing.T) {
+	t.Parallel()
+	want := []Event{
+		{Time: 0, Type: EventAddNode, Name: "node1", Capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("1")}},
+		{Time: 1.5, Type: EventCreatePod, Namespace: "ns1", Name: "pod1", Labels: map[string]string{"app": "web", "tier": "frontend"}, Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}, PriorityClassName: "high"},
+		{Time: 3, Type: EventDeletePod, Namespace: "ns1", Name: "pod1"},
+	}
+	tests := []struct {
+		name     string
+		fileName string
+		data     string
+		wantErr  bool
+	}{
+		{
+			name:     "read CSV and sort the events by their times",
+			fileName: "trace.csv",
+			data: `time,type,namespace,name,labels,priorityClassName,cpu,nvidia.com/gpu
+3,DeletePod,ns1,pod1,,,,
+1.5,CreatePod,ns1,pod1,"app=web,tier=frontend",high,500m,
+0,AddNode,,node1,,,4,1
+`,
+		},
+		{
+			name:     "read JSON array",
+			fileName: "trace.json",
+			data: ` [
+  {"time": 0, "type": "AddNode", "name": "node1", "capacity": {"cpu": "4", "nvidia.com/gpu": "1"}},
+  {"time": 1.5, "type": "CreatePod", "namespace": "ns1", "name": "pod1", "labels": {"app": "web", "tier": "frontend"}, "requests": {"cpu": "500m"}, "priorityClassName": "high"},
+  {"time": 3, "type": "DeletePod", "namespace": "ns1", "name": "pod1"}
+]`,
+		},
+		{
+			name:     "read newline-delimited JSON",
+			fileName: "trace.jsonl",
+			data: `{"time": 3, "type": "DeletePod", "namespace": "ns1", "name": "pod1"}
+{"time": 0, "type": "AddNode", "name": "node1", "capacity": {"cpu": "4", "nvidia.com/gpu": "1"}}
+{"time": 1.5, "type": "CreatePod", "namespace": "ns1", "name": "pod1", "labels": {"app": "web", "tier": "frontend"}, "requests": {"cpu": "500m"}, "priorityClassName": "high"}
+`,
+		},
+		{
+			name:     "return error if a quantity is invalid",
+			fileName: "trace.csv",
+			data:     "time,type,name,cpu\n0,CreatePod,pod1,a lot\n",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			assert.NoError(t, os.WriteFile(path, []byte(tt.data), 0o600))
+
+			got, err := ReadTrace(path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, len(want))
+			for i := range want {
+				assert.Equal(t, want[i].Time, got[i].Time)
+				assert.Equal(t, want[i].Type, got[i].Type)
+				assert.Equal(t, want[i].Namespace, got[i].Namespace)
+				assert.Equal(t, want[i].Name, got[i].Name)
+				assert.Equal(t, want[i].Labels, got[i].Labels)
+				assert.Equal(t, want[i].PriorityClassName, got[i].PriorityClassName)
+				assert.True(t, equalResources(want[i].Requests, got[i].Requests), "requests of event %d: %v", i, got[i].Requests)
+				assert.True(t, equalResources(want[i].Capacity, got[i].Capacity), "capacity of event %d: %v", i, got[i].Capacity)
+			}
+		})
+	}
+}
+
+func equalResources(a, b corev1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v.Cmp(w) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// convert converts the apply configuration to the object.
+func convert(t *testing.T, from, to interface{}) {
+	t.Helper()
+	b, err := json.Marshal(from)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(b, to))
+}
+
+func TestReadTrace_schedulable(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "trace.csv")
+	data := `time,type,namespace,name,cpu,memory
+0,AddNode,,node1,4,8Gi
+1,CreatePod,default,pod1,1,1Gi
+2,CreatePod,default,pod2,2,2Gi
+3,CreatePod,default,pod3,2,2Gi
+`
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	events, err := ReadTrace(path)
+	assert.NoError(t, err)
+
+	// The Pods in the trace fit in the Node until its resources run out, as the scheduler checks it.
+	nodeInfo := framework.NewNodeInfo()
+	var fits []bool
+	for i := range events {
+		e := &events[i]
+		switch e.Type {
+		case EventAddNode:
+			node := &corev1.Node{}
+			convert(t, nodeApplyConfiguration(e), node)
+			nodeInfo.SetNode(node)
+		case EventCreatePod:
+			pac, err := podApplyConfiguration(e)
+			assert.NoError(t, err)
+			pod := &corev1.Pod{}
+			convert(t, pac, pod)
+			insufficient := noderesources.Fits(pod, nodeInfo)
+			fits = append(fits, len(insufficient) == 0)
+			if len(insufficient) == 0 {
+				nodeInfo.AddPod(pod)
+			}
+		}
+	}
+	assert.Equal(t, []bool{true, true, false}, fits)
+}
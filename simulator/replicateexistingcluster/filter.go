@@ -0,0 +1,262 @@
+package replicateexistingcluster
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
+	simulatornode "sigs.k8s.io/kube-scheduler-simulator/simulator/node"
+)
+
+// ErrInvalidImportOptions represents the ImportOptions are invalid. (e.g., the selector can't be parsed.)
+var ErrInvalidImportOptions = errors.New("invalid import options")
+
+// ImportOptions is the options to filter and transform the resources imported from the existing cluster.
+// The zero value imports all resources as they are.
+type ImportOptions struct {
+	// NamespaceSelector is the label selector of the Namespaces whose resources are imported.
+	// You can select the Namespaces by their names with the `kubernetes.io/metadata.name` label.
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+	// LabelSelector is the label selector of the Pods and the workload controllers
+	// (Deployments, ReplicaSets, StatefulSets, DaemonSets and Jobs) to be imported.
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// NodeSelector is the label selector of the Nodes to be imported.
+	// The Pods bound to the other Nodes aren't imported either unless StripBindings is true.
+	NodeSelector string `json:"nodeSelector,omitempty"`
+	// ExcludeDaemonSetPods excludes the Pods owned by DaemonSets and the DaemonSets themselves.
+	ExcludeDaemonSetPods bool `json:"excludeDaemonSetPods,omitempty"`
+	// ExcludeSystemPods excludes the Pods and the workload controllers in the kube-* Namespaces,
+	// and the Pods with the system-node-critical or system-cluster-critical PriorityClass.
+	ExcludeSystemPods bool `json:"excludeSystemPods,omitempty"`
+	// StripBindings turns all imported Pods into pending Pods, so that the scheduler in the simulator places them from scratch.
+	// The Pods which have already finished (Succeeded or Failed) aren't imported with it.
+	StripBindings bool `json:"stripBindings,omitempty"`
+	// Anonymize anonymizes the imported resources with export.Anonymizer.
+	// The selectors above are matched against the resources before they are anonymized.
+	Anonymize bool `json:"anonymize,omitempty"`
+}
+
+// Validate checks whether the selectors can be parsed.
+func (o ImportOptions) Validate() error {
+	if _, err := newImportFilter(o); err != nil {
+		return err
+	}
+	return nil
+}
+
+// importFilter selects and transforms the resources with ImportOptions.
+type importFilter struct {
+	opts              ImportOptions
+	namespaceSelector labels.Selector
+	labelSelector     labels.Selector
+	nodeSelector      labels.Selector
+}
+
+func newImportFilter(opts ImportOptions) (*importFilter, error) {
+	f := &importFilter{opts: opts}
+	var err error
+	if f.namespaceSelector, err = labels.Parse(opts.NamespaceSelector); err != nil {
+		return nil, xerrors.Errorf("parse namespaceSelector %q: %v: %w", opts.NamespaceSelector, err, ErrInvalidImportOptions)
+	}
+	if f.labelSelector, err = labels.Parse(opts.LabelSelector); err != nil {
+		return nil, xerrors.Errorf("parse labelSelector %q: %v: %w", opts.LabelSelector, err, ErrInvalidImportOptions)
+	}
+	if f.nodeSelector, err = labels.Parse(opts.NodeSelector); err != nil {
+		return nil, xerrors.Errorf("parse nodeSelector %q: %v: %w", opts.NodeSelector, err, ErrInvalidImportOptions)
+	}
+	return f, nil
+}
+
+// namespaceSelected returns true if the resources in the Namespace are imported.
+func (f *importFilter) namespaceSelected(ns *corev1.Namespace) bool {
+	return f.namespaceSelector.Matches(labels.Set(ns.Labels))
+}
+
+// nodeSelected returns true if the Node is imported.
+func (f *importFilter) nodeSelected(node *corev1.Node) bool {
+	return f.nodeSelector.Matches(labels.Set(node.Labels))
+}
+
+// workloadSelected returns true if the workload controller is imported.
+func (f *importFilter) workloadSelected(meta *metav1.ObjectMeta) bool {
+	if f.opts.ExcludeSystemPods && isSystemNamespace(meta.Namespace) {
+		return false
+	}
+	return f.labelSelector.Matches(labels.Set(meta.Labels))
+}
+
+// podSelected returns true if the Pod is imported.
+// nodeSelected tells whether the Node which the Pod is bound to is imported.
+func (f *importFilter) podSelected(pod *corev1.Pod, nodeSelected bool) bool {
+	if !f.workloadSelected(&pod.ObjectMeta) {
+		return false
+	}
+	if f.opts.ExcludeSystemPods && (pod.Spec.PriorityClassName == "system-node-critical" || pod.Spec.PriorityClassName == "system-cluster-critical") {
+		return false
+	}
+	if f.opts.ExcludeDaemonSetPods && isOwnedByDaemonSet(pod) {
+		return false
+	}
+	if f.opts.StripBindings {
+		return pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+	}
+	return pod.Spec.NodeName == "" || nodeSelected
+}
+
+// transformPod returns the Pod to be imported.
+func (f *importFilter) transformPod(pod *corev1.Pod) *corev1.Pod {
+	if !f.opts.StripBindings {
+		return pod
+	}
+	p := pod.DeepCopy()
+	p.Spec.NodeName = ""
+	p.Status = corev1.PodStatus{}
+	return p
+}
+
+// transformNode returns the Node to be imported.
+// Its conditions are represented with the failures because the fake node agent in the simulator
+// reports the conditions of the Nodes according to the failures. (See node.FailureAnnotationKey.)
+func (f *importFilter) transformNode(n *corev1.Node) *corev1.Node {
+	ret := n.DeepCopy()
+	simulatornode.SetFailures(ret, simulatornode.FailuresOf(n))
+	return ret
+}
+
+// filter filters and transforms the resources exported from the existing cluster.
+//
+//nolint:funlen,cyclop,gocognit // For readability.
+func (f *importFilter) filter(r *export.ResourcesForExport) {
+	// The Namespaces which aren't exported (e.g., the ones exported with errors ignored) are regarded as selected.
+	excludedNamespaces := map[string]bool{}
+	namespaces := r.Namespaces[:0]
+	for i := range r.Namespaces {
+		if !f.namespaceSelected(&r.Namespaces[i]) {
+			excludedNamespaces[r.Namespaces[i].Name] = true
+			continue
+		}
+		namespaces = append(namespaces, r.Namespaces[i])
+	}
+	r.Namespaces = namespaces
+	namespaced := func(meta *metav1.ObjectMeta) bool {
+		return !excludedNamespaces[meta.Namespace]
+	}
+
+	excludedNodes := map[string]bool{}
+	nodes := r.Nodes[:0]
+	for i := range r.Nodes {
+		if !f.nodeSelected(&r.Nodes[i]) {
+			excludedNodes[r.Nodes[i].Name] = true
+			continue
+		}
+		nodes = append(nodes, *f.transformNode(&r.Nodes[i]))
+	}
+	r.Nodes = nodes
+	csiNodes := r.CSINodes[:0]
+	for i := range r.CSINodes {
+		if !excludedNodes[r.CSINodes[i].Name] {
+			csiNodes = append(csiNodes, r.CSINodes[i])
+		}
+	}
+	r.CSINodes = csiNodes
+
+	pods := make([]corev1.Pod, 0, len(r.Pods))
+	for i := range r.Pods {
+		p := &r.Pods[i]
+		if namespaced(&p.ObjectMeta) && f.podSelected(p, !excludedNodes[p.Spec.NodeName]) {
+			pods = append(pods, *f.transformPod(p))
+		}
+	}
+	r.Pods = pods
+
+	deployments := r.Deployments[:0]
+	for i := range r.Deployments {
+		if namespaced(&r.Deployments[i].ObjectMeta) && f.workloadSelected(&r.Deployments[i].ObjectMeta) {
+			deployments = append(deployments, r.Deployments[i])
+		}
+	}
+	r.Deployments = deployments
+	replicaSets := r.ReplicaSets[:0]
+	for i := range r.ReplicaSets {
+		if namespaced(&r.ReplicaSets[i].ObjectMeta) && f.workloadSelected(&r.ReplicaSets[i].ObjectMeta) {
+			replicaSets = append(replicaSets, r.ReplicaSets[i])
+		}
+	}
+	r.ReplicaSets = replicaSets
+	statefulSets := r.StatefulSets[:0]
+	for i := range r.StatefulSets {
+		if namespaced(&r.StatefulSets[i].ObjectMeta) && f.workloadSelected(&r.StatefulSets[i].ObjectMeta) {
+			statefulSets = append(statefulSets, r.StatefulSets[i])
+		}
+	}
+	r.StatefulSets = statefulSets
+	daemonSets := r.DaemonSets[:0]
+	for i := range r.DaemonSets {
+		if namespaced(&r.DaemonSets[i].ObjectMeta) && f.workloadSelected(&r.DaemonSets[i].ObjectMeta) && !f.opts.ExcludeDaemonSetPods {
+			daemonSets = append(daemonSets, r.DaemonSets[i])
+		}
+	}
+	r.DaemonSets = daemonSets
+	jobs := r.Jobs[:0]
+	for i := range r.Jobs {
+		if namespaced(&r.Jobs[i].ObjectMeta) && f.workloadSelected(&r.Jobs[i].ObjectMeta) {
+			jobs = append(jobs, r.Jobs[i])
+		}
+	}
+	r.Jobs = jobs
+
+	pvcs := r.Pvcs[:0]
+	for i := range r.Pvcs {
+		if namespaced(&r.Pvcs[i].ObjectMeta) {
+			pvcs = append(pvcs, r.Pvcs[i])
+		}
+	}
+	r.Pvcs = pvcs
+	pdbs := r.PodDisruptionBudgets[:0]
+	for i := range r.PodDisruptionBudgets {
+		if namespaced(&r.PodDisruptionBudgets[i].ObjectMeta) {
+			pdbs = append(pdbs, r.PodDisruptionBudgets[i])
+		}
+	}
+	r.PodDisruptionBudgets = pdbs
+	quotas := r.ResourceQuotas[:0]
+	for i := range r.ResourceQuotas {
+		if namespaced(&r.ResourceQuotas[i].ObjectMeta) {
+			quotas = append(quotas, r.ResourceQuotas[i])
+		}
+	}
+	r.ResourceQuotas = quotas
+	limitRanges := r.LimitRanges[:0]
+	for i := range r.LimitRanges {
+		if namespaced(&r.LimitRanges[i].ObjectMeta) {
+			limitRanges = append(limitRanges, r.LimitRanges[i])
+		}
+	}
+	r.LimitRanges = limitRanges
+	capacities := r.CSIStorageCapacities[:0]
+	for i := range r.CSIStorageCapacities {
+		if namespaced(&r.CSIStorageCapacities[i].ObjectMeta) {
+			capacities = append(capacities, r.CSIStorageCapacities[i])
+		}
+	}
+	r.CSIStorageCapacities = capacities
+}
+
+func isSystemNamespace(name string) bool {
+	return strings.HasPrefix(name, "kube-")
+}
+
+func isOwnedByDaemonSet(pod *corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" && ref.APIVersion == appsv1.SchemeGroupVersion.String() {
+			return true
+		}
+	}
+	return false
+}
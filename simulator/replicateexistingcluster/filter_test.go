@@ -0,0 +1,162 @@
+package replicateexistingcluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
+)
+
+func testResources() *export.ResourcesForExport {
+	daemonSetRef := []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "ds1"}}
+	return &export.ResourcesForExport{
+		Namespaces: []corev1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"kubernetes.io/metadata.name": "default"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", Labels: map[string]string{"kubernetes.io/metadata.name": "kube-system"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"kubernetes.io/metadata.name": "ns1"}}},
+		},
+		Nodes: []corev1.Node{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"pool": "a"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"pool": "b"}}},
+		},
+		CSINodes: []storagev1.CSINode{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+		},
+		Pods: []corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", Labels: map[string]string{"app": "web"}}, Spec: corev1.PodSpec{NodeName: "node1"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "default", Labels: map[string]string{"app": "batch"}}, Spec: corev1.PodSpec{NodeName: "node2"}, Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod3", Namespace: "ns1", OwnerReferences: daemonSetRef}, Spec: corev1.PodSpec{NodeName: "node2"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod4", Namespace: "kube-system"}, Spec: corev1.PodSpec{NodeName: "node1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod5", Namespace: "ns1"}, Spec: corev1.PodSpec{NodeName: "node1", PriorityClassName: "system-node-critical"}},
+		},
+		Deployments: []appsv1.Deployment{
+			{ObjectMeta: metav1.ObjectMeta{Name: "deployment1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		},
+		DaemonSets: []appsv1.DaemonSet{
+			{ObjectMeta: metav1.ObjectMeta{Name: "ds1", Namespace: "ns1"}},
+		},
+		Pvcs: []corev1.PersistentVolumeClaim{
+			{ObjectMeta: metav1.ObjectMeta{Name: "pvc1", Namespace: "ns1"}},
+		},
+	}
+}
+
+func podNames(r *export.ResourcesForExport) []string {
+	names := []string{}
+	for _, p := range r.Pods {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestImportFilter_filter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    ImportOptions
+		check   func(t *testing.T, r *export.ResourcesForExport)
+		wantErr bool
+	}{
+		{
+			name: "import all resources by default",
+			opts: ImportOptions{},
+			check: func(t *testing.T, r *export.ResourcesForExport) {
+				t.Helper()
+				assert.Equal(t, testResources(), r)
+			},
+		},
+		{
+			name: "select the namespaces",
+			opts: ImportOptions{NamespaceSelector: "kubernetes.io/metadata.name in (default, kube-system)"},
+			check: func(t *testing.T, r *export.ResourcesForExport) {
+				t.Helper()
+				assert.Len(t, r.Namespaces, 2)
+				assert.Equal(t, []string{"pod1", "pod2", "pod4"}, podNames(r))
+				assert.Empty(t, r.DaemonSets)
+				assert.Empty(t, r.Pvcs)
+				assert.Len(t, r.Deployments, 1)
+			},
+		},
+		{
+			name: "select the pods and the workloads by labels",
+			opts: ImportOptions{LabelSelector: "app=web"},
+			check: func(t *testing.T, r *export.ResourcesForExport) {
+				t.Helper()
+				assert.Equal(t, []string{"pod1"}, podNames(r))
+				assert.Len(t, r.Deployments, 1)
+				assert.Empty(t, r.DaemonSets)
+				assert.Len(t, r.Pvcs, 1)
+			},
+		},
+		{
+			name: "select the nodes and the pods bound to them",
+			opts: ImportOptions{NodeSelector: "pool=a"},
+			check: func(t *testing.T, r *export.ResourcesForExport) {
+				t.Helper()
+				assert.Len(t, r.Nodes, 1)
+				assert.Equal(t, "node1", r.Nodes[0].Name)
+				assert.Len(t, r.CSINodes, 1)
+				assert.Equal(t, "node1", r.CSINodes[0].Name)
+				assert.Equal(t, []string{"pod1", "pod4", "pod5"}, podNames(r))
+			},
+		},
+		{
+			name: "exclude the DaemonSets and their pods",
+			opts: ImportOptions{ExcludeDaemonSetPods: true},
+			check: func(t *testing.T, r *export.ResourcesForExport) {
+				t.Helper()
+				assert.Equal(t, []string{"pod1", "pod2", "pod4", "pod5"}, podNames(r))
+				assert.Empty(t, r.DaemonSets)
+			},
+		},
+		{
+			name: "exclude the system pods",
+			opts: ImportOptions{ExcludeSystemPods: true},
+			check: func(t *testing.T, r *export.ResourcesForExport) {
+				t.Helper()
+				assert.Equal(t, []string{"pod1", "pod2", "pod3"}, podNames(r))
+				// The Namespace itself is still imported.
+				assert.Len(t, r.Namespaces, 3)
+			},
+		},
+		{
+			name: "strip the bindings of the pods which aren't finished",
+			opts: ImportOptions{StripBindings: true, NodeSelector: "pool=a"},
+			check: func(t *testing.T, r *export.ResourcesForExport) {
+				t.Helper()
+				// The pods bound to the unselected Nodes are imported too, since they are placed from scratch.
+				assert.Equal(t, []string{"pod1", "pod3", "pod4", "pod5"}, podNames(r))
+				for _, p := range r.Pods {
+					assert.Empty(t, p.Spec.NodeName)
+					assert.Equal(t, corev1.PodStatus{}, p.Status)
+				}
+			},
+		},
+		{
+			name:    "return error if the selector is invalid",
+			opts:    ImportOptions{NodeSelector: "pool in (a"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := newImportFilter(tt.opts)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidImportOptions)
+				return
+			}
+			assert.NoError(t, err)
+			r := testResources()
+			f.filter(r)
+			tt.check(t, r)
+		})
+	}
+}
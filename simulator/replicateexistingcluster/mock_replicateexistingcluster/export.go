@@ -102,3 +102,17 @@ func (mr *MockExportServiceMockRecorder) Import(arg0, arg1 interface{}, arg2 ...
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockExportService)(nil).Import), varargs...)
 }
+
+// WithImportReport mocks base method.
+func (m *MockExportService) WithImportReport(arg0 *export.ImportReport) export.Option {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithImportReport", arg0)
+	ret0, _ := ret[0].(export.Option)
+	return ret0
+}
+
+// WithImportReport indicates an expected call of WithImportReport.
+func (mr *MockExportServiceMockRecorder) WithImportReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithImportReport", reflect.TypeOf((*MockExportService)(nil).WithImportReport), arg0)
+}
@@ -16,6 +16,11 @@ import (
 type Service struct {
 	existingClusterExportService ExportService
 	simulatorExportService       ExportService
+	// options is the ImportOptions used by ImportFromExistingCluster.
+	options ImportOptions
+	// anonymizer anonymizes the resources when ImportOptions.Anonymize is true.
+	// It's shared by all imports so that the same object gets the same name every time it's imported.
+	anonymizer *export.Anonymizer
 }
 
 type ExportService interface {
@@ -23,13 +28,17 @@ type ExportService interface {
 	Import(ctx context.Context, resources *export.ResourcesForImport, opts ...export.Option) error
 	IgnoreErr() export.Option
 	IgnoreSchedulerConfiguration() export.Option
+	WithImportReport(r *export.ImportReport) export.Option
 }
 
 // NewReplicateExistingClusterService initializes Service.
-func NewReplicateExistingClusterService(exportService ExportService, existingClusterExportService ExportService) *Service {
+// The resources are filtered and transformed with opts when they are imported by ImportFromExistingCluster.
+func NewReplicateExistingClusterService(exportService ExportService, existingClusterExportService ExportService, opts ImportOptions) *Service {
 	return &Service{
 		existingClusterExportService: existingClusterExportService,
 		simulatorExportService:       exportService,
+		options:                      opts,
+		anonymizer:                   export.NewAnonymizer(),
 	}
 }
 
@@ -38,13 +47,9 @@ func NewReplicateExistingClusterService(exportService ExportService, existingClu
 // Note: this method doesn't handle scheduler configuration.
 // If users want to use their scheduler configuration, they need to use `KUBE_SCHEDULER_CONFIG_PATH` env.
 func (s *Service) ImportFromExistingCluster(ctx context.Context) error {
-	expRes, err := s.existingClusterExportService.Export(ctx)
-	if err != nil {
-		return xerrors.Errorf("call Export of existingClusterExportService: %w", err)
-	}
-	impRes, err := export.ConvertResourcesForImportToResourcesForExport(expRes)
+	impRes, err := s.resourcesForImport(ctx, s.options)
 	if err != nil {
-		return xerrors.Errorf("call ConvertResourcesForImportToResourcesForExport: %w", err)
+		return xerrors.Errorf("get resources to be imported: %w", err)
 	}
 	// Import to the simulator.
 	if err := s.simulatorExportService.Import(ctx, impRes, s.simulatorExportService.IgnoreErr(), s.simulatorExportService.IgnoreSchedulerConfiguration()); err != nil {
@@ -52,3 +57,47 @@ func (s *Service) ImportFromExistingCluster(ctx context.Context) error {
 	}
 	return nil
 }
+
+// ImportFromExistingClusterWithOptions is the same as ImportFromExistingCluster,
+// but the resources are filtered and transformed with the given opts instead of the ones given to NewReplicateExistingClusterService.
+// It returns the result of importing each object.
+func (s *Service) ImportFromExistingClusterWithOptions(ctx context.Context, opts ImportOptions) (*export.ImportReport, error) {
+	impRes, err := s.resourcesForImport(ctx, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("get resources to be imported: %w", err)
+	}
+	report := &export.ImportReport{}
+	if err := s.simulatorExportService.Import(ctx, impRes, s.simulatorExportService.IgnoreErr(), s.simulatorExportService.IgnoreSchedulerConfiguration(), s.simulatorExportService.WithImportReport(report)); err != nil {
+		return nil, xerrors.Errorf("call Import of the simulater export service: %w", err)
+	}
+	return report, nil
+}
+
+// resourcesForImport exports the resources from the existing cluster, and filters and transforms them with opts.
+func (s *Service) resourcesForImport(ctx context.Context, opts ImportOptions) (*export.ResourcesForImport, error) {
+	f, err := newImportFilter(opts)
+	if err != nil {
+		return nil, xerrors.Errorf("initialize import filter: %w", err)
+	}
+	expRes, err := s.existingClusterExportService.Export(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("call Export of existingClusterExportService: %w", err)
+	}
+	f.filter(expRes)
+	if opts.Anonymize {
+		s.anonymizer.Anonymize(expRes)
+	}
+	impRes, err := export.ConvertResourcesForImportToResourcesForExport(expRes)
+	if err != nil {
+		return nil, xerrors.Errorf("call ConvertResourcesForImportToResourcesForExport: %w", err)
+	}
+	return impRes, nil
+}
+
+// objectName returns the name of the object imported with ImportFromExistingCluster in the simulator.
+func (s *Service) objectName(name string) string {
+	if s.options.Anonymize {
+		return s.anonymizer.Name(name)
+	}
+	return name
+}
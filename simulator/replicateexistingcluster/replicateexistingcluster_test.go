@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"golang.org/x/xerrors"
 
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
@@ -60,7 +61,7 @@ func TestService_ImportFromExistingCluster(t *testing.T) {
 			mockSimulatorExportService := mock_replicateexistingcluster.NewMockExportService(ctrl)
 			mockClusterExportService := mock_replicateexistingcluster.NewMockExportService(ctrl)
 
-			s := NewReplicateExistingClusterService(mockSimulatorExportService, mockClusterExportService)
+			s := NewReplicateExistingClusterService(mockSimulatorExportService, mockClusterExportService, ImportOptions{})
 			tt.prepareEachServiceMockFn(mockSimulatorExportService, mockClusterExportService)
 
 			if err := s.ImportFromExistingCluster(context.Background()); (err != nil) != tt.wantErr {
@@ -69,3 +70,60 @@ func TestService_ImportFromExistingCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ImportFromExistingClusterWithOptions(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	simulatorExport := mock_replicateexistingcluster.NewMockExportService(ctrl)
+	clusterExport := mock_replicateexistingcluster.NewMockExportService(ctrl)
+
+	dummyOption := new(export.Option)
+	clusterExport.EXPECT().Export(gomock.Any()).Return(testResources(), nil)
+	simulatorExport.EXPECT().IgnoreErr().Return(*dummyOption)
+	simulatorExport.EXPECT().IgnoreSchedulerConfiguration().Return(*dummyOption)
+	simulatorExport.EXPECT().WithImportReport(gomock.Any()).Return(*dummyOption)
+	// Only pod1 is imported without spec.nodeName.
+	simulatorExport.EXPECT().Import(gomock.Any(), resourcesMatcher(func(r *export.ResourcesForImport) bool {
+		return len(r.Pods) == 1 && *r.Pods[0].Name == "pod1" && r.Pods[0].Spec.NodeName == nil
+	}), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	s := NewReplicateExistingClusterService(simulatorExport, clusterExport, ImportOptions{})
+	report, err := s.ImportFromExistingClusterWithOptions(context.Background(), ImportOptions{LabelSelector: "app=web", StripBindings: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, report)
+
+	_, err = s.ImportFromExistingClusterWithOptions(context.Background(), ImportOptions{LabelSelector: "app in (web"})
+	assert.ErrorIs(t, err, ErrInvalidImportOptions)
+}
+
+func TestService_ImportFromExistingCluster_Anonymize(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	simulatorExport := mock_replicateexistingcluster.NewMockExportService(ctrl)
+	clusterExport := mock_replicateexistingcluster.NewMockExportService(ctrl)
+
+	dummyOption := new(export.Option)
+	clusterExport.EXPECT().Export(gomock.Any()).Return(testResources(), nil)
+	simulatorExport.EXPECT().IgnoreErr().Return(*dummyOption)
+	simulatorExport.EXPECT().IgnoreSchedulerConfiguration().Return(*dummyOption)
+
+	s := NewReplicateExistingClusterService(simulatorExport, clusterExport, ImportOptions{LabelSelector: "app=web", Anonymize: true})
+	// The selector is matched before the resources are anonymized, and the names are the ones given by objectName.
+	simulatorExport.EXPECT().Import(gomock.Any(), resourcesMatcher(func(r *export.ResourcesForImport) bool {
+		return len(r.Pods) == 1 && *r.Pods[0].Name == s.objectName("pod1") && *r.Pods[0].Name != "pod1"
+	}), gomock.Any(), gomock.Any()).Return(nil)
+
+	assert.NoError(t, s.ImportFromExistingCluster(context.Background()))
+}
+
+// resourcesMatcher is the gomock.Matcher which matches ResourcesForImport with the function.
+type resourcesMatcher func(r *export.ResourcesForImport) bool
+
+func (m resourcesMatcher) Matches(x interface{}) bool {
+	r, ok := x.(*export.ResourcesForImport)
+	return ok && m(r)
+}
+
+func (m resourcesMatcher) String() string {
+	return "matches the ResourcesForImport"
+}
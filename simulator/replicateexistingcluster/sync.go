@@ -0,0 +1,474 @@
+package replicateexistingcluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
+)
+
+const (
+	syncerName = "existing-cluster-syncer"
+	// syncWorkers is the number of the workers mirroring the changes of each resource.
+	syncWorkers = 4
+)
+
+// Syncer keeps mirroring the Nodes and the Pods in the existing cluster into the simulator.
+//
+// While it's paused, the changes in the existing cluster aren't mirrored,
+// and the users can experiment on the simulator with the copy of the existing cluster.
+// When it's resumed (or frozen), the Nodes and the Pods in the simulator are made the same as the ones in the existing cluster again.
+//
+// Only the changes affecting the scheduling are mirrored, i.e., the changes of the labels and the specs (including the bindings) of the Nodes and the Pods,
+// and the capacities and the conditions of the Nodes. The changed objects are queued and mirrored by the workers in parallel.
+//
+// The conditions of the Nodes aren't written directly because the fake node agent would reset them.
+// They are mirrored as the failures injected to the Nodes instead. (See node.FailureAnnotationKey.)
+type Syncer struct {
+	service *Service
+	// client is the client of the simulator.
+	client         clientset.Interface
+	externalClient clientset.Interface
+
+	// filter selects and transforms the Nodes and the Pods with the ImportOptions of the service.
+	filter          *importFilter
+	namespaceLister corelisters.NamespaceLister
+	nodeLister      corelisters.NodeLister
+	podLister       corelisters.PodLister
+	// nodeQueue has the names of the changed Nodes, and podQueue has the keys (namespace/name) of the changed Pods.
+	nodeQueue workqueue.RateLimitingInterface
+	podQueue  workqueue.RateLimitingInterface
+
+	// mu is held for writing while the simulator is resynced, and for reading while a change is mirrored
+	// so that the changes aren't interleaved with a resync.
+	mu      sync.RWMutex
+	started bool
+	paused  bool
+	// lastResyncTime is the time when the simulator was made the same as the existing cluster last time.
+	lastResyncTime time.Time
+}
+
+// SyncStatus is the status of Syncer.
+type SyncStatus struct {
+	Paused         bool      `json:"paused"`
+	LastResyncTime time.Time `json:"lastResyncTime"`
+}
+
+// NewSyncer initializes Syncer.
+// The client is for the simulator, and the externalClient is for the existing cluster.
+func NewSyncer(service *Service, client, externalClient clientset.Interface) *Syncer {
+	return &Syncer{
+		service:        service,
+		client:         client,
+		externalClient: externalClient,
+		nodeQueue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), syncerName+"-node"),
+		podQueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), syncerName+"-pod"),
+	}
+}
+
+// Start starts watching the existing cluster, and imports all resources from it.
+// The watch is stopped when the ctx is done.
+// It should be called instead of ImportFromExistingCluster.
+func (s *Syncer) Start(ctx context.Context) error {
+	f, err := newImportFilter(s.service.options)
+	if err != nil {
+		return xerrors.Errorf("initialize import filter: %w", err)
+	}
+	s.filter = f
+
+	informerFactory := informers.NewSharedInformerFactory(s.externalClient, 0)
+	s.namespaceLister = informerFactory.Core().V1().Namespaces().Lister()
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	podInformer := informerFactory.Core().V1().Pods()
+	s.nodeLister = nodeInformer.Lister()
+	s.podLister = podInformer.Lister()
+
+	if _, err := nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: s.enqueueFunc(s.nodeQueue),
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNode, ok1 := oldObj.(*corev1.Node)
+			newNode, ok2 := newObj.(*corev1.Node)
+			if ok1 && ok2 && !nodeChanged(oldNode, newNode) {
+				return
+			}
+			s.enqueueFunc(s.nodeQueue)(newObj)
+		},
+		DeleteFunc: s.enqueueFunc(s.nodeQueue),
+	}); err != nil {
+		return xerrors.Errorf("add event handler to Node informer: %w", err)
+	}
+	if _, err := podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: s.enqueueFunc(s.podQueue),
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPod, ok1 := oldObj.(*corev1.Pod)
+			newPod, ok2 := newObj.(*corev1.Pod)
+			if ok1 && ok2 && !podChanged(oldPod, newPod) {
+				return
+			}
+			s.enqueueFunc(s.podQueue)(newObj)
+		},
+		DeleteFunc: s.enqueueFunc(s.podQueue),
+	}); err != nil {
+		return xerrors.Errorf("add event handler to Pod informer: %w", err)
+	}
+
+	informerFactory.Start(ctx.Done())
+	for typ, synced := range informerFactory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return xerrors.Errorf("wait for the cache of %v to be synced", typ)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The events before here are ignored, since the resync imports the latest resources.
+	if err := s.resync(ctx); err != nil {
+		return xerrors.Errorf("resync with the existing cluster: %w", err)
+	}
+	s.started = true
+
+	for i := 0; i < syncWorkers; i++ {
+		go wait.UntilWithContext(ctx, s.worker(s.nodeQueue, s.syncNode), time.Second)
+		go wait.UntilWithContext(ctx, s.worker(s.podQueue, s.syncPod), time.Second)
+	}
+	go func() {
+		<-ctx.Done()
+		s.nodeQueue.ShutDown()
+		s.podQueue.ShutDown()
+	}()
+	return nil
+}
+
+// Pause stops mirroring the changes in the existing cluster.
+func (s *Syncer) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = true
+}
+
+// Resume makes the simulator the same as the existing cluster, and restarts mirroring the changes.
+// The changes made on the Nodes and the Pods in the simulator while it's paused are discarded.
+func (s *Syncer) Resume(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.resync(ctx); err != nil {
+		return xerrors.Errorf("resync with the existing cluster: %w", err)
+	}
+	s.paused = false
+	return nil
+}
+
+// Freeze makes the simulator the same as the existing cluster at this moment, and pauses the sync.
+func (s *Syncer) Freeze(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.resync(ctx); err != nil {
+		return xerrors.Errorf("resync with the existing cluster: %w", err)
+	}
+	s.paused = true
+	return nil
+}
+
+// Status returns the status of Syncer.
+func (s *Syncer) Status() SyncStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return SyncStatus{Paused: s.paused, LastResyncTime: s.lastResyncTime}
+}
+
+// resync imports all resources from the existing cluster,
+// and deletes the Nodes and the Pods which don't exist in the existing cluster from the simulator.
+// It must be called with s.mu held for writing.
+//
+//nolint:cyclop // For readability.
+func (s *Syncer) resync(ctx context.Context) error {
+	srcNodes, err := s.nodeLister.List(labels.Everything())
+	if err != nil {
+		return xerrors.Errorf("list Nodes in the existing cluster: %w", err)
+	}
+	srcPods, err := s.podLister.List(labels.Everything())
+	if err != nil {
+		return xerrors.Errorf("list Pods in the existing cluster: %w", err)
+	}
+	srcNodeNames := make(map[string]bool, len(srcNodes))
+	for _, n := range srcNodes {
+		if s.filter.nodeSelected(n) {
+			srcNodeNames[s.service.objectName(n.Name)] = true
+		}
+	}
+	srcPodNodeNames := make(map[string]string, len(srcPods))
+	for _, p := range srcPods {
+		if s.podSelected(p) {
+			srcPodNodeNames[s.service.objectName(p.Namespace)+"/"+s.service.objectName(p.Name)] = s.service.objectName(s.filter.transformPod(p).Spec.NodeName)
+		}
+	}
+
+	pods, err := s.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return xerrors.Errorf("list Pods in the simulator: %w", err)
+	}
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		nodeName, ok := srcPodNodeNames[p.Namespace+"/"+p.Name]
+		// The Pods bound to another Node are recreated, because spec.nodeName can't be changed.
+		if ok && (nodeName == "" || p.Spec.NodeName == "" || nodeName == p.Spec.NodeName) {
+			continue
+		}
+		if err := s.deletePod(ctx, p.Namespace, p.Name); err != nil {
+			return xerrors.Errorf("delete Pod: %w", err)
+		}
+	}
+	nodes, err := s.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return xerrors.Errorf("list Nodes in the simulator: %w", err)
+	}
+	for i := range nodes.Items {
+		if srcNodeNames[nodes.Items[i].Name] {
+			continue
+		}
+		if err := s.deleteNode(ctx, nodes.Items[i].Name); err != nil {
+			return xerrors.Errorf("delete Node: %w", err)
+		}
+	}
+
+	if err := s.service.ImportFromExistingCluster(ctx); err != nil {
+		return xerrors.Errorf("import from the existing cluster: %w", err)
+	}
+	s.lastResyncTime = time.Now()
+	return nil
+}
+
+// mirroring returns true if the changes in the existing cluster should be mirrored now.
+// It must be called with s.mu held.
+func (s *Syncer) mirroring() bool {
+	return s.started && !s.paused
+}
+
+// enqueueFunc returns the event handler function which adds the key of the object to the queue.
+// The changes while the sync is paused aren't queued, since the simulator is resynced when it's resumed.
+func (s *Syncer) enqueueFunc(queue workqueue.RateLimitingInterface) func(interface{}) {
+	return func(obj interface{}) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		if !s.mirroring() {
+			return
+		}
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		queue.Add(key)
+	}
+}
+
+// worker returns the function which mirrors the changes in the queue with sync until the queue is shut down.
+func (s *Syncer) worker(queue workqueue.RateLimitingInterface, syncFunc func(context.Context, string) error) func(context.Context) {
+	return func(ctx context.Context) {
+		for s.processNextWorkItem(ctx, queue, syncFunc) {
+		}
+	}
+}
+
+func (s *Syncer) processNextWorkItem(ctx context.Context, queue workqueue.RateLimitingInterface, syncFunc func(context.Context, string) error) bool {
+	key, quit := queue.Get()
+	if quit {
+		return false
+	}
+	defer queue.Done(key)
+
+	if err := syncFunc(ctx, key.(string)); err != nil {
+		utilruntime.HandleError(xerrors.Errorf("mirror %q: %w", key, err))
+		queue.AddRateLimited(key)
+		return true
+	}
+	queue.Forget(key)
+	return true
+}
+
+// syncNode mirrors the Node in the existing cluster into the simulator.
+// The Node is deleted from the simulator if it's deleted or not selected in the existing cluster.
+func (s *Syncer) syncNode(ctx context.Context, name string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.mirroring() {
+		return nil
+	}
+
+	node, err := s.nodeLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		return s.deleteNode(ctx, s.service.objectName(name))
+	}
+	if err != nil {
+		return xerrors.Errorf("get Node in the existing cluster: %w", err)
+	}
+	if !s.filter.nodeSelected(node) {
+		return s.deleteNode(ctx, s.service.objectName(name))
+	}
+	if err := s.mirrorNode(ctx, node); err != nil {
+		return xerrors.Errorf("mirror Node: %w", err)
+	}
+	return nil
+}
+
+// syncPod mirrors the Pod in the existing cluster into the simulator.
+// The Pod is deleted from the simulator if it's deleted or not selected in the existing cluster.
+func (s *Syncer) syncPod(ctx context.Context, key string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.mirroring() {
+		return nil
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return xerrors.Errorf("split key: %w", err)
+	}
+	pod, err := s.podLister.Pods(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return s.deletePod(ctx, s.service.objectName(namespace), s.service.objectName(name))
+	}
+	if err != nil {
+		return xerrors.Errorf("get Pod in the existing cluster: %w", err)
+	}
+	if !s.podSelected(pod) {
+		return s.deletePod(ctx, s.service.objectName(namespace), s.service.objectName(name))
+	}
+	if err := s.mirrorPod(ctx, s.filter.transformPod(pod)); err != nil {
+		return xerrors.Errorf("mirror Pod: %w", err)
+	}
+	return nil
+}
+
+// nodeChanged returns true if the Node is changed in the way affecting the scheduling.
+// The changes of the heartbeats of the conditions are ignored, since the kubelet updates them periodically.
+func nodeChanged(oldNode, newNode *corev1.Node) bool {
+	if !equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		!equality.Semantic.DeepEqual(oldNode.Spec, newNode.Spec) ||
+		!equality.Semantic.DeepEqual(oldNode.Status.Capacity, newNode.Status.Capacity) ||
+		!equality.Semantic.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable) ||
+		len(oldNode.Status.Conditions) != len(newNode.Status.Conditions) {
+		return true
+	}
+	for i := range oldNode.Status.Conditions {
+		o, n := oldNode.Status.Conditions[i], newNode.Status.Conditions[i]
+		if o.Type != n.Type || o.Status != n.Status {
+			return true
+		}
+	}
+	return false
+}
+
+// podChanged returns true if the labels or the spec of the Pod are changed. (e.g., the Pod is bound.)
+// The changes of the status are ignored.
+func podChanged(oldPod, newPod *corev1.Pod) bool {
+	return !equality.Semantic.DeepEqual(oldPod.Labels, newPod.Labels) ||
+		!equality.Semantic.DeepEqual(oldPod.Spec, newPod.Spec)
+}
+
+// podSelected returns true if the Pod is imported with the ImportOptions.
+// The Namespace and the Node which aren't found are regarded as selected.
+func (s *Syncer) podSelected(pod *corev1.Pod) bool {
+	if ns, err := s.namespaceLister.Get(pod.Namespace); err == nil && !s.filter.namespaceSelected(ns) {
+		return false
+	}
+	nodeSelected := true
+	if node, err := s.nodeLister.Get(pod.Spec.NodeName); err == nil {
+		nodeSelected = s.filter.nodeSelected(node)
+	}
+	return s.filter.podSelected(pod, nodeSelected)
+}
+
+// mirrorPod applies the Pod in the existing cluster to the simulator.
+// If the Pod in the simulator is bound to another Node, (e.g., the scheduler in the simulator has scheduled it)
+// it's recreated so that it's bound to the same Node as the existing cluster.
+func (s *Syncer) mirrorPod(ctx context.Context, pod *corev1.Pod) error {
+	if pod.Spec.NodeName != "" {
+		namespace, name := s.service.objectName(pod.Namespace), s.service.objectName(pod.Name)
+		current, err := s.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return xerrors.Errorf("get Pod in the simulator: %w", err)
+		}
+		if err == nil && current.Spec.NodeName != "" && current.Spec.NodeName != s.service.objectName(pod.Spec.NodeName) {
+			if err := s.deletePod(ctx, namespace, name); err != nil {
+				return xerrors.Errorf("delete Pod bound to another Node: %w", err)
+			}
+		}
+	}
+	if err := s.importResources(ctx, &export.ResourcesForExport{Pods: []corev1.Pod{*pod.DeepCopy()}}); err != nil {
+		return xerrors.Errorf("import Pod: %w", err)
+	}
+	return nil
+}
+
+// mirrorNode applies the Node in the existing cluster to the simulator.
+// The capacities are updated separately, since the apply doesn't update the status of the existing Node.
+// The conditions are mirrored as the failures instead so that the fake node agent keeps reporting them.
+func (s *Syncer) mirrorNode(ctx context.Context, node *corev1.Node) error {
+	resources := &export.ResourcesForExport{Nodes: []corev1.Node{*s.filter.transformNode(node)}}
+	if err := s.importResources(ctx, resources); err != nil {
+		return xerrors.Errorf("import Node: %w", err)
+	}
+	// The resources are anonymized in place, so the Node has the name in the simulator.
+	src := &resources.Nodes[0]
+	current, err := s.client.CoreV1().Nodes().Get(ctx, src.Name, metav1.GetOptions{})
+	if err != nil {
+		return xerrors.Errorf("get Node in the simulator: %w", err)
+	}
+	current.Status.Capacity = src.Status.Capacity
+	current.Status.Allocatable = src.Status.Allocatable
+	if _, err := s.client.CoreV1().Nodes().UpdateStatus(ctx, current, metav1.UpdateOptions{}); err != nil {
+		return xerrors.Errorf("update status of Node in the simulator: %w", err)
+	}
+	return nil
+}
+
+// importResources imports the resources with the export service of the simulator.
+// They are anonymized in place if ImportOptions.Anonymize is true, so they must not be the objects in the informer cache.
+func (s *Syncer) importResources(ctx context.Context, resources *export.ResourcesForExport) error {
+	if s.service.options.Anonymize {
+		s.service.anonymizer.Anonymize(resources)
+	}
+	impRes, err := export.ConvertResourcesForImportToResourcesForExport(resources)
+	if err != nil {
+		return xerrors.Errorf("call ConvertResourcesForImportToResourcesForExport: %w", err)
+	}
+	if err := s.service.simulatorExportService.Import(ctx, impRes, s.service.simulatorExportService.IgnoreSchedulerConfiguration()); err != nil {
+		return xerrors.Errorf("call Import of the simulator export service: %w", err)
+	}
+	return nil
+}
+
+// deletePod deletes the Pod from the simulator. The namespace and the name are the ones in the simulator.
+func (s *Syncer) deletePod(ctx context.Context, namespace, name string) error {
+	noGrace := int64(0)
+	err := s.client.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{GracePeriodSeconds: &noGrace})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return xerrors.Errorf("delete Pod %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// deleteNode deletes the Node from the simulator. The name is the one in the simulator.
+func (s *Syncer) deleteNode(ctx context.Context, name string) error {
+	err := s.client.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return xerrors.Errorf("delete Node %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package replicateexistingcluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
+	simulatornode "sigs.k8s.io/kube-scheduler-simulator/simulator/node"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/replicateexistingcluster/mock_replicateexistingcluster"
+)
+
+func node(name string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func pod(name, nodeName string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Spec: corev1.PodSpec{NodeName: nodeName}}
+}
+
+func TestSyncer_Start(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl := gomock.NewController(t)
+
+	externalClient := fake.NewSimpleClientset(node("node1"), pod("pod1", "node1"), pod("pod2", ""))
+	// pod1 is bound to another Node, and node2 and pod3 don't exist in the existing cluster.
+	client := fake.NewSimpleClientset(node("node1"), node("node2"), pod("pod1", "node2"), pod("pod2", "node1"), pod("pod3", "node1"))
+
+	simulatorExport := mock_replicateexistingcluster.NewMockExportService(ctrl)
+	clusterExport := mock_replicateexistingcluster.NewMockExportService(ctrl)
+	dummyOption := new(export.Option)
+	clusterExport.EXPECT().Export(gomock.Any()).Return(&export.ResourcesForExport{}, nil)
+	simulatorExport.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	simulatorExport.EXPECT().IgnoreErr().Return(*dummyOption)
+	simulatorExport.EXPECT().IgnoreSchedulerConfiguration().Return(*dummyOption)
+
+	s := NewSyncer(NewReplicateExistingClusterService(simulatorExport, clusterExport, ImportOptions{}), client, externalClient)
+	assert.NoError(t, s.Start(ctx))
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, nodes.Items, 1)
+	assert.Equal(t, "node1", nodes.Items[0].Name)
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	// The Pod which isn't bound in the existing cluster is kept even if the simulator has bound it.
+	assert.Len(t, pods.Items, 1)
+	assert.Equal(t, "pod2", pods.Items[0].Name)
+	assert.False(t, s.Status().Paused)
+	assert.False(t, s.Status().LastResyncTime.IsZero())
+}
+
+func TestSyncer_mirror(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl := gomock.NewController(t)
+
+	externalClient := fake.NewSimpleClientset(node("node1"))
+	client := fake.NewSimpleClientset(node("node1"))
+
+	simulatorExport := mock_replicateexistingcluster.NewMockExportService(ctrl)
+	clusterExport := mock_replicateexistingcluster.NewMockExportService(ctrl)
+	dummyOption := new(export.Option)
+	clusterExport.EXPECT().Export(gomock.Any()).Return(&export.ResourcesForExport{}, nil).AnyTimes()
+	simulatorExport.EXPECT().IgnoreErr().Return(*dummyOption).AnyTimes()
+	simulatorExport.EXPECT().IgnoreSchedulerConfiguration().Return(*dummyOption).AnyTimes()
+	// The import by the resync, which has two options. It has to be registered first
+	// because the matcher of the variadic options below matches any number of options.
+	simulatorExport.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	imported := make(chan *export.ResourcesForImport, 10)
+	simulatorExport.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *export.ResourcesForImport, _ export.Option) error {
+		imported <- r
+		return nil
+	}).AnyTimes()
+
+	s := NewSyncer(NewReplicateExistingClusterService(simulatorExport, clusterExport, ImportOptions{}), client, externalClient)
+	assert.NoError(t, s.Start(ctx))
+
+	// The new Pod is mirrored.
+	_, err := externalClient.CoreV1().Pods("default").Create(ctx, pod("pod1", "node1"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	select {
+	case r := <-imported:
+		assert.Len(t, r.Pods, 1)
+		assert.Equal(t, "pod1", *r.Pods[0].Name)
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("the Pod isn't mirrored")
+	}
+
+	// The changes of the status of the Pod aren't mirrored.
+	p, err := externalClient.CoreV1().Pods("default").Get(ctx, "pod1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	p.Status.Phase = corev1.PodRunning
+	_, err = externalClient.CoreV1().Pods("default").UpdateStatus(ctx, p, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	select {
+	case r := <-imported:
+		t.Fatalf("the status of the Pod is mirrored: %v", r)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The capacity of the Node is mirrored to the status.
+	n := node("node1")
+	n.Status.Capacity = corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")}
+	_, err = externalClient.CoreV1().Nodes().UpdateStatus(ctx, n, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		n, err := client.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+		return err == nil && n.Status.Capacity.Pods().Value() == 10
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+	<-imported
+
+	// The conditions of the Node are mirrored as the failures, not to the status.
+	n.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}
+	_, err = externalClient.CoreV1().Nodes().UpdateStatus(ctx, n, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	select {
+	case r := <-imported:
+		assert.Len(t, r.Nodes, 1)
+		assert.Equal(t, "NotReady", r.Nodes[0].Annotations[simulatornode.FailureAnnotationKey])
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("the conditions of the Node aren't mirrored")
+	}
+	got, err := client.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, got.Status.Conditions)
+
+	// The deletion of the Node is mirrored.
+	assert.NoError(t, externalClient.CoreV1().Nodes().Delete(ctx, "node1", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		return err == nil && len(nodes.Items) == 0
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+
+	// The changes aren't mirrored while the sync is frozen.
+	assert.NoError(t, s.Freeze(ctx))
+	assert.True(t, s.Status().Paused)
+	_, err = externalClient.CoreV1().Nodes().Create(ctx, node("node2"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	select {
+	case r := <-imported:
+		t.Fatalf("the Node is mirrored while the sync is frozen: %v", r)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.NoError(t, s.Resume(ctx))
+	assert.False(t, s.Status().Paused)
+}
+
+func Test_nodeChanged(t *testing.T) {
+	t.Parallel()
+	ready := func(status corev1.ConditionStatus, heartbeat int64) *corev1.Node {
+		n := node("node1")
+		n.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status, LastHeartbeatTime: metav1.Unix(heartbeat, 0)}}
+		return n
+	}
+	tainted := node("node1")
+	tainted.Spec.Taints = []corev1.Taint{{Key: "key", Effect: corev1.TaintEffectNoSchedule}}
+
+	tests := []struct {
+		name    string
+		oldNode *corev1.Node
+		newNode *corev1.Node
+		want    bool
+	}{
+		{
+			name:    "only the heartbeat is changed",
+			oldNode: ready(corev1.ConditionTrue, 1),
+			newNode: ready(corev1.ConditionTrue, 2),
+			want:    false,
+		},
+		{
+			name:    "the condition is changed",
+			oldNode: ready(corev1.ConditionTrue, 1),
+			newNode: ready(corev1.ConditionFalse, 1),
+			want:    true,
+		},
+		{
+			name:    "the spec is changed",
+			oldNode: node("node1"),
+			newNode: tainted,
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, nodeChanged(tt.oldNode, tt.newNode))
+		})
+	}
+}
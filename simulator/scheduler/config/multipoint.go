@@ -0,0 +1,153 @@
+package config
+
+import (
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	v1 "k8s.io/kube-scheduler/config/v1"
+	"k8s.io/kube-scheduler/config/v1beta2"
+	"k8s.io/kube-scheduler/config/v1beta3"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
+)
+
+// defaultProfilePlugins defaults the plugins of each profile in the version of cfg.
+// v1beta3 and v1 enable the default plugins through multiPoint with their own weights,
+// which would be lost if the profiles were defaulted after being converted into v1beta2.
+// The other fields of the profiles are left unset so that the simulator can default them later as usual.
+func defaultProfilePlugins(cfg runtime.Object) {
+	switch c := cfg.(type) {
+	case *v1beta3.KubeSchedulerConfiguration:
+		if len(c.Profiles) == 0 {
+			c.Profiles = []v1beta3.KubeSchedulerProfile{{}}
+		}
+		for i := range c.Profiles {
+			defaulted := &v1beta3.KubeSchedulerConfiguration{Profiles: []v1beta3.KubeSchedulerProfile{*c.Profiles[i].DeepCopy()}}
+			scheme.Scheme.Default(defaulted)
+			c.Profiles[i].Plugins = defaulted.Profiles[0].Plugins
+		}
+	case *v1.KubeSchedulerConfiguration:
+		if len(c.Profiles) == 0 {
+			c.Profiles = []v1.KubeSchedulerProfile{{}}
+		}
+		for i := range c.Profiles {
+			defaulted := &v1.KubeSchedulerConfiguration{Profiles: []v1.KubeSchedulerProfile{*c.Profiles[i].DeepCopy()}}
+			scheme.Scheme.Default(defaulted)
+			c.Profiles[i].Plugins = defaulted.Profiles[0].Plugins
+		}
+	}
+}
+
+// expandMultiPoint moves the multiPoint plugins of each profile into the extension points that they implement.
+// The simulator replaces the plugins at each extension point with the wrapped ones and disables the rest,
+// so the plugins left in multiPoint would never run.
+//
+// The plugins are ordered in the same way as the scheduler framework does:
+// the multiPoint plugins re-configured at the extension point, the other multiPoint plugins, and then the rest of the plugins at the extension point.
+// The plugins that don't implement any of the known extension points are left in multiPoint.
+func expandMultiPoint(cfg *v1beta2.KubeSchedulerConfiguration) error {
+	defaultCfg, err := DefaultSchedulerConfig()
+	if err != nil || len(defaultCfg.Profiles) != 1 {
+		// default Config should only have default-scheduler configuration.
+		return xerrors.Errorf("get default scheduler configuration: %w", err)
+	}
+	def := defaultCfg.Profiles[0].Plugins
+
+	for i := range cfg.Profiles {
+		pls := cfg.Profiles[i].Plugins
+		if pls == nil || len(pls.MultiPoint.Enabled) == 0 {
+			continue
+		}
+
+		points := []struct {
+			pluginSet *v1beta2.PluginSet
+			members   []v1beta2.Plugin
+			score     bool
+		}{
+			{pluginSet: &pls.QueueSort, members: def.QueueSort.Enabled},
+			{pluginSet: &pls.PreEnqueue, members: def.PreEnqueue.Enabled},
+			{pluginSet: &pls.PreFilter, members: append(def.PreFilter.Enabled, OutOfTreePreFilterPlugins()...)},
+			{pluginSet: &pls.Filter, members: append(def.Filter.Enabled, OutOfTreeFilterPlugins()...)},
+			{pluginSet: &pls.PostFilter, members: append(def.PostFilter.Enabled, OutOfTreePostFilterPlugins()...)},
+			{pluginSet: &pls.PreScore, members: append(def.PreScore.Enabled, OutOfTreePreScorePlugins()...)},
+			{pluginSet: &pls.Score, members: append(def.Score.Enabled, OutOfTreeScorePlugins()...), score: true},
+			{pluginSet: &pls.Reserve, members: append(def.Reserve.Enabled, OutOfTreeReservePlugins()...)},
+			{pluginSet: &pls.Permit, members: append(def.Permit.Enabled, OutOfTreePermitPlugins()...)},
+			{pluginSet: &pls.PreBind, members: append(def.PreBind.Enabled, OutOfTreePreBindPlugins()...)},
+			{pluginSet: &pls.Bind, members: append(def.Bind.Enabled, OutOfTreeBindPlugins()...)},
+			{pluginSet: &pls.PostBind, members: append(def.PostBind.Enabled, OutOfTreePostBindPlugins()...)},
+		}
+
+		expanded := sets.NewString()
+		for _, p := range points {
+			members := sets.NewString()
+			for _, m := range p.members {
+				members.Insert(m.Name)
+			}
+			for _, mp := range pls.MultiPoint.Enabled {
+				if members.Has(mp.Name) {
+					expanded.Insert(mp.Name)
+				}
+			}
+			expandPluginSet(p.pluginSet, pls.MultiPoint.Enabled, members, p.score)
+		}
+
+		var rest []v1beta2.Plugin
+		for _, mp := range pls.MultiPoint.Enabled {
+			if !expanded.Has(mp.Name) {
+				rest = append(rest, mp)
+			}
+		}
+		pls.MultiPoint = v1beta2.PluginSet{Enabled: rest}
+	}
+	return nil
+}
+
+// expandPluginSet enables the multiPoint plugins which are members of the extension point in pluginSet,
+// and disables all the other plugins so that nothing is added to it by defaulting.
+// The extension point which already disables all plugins is left as it is because the scheduler framework skips multiPoint for it.
+func expandPluginSet(pluginSet *v1beta2.PluginSet, multiPoint []v1beta2.Plugin, members sets.String, score bool) {
+	disabled := sets.NewString()
+	for _, p := range pluginSet.Disabled {
+		disabled.Insert(p.Name)
+	}
+	if disabled.Has("*") {
+		return
+	}
+	enabled := sets.NewString()
+	for _, p := range pluginSet.Enabled {
+		enabled.Insert(p.Name)
+	}
+
+	overridden := sets.NewString()
+	var fromMultiPoint []v1beta2.Plugin
+	for _, mp := range multiPoint {
+		if !members.Has(mp.Name) || disabled.Has(mp.Name) {
+			continue
+		}
+		if enabled.Has(mp.Name) {
+			overridden.Insert(mp.Name)
+			continue
+		}
+		p := v1beta2.Plugin{Name: mp.Name}
+		if score {
+			p.Weight = mp.Weight
+		}
+		fromMultiPoint = append(fromMultiPoint, p)
+	}
+
+	var overrides, others []v1beta2.Plugin
+	for _, p := range pluginSet.Enabled {
+		if overridden.Has(p.Name) {
+			overrides = append(overrides, p)
+			continue
+		}
+		others = append(others, p)
+	}
+
+	ret := make([]v1beta2.Plugin, 0, len(overrides)+len(fromMultiPoint)+len(others))
+	ret = append(ret, overrides...)
+	ret = append(ret, fromMultiPoint...)
+	ret = append(ret, others...)
+	pluginSet.Enabled = ret
+	pluginSet.Disabled = []v1beta2.Plugin{{Name: "*"}}
+}
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"errors"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	v1 "k8s.io/kube-scheduler/config/v1"
+	"k8s.io/kube-scheduler/config/v1beta2"
+	"k8s.io/kube-scheduler/config/v1beta3"
+	internalconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
+)
+
+// The simulator handles KubeSchedulerConfiguration as v1beta2 internally.
+// KubeSchedulerConfiguration in the other supported versions is converted into v1beta2 when it comes in,
+// and is converted back into the version that the client asks for when it goes out.
+//
+// Note that the conversion is done via the internal KubeSchedulerConfiguration.
+// The plugins of each profile are defaulted in the source version before the conversion,
+// and the multiPoint plugins are expanded into each extension point so that they keep the default weights of the source version.
+// The fields that v1beta2 doesn't have (e.g., .profiles[*].percentageOfNodesToScore in v1) are rejected.
+
+// ErrUnsupportedVersion represents the version of KubeSchedulerConfiguration isn't supported by the simulator.
+var ErrUnsupportedVersion = errors.New("unsupported version of KubeSchedulerConfiguration")
+
+// ErrUnsupportedField represents KubeSchedulerConfiguration has the field which cannot be converted into v1beta2.
+var ErrUnsupportedField = errors.New("unsupported field of KubeSchedulerConfiguration")
+
+// SupportedVersions are the versions of KubeSchedulerConfiguration that the simulator supports.
+var SupportedVersions = []schema.GroupVersion{
+	v1beta2.SchemeGroupVersion,
+	v1beta3.SchemeGroupVersion,
+	v1.SchemeGroupVersion,
+}
+
+const kind = "KubeSchedulerConfiguration"
+
+// ParseVersion parses the version of KubeSchedulerConfiguration.
+// It accepts both of the version only (e.g., "v1") and the apiVersion (e.g., "kubescheduler.config.k8s.io/v1").
+// It returns v1beta2 when the version is empty.
+func ParseVersion(version string) (schema.GroupVersion, error) {
+	if version == "" {
+		return v1beta2.SchemeGroupVersion, nil
+	}
+	for _, gv := range SupportedVersions {
+		if version == gv.Version || version == gv.String() {
+			return gv, nil
+		}
+	}
+	return schema.GroupVersion{}, xerrors.Errorf("parse version %q: %w", version, ErrUnsupportedVersion)
+}
+
+// DecodeSchedulerCfg decodes KubeSchedulerConfiguration in any supported version and converts it into v1beta2.
+// The data without apiVersion and kind is decoded as v1beta2.
+func DecodeSchedulerCfg(buf []byte) (*v1beta2.KubeSchedulerConfiguration, error) {
+	decoder := scheme.Codecs.UniversalDeserializer()
+	defaultGVK := v1beta2.SchemeGroupVersion.WithKind(kind)
+	obj, gvk, err := decoder.Decode(buf, &defaultGVK, nil)
+	if err != nil {
+		return nil, xerrors.Errorf("load an k8s object from buffer: %w", err)
+	}
+	if gvk.Kind != kind {
+		return nil, xerrors.Errorf("decode %s, but got unexpected kind: %s", kind, gvk.Kind)
+	}
+
+	cfg, err := ConvertToV1beta2(obj)
+	if err != nil {
+		return nil, xerrors.Errorf("convert %s to v1beta2: %w", gvk.GroupVersion(), err)
+	}
+	return cfg, nil
+}
+
+// ConvertToV1beta2 converts KubeSchedulerConfiguration in any supported version into v1beta2.
+// Plugin args in .profiles[*].pluginConfig[*].args.raw are decoded into typed objects.
+func ConvertToV1beta2(obj runtime.Object) (*v1beta2.KubeSchedulerConfiguration, error) {
+	var cfg nestedObjectsCodec
+	switch c := obj.(type) {
+	case *v1beta2.KubeSchedulerConfiguration:
+		cfg = c.DeepCopy()
+	case *v1beta3.KubeSchedulerConfiguration:
+		cfg = c.DeepCopy()
+	case *v1.KubeSchedulerConfiguration:
+		for i := range c.Profiles {
+			if c.Profiles[i].PercentageOfNodesToScore != nil {
+				return nil, xerrors.Errorf("convert .profiles[%d].percentageOfNodesToScore: %w", i, ErrUnsupportedField)
+			}
+		}
+		cfg = c.DeepCopy()
+	default:
+		return nil, xerrors.Errorf("convert %T: %w", obj, ErrUnsupportedVersion)
+	}
+	if err := decodeNestedObjects(cfg); err != nil {
+		return nil, xerrors.Errorf("decode nested plugin args: %w", err)
+	}
+
+	if ret, ok := cfg.(*v1beta2.KubeSchedulerConfiguration); ok {
+		ret.SetGroupVersionKind(v1beta2.SchemeGroupVersion.WithKind(kind))
+		return ret, nil
+	}
+
+	defaultProfilePlugins(cfg)
+	ret := &v1beta2.KubeSchedulerConfiguration{}
+	if err := convert(cfg, ret); err != nil {
+		return nil, xerrors.Errorf("convert to v1beta2: %w", err)
+	}
+	if err := expandMultiPoint(ret); err != nil {
+		return nil, xerrors.Errorf("expand multiPoint plugins: %w", err)
+	}
+	ret.SetGroupVersionKind(v1beta2.SchemeGroupVersion.WithKind(kind))
+	return ret, nil
+}
+
+// nestedObjectsCodec is implemented by KubeSchedulerConfiguration in all versions.
+type nestedObjectsCodec interface {
+	runtime.Object
+	EncodeNestedObjects(e runtime.Encoder) error
+	DecodeNestedObjects(d runtime.Decoder) error
+}
+
+// decodeNestedObjects makes all plugin args in cfg typed objects.
+// The args which are already typed objects are encoded once because DecodeNestedObjects only sees .args.raw.
+func decodeNestedObjects(cfg nestedObjectsCodec) error {
+	gvks, _, err := scheme.Scheme.ObjectKinds(cfg)
+	if err != nil || len(gvks) == 0 {
+		return xerrors.Errorf("get the kind of %T: %w", cfg, err)
+	}
+	if err := cfg.EncodeNestedObjects(scheme.Codecs.LegacyCodec(gvks[0].GroupVersion())); err != nil {
+		return xerrors.Errorf("encode nested plugin args: %w", err)
+	}
+	if err := cfg.DecodeNestedObjects(scheme.Codecs.UniversalDeserializer()); err != nil {
+		return xerrors.Errorf("decode nested plugin args: %w", err)
+	}
+	return nil
+}
+
+// ConvertFromV1beta2 converts v1beta2 KubeSchedulerConfiguration into the given version.
+func ConvertFromV1beta2(cfg *v1beta2.KubeSchedulerConfiguration, gv schema.GroupVersion) (runtime.Object, error) {
+	// make sure the plugin args are typed objects so that they are converted as well.
+	in, err := ConvertToV1beta2(cfg)
+	if err != nil {
+		return nil, xerrors.Errorf("decode the configuration: %w", err)
+	}
+
+	var out runtime.Object
+	switch gv {
+	case v1beta2.SchemeGroupVersion:
+		return in, nil
+	case v1beta3.SchemeGroupVersion:
+		out = &v1beta3.KubeSchedulerConfiguration{}
+	case v1.SchemeGroupVersion:
+		out = &v1.KubeSchedulerConfiguration{}
+	default:
+		return nil, xerrors.Errorf("convert to %s: %w", gv, ErrUnsupportedVersion)
+	}
+
+	if err := convert(in, out); err != nil {
+		return nil, xerrors.Errorf("convert to %s: %w", gv, err)
+	}
+	out.GetObjectKind().SetGroupVersionKind(gv.WithKind(kind))
+	return out, nil
+}
+
+// convert converts KubeSchedulerConfiguration between versions via the internal configuration.
+//
+// The fields other than profiles and extenders are defaulted in the source version before the conversion
+// because the internal configuration cannot keep them unset. (e.g., unset parallelism would become 0.)
+// Profiles and extenders are converted without defaulting so that the simulator can default them later as usual.
+func convert(in, out runtime.Object) error {
+	defaulted := withoutProfilesAndExtenders(in)
+	scheme.Scheme.Default(defaulted)
+	if err := convertViaInternal(defaulted, out); err != nil {
+		return xerrors.Errorf("convert the defaulted configuration: %w", err)
+	}
+
+	asis := out.DeepCopyObject()
+	if err := convertViaInternal(in, asis); err != nil {
+		return xerrors.Errorf("convert the configuration: %w", err)
+	}
+	switch o := out.(type) {
+	case *v1beta2.KubeSchedulerConfiguration:
+		a, _ := asis.(*v1beta2.KubeSchedulerConfiguration)
+		o.Profiles, o.Extenders = a.Profiles, a.Extenders
+		for i := range o.Profiles {
+			o.Profiles[i].SchedulerName = unsetIfEmpty(o.Profiles[i].SchedulerName)
+		}
+	case *v1beta3.KubeSchedulerConfiguration:
+		a, _ := asis.(*v1beta3.KubeSchedulerConfiguration)
+		o.Profiles, o.Extenders = a.Profiles, a.Extenders
+		for i := range o.Profiles {
+			o.Profiles[i].SchedulerName = unsetIfEmpty(o.Profiles[i].SchedulerName)
+		}
+	case *v1.KubeSchedulerConfiguration:
+		a, _ := asis.(*v1.KubeSchedulerConfiguration)
+		o.Profiles, o.Extenders = a.Profiles, a.Extenders
+		for i := range o.Profiles {
+			o.Profiles[i].SchedulerName = unsetIfEmpty(o.Profiles[i].SchedulerName)
+		}
+	}
+	return nil
+}
+
+// withoutProfilesAndExtenders returns the copy of KubeSchedulerConfiguration which doesn't have profiles and extenders.
+func withoutProfilesAndExtenders(obj runtime.Object) runtime.Object {
+	ret := obj.DeepCopyObject()
+	switch c := ret.(type) {
+	case *v1beta2.KubeSchedulerConfiguration:
+		c.Profiles, c.Extenders = nil, nil
+	case *v1beta3.KubeSchedulerConfiguration:
+		c.Profiles, c.Extenders = nil, nil
+	case *v1.KubeSchedulerConfiguration:
+		c.Profiles, c.Extenders = nil, nil
+	}
+	return ret
+}
+
+func convertViaInternal(in, out runtime.Object) error {
+	internal := &internalconfig.KubeSchedulerConfiguration{}
+	if err := scheme.Scheme.Convert(in, internal, nil); err != nil {
+		return xerrors.Errorf("convert to the internal configuration: %w", err)
+	}
+	if err := scheme.Scheme.Convert(internal, out, nil); err != nil {
+		return xerrors.Errorf("convert from the internal configuration: %w", err)
+	}
+	return nil
+}
+
+// unsetIfEmpty returns nil if s is empty.
+// The unset schedulerName becomes the empty string through the internal configuration.
+func unsetIfEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,419 @@
+package config
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	v1 "k8s.io/kube-scheduler/config/v1"
+	"k8s.io/kube-scheduler/config/v1beta2"
+	"k8s.io/kube-scheduler/config/v1beta3"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
+	"k8s.io/utils/pointer"
+)
+
+func TestDecodeSchedulerCfg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		buf     []byte
+		want    *v1beta2.KubeSchedulerConfiguration
+		wantErr bool
+	}{
+		{
+			name: "success with normal configuration",
+			buf: []byte(`
+apiVersion: kubescheduler.config.k8s.io/v1beta2
+kind: KubeSchedulerConfiguration
+profiles:
+- pluginConfig:
+  - args:
+      scoringStrategy:
+        resources:
+        - name: cpu
+          weight: 1
+        type: MostAllocated
+    name: NodeResourcesFit
+`),
+			want: &v1beta2.KubeSchedulerConfiguration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "KubeSchedulerConfiguration",
+					APIVersion: "kubescheduler.config.k8s.io/v1beta2",
+				},
+				Profiles: []v1beta2.KubeSchedulerProfile{
+					{
+						PluginConfig: []v1beta2.PluginConfig{
+							{
+								Name: "NodeResourcesFit",
+								Args: runtime.RawExtension{
+									Object: &v1beta2.NodeResourcesFitArgs{
+										ScoringStrategy: &v1beta2.ScoringStrategy{
+											Resources: []v1beta2.ResourceSpec{
+												{
+													Name:   "cpu",
+													Weight: 1,
+												},
+											},
+											Type: "MostAllocated",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "success with v1 configuration",
+			buf: []byte(`
+apiVersion: kubescheduler.config.k8s.io/v1
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: my-scheduler
+  plugins:
+    score:
+      enabled:
+      - name: NodeResourcesFit
+        weight: 3
+  pluginConfig:
+  - args:
+      scoringStrategy:
+        resources:
+        - name: cpu
+          weight: 1
+        type: MostAllocated
+    name: NodeResourcesFit
+`),
+			want: wantConvertedFromNewerVersion(),
+		},
+		{
+			name: "success with v1beta3 configuration",
+			buf: []byte(`
+apiVersion: kubescheduler.config.k8s.io/v1beta3
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: my-scheduler
+  plugins:
+    score:
+      enabled:
+      - name: NodeResourcesFit
+        weight: 3
+  pluginConfig:
+  - args:
+      scoringStrategy:
+        resources:
+        - name: cpu
+          weight: 1
+        type: MostAllocated
+    name: NodeResourcesFit
+`),
+			want: wantConvertedFromNewerVersion(),
+		},
+		{
+			name: "fail because of wrong apiVersion",
+			buf: []byte(`
+apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+profiles:
+- pluginConfig:
+  - args:
+      scoringStrategy:
+        resources:
+        - name: cpu
+          weight: 1
+        type: MostAllocated
+    name: NodeResourcesFit
+`),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := DecodeSchedulerCfg(tt.buf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeSchedulerCfg() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				if len(got.Profiles) != len(tt.want.Profiles) {
+					t.Errorf("unmatch length of profiles, want: %v, got: %v", len(tt.want.Profiles), len(got.Profiles))
+					return
+				}
+
+				for k := range got.Profiles {
+					sort.SliceStable(got.Profiles[k].PluginConfig, func(i, j int) bool {
+						return got.Profiles[k].PluginConfig[i].Name < got.Profiles[k].PluginConfig[j].Name
+					})
+					sort.SliceStable(tt.want.Profiles[k].PluginConfig, func(i, j int) bool {
+						return tt.want.Profiles[k].PluginConfig[i].Name < tt.want.Profiles[k].PluginConfig[j].Name
+					})
+				}
+				// remove Raw to assert
+				for i := range got.Profiles {
+					prof := &got.Profiles[i]
+					for j := range prof.PluginConfig {
+						prof.PluginConfig[j].Args.Raw = nil
+					}
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// wantConvertedFromNewerVersion returns the v1beta2 configuration converted from the v1beta3 or v1 configuration in TestDecodeSchedulerCfg.
+// The fields other than profiles and extenders are defaulted.
+func wantConvertedFromNewerVersion() *v1beta2.KubeSchedulerConfiguration {
+	cfg := &v1beta2.KubeSchedulerConfiguration{}
+	scheme.Scheme.Default(cfg)
+	cfg.SetGroupVersionKind(v1beta2.SchemeGroupVersion.WithKind("KubeSchedulerConfiguration"))
+	// The converted configuration always has these fields, which are removed in v1beta3.
+	cfg.HealthzBindAddress = pointer.String("")
+	cfg.MetricsBindAddress = pointer.String("")
+	cfg.Extenders = nil
+	cfg.Profiles = []v1beta2.KubeSchedulerProfile{
+		{
+			SchedulerName: pointer.String("my-scheduler"),
+			// The default plugins are enabled with the weights of the source version, not of v1beta2.
+			Plugins: &v1beta2.Plugins{
+				QueueSort:  disableOthers("PrioritySort"),
+				PreEnqueue: disableOthers(),
+				PreFilter:  disableOthers("NodeAffinity", "NodePorts", "NodeResourcesFit", "VolumeRestrictions", "VolumeBinding", "PodTopologySpread", "InterPodAffinity"),
+				Filter: disableOthers("NodeUnschedulable", "NodeName", "TaintToleration", "NodeAffinity", "NodePorts", "NodeResourcesFit", "VolumeRestrictions",
+					"EBSLimits", "GCEPDLimits", "NodeVolumeLimits", "AzureDiskLimits", "VolumeBinding", "VolumeZone", "PodTopologySpread", "InterPodAffinity"),
+				PostFilter: disableOthers("DefaultPreemption"),
+				PreScore:   disableOthers("TaintToleration", "NodeAffinity", "PodTopologySpread", "InterPodAffinity"),
+				Score: v1beta2.PluginSet{
+					Enabled: []v1beta2.Plugin{
+						// explicitly re-configured plugin comes first.
+						{Name: "NodeResourcesFit", Weight: pointer.Int32(3)},
+						{Name: "TaintToleration", Weight: pointer.Int32(3)},
+						{Name: "NodeAffinity", Weight: pointer.Int32(2)},
+						{Name: "PodTopologySpread", Weight: pointer.Int32(2)},
+						{Name: "InterPodAffinity", Weight: pointer.Int32(2)},
+						{Name: "NodeResourcesBalancedAllocation", Weight: pointer.Int32(1)},
+						{Name: "ImageLocality", Weight: pointer.Int32(1)},
+					},
+					Disabled: []v1beta2.Plugin{{Name: "*"}},
+				},
+				Reserve:  disableOthers("VolumeBinding"),
+				Permit:   disableOthers(),
+				PreBind:  disableOthers("VolumeBinding"),
+				Bind:     disableOthers("DefaultBinder"),
+				PostBind: disableOthers(),
+			},
+			PluginConfig: []v1beta2.PluginConfig{
+				{
+					Name: "NodeResourcesFit",
+					Args: runtime.RawExtension{
+						Object: &v1beta2.NodeResourcesFitArgs{
+							TypeMeta: metav1.TypeMeta{
+								Kind:       "NodeResourcesFitArgs",
+								APIVersion: "kubescheduler.config.k8s.io/v1beta2",
+							},
+							ScoringStrategy: &v1beta2.ScoringStrategy{
+								Resources: []v1beta2.ResourceSpec{
+									{
+										Name:   "cpu",
+										Weight: 1,
+									},
+								},
+								Type: "MostAllocated",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return cfg
+}
+
+// disableOthers returns the PluginSet which enables only the given plugins.
+func disableOthers(names ...string) v1beta2.PluginSet {
+	enabled := make([]v1beta2.Plugin, 0, len(names))
+	for _, n := range names {
+		enabled = append(enabled, v1beta2.Plugin{Name: n})
+	}
+	return v1beta2.PluginSet{Enabled: enabled, Disabled: []v1beta2.Plugin{{Name: "*"}}}
+}
+
+func TestConvertToV1beta2(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		obj     runtime.Object
+		want    func(t *testing.T, got *v1beta2.KubeSchedulerConfiguration)
+		wantErr error
+	}{
+		{
+			name: "v1 profile without plugins gets the default plugins of v1",
+			obj: &v1.KubeSchedulerConfiguration{
+				Profiles: []v1.KubeSchedulerProfile{{SchedulerName: pointer.String("my-scheduler")}},
+			},
+			want: func(t *testing.T, got *v1beta2.KubeSchedulerConfiguration) {
+				t.Helper()
+				weights := map[string]int32{}
+				for _, p := range got.Profiles[0].Plugins.Score.Enabled {
+					weights[p.Name] = *p.Weight
+				}
+				assert.Equal(t, map[string]int32{
+					"TaintToleration":                 3,
+					"NodeAffinity":                    2,
+					"PodTopologySpread":               2,
+					"InterPodAffinity":                2,
+					"NodeResourcesFit":                1,
+					"NodeResourcesBalancedAllocation": 1,
+					"ImageLocality":                   1,
+				}, weights)
+				assert.Empty(t, got.Profiles[0].Plugins.MultiPoint.Enabled)
+			},
+		},
+		{
+			name: "v1beta3 without profiles gets the default plugins of v1beta3",
+			obj:  &v1beta3.KubeSchedulerConfiguration{},
+			want: func(t *testing.T, got *v1beta2.KubeSchedulerConfiguration) {
+				t.Helper()
+				assert.Len(t, got.Profiles, 1)
+				assert.Equal(t, v1beta2.Plugin{Name: "TaintToleration", Weight: pointer.Int32(3)}, got.Profiles[0].Plugins.Score.Enabled[0])
+			},
+		},
+		{
+			name: "multiPoint plugin disabled at an extension point isn't enabled there",
+			obj: &v1.KubeSchedulerConfiguration{
+				Profiles: []v1.KubeSchedulerProfile{
+					{
+						Plugins: &v1.Plugins{
+							Score: v1.PluginSet{Disabled: []v1.Plugin{{Name: "TaintToleration"}}},
+						},
+					},
+				},
+			},
+			want: func(t *testing.T, got *v1beta2.KubeSchedulerConfiguration) {
+				t.Helper()
+				for _, p := range got.Profiles[0].Plugins.Score.Enabled {
+					assert.NotEqual(t, "TaintToleration", p.Name)
+				}
+				assert.Contains(t, got.Profiles[0].Plugins.Filter.Enabled, v1beta2.Plugin{Name: "TaintToleration"})
+			},
+		},
+		{
+			name: "extension point disabling all plugins is left as it is",
+			obj: &v1.KubeSchedulerConfiguration{
+				Profiles: []v1.KubeSchedulerProfile{
+					{
+						Plugins: &v1.Plugins{
+							Score: v1.PluginSet{
+								Enabled:  []v1.Plugin{{Name: "ImageLocality", Weight: pointer.Int32(5)}},
+								Disabled: []v1.Plugin{{Name: "*"}},
+							},
+						},
+					},
+				},
+			},
+			want: func(t *testing.T, got *v1beta2.KubeSchedulerConfiguration) {
+				t.Helper()
+				assert.Equal(t, []v1beta2.Plugin{{Name: "ImageLocality", Weight: pointer.Int32(5)}}, got.Profiles[0].Plugins.Score.Enabled)
+			},
+		},
+		{
+			name: "v1 percentageOfNodesToScore in profiles is rejected",
+			obj: &v1.KubeSchedulerConfiguration{
+				Profiles: []v1.KubeSchedulerProfile{{PercentageOfNodesToScore: pointer.Int32(50)}},
+			},
+			wantErr: ErrUnsupportedField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ConvertToV1beta2(tt.obj)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			tt.want(t, got)
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version string
+		want    schema.GroupVersion
+		wantErr bool
+	}{
+		{
+			name:    "empty version means v1beta2",
+			version: "",
+			want:    v1beta2.SchemeGroupVersion,
+		},
+		{
+			name:    "version only",
+			version: "v1",
+			want:    v1.SchemeGroupVersion,
+		},
+		{
+			name:    "apiVersion",
+			version: "kubescheduler.config.k8s.io/v1beta3",
+			want:    v1beta3.SchemeGroupVersion,
+		},
+		{
+			name:    "unsupported version",
+			version: "v1beta1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseVersion(tt.version)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedVersion)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConvertFromV1beta2(t *testing.T) {
+	t.Parallel()
+
+	for _, gv := range SupportedVersions {
+		gv := gv
+		t.Run(gv.String(), func(t *testing.T) {
+			t.Parallel()
+			cfg, err := DefaultSchedulerConfig()
+			assert.NoError(t, err)
+
+			got, err := ConvertFromV1beta2(cfg, gv)
+			assert.NoError(t, err)
+			assert.Equal(t, gv.WithKind("KubeSchedulerConfiguration"), got.GetObjectKind().GroupVersionKind())
+
+			// converting it back to v1beta2 should give the same profiles.
+			back, err := ConvertToV1beta2(got)
+			assert.NoError(t, err)
+			assert.Equal(t, len(cfg.Profiles[0].PluginConfig), len(back.Profiles[0].PluginConfig))
+			assert.Equal(t, cfg.Profiles[0].SchedulerName, back.Profiles[0].SchedulerName)
+			assert.Equal(t, cfg.Profiles[0].Plugins.Score.Enabled[0].Name, back.Profiles[0].Plugins.Score.Enabled[0].Name)
+			assert.Equal(t, cfg.Parallelism, back.Parallelism)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+var ErrSchedulerNotPaused = errors.New("scheduler is not paused")
+
+// cycleGate holds scheduling cycles while the scheduler is paused.
+// It wraps the NextPod function of the scheduler so that the scheduler holds the Pod popped from the scheduling queue
+// until the gate is resumed or one scheduling cycle is released by step.
+// The gate is checked after the Pod is popped because the scheduler may already be blocked in popping when it's paused.
+// The gate is kept across restarts of the scheduler so that the scheduler stays paused after its configuration is changed.
+type cycleGate struct {
+	mu     sync.Mutex
+	paused bool
+	// resumed is closed when the gate is resumed.
+	resumed chan struct{}
+	// stepCh is used to release one scheduling cycle while the gate is paused.
+	// It can keep one release for the next Pod when no Pod is held at the gate.
+	stepCh chan struct{}
+}
+
+func newCycleGate() *cycleGate {
+	return &cycleGate{
+		resumed: make(chan struct{}),
+		stepCh:  make(chan struct{}, 1),
+	}
+}
+
+// wrapNextPod returns the NextPod function which waits for the gate after popping the next Pod.
+// It returns nil when ctx is done while waiting, which the scheduler treats as the closed scheduling queue.
+// The Pod held at the moment is dropped then, but the scheduler is being shut down and the next one lists all Pods again.
+func (g *cycleGate) wrapNextPod(ctx context.Context, next func() *framework.QueuedPodInfo) func() *framework.QueuedPodInfo {
+	return func() *framework.QueuedPodInfo {
+		podInfo := next()
+		if podInfo == nil {
+			return nil
+		}
+		if !g.wait(ctx) {
+			return nil
+		}
+		return podInfo
+	}
+}
+
+// wait blocks until the gate is resumed or one scheduling cycle is released.
+// It returns false when ctx is done.
+func (g *cycleGate) wait(ctx context.Context) bool {
+	g.mu.Lock()
+	paused, resumed := g.paused, g.resumed
+	g.mu.Unlock()
+	if !paused {
+		return true
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-resumed:
+		return true
+	case <-g.stepCh:
+		return true
+	}
+}
+
+// pause makes the scheduler hold the next Pod popped from the scheduling queue instead of scheduling it.
+// The scheduling cycle running at the moment isn't interrupted.
+func (g *cycleGate) pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.paused {
+		return
+	}
+	g.paused = true
+	g.resumed = make(chan struct{})
+	// Drop the release kept before the gate was resumed last time.
+	select {
+	case <-g.stepCh:
+	default:
+	}
+}
+
+// resume lets the scheduler schedule the Pods freely again, including the Pod held at the moment.
+func (g *cycleGate) resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.paused {
+		return
+	}
+	g.paused = false
+	close(g.resumed)
+}
+
+// step releases one scheduling cycle while the gate is paused.
+// If no Pod is held at the gate, the release is kept for the next Pod popped from the scheduling queue.
+// It blocks while the previous release is still kept.
+func (g *cycleGate) step(ctx context.Context) error {
+	g.mu.Lock()
+	paused, resumed := g.paused, g.resumed
+	g.mu.Unlock()
+	if !paused {
+		return ErrSchedulerNotPaused
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-resumed:
+		// The gate is resumed while waiting, and then the cycle doesn't need to be released.
+		return ErrSchedulerNotPaused
+	case g.stepCh <- struct{}{}:
+		return nil
+	}
+}
+
+func (g *cycleGate) isPaused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
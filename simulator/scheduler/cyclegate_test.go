@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func Test_cycleGate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	podInfo := &framework.QueuedPodInfo{PodInfo: &framework.PodInfo{Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}}}}
+	g := newCycleGate()
+	nextPod := g.wrapNextPod(ctx, func() *framework.QueuedPodInfo { return podInfo })
+
+	// The gate isn't paused at first.
+	assert.Equal(t, podInfo, nextPod())
+	assert.ErrorIs(t, g.step(ctx), ErrSchedulerNotPaused)
+
+	g.pause()
+	assert.True(t, g.isPaused())
+	popped := make(chan *framework.QueuedPodInfo)
+	go func() {
+		for {
+			p := nextPod()
+			if p == nil {
+				close(popped)
+				return
+			}
+			popped <- p
+		}
+	}()
+
+	select {
+	case <-popped:
+		t.Fatal("the Pod is popped while the gate is paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// step releases only one cycle.
+	assert.NoError(t, g.step(ctx))
+	assert.Equal(t, podInfo, <-popped)
+	select {
+	case <-popped:
+		t.Fatal("the Pod is popped more than once by one step")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// resume releases all cycles.
+	g.resume()
+	assert.False(t, g.isPaused())
+	assert.Equal(t, podInfo, <-popped)
+	assert.Equal(t, podInfo, <-popped)
+
+	// NextPod returns nil when ctx is done while waiting for the gate.
+	g.pause()
+	// drain the Pod which might have been popped before pausing.
+	select {
+	case <-popped:
+	case <-time.After(100 * time.Millisecond):
+	}
+	cancel()
+	for p := range popped {
+		assert.Equal(t, podInfo, p)
+	}
+}
+
+func Test_cycleGate_pauseWhilePopping(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	podInfo := &framework.QueuedPodInfo{PodInfo: &framework.PodInfo{Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}}}}
+	queue := make(chan *framework.QueuedPodInfo)
+	popping := make(chan struct{})
+	g := newCycleGate()
+	nextPod := g.wrapNextPod(ctx, func() *framework.QueuedPodInfo {
+		close(popping)
+		return <-queue
+	})
+
+	popped := make(chan *framework.QueuedPodInfo)
+	go func() { popped <- nextPod() }()
+	// The scheduler is blocked in popping because the queue is empty when it's paused.
+	<-popping
+	g.pause()
+	queue <- podInfo
+
+	select {
+	case <-popped:
+		t.Fatal("the Pod arriving after pausing is scheduled while the gate is paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.NoError(t, g.step(ctx))
+	assert.Equal(t, podInfo, <-popped)
+}
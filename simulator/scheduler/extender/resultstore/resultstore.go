@@ -7,6 +7,7 @@ import (
 	"golang.org/x/xerrors"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	extenderv1 "k8s.io/kube-scheduler/extender/v1"
 
@@ -32,7 +33,7 @@ type store struct {
 }
 
 // key is the key of result map on Store.
-// key is created from namespace and podName.
+// key is created from the Pod UID.
 type key string
 
 type result struct {
@@ -54,10 +55,10 @@ func New() Store {
 	return s
 }
 
-// newKey creates key with namespace and podName.
-func newKey(namespace, podName string) key {
-	k := namespace + "/" + podName
-	return key(k)
+// newKey creates key with podUID
+// so that a recreated Pod with the same name doesn't take over the results.
+func newKey(podUID types.UID) key {
+	return key(podUID)
 }
 
 func newData() *result {
@@ -74,7 +75,7 @@ func (s *store) AddStoredResultToPod(pod *v1.Pod) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if _, ok := s.results[k]; !ok {
 		// Store doesn't have any scheduling result of the Pod.
 		return
@@ -102,7 +103,7 @@ func (s *store) AddStoredResultToPod(pod *v1.Pod) {
 }
 
 func (s *store) addFilterResultToPod(pod *v1.Pod) error {
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	results, err := json.Marshal(s.results[k].filter)
 	if err != nil {
 		return xerrors.Errorf("encode Filter results to json: %w", err)
@@ -112,7 +113,7 @@ func (s *store) addFilterResultToPod(pod *v1.Pod) error {
 }
 
 func (s *store) addPrioritizeResultToPod(pod *v1.Pod) error {
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	results, err := json.Marshal(s.results[k].prioritize)
 	if err != nil {
 		return xerrors.Errorf("encode Prioritize results to json: %w", err)
@@ -122,7 +123,7 @@ func (s *store) addPrioritizeResultToPod(pod *v1.Pod) error {
 }
 
 func (s *store) addPreemptResultToPod(pod *v1.Pod) error {
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	results, err := json.Marshal(s.results[k].preempt)
 	if err != nil {
 		return xerrors.Errorf("encode Preempt results to json: %w", err)
@@ -132,7 +133,7 @@ func (s *store) addPreemptResultToPod(pod *v1.Pod) error {
 }
 
 func (s *store) addBindResultToPod(pod *v1.Pod) error {
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	results, err := json.Marshal(s.results[k].bind)
 	if err != nil {
 		return xerrors.Errorf("encode Bind results to json: %w", err)
@@ -146,7 +147,7 @@ func (s *store) AddFilterResult(args extenderv1.ExtenderArgs, result extenderv1.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(args.Pod.Namespace, args.Pod.Name)
+	k := newKey(args.Pod.UID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -158,7 +159,7 @@ func (s *store) AddPrioritizeResult(args extenderv1.ExtenderArgs, result extende
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(args.Pod.Namespace, args.Pod.Name)
+	k := newKey(args.Pod.UID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -170,7 +171,7 @@ func (s *store) AddPreemptResult(args extenderv1.ExtenderPreemptionArgs, result
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(args.Pod.Namespace, args.Pod.Name)
+	k := newKey(args.Pod.UID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -182,7 +183,7 @@ func (s *store) AddBindResult(args extenderv1.ExtenderBindingArgs, result extend
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(args.PodNamespace, args.PodName)
+	k := newKey(args.PodUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -193,7 +194,7 @@ func (s *store) AddBindResult(args extenderv1.ExtenderBindingArgs, result extend
 func (s *store) DeleteData(pod v1.Pod) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.deleteData(newKey(pod.Namespace, pod.Name))
+	s.deleteData(newKey(pod.UID))
 }
 
 // deleteData deletes the result stored with the given key.
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	extenderv1 "k8s.io/kube-scheduler/extender/v1"
 
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/extender/annotation"
@@ -17,6 +18,7 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 	t.Parallel()
 	podName := "pod1"
 	namespace := "default"
+	podUID := types.UID("uid1")
 	tests := []struct {
 		name    string
 		result  map[key]*result
@@ -26,7 +28,7 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 		{
 			name: "success",
 			result: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"node0": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -60,12 +62,14 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			wantPod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 					Annotations: map[string]string{
 						annotation.ExtenderFilterResultAnnotationKey: func() string {
 							r := map[string]extenderv1.ExtenderFilterResult{
@@ -121,19 +125,21 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			wantPod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 		},
 		{
 			name: "success without some data on store",
 			result: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"node0": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -152,12 +158,14 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			wantPod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 					Annotations: map[string]string{
 						annotation.ExtenderFilterResultAnnotationKey: func() string {
 							r := map[string]extenderv1.ExtenderFilterResult{
@@ -200,6 +208,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 	t.Parallel()
 	podName := "pod1"
 	namespace := "default"
+	podUID := types.UID("uid1")
 	tests := []struct {
 		name          string
 		hostname      string
@@ -216,6 +225,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -228,7 +238,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 			},
 			prepareResult: map[key]*result{},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"extenderserver": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -252,6 +262,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -263,7 +274,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 				Error:                      "myerror",
 			},
 			prepareResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"extenderserver": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename0"}}}},
@@ -279,7 +290,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"extenderserver": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -303,6 +314,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -314,7 +326,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 				Error:                      "myerror",
 			},
 			prepareResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"different-extenderserver": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename0"}}}},
@@ -330,7 +342,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"extenderserver": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -361,6 +373,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -372,7 +385,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 				Error:                      "myerror",
 			},
 			prepareResult: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"extenderserver": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename0"}}}},
@@ -388,7 +401,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"extenderserver": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -402,7 +415,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
 					bind:       map[string]extenderv1.ExtenderBindingResult{},
 				},
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"extenderserver": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename0"}}}},
@@ -438,6 +451,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 	t.Parallel()
 	podName := "pod1"
 	namespace := "default"
+	podUID := types.UID("uid1")
 	tests := []struct {
 		name          string
 		hostname      string
@@ -454,6 +468,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -465,7 +480,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 			},
 			prepareResult: map[key]*result{},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{
 						"extenderserver": {
@@ -488,6 +503,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -498,7 +514,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 				},
 			},
 			prepareResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{
 						"extenderserver": {
@@ -513,7 +529,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{
 						"extenderserver": {
@@ -536,6 +552,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -546,7 +563,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 				},
 			},
 			prepareResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{
 						"different-extenderserver": {
@@ -561,7 +578,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{
 						"extenderserver": {
@@ -590,6 +607,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -600,7 +618,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 				},
 			},
 			prepareResult: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{
 						"extenderserver": {
@@ -615,7 +633,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{
 						"extenderserver": {
@@ -628,7 +646,7 @@ func TestStore_AddPrioritizeResult(t *testing.T) {
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{},
 					bind:    map[string]extenderv1.ExtenderBindingResult{},
 				},
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{
 						"extenderserver": {
@@ -663,6 +681,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 	t.Parallel()
 	podName := "pod1"
 	namespace := "default"
+	podUID := types.UID("uid1")
 	tests := []struct {
 		name             string
 		hostname         string
@@ -679,6 +698,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -687,7 +707,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 			},
 			prepareResult: map[key]*result{},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{
@@ -707,6 +727,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -714,7 +735,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 				NodeNameToMetaVictims: map[string]*extenderv1.MetaVictims{"foo": {Pods: []*extenderv1.MetaPod{{UID: "myuid"}}, NumPDBViolations: 1}},
 			},
 			prepareResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{
@@ -726,7 +747,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{
@@ -746,6 +767,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -753,7 +775,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 				NodeNameToMetaVictims: map[string]*extenderv1.MetaVictims{"foo": {Pods: []*extenderv1.MetaPod{{UID: "myuid"}}, NumPDBViolations: 1}},
 			},
 			prepareResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{
@@ -765,7 +787,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{
@@ -788,6 +810,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      podName,
 						Namespace: namespace,
+						UID:       podUID,
 					},
 				},
 			},
@@ -795,7 +818,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 				NodeNameToMetaVictims: map[string]*extenderv1.MetaVictims{"foo": {Pods: []*extenderv1.MetaPod{{UID: "myuid"}}, NumPDBViolations: 1}},
 			},
 			prepareResult: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{
@@ -807,7 +830,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{
@@ -817,7 +840,7 @@ func TestStore_AddPreemptResult(t *testing.T) {
 					},
 					bind: map[string]extenderv1.ExtenderBindingResult{},
 				},
-				"default/pod2": {
+				"uid2": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt: map[string]extenderv1.ExtenderPreemptionResult{
@@ -849,6 +872,7 @@ func TestStore_AddBindResult(t *testing.T) {
 	t.Parallel()
 	podName := "pod1"
 	namespace := "default"
+	podUID := types.UID("uid1")
 	tests := []struct {
 		name          string
 		hostname      string
@@ -863,13 +887,14 @@ func TestStore_AddBindResult(t *testing.T) {
 			args: extenderv1.ExtenderBindingArgs{
 				PodName:      podName,
 				PodNamespace: namespace,
+				PodUID:       podUID,
 			},
 			bindingResult: extenderv1.ExtenderBindingResult{
 				Error: "myerror",
 			},
 			prepareResult: map[key]*result{},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
@@ -887,12 +912,13 @@ func TestStore_AddBindResult(t *testing.T) {
 			args: extenderv1.ExtenderBindingArgs{
 				PodName:      podName,
 				PodNamespace: namespace,
+				PodUID:       podUID,
 			},
 			bindingResult: extenderv1.ExtenderBindingResult{
 				Error: "myerror",
 			},
 			prepareResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
@@ -904,7 +930,7 @@ func TestStore_AddBindResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
@@ -922,12 +948,13 @@ func TestStore_AddBindResult(t *testing.T) {
 			args: extenderv1.ExtenderBindingArgs{
 				PodName:      podName,
 				PodNamespace: namespace,
+				PodUID:       podUID,
 			},
 			bindingResult: extenderv1.ExtenderBindingResult{
 				Error: "myerror",
 			},
 			prepareResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
@@ -939,7 +966,7 @@ func TestStore_AddBindResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
@@ -960,12 +987,13 @@ func TestStore_AddBindResult(t *testing.T) {
 			args: extenderv1.ExtenderBindingArgs{
 				PodName:      podName,
 				PodNamespace: namespace,
+				PodUID:       podUID,
 			},
 			bindingResult: extenderv1.ExtenderBindingResult{
 				Error: "myerror",
 			},
 			prepareResult: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
@@ -977,7 +1005,7 @@ func TestStore_AddBindResult(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
@@ -987,7 +1015,7 @@ func TestStore_AddBindResult(t *testing.T) {
 						},
 					},
 				},
-				"default/pod2": {
+				"uid2": {
 					filter:     map[string]extenderv1.ExtenderFilterResult{},
 					prioritize: map[string]extenderv1.HostPriorityList{},
 					preempt:    map[string]extenderv1.ExtenderPreemptionResult{},
@@ -1019,6 +1047,7 @@ func TestStore_DeleteData(t *testing.T) {
 	t.Parallel()
 	podName := "pod1"
 	namespace := "default"
+	podUID := types.UID("uid1")
 	tests := []struct {
 		name       string
 		target     corev1.Pod
@@ -1031,10 +1060,11 @@ func TestStore_DeleteData(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			result: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"node0": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -1063,7 +1093,7 @@ func TestStore_DeleteData(t *testing.T) {
 						},
 					},
 				},
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"node0": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -1094,7 +1124,7 @@ func TestStore_DeleteData(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"node0": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -1131,10 +1161,11 @@ func TestStore_DeleteData(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			result: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"node0": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -1165,7 +1196,7 @@ func TestStore_DeleteData(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]extenderv1.ExtenderFilterResult{
 						"node0": {
 							Nodes:                      &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "nodename"}}}},
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"golang.org/x/xerrors"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/v1beta2"
+
+	simulatorschedconfig "sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
+)
+
+// IgnoredFields returns the fields in cfg which cannot be applied to the scheduler in the simulator.
+// (e.g., leaderElection, because the scheduler in the simulator always runs without the leader election.)
+// Only the fields which have non-default values are returned, since it's no problem to ignore the default values.
+func IgnoredFields(cfg *v1beta2config.KubeSchedulerConfiguration) ([]string, error) {
+	defaultCfg, err := simulatorschedconfig.DefaultSchedulerConfig()
+	if err != nil {
+		return nil, xerrors.Errorf("get default scheduler config: %w", err)
+	}
+
+	// Profiles and extenders are always applied, and they don't need to be defaulted here.
+	defaulted := cfg.DeepCopy()
+	defaulted.Profiles = nil
+	defaulted.Extenders = nil
+	v1beta2.SetDefaults_KubeSchedulerConfiguration(defaulted)
+
+	ignored := []string{}
+	if !apiequality.Semantic.DeepEqual(defaulted.LeaderElection, defaultCfg.LeaderElection) {
+		ignored = append(ignored, "leaderElection")
+	}
+	if !apiequality.Semantic.DeepEqual(defaulted.ClientConnection, defaultCfg.ClientConnection) {
+		ignored = append(ignored, "clientConnection")
+	}
+	if !equalAddress(defaulted.HealthzBindAddress, defaultCfg.HealthzBindAddress) {
+		ignored = append(ignored, "healthzBindAddress")
+	}
+	if !equalAddress(defaulted.MetricsBindAddress, defaultCfg.MetricsBindAddress) {
+		ignored = append(ignored, "metricsBindAddress")
+	}
+	if !apiequality.Semantic.DeepEqual(defaulted.EnableProfiling, defaultCfg.EnableProfiling) {
+		ignored = append(ignored, "enableProfiling")
+	}
+	if !apiequality.Semantic.DeepEqual(defaulted.EnableContentionProfiling, defaultCfg.EnableContentionProfiling) {
+		ignored = append(ignored, "enableContentionProfiling")
+	}
+	return ignored, nil
+}
+
+// equalAddress compares the bind addresses.
+// The unset address and the empty address are treated as the same
+// because the configuration converted from v1beta3 or later has the empty address.
+func equalAddress(a, b *string) bool {
+	var x, y string
+	if a != nil {
+		x = *a
+	}
+	if b != nil {
+		y = *b
+	}
+	return x == y
+}
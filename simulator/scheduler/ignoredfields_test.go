@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
+	"k8s.io/utils/pointer"
+
+	simulatorschedconfig "sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
+)
+
+func TestIgnoredFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  func() *v1beta2config.KubeSchedulerConfiguration
+		want []string
+	}{
+		{
+			name: "empty configuration has no ignored fields",
+			cfg: func() *v1beta2config.KubeSchedulerConfiguration {
+				return &v1beta2config.KubeSchedulerConfiguration{}
+			},
+			want: []string{},
+		},
+		{
+			name: "default configuration has no ignored fields",
+			cfg: func() *v1beta2config.KubeSchedulerConfiguration {
+				cfg, _ := simulatorschedconfig.DefaultSchedulerConfig()
+				return cfg
+			},
+			want: []string{},
+		},
+		{
+			name: "the fields which affect the scheduling aren't ignored",
+			cfg: func() *v1beta2config.KubeSchedulerConfiguration {
+				return &v1beta2config.KubeSchedulerConfiguration{
+					Parallelism:              pointer.Int32(3),
+					PercentageOfNodesToScore: pointer.Int32(50),
+					PodInitialBackoffSeconds: pointer.Int64(5),
+					PodMaxBackoffSeconds:     pointer.Int64(20),
+				}
+			},
+			want: []string{},
+		},
+		{
+			name: "non-default leaderElection, clientConnection and profiling are ignored",
+			cfg: func() *v1beta2config.KubeSchedulerConfiguration {
+				cfg := &v1beta2config.KubeSchedulerConfiguration{}
+				cfg.EnableProfiling = pointer.Bool(false)
+				cfg.LeaderElection.LeaseDuration = metav1.Duration{Duration: 100}
+				cfg.ClientConnection.QPS = 1000
+				return cfg
+			},
+			want: []string{"leaderElection", "clientConnection", "enableProfiling", "enableContentionProfiling"},
+		},
+		{
+			name: "the empty bind address is treated as unset",
+			cfg: func() *v1beta2config.KubeSchedulerConfiguration {
+				return &v1beta2config.KubeSchedulerConfiguration{
+					HealthzBindAddress: pointer.String(""),
+					MetricsBindAddress: pointer.String("0.0.0.0:10251"),
+				}
+			},
+			want: []string{"metricsBindAddress"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := IgnoredFields(tt.cfg())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
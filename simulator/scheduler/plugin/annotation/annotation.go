@@ -13,6 +13,8 @@ const (
 	PreScoreResultAnnotationKey = "scheduler-simulator/prescore-result"
 	// ScoreResultAnnotationKey has the scoring result.
 	ScoreResultAnnotationKey = "scheduler-simulator/score-result"
+	// NormalizedScoreResultAnnotationKey has the normalized score before the score plugin weight is applied.
+	NormalizedScoreResultAnnotationKey = "scheduler-simulator/normalizedscore-result"
 	// FinalScoreResultAnnotationKey has the final score(= normalized and applied score plugin weight).
 	FinalScoreResultAnnotationKey = "scheduler-simulator/finalscore-result"
 	// ReserveResultAnnotationKey has the reserve result.
@@ -25,6 +27,8 @@ const (
 	PreBindResultAnnotationKey = "scheduler-simulator/prebind-result"
 	// BindResultAnnotationKey has the prebind result.
 	BindResultAnnotationKey = "scheduler-simulator/bind-result"
+	// PostBindResultAnnotationKey has the postbind result.
+	PostBindResultAnnotationKey = "scheduler-simulator/postbind-result"
 	// SelectedNodeAnnotationKey has the selected node name. It's filled when a Pod go through the Reserve phase.
 	SelectedNodeAnnotationKey = "scheduler-simulator/selected-node"
 )
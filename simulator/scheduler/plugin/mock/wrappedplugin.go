@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/core/v1"
+	types "k8s.io/apimachinery/pkg/types"
 	framework "k8s.io/kubernetes/pkg/scheduler/framework"
 )
 
@@ -38,135 +39,147 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 }
 
 // AddBindResult mocks base method.
-func (m *MockStore) AddBindResult(arg0, arg1, arg2, arg3 string) {
+func (m *MockStore) AddBindResult(arg0 types.UID, arg1, arg2 string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddBindResult", arg0, arg1, arg2, arg3)
+	m.ctrl.Call(m, "AddBindResult", arg0, arg1, arg2)
 }
 
 // AddBindResult indicates an expected call of AddBindResult.
-func (mr *MockStoreMockRecorder) AddBindResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddBindResult(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBindResult", reflect.TypeOf((*MockStore)(nil).AddBindResult), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBindResult", reflect.TypeOf((*MockStore)(nil).AddBindResult), arg0, arg1, arg2)
 }
 
 // AddFilterResult mocks base method.
-func (m *MockStore) AddFilterResult(arg0, arg1, arg2, arg3, arg4 string) {
+func (m *MockStore) AddFilterResult(arg0 types.UID, arg1, arg2, arg3 string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddFilterResult", arg0, arg1, arg2, arg3, arg4)
+	m.ctrl.Call(m, "AddFilterResult", arg0, arg1, arg2, arg3)
 }
 
 // AddFilterResult indicates an expected call of AddFilterResult.
-func (mr *MockStoreMockRecorder) AddFilterResult(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddFilterResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilterResult", reflect.TypeOf((*MockStore)(nil).AddFilterResult), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilterResult", reflect.TypeOf((*MockStore)(nil).AddFilterResult), arg0, arg1, arg2, arg3)
 }
 
 // AddNormalizedScoreResult mocks base method.
-func (m *MockStore) AddNormalizedScoreResult(arg0, arg1, arg2, arg3 string, arg4 int64) {
+func (m *MockStore) AddNormalizedScoreResult(arg0 types.UID, arg1, arg2 string, arg3 int64) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddNormalizedScoreResult", arg0, arg1, arg2, arg3, arg4)
+	m.ctrl.Call(m, "AddNormalizedScoreResult", arg0, arg1, arg2, arg3)
 }
 
 // AddNormalizedScoreResult indicates an expected call of AddNormalizedScoreResult.
-func (mr *MockStoreMockRecorder) AddNormalizedScoreResult(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddNormalizedScoreResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNormalizedScoreResult", reflect.TypeOf((*MockStore)(nil).AddNormalizedScoreResult), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNormalizedScoreResult", reflect.TypeOf((*MockStore)(nil).AddNormalizedScoreResult), arg0, arg1, arg2, arg3)
 }
 
 // AddPermitResult mocks base method.
-func (m *MockStore) AddPermitResult(arg0, arg1, arg2, arg3 string, arg4 time.Duration) {
+func (m *MockStore) AddPermitResult(arg0 types.UID, arg1, arg2 string, arg3 time.Duration) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddPermitResult", arg0, arg1, arg2, arg3, arg4)
+	m.ctrl.Call(m, "AddPermitResult", arg0, arg1, arg2, arg3)
 }
 
 // AddPermitResult indicates an expected call of AddPermitResult.
-func (mr *MockStoreMockRecorder) AddPermitResult(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddPermitResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPermitResult", reflect.TypeOf((*MockStore)(nil).AddPermitResult), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPermitResult", reflect.TypeOf((*MockStore)(nil).AddPermitResult), arg0, arg1, arg2, arg3)
+}
+
+// AddPostBindResult mocks base method.
+func (m *MockStore) AddPostBindResult(arg0 types.UID, arg1, arg2 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddPostBindResult", arg0, arg1, arg2)
+}
+
+// AddPostBindResult indicates an expected call of AddPostBindResult.
+func (mr *MockStoreMockRecorder) AddPostBindResult(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPostBindResult", reflect.TypeOf((*MockStore)(nil).AddPostBindResult), arg0, arg1, arg2)
 }
 
 // AddPostFilterResult mocks base method.
-func (m *MockStore) AddPostFilterResult(arg0, arg1, arg2, arg3 string, arg4 []string) {
+func (m *MockStore) AddPostFilterResult(arg0 types.UID, arg1, arg2 string, arg3 []string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddPostFilterResult", arg0, arg1, arg2, arg3, arg4)
+	m.ctrl.Call(m, "AddPostFilterResult", arg0, arg1, arg2, arg3)
 }
 
 // AddPostFilterResult indicates an expected call of AddPostFilterResult.
-func (mr *MockStoreMockRecorder) AddPostFilterResult(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddPostFilterResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPostFilterResult", reflect.TypeOf((*MockStore)(nil).AddPostFilterResult), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPostFilterResult", reflect.TypeOf((*MockStore)(nil).AddPostFilterResult), arg0, arg1, arg2, arg3)
 }
 
 // AddPreBindResult mocks base method.
-func (m *MockStore) AddPreBindResult(arg0, arg1, arg2, arg3 string) {
+func (m *MockStore) AddPreBindResult(arg0 types.UID, arg1, arg2 string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddPreBindResult", arg0, arg1, arg2, arg3)
+	m.ctrl.Call(m, "AddPreBindResult", arg0, arg1, arg2)
 }
 
 // AddPreBindResult indicates an expected call of AddPreBindResult.
-func (mr *MockStoreMockRecorder) AddPreBindResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddPreBindResult(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPreBindResult", reflect.TypeOf((*MockStore)(nil).AddPreBindResult), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPreBindResult", reflect.TypeOf((*MockStore)(nil).AddPreBindResult), arg0, arg1, arg2)
 }
 
 // AddPreFilterResult mocks base method.
-func (m *MockStore) AddPreFilterResult(arg0, arg1, arg2, arg3 string, arg4 *framework.PreFilterResult) {
+func (m *MockStore) AddPreFilterResult(arg0 types.UID, arg1, arg2 string, arg3 *framework.PreFilterResult) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddPreFilterResult", arg0, arg1, arg2, arg3, arg4)
+	m.ctrl.Call(m, "AddPreFilterResult", arg0, arg1, arg2, arg3)
 }
 
 // AddPreFilterResult indicates an expected call of AddPreFilterResult.
-func (mr *MockStoreMockRecorder) AddPreFilterResult(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddPreFilterResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPreFilterResult", reflect.TypeOf((*MockStore)(nil).AddPreFilterResult), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPreFilterResult", reflect.TypeOf((*MockStore)(nil).AddPreFilterResult), arg0, arg1, arg2, arg3)
 }
 
 // AddPreScoreResult mocks base method.
-func (m *MockStore) AddPreScoreResult(arg0, arg1, arg2, arg3 string) {
+func (m *MockStore) AddPreScoreResult(arg0 types.UID, arg1, arg2 string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddPreScoreResult", arg0, arg1, arg2, arg3)
+	m.ctrl.Call(m, "AddPreScoreResult", arg0, arg1, arg2)
 }
 
 // AddPreScoreResult indicates an expected call of AddPreScoreResult.
-func (mr *MockStoreMockRecorder) AddPreScoreResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddPreScoreResult(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPreScoreResult", reflect.TypeOf((*MockStore)(nil).AddPreScoreResult), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPreScoreResult", reflect.TypeOf((*MockStore)(nil).AddPreScoreResult), arg0, arg1, arg2)
 }
 
 // AddReserveResult mocks base method.
-func (m *MockStore) AddReserveResult(arg0, arg1, arg2, arg3 string) {
+func (m *MockStore) AddReserveResult(arg0 types.UID, arg1, arg2 string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddReserveResult", arg0, arg1, arg2, arg3)
+	m.ctrl.Call(m, "AddReserveResult", arg0, arg1, arg2)
 }
 
 // AddReserveResult indicates an expected call of AddReserveResult.
-func (mr *MockStoreMockRecorder) AddReserveResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddReserveResult(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReserveResult", reflect.TypeOf((*MockStore)(nil).AddReserveResult), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReserveResult", reflect.TypeOf((*MockStore)(nil).AddReserveResult), arg0, arg1, arg2)
 }
 
 // AddScoreResult mocks base method.
-func (m *MockStore) AddScoreResult(arg0, arg1, arg2, arg3 string, arg4 int64) {
+func (m *MockStore) AddScoreResult(arg0 types.UID, arg1, arg2 string, arg3 int64) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddScoreResult", arg0, arg1, arg2, arg3, arg4)
+	m.ctrl.Call(m, "AddScoreResult", arg0, arg1, arg2, arg3)
 }
 
 // AddScoreResult indicates an expected call of AddScoreResult.
-func (mr *MockStoreMockRecorder) AddScoreResult(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddScoreResult(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScoreResult", reflect.TypeOf((*MockStore)(nil).AddScoreResult), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScoreResult", reflect.TypeOf((*MockStore)(nil).AddScoreResult), arg0, arg1, arg2, arg3)
 }
 
 // AddSelectedNode mocks base method.
-func (m *MockStore) AddSelectedNode(arg0, arg1, arg2 string) {
+func (m *MockStore) AddSelectedNode(arg0 types.UID, arg1 string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddSelectedNode", arg0, arg1, arg2)
+	m.ctrl.Call(m, "AddSelectedNode", arg0, arg1)
 }
 
 // AddSelectedNode indicates an expected call of AddSelectedNode.
-func (mr *MockStoreMockRecorder) AddSelectedNode(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddSelectedNode(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSelectedNode", reflect.TypeOf((*MockStore)(nil).AddSelectedNode), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSelectedNode", reflect.TypeOf((*MockStore)(nil).AddSelectedNode), arg0, arg1)
 }
 
 // MockPreFilterPluginExtender is a mock of PreFilterPluginExtender interface.
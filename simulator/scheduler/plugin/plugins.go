@@ -27,7 +27,7 @@ func NewRegistry(sharedStore storereflector.Reflector, cfg *schedulerConfig.Kube
 	// Add the resultStore to the sharedStore to store the results and share it.
 	sharedStore.AddResultStore(store, ResultStoreKey)
 
-	ret, err := newPluginFactories(store)
+	ret, err := newPluginFactories(store, sharedStore)
 	if err != nil {
 		return nil, xerrors.Errorf("New pluginFactories: %w", err)
 	}
@@ -35,7 +35,7 @@ func NewRegistry(sharedStore storereflector.Reflector, cfg *schedulerConfig.Kube
 	return ret, nil
 }
 
-func newPluginFactories(store *schedulingresultstore.Store) (map[string]schedulerRuntime.PluginFactory, error) {
+func newPluginFactories(store *schedulingresultstore.Store, sharedStore storereflector.Reflector) (map[string]schedulerRuntime.PluginFactory, error) {
 	registeredpls, err := registeredPlugins()
 	if err != nil {
 		return nil, xerrors.Errorf("get default score/filter plugins: %w", err)
@@ -79,6 +79,7 @@ func newPluginFactories(store *schedulingresultstore.Store) (map[string]schedule
 		}
 		ret[pluginName(pl.Name)] = factory
 	}
+	ret[resultFlusherName] = newResultFlusherFactory(sharedStore)
 
 	return ret, nil
 }
@@ -172,6 +173,7 @@ func NewPluginConfig(pc []v1beta2.PluginConfig) ([]v1beta2.PluginConfig, error)
 
 // ConvertForSimulator convert v1beta2.Plugins for simulator.
 // It ignores non-default plugin.
+// It also adds the plugin which reflects the PostBind results on the Pod at the end of PostBind.
 //
 //nolint:cyclop
 func ConvertForSimulator(pls *v1beta2.Plugins) (*v1beta2.Plugins, error) {
@@ -207,6 +209,7 @@ func ConvertForSimulator(pls *v1beta2.Plugins) (*v1beta2.Plugins, error) {
 	if err := applyPluingSet(&newpls.PostBind, pls.PostBind, config.InTreePostBindPluginSet); err != nil {
 		return nil, xerrors.Errorf("merge PostBind plugins: %w", err)
 	}
+	newpls.PostBind.Enabled = append(newpls.PostBind.Enabled, v1beta2.Plugin{Name: resultFlusherName})
 
 	return newpls, nil
 }
@@ -156,7 +156,9 @@ func TestConvertForSimulator(t *testing.T) {
 					},
 				},
 				PostBind: v1beta2.PluginSet{
-					Enabled: []v1beta2.Plugin{},
+					Enabled: []v1beta2.Plugin{
+						{Name: "SimulatorResultFlusher"},
+					},
 					Disabled: []v1beta2.Plugin{
 						{
 							Name: "*",
@@ -318,7 +320,9 @@ func TestConvertForSimulator(t *testing.T) {
 					},
 				},
 				PostBind: v1beta2.PluginSet{
-					Enabled: []v1beta2.Plugin{},
+					Enabled: []v1beta2.Plugin{
+						{Name: "SimulatorResultFlusher"},
+					},
 					Disabled: []v1beta2.Plugin{
 						{
 							Name: "*",
@@ -490,7 +494,9 @@ func TestConvertForSimulator(t *testing.T) {
 					},
 				},
 				PostBind: v1beta2.PluginSet{
-					Enabled: []v1beta2.Plugin{},
+					Enabled: []v1beta2.Plugin{
+						{Name: "SimulatorResultFlusher"},
+					},
 					Disabled: []v1beta2.Plugin{
 						{
 							Name: "*",
@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/storereflector"
+)
+
+// resultFlusherName is the name of resultFlusher.
+const resultFlusherName = "SimulatorResultFlusher"
+
+// resultFlusher is the PostBind plugin that reflects the results stored for the Pod on the Pod.
+// PostBind runs after the Pod is bound, so the PostBind results are stored after the other results are reflected on the Pod,
+// and the Pod might not be updated anymore.
+// ConvertForSimulator adds it at the end of PostBind so that all PostBind results are reflected at once.
+type resultFlusher struct {
+	sharedStore storereflector.Reflector
+	handle      framework.Handle
+}
+
+var _ framework.PostBindPlugin = &resultFlusher{}
+
+func newResultFlusherFactory(sharedStore storereflector.Reflector) func(runtime.Object, framework.Handle) (framework.Plugin, error) {
+	return func(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+		return &resultFlusher{sharedStore: sharedStore, handle: h}, nil
+	}
+}
+
+func (f *resultFlusher) Name() string { return resultFlusherName }
+
+// PostBind reflects the stored results on the Pod. It does nothing when no results are stored.
+func (f *resultFlusher) PostBind(ctx context.Context, _ *framework.CycleState, pod *v1.Pod, _ string) {
+	f.sharedStore.ReflectResultsToPod(ctx, f.handle.ClientSet(), pod)
+}
@@ -9,6 +9,7 @@ import (
 	"golang.org/x/xerrors"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
@@ -48,6 +49,11 @@ type result struct {
 	// node name → plugin name → score(string)
 	score map[string]map[string]string
 
+	// node name → plugin name → normalized score(string)
+	// This score is normalized by NormalizeScore, but the weight isn't applied yet.
+	// For plugins which don't have NormalizeScore, it's the same as the score.
+	normalizedScore map[string]map[string]string
+
 	// node name → plugin name → finalScore(string)
 	// This score is normalized and applied weight for each plugins.
 	finalScore map[string]map[string]string
@@ -83,6 +89,10 @@ type result struct {
 
 	// plugin name → bind result(string)
 	bind map[string]string
+
+	// plugin name → postbind result(string)
+	// PostBind doesn't return any status, so SuccessMessage is shown when the plugin is called.
+	postBind map[string]string
 }
 
 func New(scorePluginWeight map[string]int32) *Store {
@@ -96,18 +106,18 @@ func New(scorePluginWeight map[string]int32) *Store {
 }
 
 // key is the key of result map on Store.
-// key is created from namespace and podName.
+// key is created from the Pod UID so that a recreated Pod with the same name doesn't take over the results.
 type key string
 
-// newKey creates key with namespace and podName.
-func newKey(namespace, podName string) key {
-	k := namespace + "/" + podName
-	return key(k)
+// newKey creates key with podUID.
+func newKey(podUID types.UID) key {
+	return key(podUID)
 }
 
 func newData() *result {
 	d := &result{
 		score:           map[string]map[string]string{},
+		normalizedScore: map[string]map[string]string{},
 		finalScore:      map[string]map[string]string{},
 		preFilterResult: map[string][]string{},
 		preFilterStatus: map[string]string{},
@@ -119,6 +129,7 @@ func newData() *result {
 		reserve:         map[string]string{},
 		bind:            map[string]string{},
 		prebind:         map[string]string{},
+		postBind:        map[string]string{},
 	}
 	return d
 }
@@ -130,7 +141,7 @@ func (s *Store) AddStoredResultToPod(pod *v1.Pod) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if _, ok := s.results[k]; !ok {
 		// Store doesn't have scheduling result of pod.
 		return
@@ -161,6 +172,11 @@ func (s *Store) AddStoredResultToPod(pod *v1.Pod) {
 		return
 	}
 
+	if err := s.addNormalizedScoreResultToPod(pod); err != nil {
+		klog.Errorf("failed to add normalized score result to pod: %+v", err)
+		return
+	}
+
 	if err := s.addFinalScoreResultToPod(pod); err != nil {
 		klog.Errorf("failed to add final score result to pod: %+v", err)
 		return
@@ -186,11 +202,16 @@ func (s *Store) AddStoredResultToPod(pod *v1.Pod) {
 		return
 	}
 
+	if err := s.addPostBindResultToPod(pod); err != nil {
+		klog.Errorf("failed to add postbind result to pod: %+v", err)
+		return
+	}
+
 	s.addSelectedNodeToPod(pod)
 }
 
 func (s *Store) addPreFilterResultToPod(pod *v1.Pod) error {
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 
 	_, ok := pod.GetAnnotations()[annotation.PreFilterResultAnnotationKey]
 	if !ok {
@@ -229,7 +250,7 @@ func (s *Store) addPreScoreResultToPod(pod *v1.Pod) error {
 		return nil
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if s.results[k].preScore == nil {
 		s.results[k].preScore = map[string]string{}
 	}
@@ -248,7 +269,7 @@ func (s *Store) addBindResultToPod(pod *v1.Pod) error {
 		return nil
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if s.results[k].bind == nil {
 		s.results[k].bind = map[string]string{}
 	}
@@ -267,7 +288,7 @@ func (s *Store) addPreBindResultToPod(pod *v1.Pod) error {
 		return nil
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if s.results[k].prebind == nil {
 		s.results[k].prebind = map[string]string{}
 	}
@@ -280,13 +301,40 @@ func (s *Store) addPreBindResultToPod(pod *v1.Pod) error {
 	return nil
 }
 
+// addPostBindResultToPod adds the postbind result to the pod.
+// Unlike the other results, it merges the result into the annotation even if the annotation already exists
+// because PostBind plugins run after the Pod is bound, and the result may be stored after the other results are reflected on the Pod.
+func (s *Store) addPostBindResultToPod(pod *v1.Pod) error {
+	k := newKey(pod.UID)
+	postBind := map[string]string{}
+	if a, ok := pod.GetAnnotations()[annotation.PostBindResultAnnotationKey]; ok {
+		if len(s.results[k].postBind) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal([]byte(a), &postBind); err != nil {
+			return xerrors.Errorf("decode json of the recorded postBind status: %w", err)
+		}
+	}
+	for pluginName, status := range s.results[k].postBind {
+		postBind[pluginName] = status
+	}
+
+	status, err := json.Marshal(postBind)
+	if err != nil {
+		return xerrors.Errorf("encode json to record postBind status: %w", err)
+	}
+
+	metav1.SetMetaDataAnnotation(&pod.ObjectMeta, annotation.PostBindResultAnnotationKey, string(status))
+	return nil
+}
+
 func (s *Store) addSelectedNodeToPod(pod *v1.Pod) {
 	_, ok := pod.GetAnnotations()[annotation.SelectedNodeAnnotationKey]
 	if ok {
 		return
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	metav1.SetMetaDataAnnotation(&pod.ObjectMeta, annotation.SelectedNodeAnnotationKey, s.results[k].selectedNode)
 }
 
@@ -296,7 +344,7 @@ func (s *Store) addReserveResultToPod(pod *v1.Pod) error {
 		return nil
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if s.results[k].reserve == nil {
 		s.results[k].reserve = map[string]string{}
 	}
@@ -309,7 +357,7 @@ func (s *Store) addReserveResultToPod(pod *v1.Pod) error {
 }
 
 func (s *Store) addPermitResultToPod(pod *v1.Pod) error {
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 
 	_, ok := pod.GetAnnotations()[annotation.PermitTimeoutResultAnnotationKey]
 	if !ok {
@@ -345,7 +393,7 @@ func (s *Store) addFilterResultToPod(pod *v1.Pod) error {
 		return nil
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if s.results[k].filter == nil {
 		s.results[k].filter = map[string]map[string]string{}
 	}
@@ -364,7 +412,7 @@ func (s *Store) addPostFilterResultToPod(pod *v1.Pod) error {
 		return nil
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if s.results[k].postFilter == nil {
 		s.results[k].postFilter = map[string]map[string]string{}
 	}
@@ -382,7 +430,7 @@ func (s *Store) addScoreResultToPod(pod *v1.Pod) error {
 		return nil
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if s.results[k].score == nil {
 		s.results[k].score = map[string]map[string]string{}
 	}
@@ -395,13 +443,32 @@ func (s *Store) addScoreResultToPod(pod *v1.Pod) error {
 	return nil
 }
 
+func (s *Store) addNormalizedScoreResultToPod(pod *v1.Pod) error {
+	_, ok := pod.GetAnnotations()[annotation.NormalizedScoreResultAnnotationKey]
+	if ok {
+		return nil
+	}
+
+	k := newKey(pod.UID)
+	if s.results[k].normalizedScore == nil {
+		s.results[k].normalizedScore = map[string]map[string]string{}
+	}
+	scores, err := json.Marshal(s.results[k].normalizedScore)
+	if err != nil {
+		return xerrors.Errorf("encode json to record normalized scores: %w", err)
+	}
+
+	metav1.SetMetaDataAnnotation(&pod.ObjectMeta, annotation.NormalizedScoreResultAnnotationKey, string(scores))
+	return nil
+}
+
 func (s *Store) addFinalScoreResultToPod(pod *v1.Pod) error {
 	_, ok := pod.GetAnnotations()[annotation.FinalScoreResultAnnotationKey]
 	if ok {
 		return nil
 	}
 
-	k := newKey(pod.Namespace, pod.Name)
+	k := newKey(pod.UID)
 	if s.results[k].finalScore == nil {
 		s.results[k].finalScore = map[string]map[string]string{}
 	}
@@ -415,11 +482,11 @@ func (s *Store) addFinalScoreResultToPod(pod *v1.Pod) error {
 }
 
 // AddFilterResult adds filtering result to pod annotation.
-func (s *Store) AddFilterResult(namespace, podName, nodeName, pluginName, reason string) {
+func (s *Store) AddFilterResult(podUID types.UID, nodeName, pluginName, reason string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -434,11 +501,11 @@ func (s *Store) AddFilterResult(namespace, podName, nodeName, pluginName, reason
 // AddPostFilterResult adds post filter result to the pod annotaiton.
 //   - nominatedNodeName represents the node name which nominated by the postFilter plugin.
 //     Otherwise, the string "" would be stored in this arg.
-func (s *Store) AddPostFilterResult(namespace, podName, nominatedNodeName, pluginName string, nodeNames []string) {
+func (s *Store) AddPostFilterResult(podUID types.UID, nominatedNodeName, pluginName string, nodeNames []string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -453,11 +520,11 @@ func (s *Store) AddPostFilterResult(namespace, podName, nominatedNodeName, plugi
 }
 
 // AddScoreResult adds scoring result to pod annotation.
-func (s *Store) AddScoreResult(namespace, podName, nodeName, pluginName string, score int64) {
+func (s *Store) AddScoreResult(podUID types.UID, nodeName, pluginName string, score int64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -469,23 +536,31 @@ func (s *Store) AddScoreResult(namespace, podName, nodeName, pluginName string,
 	s.results[k].score[nodeName][pluginName] = strconv.FormatInt(score, 10)
 
 	// we already locked on first of this func
-	s.addNormalizedScoreResultWithoutLock(namespace, podName, nodeName, pluginName, score)
+	s.addNormalizedScoreResultWithoutLock(podUID, nodeName, pluginName, score)
 }
 
-// AddNormalizedScoreResult adds final score result to pod annotation.
-func (s *Store) AddNormalizedScoreResult(namespace, podName, nodeName, pluginName string, normalizedscore int64) {
+// AddNormalizedScoreResult adds normalized score result and final score result to pod annotation.
+func (s *Store) AddNormalizedScoreResult(podUID types.UID, nodeName, pluginName string, normalizedscore int64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.addNormalizedScoreResultWithoutLock(namespace, podName, nodeName, pluginName, normalizedscore)
+	s.addNormalizedScoreResultWithoutLock(podUID, nodeName, pluginName, normalizedscore)
 }
 
-func (s *Store) addNormalizedScoreResultWithoutLock(namespace, podName, nodeName, pluginName string, normalizedscore int64) {
-	k := newKey(namespace, podName)
+func (s *Store) addNormalizedScoreResultWithoutLock(podUID types.UID, nodeName, pluginName string, normalizedscore int64) {
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
 
+	if s.results[k].normalizedScore == nil {
+		s.results[k].normalizedScore = map[string]map[string]string{}
+	}
+	if _, ok := s.results[k].normalizedScore[nodeName]; !ok {
+		s.results[k].normalizedScore[nodeName] = map[string]string{}
+	}
+	s.results[k].normalizedScore[nodeName][pluginName] = strconv.FormatInt(normalizedscore, 10)
+
 	if _, ok := s.results[k].finalScore[nodeName]; !ok {
 		s.results[k].finalScore[nodeName] = map[string]string{}
 	}
@@ -505,7 +580,7 @@ func (s *Store) applyWeightOnScore(pluginName string, score int64) int64 {
 func (s *Store) DeleteData(pod v1.Pod) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.deleteData(newKey(pod.Namespace, pod.Name))
+	s.deleteData(newKey(pod.UID))
 }
 
 // deleteData deletes the result stored with the given key.
@@ -514,11 +589,11 @@ func (s *Store) deleteData(k key) {
 	delete(s.results, k)
 }
 
-func (s *Store) AddPreFilterResult(namespace, podName, pluginName, reason string, preFilterResult *framework.PreFilterResult) {
+func (s *Store) AddPreFilterResult(podUID types.UID, pluginName, reason string, preFilterResult *framework.PreFilterResult) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -529,11 +604,11 @@ func (s *Store) AddPreFilterResult(namespace, podName, pluginName, reason string
 	}
 }
 
-func (s *Store) AddPreScoreResult(namespace, podName, pluginName, reason string) {
+func (s *Store) AddPreScoreResult(podUID types.UID, pluginName, reason string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -541,11 +616,11 @@ func (s *Store) AddPreScoreResult(namespace, podName, pluginName, reason string)
 	s.results[k].preScore[pluginName] = reason
 }
 
-func (s *Store) AddPermitResult(namespace, podName, pluginName, status string, timeout time.Duration) {
+func (s *Store) AddPermitResult(podUID types.UID, pluginName, status string, timeout time.Duration) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -554,11 +629,11 @@ func (s *Store) AddPermitResult(namespace, podName, pluginName, status string, t
 	s.results[k].permitTimeout[pluginName] = timeout.String()
 }
 
-func (s *Store) AddSelectedNode(namespace, podName, nodeName string) {
+func (s *Store) AddSelectedNode(podUID types.UID, nodeName string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -566,11 +641,11 @@ func (s *Store) AddSelectedNode(namespace, podName, nodeName string) {
 	s.results[k].selectedNode = nodeName
 }
 
-func (s *Store) AddReserveResult(namespace, podName, pluginName, status string) {
+func (s *Store) AddReserveResult(podUID types.UID, pluginName, status string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -578,11 +653,11 @@ func (s *Store) AddReserveResult(namespace, podName, pluginName, status string)
 	s.results[k].reserve[pluginName] = status
 }
 
-func (s *Store) AddBindResult(namespace, podName, pluginName, status string) {
+func (s *Store) AddBindResult(podUID types.UID, pluginName, status string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
@@ -590,14 +665,27 @@ func (s *Store) AddBindResult(namespace, podName, pluginName, status string) {
 	s.results[k].bind[pluginName] = status
 }
 
-func (s *Store) AddPreBindResult(namespace, podName, pluginName, status string) {
+func (s *Store) AddPreBindResult(podUID types.UID, pluginName, status string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	k := newKey(namespace, podName)
+	k := newKey(podUID)
 	if _, ok := s.results[k]; !ok {
 		s.results[k] = newData()
 	}
 
 	s.results[k].prebind[pluginName] = status
 }
+
+// AddPostBindResult adds postbind result to pod annotation.
+func (s *Store) AddPostBindResult(podUID types.UID, pluginName, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := newKey(podUID)
+	if _, ok := s.results[k]; !ok {
+		s.results[k] = newData()
+	}
+
+	s.results[k].postBind[pluginName] = status
+}
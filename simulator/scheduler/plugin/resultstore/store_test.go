@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
@@ -18,8 +19,7 @@ import (
 func TestStore_AddFilterResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace  string
-		podName    string
+		podUID     types.UID
 		nodeName   string
 		pluginName string
 		reason     string
@@ -34,14 +34,13 @@ func TestStore_AddFilterResult(t *testing.T) {
 			name:         "success with empty result",
 			resultbefore: map[key]*result{},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin1",
 				reason:     PassedFilterMessage,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					selectedNode:    "",
 					preScore:        map[string]string{},
 					preFilterStatus: map[string]string{},
@@ -51,6 +50,8 @@ func TestStore_AddFilterResult(t *testing.T) {
 					reserve:         map[string]string{},
 					prebind:         map[string]string{},
 					bind:            map[string]string{},
+					postBind:        map[string]string{},
+					normalizedScore: map[string]map[string]string{},
 					score:           map[string]map[string]string{},
 					finalScore:      map[string]map[string]string{},
 					filter: map[string]map[string]string{
@@ -65,7 +66,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 		{
 			name: "success with non-empty filter map for the node",
 			resultbefore: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter: map[string]map[string]string{
@@ -77,14 +78,13 @@ func TestStore_AddFilterResult(t *testing.T) {
 				},
 			},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin2",
 				reason:     PassedFilterMessage,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter: map[string]map[string]string{
@@ -100,7 +100,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 		{
 			name: "success when no map for the node",
 			resultbefore: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter: map[string]map[string]string{
@@ -112,14 +112,13 @@ func TestStore_AddFilterResult(t *testing.T) {
 				},
 			},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin1",
 				reason:     PassedFilterMessage,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter: map[string]map[string]string{
@@ -143,7 +142,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 				mu:      new(sync.Mutex),
 				results: tt.resultbefore,
 			}
-			s.AddFilterResult(tt.args.namespace, tt.args.podName, tt.args.nodeName, tt.args.pluginName, tt.args.reason)
+			s.AddFilterResult(tt.args.podUID, tt.args.nodeName, tt.args.pluginName, tt.args.reason)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -152,8 +151,7 @@ func TestStore_AddFilterResult(t *testing.T) {
 func TestStore_AddPostFilterResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace         string
-		podName           string
+		podUID            types.UID
 		nominatedNodeName string
 		pluginName        string
 		nodeNames         []string
@@ -168,14 +166,13 @@ func TestStore_AddPostFilterResult(t *testing.T) {
 			name:         "success with empty result",
 			resultbefore: map[key]*result{},
 			args: args{
-				namespace:         "default",
-				podName:           "pod1",
+				podUID:            "uid1",
 				nominatedNodeName: "node1",
 				pluginName:        "plugin1",
 				nodeNames:         []string{"node1", "node2"},
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					selectedNode:    "",
 					preScore:        map[string]string{},
 					preFilterStatus: map[string]string{},
@@ -185,6 +182,8 @@ func TestStore_AddPostFilterResult(t *testing.T) {
 					reserve:         map[string]string{},
 					prebind:         map[string]string{},
 					bind:            map[string]string{},
+					postBind:        map[string]string{},
+					normalizedScore: map[string]map[string]string{},
 					score:           map[string]map[string]string{},
 					finalScore:      map[string]map[string]string{},
 					filter:          map[string]map[string]string{},
@@ -200,7 +199,7 @@ func TestStore_AddPostFilterResult(t *testing.T) {
 		{
 			name: "success with non-empty postFilter map for the node",
 			resultbefore: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter:     map[string]map[string]string{},
@@ -210,14 +209,13 @@ func TestStore_AddPostFilterResult(t *testing.T) {
 				},
 			},
 			args: args{
-				namespace:         "default",
-				podName:           "pod1",
+				podUID:            "uid1",
 				nominatedNodeName: "node1",
 				pluginName:        "plugin2",
 				nodeNames:         []string{"node1", "node2"},
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter:     map[string]map[string]string{},
@@ -233,7 +231,7 @@ func TestStore_AddPostFilterResult(t *testing.T) {
 		{
 			name: "success when no map for the node",
 			resultbefore: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter:     map[string]map[string]string{},
@@ -243,14 +241,13 @@ func TestStore_AddPostFilterResult(t *testing.T) {
 				},
 			},
 			args: args{
-				namespace:         "default",
-				podName:           "pod1",
+				podUID:            "uid1",
 				nominatedNodeName: "node1",
 				pluginName:        "plugin2",
 				nodeNames:         []string{"node1", "node2"},
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter:     map[string]map[string]string{},
@@ -273,7 +270,7 @@ func TestStore_AddPostFilterResult(t *testing.T) {
 				mu:      new(sync.Mutex),
 				results: tt.resultbefore,
 			}
-			s.AddPostFilterResult(tt.args.namespace, tt.args.podName, tt.args.nominatedNodeName, tt.args.pluginName, tt.args.nodeNames)
+			s.AddPostFilterResult(tt.args.podUID, tt.args.nominatedNodeName, tt.args.pluginName, tt.args.nodeNames)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -282,8 +279,7 @@ func TestStore_AddPostFilterResult(t *testing.T) {
 func TestStore_AddScoreResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace  string
-		podName    string
+		podUID     types.UID
 		nodeName   string
 		pluginName string
 		score      int64
@@ -300,14 +296,13 @@ func TestStore_AddScoreResult(t *testing.T) {
 			resultbefore:      map[key]*result{},
 			scorePluginWeight: map[string]int32{"plugin1": 2},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin1",
 				score:      10,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					selectedNode:    "",
 					preScore:        map[string]string{},
 					preFilterStatus: map[string]string{},
@@ -317,8 +312,14 @@ func TestStore_AddScoreResult(t *testing.T) {
 					reserve:         map[string]string{},
 					prebind:         map[string]string{},
 					bind:            map[string]string{},
+					postBind:        map[string]string{},
 					filter:          map[string]map[string]string{},
 					postFilter:      map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node1": {
+							"plugin1": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node1": {
 							"plugin1": "20",
@@ -335,9 +336,14 @@ func TestStore_AddScoreResult(t *testing.T) {
 		{
 			name: "success with non-empty filter map for the node",
 			resultbefore: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]map[string]string{},
 					postFilter: map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node1": {
+							"plugin1": "15",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node1": {
 							"plugin1": "30",
@@ -352,16 +358,21 @@ func TestStore_AddScoreResult(t *testing.T) {
 			},
 			scorePluginWeight: map[string]int32{"plugin2": 2},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin2",
 				score:      10,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]map[string]string{},
 					postFilter: map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node1": {
+							"plugin1": "15",
+							"plugin2": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node1": {
 							"plugin1": "30",
@@ -380,9 +391,14 @@ func TestStore_AddScoreResult(t *testing.T) {
 		{
 			name: "success when no map for the node",
 			resultbefore: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]map[string]string{},
 					postFilter: map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node0": {
+							"plugin1": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node0": {
 							"plugin1": "20",
@@ -397,16 +413,23 @@ func TestStore_AddScoreResult(t *testing.T) {
 			},
 			scorePluginWeight: map[string]int32{"plugin1": 2},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin1",
 				score:      10,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]map[string]string{},
 					postFilter: map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node0": {
+							"plugin1": "10",
+						},
+						"node1": {
+							"plugin1": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node0": {
 							"plugin1": "20",
@@ -436,7 +459,7 @@ func TestStore_AddScoreResult(t *testing.T) {
 				results:           tt.resultbefore,
 				scorePluginWeight: tt.scorePluginWeight,
 			}
-			s.AddScoreResult(tt.args.namespace, tt.args.podName, tt.args.nodeName, tt.args.pluginName, tt.args.score)
+			s.AddScoreResult(tt.args.podUID, tt.args.nodeName, tt.args.pluginName, tt.args.score)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -445,8 +468,7 @@ func TestStore_AddScoreResult(t *testing.T) {
 func TestStore_AddNormalizedScoreResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace  string
-		podName    string
+		podUID     types.UID
 		nodeName   string
 		pluginName string
 		score      int64
@@ -463,14 +485,13 @@ func TestStore_AddNormalizedScoreResult(t *testing.T) {
 			resultbefore:      map[key]*result{},
 			scorePluginWeight: map[string]int32{"plugin1": 2},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin1",
 				score:      10,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					selectedNode:    "",
 					preScore:        map[string]string{},
 					preFilterStatus: map[string]string{},
@@ -480,9 +501,15 @@ func TestStore_AddNormalizedScoreResult(t *testing.T) {
 					reserve:         map[string]string{},
 					prebind:         map[string]string{},
 					bind:            map[string]string{},
+					postBind:        map[string]string{},
 					filter:          map[string]map[string]string{},
 					postFilter:      map[string]map[string]string{},
 					score:           map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node1": {
+							"plugin1": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node1": {
 							"plugin1": "20",
@@ -494,9 +521,14 @@ func TestStore_AddNormalizedScoreResult(t *testing.T) {
 		{
 			name: "success with non-empty filter map for the node",
 			resultbefore: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]map[string]string{},
 					postFilter: map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node1": {
+							"plugin1": "15",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node1": {
 							"plugin1": "30",
@@ -506,16 +538,21 @@ func TestStore_AddNormalizedScoreResult(t *testing.T) {
 			},
 			scorePluginWeight: map[string]int32{"plugin2": 2},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin2",
 				score:      10,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]map[string]string{},
 					postFilter: map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node1": {
+							"plugin1": "15",
+							"plugin2": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node1": {
 							"plugin1": "30",
@@ -528,9 +565,14 @@ func TestStore_AddNormalizedScoreResult(t *testing.T) {
 		{
 			name: "success when no map for the node",
 			resultbefore: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]map[string]string{},
 					postFilter: map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node0": {
+							"plugin1": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node0": {
 							"plugin1": "20",
@@ -540,16 +582,23 @@ func TestStore_AddNormalizedScoreResult(t *testing.T) {
 			},
 			scorePluginWeight: map[string]int32{"plugin1": 2},
 			args: args{
-				namespace:  "default",
-				podName:    "pod1",
+				podUID:     "uid1",
 				nodeName:   "node1",
 				pluginName: "plugin1",
 				score:      10,
 			},
 			wantResultMap: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter:     map[string]map[string]string{},
 					postFilter: map[string]map[string]string{},
+					normalizedScore: map[string]map[string]string{
+						"node0": {
+							"plugin1": "10",
+						},
+						"node1": {
+							"plugin1": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node0": {
 							"plugin1": "20",
@@ -571,7 +620,7 @@ func TestStore_AddNormalizedScoreResult(t *testing.T) {
 				results:           tt.resultbefore,
 				scorePluginWeight: tt.scorePluginWeight,
 			}
-			s.AddNormalizedScoreResult(tt.args.namespace, tt.args.podName, tt.args.nodeName, tt.args.pluginName, tt.args.score)
+			s.AddNormalizedScoreResult(tt.args.podUID, tt.args.nodeName, tt.args.pluginName, tt.args.score)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -580,6 +629,7 @@ func TestStore_AddNormalizedScoreResult(t *testing.T) {
 func TestStore_AddStoredResultToPod(t *testing.T) {
 	t.Parallel()
 	podName := "pod1"
+	podUID := types.UID("uid1")
 	namespace := "default"
 	tests := []struct {
 		name    string
@@ -590,7 +640,7 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 		{
 			name: "success",
 			result: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					selectedNode: "node",
 					preScore: map[string]string{
 						"plugin1": "preScore",
@@ -616,6 +666,9 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 					bind: map[string]string{
 						"plugin1": "bind",
 					},
+					postBind: map[string]string{
+						"plugin1": SuccessMessage,
+					},
 					filter: map[string]map[string]string{
 						"node0": {
 							"plugin1": PassedFilterMessage,
@@ -624,6 +677,14 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 							"plugin1": PassedFilterMessage,
 						},
 					},
+					normalizedScore: map[string]map[string]string{
+						"node0": {
+							"plugin1": "10",
+						},
+						"node1": {
+							"plugin1": "10",
+						},
+					},
 					finalScore: map[string]map[string]string{
 						"node0": {
 							"plugin1": "20",
@@ -652,12 +713,14 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			wantpod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 					Annotations: map[string]string{
 						annotation.SelectedNodeAnnotationKey: "node",
 						annotation.PreScoreResultAnnotationKey: func() string {
@@ -708,6 +771,12 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 							})
 							return string(d)
 						}(),
+						annotation.PostBindResultAnnotationKey: func() string {
+							d, _ := json.Marshal(map[string]string{
+								"plugin1": SuccessMessage,
+							})
+							return string(d)
+						}(),
 						annotation.FilterResultAnnotationKey: func() string {
 							r := map[string]map[string]string{
 								"node0": {
@@ -732,6 +801,18 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 							d, _ := json.Marshal(r)
 							return string(d)
 						}(),
+						annotation.NormalizedScoreResultAnnotationKey: func() string {
+							r := map[string]map[string]string{
+								"node0": {
+									"plugin1": "10",
+								},
+								"node1": {
+									"plugin1": "10",
+								},
+							}
+							d, _ := json.Marshal(r)
+							return string(d)
+						}(),
 						annotation.FinalScoreResultAnnotationKey: func() string {
 							r := map[string]map[string]string{
 								"node0": {
@@ -765,19 +846,21 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			wantpod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 		},
 		{
 			name: "success without some data on store",
 			result: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					score:      map[string]map[string]string{},
 					finalScore: map[string]map[string]string{},
 					filter: map[string]map[string]string{
@@ -795,12 +878,14 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			wantpod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 					Annotations: map[string]string{
 						annotation.FilterResultAnnotationKey: func() string {
 							r := map[string]map[string]string{
@@ -815,6 +900,7 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 							return string(d)
 						}(),
 						annotation.ScoreResultAnnotationKey:           "{}",
+						annotation.NormalizedScoreResultAnnotationKey: "{}",
 						annotation.FinalScoreResultAnnotationKey:      "{}",
 						annotation.PostFilterResultAnnotationKey:      "{}",
 						annotation.SelectedNodeAnnotationKey:          "",
@@ -826,10 +912,80 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 						annotation.ReserveResultAnnotationKey:         "{}",
 						annotation.PreBindResultAnnotationKey:         "{}",
 						annotation.BindResultAnnotationKey:            "{}",
+						annotation.PostBindResultAnnotationKey:        "{}",
 					},
 				},
 			},
 		},
+		{
+			name: "merge postbind result stored after the other results are added to the pod",
+			result: map[key]*result{
+				"uid1": func() *result {
+					d := newData()
+					d.postBind = map[string]string{
+						"plugin2": SuccessMessage,
+					}
+					return d
+				}(),
+			},
+			newObj: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      podName,
+					Namespace: namespace,
+					UID:       podUID,
+					Annotations: map[string]string{
+						annotation.ScoreResultAnnotationKey:    `{"node0":{"plugin1":"10"}}`,
+						annotation.PostBindResultAnnotationKey: `{"plugin1":"success"}`,
+					},
+				},
+			},
+			wantpod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      podName,
+					Namespace: namespace,
+					UID:       podUID,
+					Annotations: map[string]string{
+						annotation.ScoreResultAnnotationKey:           `{"node0":{"plugin1":"10"}}`,
+						annotation.PostBindResultAnnotationKey:        `{"plugin1":"success","plugin2":"success"}`,
+						annotation.FilterResultAnnotationKey:          "{}",
+						annotation.NormalizedScoreResultAnnotationKey: "{}",
+						annotation.FinalScoreResultAnnotationKey:      "{}",
+						annotation.PostFilterResultAnnotationKey:      "{}",
+						annotation.SelectedNodeAnnotationKey:          "",
+						annotation.PreScoreResultAnnotationKey:        "{}",
+						annotation.PreFilterResultAnnotationKey:       "{}",
+						annotation.PreFilterStatusResultAnnotationKey: "{}",
+						annotation.PermitStatusResultAnnotationKey:    "{}",
+						annotation.PermitTimeoutResultAnnotationKey:   "{}",
+						annotation.ReserveResultAnnotationKey:         "{}",
+						annotation.PreBindResultAnnotationKey:         "{}",
+						annotation.BindResultAnnotationKey:            "{}",
+					},
+				},
+			},
+		},
+		{
+			name: "the results of the previous pod with the same name aren't added to the recreated pod",
+			result: map[key]*result{
+				"uid0": {
+					selectedNode: "node",
+				},
+			},
+			newObj: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      podName,
+					Namespace: namespace,
+					UID:       podUID,
+				},
+			},
+			wantpod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      podName,
+					Namespace: namespace,
+					UID:       podUID,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -850,8 +1006,7 @@ func TestStore_AddStoredResultToPod(t *testing.T) {
 func TestStore_AddPreFilterResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace       string
-		podName         string
+		podUID          types.UID
 		pluginName      string
 		reason          string
 		preFilterResult *framework.PreFilterResult
@@ -864,8 +1019,7 @@ func TestStore_AddPreFilterResult(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				namespace:  "namespace",
-				podName:    "pod",
+				podUID:     "uid",
 				pluginName: "plugin",
 				reason:     "reason",
 				preFilterResult: &framework.PreFilterResult{
@@ -881,7 +1035,7 @@ func TestStore_AddPreFilterResult(t *testing.T) {
 					"plugin": "reason",
 				}
 				return map[key]*result{
-					"namespace/pod": d,
+					"uid": d,
 				}
 			}(),
 		},
@@ -891,7 +1045,7 @@ func TestStore_AddPreFilterResult(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			s := &Store{mu: &sync.Mutex{}, results: map[key]*result{}}
-			s.AddPreFilterResult(tt.args.namespace, tt.args.podName, tt.args.pluginName, tt.args.reason, tt.args.preFilterResult)
+			s.AddPreFilterResult(tt.args.podUID, tt.args.pluginName, tt.args.reason, tt.args.preFilterResult)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -900,8 +1054,7 @@ func TestStore_AddPreFilterResult(t *testing.T) {
 func TestStore_AddPreScoreResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace  string
-		podName    string
+		podUID     types.UID
 		pluginName string
 		reason     string
 	}
@@ -913,8 +1066,7 @@ func TestStore_AddPreScoreResult(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				namespace:  "namespace",
-				podName:    "pod",
+				podUID:     "uid",
 				pluginName: "plugin",
 				reason:     "reason",
 			},
@@ -924,7 +1076,7 @@ func TestStore_AddPreScoreResult(t *testing.T) {
 					"plugin": "reason",
 				}
 				return map[key]*result{
-					"namespace/pod": d,
+					"uid": d,
 				}
 			}(),
 		},
@@ -934,7 +1086,7 @@ func TestStore_AddPreScoreResult(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			s := &Store{mu: &sync.Mutex{}, results: map[key]*result{}}
-			s.AddPreScoreResult(tt.args.namespace, tt.args.podName, tt.args.pluginName, tt.args.reason)
+			s.AddPreScoreResult(tt.args.podUID, tt.args.pluginName, tt.args.reason)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -943,8 +1095,7 @@ func TestStore_AddPreScoreResult(t *testing.T) {
 func TestStore_AddPermitResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace  string
-		podName    string
+		podUID     types.UID
 		pluginName string
 		status     string
 		timeout    time.Duration
@@ -957,8 +1108,7 @@ func TestStore_AddPermitResult(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				namespace:  "namespace",
-				podName:    "pod",
+				podUID:     "uid",
 				pluginName: "plugin",
 				status:     "success",
 				timeout:    time.Duration(1), // meaning 1ns
@@ -972,7 +1122,7 @@ func TestStore_AddPermitResult(t *testing.T) {
 					"plugin": "1ns",
 				}
 				return map[key]*result{
-					"namespace/pod": d,
+					"uid": d,
 				}
 			}(),
 		},
@@ -982,7 +1132,7 @@ func TestStore_AddPermitResult(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			s := &Store{mu: &sync.Mutex{}, results: map[key]*result{}}
-			s.AddPermitResult(tt.args.namespace, tt.args.podName, tt.args.pluginName, tt.args.status, tt.args.timeout)
+			s.AddPermitResult(tt.args.podUID, tt.args.pluginName, tt.args.status, tt.args.timeout)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -991,9 +1141,8 @@ func TestStore_AddPermitResult(t *testing.T) {
 func TestStore_AddSelectedNode(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace string
-		podName   string
-		nodeName  string
+		podUID   types.UID
+		nodeName string
 	}
 	tests := []struct {
 		name          string
@@ -1003,15 +1152,14 @@ func TestStore_AddSelectedNode(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				namespace: "namespace",
-				podName:   "pod",
-				nodeName:  "node",
+				podUID:   "uid",
+				nodeName: "node",
 			},
 			wantResultMap: func() map[key]*result {
 				d := newData()
 				d.selectedNode = "node"
 				return map[key]*result{
-					"namespace/pod": d,
+					"uid": d,
 				}
 			}(),
 		},
@@ -1021,7 +1169,7 @@ func TestStore_AddSelectedNode(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			s := &Store{mu: &sync.Mutex{}, results: map[key]*result{}}
-			s.AddSelectedNode(tt.args.namespace, tt.args.podName, tt.args.nodeName)
+			s.AddSelectedNode(tt.args.podUID, tt.args.nodeName)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -1030,8 +1178,7 @@ func TestStore_AddSelectedNode(t *testing.T) {
 func TestStore_AddReserveResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace  string
-		podName    string
+		podUID     types.UID
 		pluginName string
 		status     string
 	}
@@ -1043,8 +1190,7 @@ func TestStore_AddReserveResult(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				namespace:  "namespace",
-				podName:    "pod",
+				podUID:     "uid",
 				pluginName: "plugin",
 				status:     "success",
 			},
@@ -1054,7 +1200,7 @@ func TestStore_AddReserveResult(t *testing.T) {
 					"plugin": "success",
 				}
 				return map[key]*result{
-					"namespace/pod": d,
+					"uid": d,
 				}
 			}(),
 		},
@@ -1064,7 +1210,7 @@ func TestStore_AddReserveResult(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			s := &Store{mu: &sync.Mutex{}, results: map[key]*result{}}
-			s.AddReserveResult(tt.args.namespace, tt.args.podName, tt.args.pluginName, tt.args.status)
+			s.AddReserveResult(tt.args.podUID, tt.args.pluginName, tt.args.status)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -1073,8 +1219,7 @@ func TestStore_AddReserveResult(t *testing.T) {
 func TestStore_AddBindResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace  string
-		podName    string
+		podUID     types.UID
 		pluginName string
 		status     string
 	}
@@ -1086,8 +1231,7 @@ func TestStore_AddBindResult(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				namespace:  "namespace",
-				podName:    "pod",
+				podUID:     "uid",
 				pluginName: "plugin",
 				status:     "success",
 			},
@@ -1097,7 +1241,7 @@ func TestStore_AddBindResult(t *testing.T) {
 					"plugin": "success",
 				}
 				return map[key]*result{
-					"namespace/pod": d,
+					"uid": d,
 				}
 			}(),
 		},
@@ -1107,7 +1251,7 @@ func TestStore_AddBindResult(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			s := &Store{mu: &sync.Mutex{}, results: map[key]*result{}}
-			s.AddBindResult(tt.args.namespace, tt.args.podName, tt.args.pluginName, tt.args.status)
+			s.AddBindResult(tt.args.podUID, tt.args.pluginName, tt.args.status)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -1116,8 +1260,7 @@ func TestStore_AddBindResult(t *testing.T) {
 func TestStore_AddPreBindResult(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		namespace  string
-		podName    string
+		podUID     types.UID
 		pluginName string
 		status     string
 	}
@@ -1129,8 +1272,7 @@ func TestStore_AddPreBindResult(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				namespace:  "namespace",
-				podName:    "pod",
+				podUID:     "uid",
 				pluginName: "plugin",
 				status:     "success",
 			},
@@ -1140,7 +1282,48 @@ func TestStore_AddPreBindResult(t *testing.T) {
 					"plugin": "success",
 				}
 				return map[key]*result{
-					"namespace/pod": d,
+					"uid": d,
+				}
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := &Store{mu: &sync.Mutex{}, results: map[key]*result{}}
+			s.AddPreBindResult(tt.args.podUID, tt.args.pluginName, tt.args.status)
+			assert.Equal(t, tt.wantResultMap, s.results)
+		})
+	}
+}
+
+func TestStore_AddPostBindResult(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		podUID     types.UID
+		pluginName string
+		status     string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantResultMap map[key]*result
+	}{
+		{
+			name: "success",
+			args: args{
+				podUID:     "uid",
+				pluginName: "plugin",
+				status:     "success",
+			},
+			wantResultMap: func() map[key]*result {
+				d := newData()
+				d.postBind = map[string]string{
+					"plugin": "success",
+				}
+				return map[key]*result{
+					"uid": d,
 				}
 			}(),
 		},
@@ -1150,7 +1333,7 @@ func TestStore_AddPreBindResult(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			s := &Store{mu: &sync.Mutex{}, results: map[key]*result{}}
-			s.AddPreBindResult(tt.args.namespace, tt.args.podName, tt.args.pluginName, tt.args.status)
+			s.AddPostBindResult(tt.args.podUID, tt.args.pluginName, tt.args.status)
 			assert.Equal(t, tt.wantResultMap, s.results)
 		})
 	}
@@ -1160,6 +1343,7 @@ func TestStore_DeleteData(t *testing.T) {
 	t.Parallel()
 	podName := "pod1"
 	namespace := "default"
+	podUID := types.UID("uid1")
 	tests := []struct {
 		name       string
 		target     corev1.Pod
@@ -1172,10 +1356,11 @@ func TestStore_DeleteData(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			result: map[key]*result{
-				"default/pod1": {
+				"uid1": {
 					filter: map[string]map[string]string{
 						"node0": {
 							"plugin1": PassedFilterMessage,
@@ -1201,7 +1386,7 @@ func TestStore_DeleteData(t *testing.T) {
 						},
 					},
 				},
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]map[string]string{
 						"node0": {
 							"plugin1": PassedFilterMessage,
@@ -1229,7 +1414,7 @@ func TestStore_DeleteData(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]map[string]string{
 						"node0": {
 							"plugin1": PassedFilterMessage,
@@ -1263,10 +1448,11 @@ func TestStore_DeleteData(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      podName,
 					Namespace: namespace,
+					UID:       podUID,
 				},
 			},
 			result: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]map[string]string{
 						"node0": {
 							"plugin1": PassedFilterMessage,
@@ -1294,7 +1480,7 @@ func TestStore_DeleteData(t *testing.T) {
 				},
 			},
 			wantResult: map[key]*result{
-				"default/pod2": {
+				"uid2": {
 					filter: map[string]map[string]string{
 						"node0": {
 							"plugin1": PassedFilterMessage,
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
@@ -14,17 +15,18 @@ import (
 //go:generate mockgen -destination=./mock/$GOFILE -package=plugin . Store,PreFilterPluginExtender,FilterPluginExtender,PostFilterPluginExtender,PreScorePluginExtender,ScorePluginExtender,NormalizeScorePluginExtender,ReservePluginExtender,PermitPluginExtender,PreBindPluginExtender,BindPluginExtender,PostBindPluginExtender
 //go:generate mockgen -destination=./mock/framework.go -package=plugin k8s.io/kubernetes/pkg/scheduler/framework PreFilterPlugin,FilterPlugin,PostFilterPlugin,PreScorePlugin,ScorePlugin,ScoreExtensions,PermitPlugin,BindPlugin,PreBindPlugin,PostBindPlugin,ReservePlugin
 type Store interface {
-	AddNormalizedScoreResult(namespace, podName, nodeName, pluginName string, normalizedscore int64)
-	AddPreFilterResult(namespace, podName, pluginName, reason string, preFilterResult *framework.PreFilterResult)
-	AddFilterResult(namespace, podName, nodeName, pluginName, reason string)
-	AddPreScoreResult(namespace, podName, pluginName, reason string)
-	AddScoreResult(namespace, podName, nodeName, pluginName string, score int64)
-	AddPostFilterResult(namespace, podName, nominatedNodeName, pluginName string, nodeNames []string)
-	AddPermitResult(namespace, podName, pluginName, status string, timeout time.Duration)
-	AddReserveResult(namespace, podName, pluginName, status string)
-	AddSelectedNode(namespace, podName, nodeName string)
-	AddBindResult(namespace, podName, pluginName, status string)
-	AddPreBindResult(namespace, podName, pluginName, status string)
+	AddNormalizedScoreResult(podUID types.UID, nodeName, pluginName string, normalizedscore int64)
+	AddPreFilterResult(podUID types.UID, pluginName, reason string, preFilterResult *framework.PreFilterResult)
+	AddFilterResult(podUID types.UID, nodeName, pluginName, reason string)
+	AddPreScoreResult(podUID types.UID, pluginName, reason string)
+	AddScoreResult(podUID types.UID, nodeName, pluginName string, score int64)
+	AddPostFilterResult(podUID types.UID, nominatedNodeName, pluginName string, nodeNames []string)
+	AddPermitResult(podUID types.UID, pluginName, status string, timeout time.Duration)
+	AddReserveResult(podUID types.UID, pluginName, status string)
+	AddSelectedNode(podUID types.UID, nodeName string)
+	AddBindResult(podUID types.UID, pluginName, status string)
+	AddPreBindResult(podUID types.UID, pluginName, status string)
+	AddPostBindResult(podUID types.UID, pluginName, status string)
 }
 
 // PreFilterPluginExtender is the extender for PreFilter plugin.
@@ -371,7 +373,7 @@ func (w *wrappedPlugin) NormalizeScore(ctx context.Context, state *framework.Cyc
 	} else {
 		// TODO: move to AfterNormalizeScore.
 		for _, s := range scores {
-			w.store.AddNormalizedScoreResult(pod.Namespace, pod.Name, s.Name, w.originalScorePlugin.Name(), s.Score)
+			w.store.AddNormalizedScoreResult(pod.UID, s.Name, w.originalScorePlugin.Name(), s.Score)
 		}
 	}
 
@@ -403,7 +405,7 @@ func (w *wrappedPlugin) Score(ctx context.Context, state *framework.CycleState,
 		klog.Errorf("failed to run score plugin. Scores won't be recorded on Pod annotation: %v, %v", s.Code(), s.Message())
 	} else {
 		// TODO: move to AfterScore.
-		w.store.AddScoreResult(pod.Namespace, pod.Name, nodeName, w.originalScorePlugin.Name(), score)
+		w.store.AddScoreResult(pod.UID, nodeName, w.originalScorePlugin.Name(), score)
 	}
 
 	if w.scorePluginExtender != nil {
@@ -444,7 +446,7 @@ func (w *wrappedPlugin) PreScore(ctx context.Context, state *framework.CycleStat
 	} else {
 		msg = s.Message()
 	}
-	w.store.AddPreScoreResult(pod.Namespace, pod.Name, w.originalPreScorePlugin.Name(), msg)
+	w.store.AddPreScoreResult(pod.UID, w.originalPreScorePlugin.Name(), msg)
 
 	if w.preScorePluginExtender != nil {
 		return w.preScorePluginExtender.AfterPreScore(ctx, state, pod, nodes, s)
@@ -476,7 +478,7 @@ func (w *wrappedPlugin) PreFilter(ctx context.Context, state *framework.CycleSta
 	} else {
 		msg = s.Message()
 	}
-	w.store.AddPreFilterResult(p.Namespace, p.Name, w.originalPreFilterPlugin.Name(), msg, result)
+	w.store.AddPreFilterResult(p.UID, w.originalPreFilterPlugin.Name(), msg, result)
 
 	if w.preFilterPluginExtender != nil {
 		return w.preFilterPluginExtender.AfterPreFilter(ctx, state, p, result, s)
@@ -507,7 +509,7 @@ func (w *wrappedPlugin) Filter(ctx context.Context, state *framework.CycleState,
 	} else {
 		msg = s.Message()
 	}
-	w.store.AddFilterResult(pod.Namespace, pod.Name, nodeInfo.Node().Name, w.originalFilterPlugin.Name(), msg)
+	w.store.AddFilterResult(pod.UID, nodeInfo.Node().Name, w.originalFilterPlugin.Name(), msg)
 
 	if w.filterPluginExtender != nil {
 		return w.filterPluginExtender.AfterFilter(ctx, state, pod, nodeInfo, s)
@@ -535,7 +537,7 @@ func (w *wrappedPlugin) PostFilter(ctx context.Context, state *framework.CycleSt
 	for k := range filteredNodeStatusMap {
 		nodeNames = append(nodeNames, k)
 	}
-	w.store.AddPostFilterResult(pod.Namespace, pod.Name, nominatedNodeName, w.originalPostFilterPlugin.Name(), nodeNames)
+	w.store.AddPostFilterResult(pod.UID, nominatedNodeName, w.originalPostFilterPlugin.Name(), nodeNames)
 
 	if w.postFilterPluginExtender != nil {
 		return w.postFilterPluginExtender.AfterPostFilter(ctx, state, pod, filteredNodeStatusMap, r, s)
@@ -568,7 +570,7 @@ func (w *wrappedPlugin) Permit(ctx context.Context, state *framework.CycleState,
 		msg = schedulingresultstore.WaitMessage
 	}
 
-	w.store.AddPermitResult(pod.Namespace, pod.Name, w.originalPermitPlugin.Name(), msg, timeout)
+	w.store.AddPermitResult(pod.UID, w.originalPermitPlugin.Name(), msg, timeout)
 
 	if w.permitPluginExtender != nil {
 		return w.permitPluginExtender.AfterPermit(ctx, state, pod, nodeName, s, timeout)
@@ -581,7 +583,7 @@ func (w *wrappedPlugin) Permit(ctx context.Context, state *framework.CycleState,
 // You can run your function before and/or after the execution of original Reserve plugin
 // by configuring with WithExtendersOption.
 func (w *wrappedPlugin) Reserve(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodename string) *framework.Status {
-	w.store.AddSelectedNode(pod.Namespace, pod.Name, nodename)
+	w.store.AddSelectedNode(pod.UID, nodename)
 
 	if w.originalReservePlugin == nil {
 		// return nil not to affect scoring
@@ -602,7 +604,7 @@ func (w *wrappedPlugin) Reserve(ctx context.Context, state *framework.CycleState
 	} else {
 		msg = s.Message()
 	}
-	w.store.AddReserveResult(pod.Namespace, pod.Name, w.originalReservePlugin.Name(), msg)
+	w.store.AddReserveResult(pod.UID, w.originalReservePlugin.Name(), msg)
 
 	if w.reservePluginExtender != nil {
 		return w.reservePluginExtender.AfterReserve(ctx, state, pod, nodename, s)
@@ -654,7 +656,7 @@ func (w *wrappedPlugin) PreBind(ctx context.Context, state *framework.CycleState
 	} else {
 		msg = s.Message()
 	}
-	w.store.AddPreBindResult(pod.Namespace, pod.Name, w.originalPreBindPlugin.Name(), msg)
+	w.store.AddPreBindResult(pod.UID, w.originalPreBindPlugin.Name(), msg)
 
 	if w.preBindPluginExtender != nil {
 		return w.preBindPluginExtender.AfterPreBind(ctx, state, pod, nodename, s)
@@ -683,7 +685,7 @@ func (w *wrappedPlugin) Bind(ctx context.Context, state *framework.CycleState, p
 	} else {
 		msg = s.Message()
 	}
-	w.store.AddBindResult(pod.Namespace, pod.Name, w.originalBindPlugin.Name(), msg)
+	w.store.AddBindResult(pod.UID, w.originalBindPlugin.Name(), msg)
 
 	if w.bindPluginExtender != nil {
 		return w.bindPluginExtender.AfterBind(ctx, state, pod, nodename, s)
@@ -706,6 +708,7 @@ func (w *wrappedPlugin) PostBind(ctx context.Context, state *framework.CycleStat
 	}
 
 	w.originalPostBindPlugin.PostBind(ctx, state, pod, nodename)
+	w.store.AddPostBindResult(pod.UID, w.originalPostBindPlugin.Name(), schedulingresultstore.SuccessMessage)
 
 	if w.postBindPluginExtender != nil {
 		w.postBindPluginExtender.AfterPostBind(ctx, state, pod, nodename)
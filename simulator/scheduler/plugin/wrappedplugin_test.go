@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
@@ -236,11 +237,11 @@ func Test_wrappedPlugin_Filter(t *testing.T) {
 		{
 			name: "success",
 			prepareStoreFn: func(m *mock_plugin.MockStore) {
-				m.EXPECT().AddFilterResult("default", "pod1", "node1", "fakeFilterPlugin", resultstore.PassedFilterMessage)
+				m.EXPECT().AddFilterResult(types.UID("uid1"), "node1", "fakeFilterPlugin", resultstore.PassedFilterMessage)
 			},
 			originalFilterPlugin: fakeFilterPlugin{},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodeInfo: func() *framework.NodeInfo {
 					n := &framework.NodeInfo{}
 					n.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
@@ -254,7 +255,7 @@ func Test_wrappedPlugin_Filter(t *testing.T) {
 			prepareStoreFn:       func(m *mock_plugin.MockStore) {},
 			originalFilterPlugin: nil, // don't have filter plugin
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", UID: "uid1"}},
 				nodeInfo: func() *framework.NodeInfo {
 					n := &framework.NodeInfo{}
 					n.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
@@ -266,11 +267,11 @@ func Test_wrappedPlugin_Filter(t *testing.T) {
 		{
 			name: "fail when original plugin return non-success",
 			prepareStoreFn: func(m *mock_plugin.MockStore) {
-				m.EXPECT().AddFilterResult("default", "pod1", "node1", "fakeMustFailWrappedPlugin", "filter failed")
+				m.EXPECT().AddFilterResult(types.UID("uid1"), "node1", "fakeMustFailWrappedPlugin", "filter failed")
 			},
 			originalFilterPlugin: fakeMustFailWrappedPlugin{},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodeInfo: func() *framework.NodeInfo {
 					n := &framework.NodeInfo{}
 					n.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
@@ -322,10 +323,10 @@ func Test_wrappedPlugin_Filter_WithPluginExtender(t *testing.T) {
 				fe.EXPECT().AfterFilter(ctx, nil, as.pod, as.nodeInfo, success2).Return(success3)
 				p.EXPECT().Name().Return("fakeFilterPlugin").AnyTimes()
 				// Filter sotres resultstore.PassedFilterMessage if it is successful.
-				s.EXPECT().AddFilterResult("default", "pod1", "node1", "fakeFilterPlugin", resultstore.PassedFilterMessage)
+				s.EXPECT().AddFilterResult(types.UID("uid1"), "node1", "fakeFilterPlugin", resultstore.PassedFilterMessage)
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodeInfo: func() *framework.NodeInfo {
 					n := &framework.NodeInfo{}
 					n.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
@@ -345,10 +346,10 @@ func Test_wrappedPlugin_Filter_WithPluginExtender(t *testing.T) {
 				fe.EXPECT().AfterFilter(ctx, nil, as.pod, as.nodeInfo, failure).Return(success3)
 				p.EXPECT().Name().Return("fakeFilterPlugin").AnyTimes()
 				// Filter stores own message if it is successful.
-				s.EXPECT().AddFilterResult("default", "pod1", "node1", "fakeFilterPlugin", failure.Message())
+				s.EXPECT().AddFilterResult(types.UID("uid1"), "node1", "fakeFilterPlugin", failure.Message())
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodeInfo: func() *framework.NodeInfo {
 					n := &framework.NodeInfo{}
 					n.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
@@ -365,7 +366,7 @@ func Test_wrappedPlugin_Filter_WithPluginExtender(t *testing.T) {
 				p.EXPECT().Name().Return("fakeFilterPlugin").AnyTimes()
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodeInfo: func() *framework.NodeInfo {
 					n := &framework.NodeInfo{}
 					n.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
@@ -384,10 +385,10 @@ func Test_wrappedPlugin_Filter_WithPluginExtender(t *testing.T) {
 				p.EXPECT().Filter(ctx, nil, as.pod, as.nodeInfo).Return(success2)
 				fe.EXPECT().AfterFilter(ctx, nil, as.pod, as.nodeInfo, success2).Return(failure)
 				p.EXPECT().Name().Return("fakeFilterPlugin").AnyTimes()
-				s.EXPECT().AddFilterResult("default", "pod1", "node1", "fakeFilterPlugin", resultstore.PassedFilterMessage)
+				s.EXPECT().AddFilterResult(types.UID("uid1"), "node1", "fakeFilterPlugin", resultstore.PassedFilterMessage)
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodeInfo: func() *framework.NodeInfo {
 					n := &framework.NodeInfo{}
 					n.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
@@ -438,7 +439,7 @@ func Test_wrappedPlugin_PostFilter(t *testing.T) {
 		{
 			name: "success",
 			prepareStoreFn: func(m *mock_plugin.MockStore) {
-				m.EXPECT().AddPostFilterResult("default", "pod1", "node1", "fakePostFilterPlugin", gomock.Any()).Do(func(_, _, _, _ string, nodeNames []string) {
+				m.EXPECT().AddPostFilterResult(types.UID("uid1"), "node1", "fakePostFilterPlugin", gomock.Any()).Do(func(_ types.UID, _, _ string, nodeNames []string) {
 					sort.SliceStable(nodeNames, func(i, j int) bool {
 						return nodeNames[i] < nodeNames[j]
 					})
@@ -447,7 +448,7 @@ func Test_wrappedPlugin_PostFilter(t *testing.T) {
 			},
 			originalPostFilterPlugin: fakePostFilterPlugin{},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				filteredNodeStatusMap: framework.NodeToStatusMap{
 					"node1": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
 					"node2": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
@@ -465,7 +466,7 @@ func Test_wrappedPlugin_PostFilter(t *testing.T) {
 			prepareStoreFn:           func(m *mock_plugin.MockStore) {},
 			originalPostFilterPlugin: nil, // don't have post filter plugin
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", UID: "uid1"}},
 				filteredNodeStatusMap: framework.NodeToStatusMap{
 					"node1": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
 					"node2": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
@@ -477,7 +478,7 @@ func Test_wrappedPlugin_PostFilter(t *testing.T) {
 		{
 			name: "fail when original plugin return non-success",
 			prepareStoreFn: func(m *mock_plugin.MockStore) {
-				m.EXPECT().AddPostFilterResult("default", "pod1", "", "fakeMustFailWrappedPlugin", gomock.Any()).Do(func(_, _, _, _ string, nodeNames []string) {
+				m.EXPECT().AddPostFilterResult(types.UID("uid1"), "", "fakeMustFailWrappedPlugin", gomock.Any()).Do(func(_ types.UID, _, _ string, nodeNames []string) {
 					sort.SliceStable(nodeNames, func(i, j int) bool {
 						return nodeNames[i] < nodeNames[j]
 					})
@@ -486,7 +487,7 @@ func Test_wrappedPlugin_PostFilter(t *testing.T) {
 			},
 			originalPostFilterPlugin: fakeMustFailWrappedPlugin{},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				filteredNodeStatusMap: framework.NodeToStatusMap{
 					"node1": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
 					"node2": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
@@ -550,7 +551,7 @@ func Test_wrappedPlugin_PostFilter_WithPluginExtender(t *testing.T) {
 				fe.EXPECT().AfterPostFilter(ctx, nil, as.pod, as.filteredNodeStatusMap, result1, success2).Return(result2, success3)
 				p.EXPECT().Name().Return("fakePostFilterPlugin").AnyTimes()
 				// PostFilter sotres resultstore.PassedFilterMessage if it is successful.
-				s.EXPECT().AddPostFilterResult("default", "pod1", "node1", "fakePostFilterPlugin", gomock.Any()).Do(func(_, _, _, _ string, nodeNames []string) {
+				s.EXPECT().AddPostFilterResult(types.UID("uid1"), "node1", "fakePostFilterPlugin", gomock.Any()).Do(func(_ types.UID, _, _ string, nodeNames []string) {
 					sort.SliceStable(nodeNames, func(i, j int) bool {
 						return nodeNames[i] < nodeNames[j]
 					})
@@ -558,7 +559,7 @@ func Test_wrappedPlugin_PostFilter_WithPluginExtender(t *testing.T) {
 				})
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				filteredNodeStatusMap: framework.NodeToStatusMap{
 					"node1": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
 					"node2": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
@@ -587,7 +588,7 @@ func Test_wrappedPlugin_PostFilter_WithPluginExtender(t *testing.T) {
 				fe.EXPECT().AfterPostFilter(ctx, nil, as.pod, as.filteredNodeStatusMap, nil, failure).Return(result2, success3)
 				p.EXPECT().Name().Return("fakePostFilterPlugin").AnyTimes()
 				// Filter stores own message if it is successful.
-				s.EXPECT().AddPostFilterResult("default", "pod1", "", "fakePostFilterPlugin", gomock.Any()).Do(func(_, _, _, _ string, nodeNames []string) {
+				s.EXPECT().AddPostFilterResult(types.UID("uid1"), "", "fakePostFilterPlugin", gomock.Any()).Do(func(_ types.UID, _, _ string, nodeNames []string) {
 					sort.SliceStable(nodeNames, func(i, j int) bool {
 						return nodeNames[i] < nodeNames[j]
 					})
@@ -595,7 +596,7 @@ func Test_wrappedPlugin_PostFilter_WithPluginExtender(t *testing.T) {
 				})
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				filteredNodeStatusMap: framework.NodeToStatusMap{
 					"node1": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
 					"node2": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
@@ -616,7 +617,7 @@ func Test_wrappedPlugin_PostFilter_WithPluginExtender(t *testing.T) {
 				p.EXPECT().Name().Return("fakePostFilterPlugin").AnyTimes()
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				filteredNodeStatusMap: framework.NodeToStatusMap{
 					"node1": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
 					"node2": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
@@ -641,7 +642,7 @@ func Test_wrappedPlugin_PostFilter_WithPluginExtender(t *testing.T) {
 				fe.EXPECT().AfterPostFilter(ctx, nil, as.pod, as.filteredNodeStatusMap, result1, success2).Return(nil, failure)
 				p.EXPECT().Name().Return("fakePostFilterPlugin").AnyTimes()
 				// PostFilter sotres resultstore.PassedFilterMessage if it is successful.
-				s.EXPECT().AddPostFilterResult("default", "pod1", "node1", "fakePostFilterPlugin", gomock.Any()).Do(func(_, _, _, _ string, nodeNames []string) {
+				s.EXPECT().AddPostFilterResult(types.UID("uid1"), "node1", "fakePostFilterPlugin", gomock.Any()).Do(func(_ types.UID, _, _ string, nodeNames []string) {
 					sort.SliceStable(nodeNames, func(i, j int) bool {
 						return nodeNames[i] < nodeNames[j]
 					})
@@ -649,7 +650,7 @@ func Test_wrappedPlugin_PostFilter_WithPluginExtender(t *testing.T) {
 				})
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				filteredNodeStatusMap: framework.NodeToStatusMap{
 					"node1": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
 					"node2": framework.NewStatus(framework.UnschedulableAndUnresolvable, ""),
@@ -730,12 +731,12 @@ func Test_wrappedPlugin_NormalizeScore(t *testing.T) {
 		{
 			name: "success",
 			prepareStoreFn: func(m *mock_plugin.MockStore) {
-				m.EXPECT().AddNormalizedScoreResult("default", "pod1", "node1", "fakeScorePlugin", int64(10))
-				m.EXPECT().AddNormalizedScoreResult("default", "pod1", "node1", "fakeScorePlugin", int64(200))
+				m.EXPECT().AddNormalizedScoreResult(types.UID("uid1"), "node1", "fakeScorePlugin", int64(10))
+				m.EXPECT().AddNormalizedScoreResult(types.UID("uid1"), "node1", "fakeScorePlugin", int64(200))
 			},
 			originalScorePlugin: fakeScorePlugin{},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				scores: []framework.NodeScore{
 					{
 						Name:  "node1",
@@ -754,7 +755,7 @@ func Test_wrappedPlugin_NormalizeScore(t *testing.T) {
 			prepareStoreFn:      func(m *mock_plugin.MockStore) {},
 			originalScorePlugin: nil, // don't have filter plugin
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", UID: "uid1"}},
 				scores: []framework.NodeScore{
 					{
 						Name:  "node1",
@@ -769,7 +770,7 @@ func Test_wrappedPlugin_NormalizeScore(t *testing.T) {
 			prepareStoreFn:      func(m *mock_plugin.MockStore) {},
 			originalScorePlugin: fakeMustFailWrappedPlugin{},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				scores: []framework.NodeScore{
 					{
 						Name:  "node1",
@@ -834,11 +835,11 @@ func Test_wrappedPlugin_NormalizeScore_WithPluginExtender(t *testing.T) {
 				se.EXPECT().NormalizeScore(ctx, nil, as.pod, as.scores).Return(success2).Do(calOnNormalizeScore)
 				spe.EXPECT().AfterNormalizeScore(ctx, nil, as.pod, as.scores, success2).Return(success3).Do(calOnAfterNormalizeScore)
 				sp.EXPECT().Name().Return("fakeNormalizeScorePlugin").AnyTimes()
-				s.EXPECT().AddNormalizedScoreResult("default", "pod1", "node1", "fakeNormalizeScorePlugin", int64(2000))
-				s.EXPECT().AddNormalizedScoreResult("default", "pod1", "node2", "fakeNormalizeScorePlugin", int64(2010))
+				s.EXPECT().AddNormalizedScoreResult(types.UID("uid1"), "node1", "fakeNormalizeScorePlugin", int64(2000))
+				s.EXPECT().AddNormalizedScoreResult(types.UID("uid1"), "node2", "fakeNormalizeScorePlugin", int64(2010))
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				scores: []framework.NodeScore{
 					{
 						Name:  "node1",
@@ -886,7 +887,7 @@ func Test_wrappedPlugin_NormalizeScore_WithPluginExtender(t *testing.T) {
 				// NormalizeScore isnt't stores own results if return error.
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				scores: []framework.NodeScore{
 					{
 						Name:  "node1",
@@ -931,11 +932,11 @@ func Test_wrappedPlugin_NormalizeScore_WithPluginExtender(t *testing.T) {
 				se.EXPECT().NormalizeScore(ctx, nil, as.pod, as.scores).Return(success2).Do(calOnNormalizeScore)
 				spe.EXPECT().AfterNormalizeScore(ctx, nil, as.pod, as.scores, success2).Return(failure).Do(calOnAfterNormalizeScore)
 				sp.EXPECT().Name().Return("fakeNormalizeScorePlugin").AnyTimes()
-				s.EXPECT().AddNormalizedScoreResult("default", "pod1", "node1", "fakeNormalizeScorePlugin", int64(2000))
-				s.EXPECT().AddNormalizedScoreResult("default", "pod1", "node2", "fakeNormalizeScorePlugin", int64(2010))
+				s.EXPECT().AddNormalizedScoreResult(types.UID("uid1"), "node1", "fakeNormalizeScorePlugin", int64(2000))
+				s.EXPECT().AddNormalizedScoreResult(types.UID("uid1"), "node2", "fakeNormalizeScorePlugin", int64(2010))
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				scores: []framework.NodeScore{
 					{
 						Name:  "node1",
@@ -973,7 +974,7 @@ func Test_wrappedPlugin_NormalizeScore_WithPluginExtender(t *testing.T) {
 				sp.EXPECT().Name().Return("fakeNormalizeScorePlugin").AnyTimes()
 			},
 			args: args{
-				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				scores: []framework.NodeScore{
 					{
 						Name:  "node1",
@@ -1042,11 +1043,11 @@ func Test_wrappedPlugin_Score(t *testing.T) {
 		{
 			name: "success",
 			prepareStoreFn: func(m *mock_plugin.MockStore) {
-				m.EXPECT().AddScoreResult("default", "pod1", "node1", "fakeScorePlugin", int64(1))
+				m.EXPECT().AddScoreResult(types.UID("uid1"), "node1", "fakeScorePlugin", int64(1))
 			},
 			originalScorePlugin: fakeScorePlugin{},
 			args: args{
-				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodename: "node1",
 			},
 			want:       1,
@@ -1057,7 +1058,7 @@ func Test_wrappedPlugin_Score(t *testing.T) {
 			prepareStoreFn:      func(m *mock_plugin.MockStore) {},
 			originalScorePlugin: nil, // don't have filter plugin
 			args: args{
-				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}},
+				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", UID: "uid1"}},
 				nodename: "node1",
 			},
 			want:       0,
@@ -1068,7 +1069,7 @@ func Test_wrappedPlugin_Score(t *testing.T) {
 			prepareStoreFn:      func(m *mock_plugin.MockStore) {},
 			originalScorePlugin: fakeMustFailWrappedPlugin{},
 			args: args{
-				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodename: "node1",
 			},
 			want:       0,
@@ -1118,10 +1119,10 @@ func Test_wrappedPlugin_Score_WithPluginExtender(t *testing.T) {
 				p.EXPECT().Score(ctx, nil, as.pod, "node1").Return(int64(2222), success2)
 				se.EXPECT().AfterScore(ctx, nil, as.pod, "node1", int64(2222), success2).Return(int64(3333), success3)
 				p.EXPECT().Name().Return("fakeScorePlugin").AnyTimes()
-				s.EXPECT().AddScoreResult("default", "pod1", "node1", "fakeScorePlugin", int64(2222))
+				s.EXPECT().AddScoreResult(types.UID("uid1"), "node1", "fakeScorePlugin", int64(2222))
 			},
 			args: args{
-				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodename: "node1",
 			},
 			want:       3333,
@@ -1137,10 +1138,10 @@ func Test_wrappedPlugin_Score_WithPluginExtender(t *testing.T) {
 				p.EXPECT().Score(ctx, nil, as.pod, "node1").Return(int64(2222), failure)
 				se.EXPECT().AfterScore(ctx, nil, as.pod, "node1", int64(2222), failure).Return(int64(3333), success3)
 				p.EXPECT().Name().Return("fakeScorePlugin").AnyTimes()
-				s.EXPECT().AddScoreResult(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				s.EXPECT().AddScoreResult(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			args: args{
-				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodename: "node1",
 			},
 			want:       3333,
@@ -1154,7 +1155,7 @@ func Test_wrappedPlugin_Score_WithPluginExtender(t *testing.T) {
 				p.EXPECT().Name().Return("fakeScorePlugin").AnyTimes()
 			},
 			args: args{
-				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodename: "node1",
 			},
 			want:       1111,
@@ -1170,10 +1171,10 @@ func Test_wrappedPlugin_Score_WithPluginExtender(t *testing.T) {
 				p.EXPECT().Score(ctx, nil, as.pod, "node1").Return(int64(2222), success2)
 				se.EXPECT().AfterScore(ctx, nil, as.pod, "node1", int64(2222), success2).Return(int64(3333), failure)
 				p.EXPECT().Name().Return("fakeScorePlugin").AnyTimes()
-				s.EXPECT().AddScoreResult("default", "pod1", "node1", "fakeScorePlugin", int64(2222))
+				s.EXPECT().AddScoreResult(types.UID("uid1"), "node1", "fakeScorePlugin", int64(2222))
 			},
 			args: args{
-				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+				pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
 				nodename: "node1",
 			},
 			want:       3333,
@@ -1235,7 +1236,7 @@ func Test_wrappedPlugin_ScoreExtensions(t *testing.T) {
 func Test_wrappedPlugin_PreScore(t *testing.T) {
 	t.Parallel()
 
-	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace", UID: "uid"}}
 	testNodes := []*v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node"}}}
 
 	tests := []struct {
@@ -1250,7 +1251,7 @@ func Test_wrappedPlugin_PreScore(t *testing.T) {
 				extender.EXPECT().BeforePreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreScoreResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddPreScoreResult(testPod.UID, "name", resultstore.SuccessMessage)
 				extender.EXPECT().AfterPreScore(gomock.Any(), gomock.Any(), testPod, testNodes, framework.NewStatus(framework.Success)).Return(framework.NewStatus(framework.Success))
 			},
 			want: framework.NewStatus(framework.Success),
@@ -1268,7 +1269,7 @@ func Test_wrappedPlugin_PreScore(t *testing.T) {
 				extender.EXPECT().BeforePreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreScoreResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddPreScoreResult(testPod.UID, "name", resultstore.SuccessMessage)
 				extender.EXPECT().AfterPreScore(gomock.Any(), gomock.Any(), testPod, testNodes, framework.NewStatus(framework.Success)).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1279,7 +1280,7 @@ func Test_wrappedPlugin_PreScore(t *testing.T) {
 				extender.EXPECT().BeforePreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreScoreResult("namespace", "pod", "name", "error")
+				s.EXPECT().AddPreScoreResult(testPod.UID, "name", "error")
 				extender.EXPECT().AfterPreScore(gomock.Any(), gomock.Any(), testPod, testNodes, framework.NewStatus(framework.Unschedulable, "error")).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1290,7 +1291,7 @@ func Test_wrappedPlugin_PreScore(t *testing.T) {
 				extender.EXPECT().BeforePreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreScoreResult("namespace", "pod", "name", "error")
+				s.EXPECT().AddPreScoreResult(testPod.UID, "name", "error")
 				extender.EXPECT().AfterPreScore(gomock.Any(), gomock.Any(), testPod, testNodes, framework.NewStatus(framework.Unschedulable, "error")).Return(framework.NewStatus(framework.Success))
 			},
 			want: framework.NewStatus(framework.Success),
@@ -1300,7 +1301,7 @@ func Test_wrappedPlugin_PreScore(t *testing.T) {
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockPreScorePlugin, extender *mock_plugin.MockPreScorePluginExtender) {
 				se.EXPECT().PreScore(gomock.Any(), gomock.Any(), testPod, testNodes).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreScoreResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddPreScoreResult(testPod.UID, "name", resultstore.SuccessMessage)
 			},
 			noExtender: true,
 			want:       framework.NewStatus(framework.Success),
@@ -1330,7 +1331,7 @@ func Test_wrappedPlugin_PreScore(t *testing.T) {
 
 func Test_wrappedPlugin_PreFilter(t *testing.T) {
 	t.Parallel()
-	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace", UID: "uid"}}
 
 	tests := []struct {
 		name           string
@@ -1345,7 +1346,7 @@ func Test_wrappedPlugin_PreFilter(t *testing.T) {
 				extender.EXPECT().BeforePreFilter(gomock.Any(), gomock.Any(), testPod).Return(nil, framework.NewStatus(framework.Success))
 				se.EXPECT().PreFilter(gomock.Any(), gomock.Any(), testPod).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreFilterResult("namespace", "pod", "name", resultstore.SuccessMessage, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
+				s.EXPECT().AddPreFilterResult(testPod.UID, "name", resultstore.SuccessMessage, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
 				extender.EXPECT().AfterPreFilter(gomock.Any(), gomock.Any(), testPod, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Success)).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Success))
 			},
 			want:  &framework.PreFilterResult{NodeNames: sets.NewString("hoge")},
@@ -1365,7 +1366,7 @@ func Test_wrappedPlugin_PreFilter(t *testing.T) {
 				extender.EXPECT().BeforePreFilter(gomock.Any(), gomock.Any(), testPod).Return(nil, framework.NewStatus(framework.Success))
 				se.EXPECT().PreFilter(gomock.Any(), gomock.Any(), testPod).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreFilterResult("namespace", "pod", "name", resultstore.SuccessMessage, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
+				s.EXPECT().AddPreFilterResult(testPod.UID, "name", resultstore.SuccessMessage, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
 				extender.EXPECT().AfterPreFilter(gomock.Any(), gomock.Any(), testPod, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Success)).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Unschedulable))
 			},
 			want:  &framework.PreFilterResult{NodeNames: sets.NewString("hoge")},
@@ -1377,7 +1378,7 @@ func Test_wrappedPlugin_PreFilter(t *testing.T) {
 				extender.EXPECT().BeforePreFilter(gomock.Any(), gomock.Any(), testPod).Return(nil, framework.NewStatus(framework.Success))
 				se.EXPECT().PreFilter(gomock.Any(), gomock.Any(), testPod).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreFilterResult("namespace", "pod", "name", "error", &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
+				s.EXPECT().AddPreFilterResult(testPod.UID, "name", "error", &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
 				extender.EXPECT().AfterPreFilter(gomock.Any(), gomock.Any(), testPod, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Unschedulable, "error")).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Unschedulable))
 			},
 			want:  &framework.PreFilterResult{NodeNames: sets.NewString("hoge")},
@@ -1389,7 +1390,7 @@ func Test_wrappedPlugin_PreFilter(t *testing.T) {
 				extender.EXPECT().BeforePreFilter(gomock.Any(), gomock.Any(), testPod).Return(nil, framework.NewStatus(framework.Success))
 				se.EXPECT().PreFilter(gomock.Any(), gomock.Any(), testPod).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreFilterResult("namespace", "pod", "name", "error", &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
+				s.EXPECT().AddPreFilterResult(testPod.UID, "name", "error", &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
 				extender.EXPECT().AfterPreFilter(gomock.Any(), gomock.Any(), testPod, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Unschedulable, "error")).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge2")}, framework.NewStatus(framework.Success))
 			},
 			want:  &framework.PreFilterResult{NodeNames: sets.NewString("hoge2")},
@@ -1400,7 +1401,7 @@ func Test_wrappedPlugin_PreFilter(t *testing.T) {
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockPreFilterPlugin, extender *mock_plugin.MockPreFilterPluginExtender) {
 				se.EXPECT().PreFilter(gomock.Any(), gomock.Any(), testPod).Return(&framework.PreFilterResult{NodeNames: sets.NewString("hoge")}, framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreFilterResult("namespace", "pod", "name", resultstore.SuccessMessage, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
+				s.EXPECT().AddPreFilterResult(testPod.UID, "name", resultstore.SuccessMessage, &framework.PreFilterResult{NodeNames: sets.NewString("hoge")})
 			},
 			noExtender: true,
 			want:       &framework.PreFilterResult{NodeNames: sets.NewString("hoge")},
@@ -1435,7 +1436,7 @@ func Test_wrappedPlugin_PreFilter(t *testing.T) {
 
 func Test_wrappedPlugin_Permit(t *testing.T) {
 	t.Parallel()
-	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace", UID: "uid"}}
 	testNodeName := "node"
 
 	tests := []struct {
@@ -1451,7 +1452,7 @@ func Test_wrappedPlugin_Permit(t *testing.T) {
 				extender.EXPECT().BeforePermit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success), time.Duration(1))
 				se.EXPECT().Permit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success), time.Duration(1))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPermitResult("namespace", "pod", "name", resultstore.SuccessMessage, time.Duration(1))
+				s.EXPECT().AddPermitResult(testPod.UID, "name", resultstore.SuccessMessage, time.Duration(1))
 				extender.EXPECT().AfterPermit(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Success), time.Duration(1)).Return(framework.NewStatus(framework.Success), time.Duration(1))
 			},
 			want:  framework.NewStatus(framework.Success),
@@ -1471,7 +1472,7 @@ func Test_wrappedPlugin_Permit(t *testing.T) {
 				extender.EXPECT().BeforePermit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success), time.Duration(1))
 				se.EXPECT().Permit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success), time.Duration(1))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPermitResult("namespace", "pod", "name", resultstore.SuccessMessage, time.Duration(1))
+				s.EXPECT().AddPermitResult(testPod.UID, "name", resultstore.SuccessMessage, time.Duration(1))
 				extender.EXPECT().AfterPermit(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Success), time.Duration(1)).Return(framework.NewStatus(framework.Unschedulable), time.Duration(2))
 			},
 			want:  framework.NewStatus(framework.Unschedulable),
@@ -1483,7 +1484,7 @@ func Test_wrappedPlugin_Permit(t *testing.T) {
 				extender.EXPECT().BeforePermit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success), time.Duration(1))
 				se.EXPECT().Permit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable, "error"), time.Duration(1))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPermitResult("namespace", "pod", "name", "error", time.Duration(1))
+				s.EXPECT().AddPermitResult(testPod.UID, "name", "error", time.Duration(1))
 				extender.EXPECT().AfterPermit(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Unschedulable, "error"), time.Duration(1)).Return(framework.NewStatus(framework.Unschedulable), time.Duration(2))
 			},
 			want:  framework.NewStatus(framework.Unschedulable),
@@ -1495,7 +1496,7 @@ func Test_wrappedPlugin_Permit(t *testing.T) {
 				extender.EXPECT().BeforePermit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success), time.Duration(1))
 				se.EXPECT().Permit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable, "error"), time.Duration(1))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPermitResult("namespace", "pod", "name", "error", time.Duration(1))
+				s.EXPECT().AddPermitResult(testPod.UID, "name", "error", time.Duration(1))
 				extender.EXPECT().AfterPermit(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Unschedulable, "error"), time.Duration(1)).Return(framework.NewStatus(framework.Success), time.Duration(2))
 			},
 			want:  framework.NewStatus(framework.Success),
@@ -1506,7 +1507,7 @@ func Test_wrappedPlugin_Permit(t *testing.T) {
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockPermitPlugin, extender *mock_plugin.MockPermitPluginExtender) {
 				se.EXPECT().Permit(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success), time.Duration(1))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPermitResult("namespace", "pod", "name", resultstore.SuccessMessage, time.Duration(1))
+				s.EXPECT().AddPermitResult(testPod.UID, "name", resultstore.SuccessMessage, time.Duration(1))
 			},
 			noExtender: true,
 			want:       framework.NewStatus(framework.Success),
@@ -1539,7 +1540,7 @@ func Test_wrappedPlugin_Permit(t *testing.T) {
 
 func Test_wrappedPlugin_Reserve(t *testing.T) {
 	t.Parallel()
-	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace", UID: "uid"}}
 	testNodeName := "node"
 
 	tests := []struct {
@@ -1551,11 +1552,11 @@ func Test_wrappedPlugin_Reserve(t *testing.T) {
 		{
 			name: "happy with extnder",
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockReservePlugin, extender *mock_plugin.MockReservePluginExtender) {
-				s.EXPECT().AddSelectedNode("namespace", "pod", "node")
+				s.EXPECT().AddSelectedNode(testPod.UID, "node")
 				extender.EXPECT().BeforeReserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Reserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddReserveResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddReserveResult(testPod.UID, "name", resultstore.SuccessMessage)
 				extender.EXPECT().AfterReserve(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Success)).Return(framework.NewStatus(framework.Success))
 			},
 			want: framework.NewStatus(framework.Success),
@@ -1563,7 +1564,7 @@ func Test_wrappedPlugin_Reserve(t *testing.T) {
 		{
 			name: "unhappy: BeforeReserve returns non-success",
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockReservePlugin, extender *mock_plugin.MockReservePluginExtender) {
-				s.EXPECT().AddSelectedNode("namespace", "pod", "node")
+				s.EXPECT().AddSelectedNode(testPod.UID, "node")
 				extender.EXPECT().BeforeReserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1571,11 +1572,11 @@ func Test_wrappedPlugin_Reserve(t *testing.T) {
 		{
 			name: "unhappy: AfterReserve returns non-success",
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockReservePlugin, extender *mock_plugin.MockReservePluginExtender) {
-				s.EXPECT().AddSelectedNode("namespace", "pod", "node")
+				s.EXPECT().AddSelectedNode(testPod.UID, "node")
 				extender.EXPECT().BeforeReserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Reserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddReserveResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddReserveResult(testPod.UID, "name", resultstore.SuccessMessage)
 				extender.EXPECT().AfterReserve(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Success)).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1583,11 +1584,11 @@ func Test_wrappedPlugin_Reserve(t *testing.T) {
 		{
 			name: "unhappy: Reserve and AfterReserve return non-success",
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockReservePlugin, extender *mock_plugin.MockReservePluginExtender) {
-				s.EXPECT().AddSelectedNode("namespace", "pod", "node")
+				s.EXPECT().AddSelectedNode(testPod.UID, "node")
 				extender.EXPECT().BeforeReserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Reserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddReserveResult("namespace", "pod", "name", "error")
+				s.EXPECT().AddReserveResult(testPod.UID, "name", "error")
 				extender.EXPECT().AfterReserve(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Unschedulable, "error")).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1595,11 +1596,11 @@ func Test_wrappedPlugin_Reserve(t *testing.T) {
 		{
 			name: "happy: Reserve returns non-success, but AfterReserve return success",
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockReservePlugin, extender *mock_plugin.MockReservePluginExtender) {
-				s.EXPECT().AddSelectedNode("namespace", "pod", "node")
+				s.EXPECT().AddSelectedNode(testPod.UID, "node")
 				extender.EXPECT().BeforeReserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Reserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddReserveResult("namespace", "pod", "name", "error")
+				s.EXPECT().AddReserveResult(testPod.UID, "name", "error")
 				extender.EXPECT().AfterReserve(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Unschedulable, "error")).Return(framework.NewStatus(framework.Success))
 			},
 			want: framework.NewStatus(framework.Success),
@@ -1607,10 +1608,10 @@ func Test_wrappedPlugin_Reserve(t *testing.T) {
 		{
 			name: "happy without extnder",
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockReservePlugin, extender *mock_plugin.MockReservePluginExtender) {
-				s.EXPECT().AddSelectedNode("namespace", "pod", "node")
+				s.EXPECT().AddSelectedNode(testPod.UID, "node")
 				se.EXPECT().Reserve(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddReserveResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddReserveResult(testPod.UID, "name", resultstore.SuccessMessage)
 			},
 			noExtender: true,
 			want:       framework.NewStatus(framework.Success),
@@ -1640,7 +1641,7 @@ func Test_wrappedPlugin_Reserve(t *testing.T) {
 
 func Test_wrappedPlugin_Unreserve(t *testing.T) {
 	t.Parallel()
-	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace", UID: "uid"}}
 	testNodeName := "node"
 
 	tests := []struct {
@@ -1695,7 +1696,7 @@ func Test_wrappedPlugin_Unreserve(t *testing.T) {
 
 func Test_wrappedPlugin_PreBind(t *testing.T) {
 	t.Parallel()
-	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace", UID: "uid"}}
 	testNodeName := "node"
 
 	tests := []struct {
@@ -1710,7 +1711,7 @@ func Test_wrappedPlugin_PreBind(t *testing.T) {
 				extender.EXPECT().BeforePreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreBindResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddPreBindResult(testPod.UID, "name", resultstore.SuccessMessage)
 				extender.EXPECT().AfterPreBind(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Success)).Return(framework.NewStatus(framework.Success))
 			},
 			want: framework.NewStatus(framework.Success),
@@ -1728,7 +1729,7 @@ func Test_wrappedPlugin_PreBind(t *testing.T) {
 				extender.EXPECT().BeforePreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreBindResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddPreBindResult(testPod.UID, "name", resultstore.SuccessMessage)
 				extender.EXPECT().AfterPreBind(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Success)).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1739,7 +1740,7 @@ func Test_wrappedPlugin_PreBind(t *testing.T) {
 				extender.EXPECT().BeforePreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreBindResult("namespace", "pod", "name", "error")
+				s.EXPECT().AddPreBindResult(testPod.UID, "name", "error")
 				extender.EXPECT().AfterPreBind(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Unschedulable, "error")).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1750,7 +1751,7 @@ func Test_wrappedPlugin_PreBind(t *testing.T) {
 				extender.EXPECT().BeforePreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreBindResult("namespace", "pod", "name", "error")
+				s.EXPECT().AddPreBindResult(testPod.UID, "name", "error")
 				extender.EXPECT().AfterPreBind(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Unschedulable, "error")).Return(framework.NewStatus(framework.Success))
 			},
 			want: framework.NewStatus(framework.Success),
@@ -1760,7 +1761,7 @@ func Test_wrappedPlugin_PreBind(t *testing.T) {
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockPreBindPlugin, extender *mock_plugin.MockPreBindPluginExtender) {
 				se.EXPECT().PreBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddPreBindResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddPreBindResult(testPod.UID, "name", resultstore.SuccessMessage)
 			},
 			noExtender: true,
 			want:       framework.NewStatus(framework.Success),
@@ -1790,7 +1791,7 @@ func Test_wrappedPlugin_PreBind(t *testing.T) {
 
 func Test_wrappedPlugin_Bind(t *testing.T) {
 	t.Parallel()
-	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace", UID: "uid"}}
 	testNodeName := "node"
 
 	tests := []struct {
@@ -1805,7 +1806,7 @@ func Test_wrappedPlugin_Bind(t *testing.T) {
 				extender.EXPECT().BeforeBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Bind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddBindResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddBindResult(testPod.UID, "name", resultstore.SuccessMessage)
 				extender.EXPECT().AfterBind(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Success)).Return(framework.NewStatus(framework.Success))
 			},
 			want: framework.NewStatus(framework.Success),
@@ -1823,7 +1824,7 @@ func Test_wrappedPlugin_Bind(t *testing.T) {
 				extender.EXPECT().BeforeBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Bind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddBindResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddBindResult(testPod.UID, "name", resultstore.SuccessMessage)
 				extender.EXPECT().AfterBind(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Success)).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1834,7 +1835,7 @@ func Test_wrappedPlugin_Bind(t *testing.T) {
 				extender.EXPECT().BeforeBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Bind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddBindResult("namespace", "pod", "name", "error")
+				s.EXPECT().AddBindResult(testPod.UID, "name", "error")
 				extender.EXPECT().AfterBind(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Unschedulable, "error")).Return(framework.NewStatus(framework.Unschedulable))
 			},
 			want: framework.NewStatus(framework.Unschedulable),
@@ -1845,7 +1846,7 @@ func Test_wrappedPlugin_Bind(t *testing.T) {
 				extender.EXPECT().BeforeBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Bind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Unschedulable, "error"))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddBindResult("namespace", "pod", "name", "error")
+				s.EXPECT().AddBindResult(testPod.UID, "name", "error")
 				extender.EXPECT().AfterBind(gomock.Any(), gomock.Any(), testPod, testNodeName, framework.NewStatus(framework.Unschedulable, "error")).Return(framework.NewStatus(framework.Success))
 			},
 			want: framework.NewStatus(framework.Success),
@@ -1855,7 +1856,7 @@ func Test_wrappedPlugin_Bind(t *testing.T) {
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockBindPlugin, extender *mock_plugin.MockBindPluginExtender) {
 				se.EXPECT().Bind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().Name().Return("name")
-				s.EXPECT().AddBindResult("namespace", "pod", "name", resultstore.SuccessMessage)
+				s.EXPECT().AddBindResult(testPod.UID, "name", resultstore.SuccessMessage)
 			},
 			noExtender: true,
 			want:       framework.NewStatus(framework.Success),
@@ -1885,7 +1886,7 @@ func Test_wrappedPlugin_Bind(t *testing.T) {
 
 func Test_wrappedPlugin_PostBind(t *testing.T) {
 	t.Parallel()
-	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+	testPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace", UID: "uid"}}
 	testNodeName := "node"
 
 	tests := []struct {
@@ -1898,6 +1899,8 @@ func Test_wrappedPlugin_PostBind(t *testing.T) {
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockPostBindPlugin, extender *mock_plugin.MockPostBindPluginExtender) {
 				extender.EXPECT().BeforePostBind(gomock.Any(), gomock.Any(), testPod, testNodeName).Return(framework.NewStatus(framework.Success))
 				se.EXPECT().PostBind(gomock.Any(), gomock.Any(), testPod, testNodeName)
+				se.EXPECT().Name().Return("fakePostBindPlugin")
+				s.EXPECT().AddPostBindResult(testPod.UID, "fakePostBindPlugin", resultstore.SuccessMessage)
 				extender.EXPECT().AfterPostBind(gomock.Any(), gomock.Any(), testPod, testNodeName)
 			},
 		},
@@ -1912,6 +1915,8 @@ func Test_wrappedPlugin_PostBind(t *testing.T) {
 			name: "happy without extender",
 			prepareMocksFn: func(s *mock_plugin.MockStore, se *mock_plugin.MockPostBindPlugin, extender *mock_plugin.MockPostBindPluginExtender) {
 				se.EXPECT().PostBind(gomock.Any(), gomock.Any(), testPod, testNodeName)
+				se.EXPECT().Name().Return("fakePostBindPlugin")
+				s.EXPECT().AddPostBindResult(testPod.UID, "fakePostBindPlugin", resultstore.SuccessMessage)
 			},
 			noExtender: true,
 		},
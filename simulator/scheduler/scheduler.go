@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"time"
 
 	"golang.org/x/xerrors"
 	v1 "k8s.io/api/core/v1"
@@ -18,6 +19,7 @@ import (
 	"k8s.io/kubernetes/pkg/scheduler/apis/config"
 	"k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
 	"k8s.io/kubernetes/pkg/scheduler/apis/config/v1beta2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
 	"k8s.io/kubernetes/pkg/scheduler/profile"
 
 	simulatorschedconfig "sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
@@ -43,6 +45,8 @@ type Service struct {
 	extenderService     ExtenderService
 	sharedStore         storereflector.Reflector
 	simulatorPort       int
+	// gate holds scheduling cycles while the scheduler is paused.
+	gate *cycleGate
 }
 
 type ExtenderService interface {
@@ -55,16 +59,17 @@ type ExtenderService interface {
 var ErrServiceDisabled = errors.New("scheduler service is disabled")
 
 // NewSchedulerService starts scheduler and return *Service.
-func NewSchedulerService(client clientset.Interface, restclientCfg *restclient.Config, initialSchedulerCfg *v1beta2config.KubeSchedulerConfiguration, externalSchedulerEnabled bool, simulatorPort int) *Service {
+// resultRecorder records the scheduling results reflected on Pods. It can be nil.
+func NewSchedulerService(client clientset.Interface, restclientCfg *restclient.Config, initialSchedulerCfg *v1beta2config.KubeSchedulerConfiguration, externalSchedulerEnabled bool, simulatorPort int, resultRecorder storereflector.ResultRecorder) *Service {
 	if externalSchedulerEnabled {
 		return &Service{disabled: true}
 	}
 
 	// sharedStore has some resultstores which are referenced by Registry of Plugins and Extenders.
-	sharedStore := storereflector.New()
+	sharedStore := storereflector.New(resultRecorder)
 
 	initCfg := initialSchedulerCfg.DeepCopy()
-	return &Service{clientset: client, restclientCfg: restclientCfg, initialSchedulerCfg: initCfg, sharedStore: sharedStore, simulatorPort: simulatorPort}
+	return &Service{clientset: client, restclientCfg: restclientCfg, initialSchedulerCfg: initCfg, sharedStore: sharedStore, simulatorPort: simulatorPort, gate: newCycleGate()}
 }
 
 func (s *Service) RestartScheduler(cfg *v1beta2config.KubeSchedulerConfiguration) error {
@@ -115,6 +120,12 @@ func (s *Service) StartScheduler(versionedcfg *v1beta2config.KubeSchedulerConfig
 
 	s.currentSchedulerCfg = versionedcfg.DeepCopy()
 
+	if ignored, err := IgnoredFields(versionedcfg); err != nil {
+		klog.Warningf("failed to check ignored fields in the scheduler configuration: %v", err)
+	} else if len(ignored) != 0 {
+		klog.Warningf("the following fields in the scheduler configuration are ignored in the simulator: %v", ignored)
+	}
+
 	var err error
 	// Extender service must be initialized using unconverted config.
 	s.extenderService, err = extender.New(clientSet, versionedcfg.Extenders, s.sharedStore)
@@ -156,6 +167,12 @@ func (s *Service) StartScheduler(versionedcfg *v1beta2config.KubeSchedulerConfig
 	if err != nil {
 		return xerrors.Errorf("create scheduler: %w", err)
 	}
+	// Make the scheduler wait for the gate before popping the next Pod so that the scheduling can be paused.
+	sched.NextPod = s.gate.wrapNextPod(ctx, sched.NextPod)
+	if s.sharedStore != nil {
+		sched.NextPod = s.wrapNextPodToStartAttempt(ctx, sched.NextPod)
+		sched.FailureHandler = s.wrapFailureHandler(sched.FailureHandler)
+	}
 
 	informerFactory.Start(ctx.Done())
 	if dynInformerFactory != nil {
@@ -171,6 +188,28 @@ func (s *Service) StartScheduler(versionedcfg *v1beta2config.KubeSchedulerConfig
 	return nil
 }
 
+// wrapNextPodToStartAttempt returns the NextPod function which tells the sharedStore that a scheduling attempt of the Pod starts
+// so that the results of each attempt are reflected and recorded separately.
+func (s *Service) wrapNextPodToStartAttempt(ctx context.Context, next func() *framework.QueuedPodInfo) func() *framework.QueuedPodInfo {
+	return func() *framework.QueuedPodInfo {
+		podInfo := next()
+		if podInfo != nil && podInfo.Pod != nil {
+			s.sharedStore.StartAttempt(ctx, podInfo.Pod, podInfo.Attempts, time.Now())
+		}
+		return podInfo
+	}
+}
+
+// wrapFailureHandler returns the FailureHandler which reflects the results on the Pod before handling the failure.
+// The failed Pod isn't always updated by the scheduler (e.g., when it fails for the same reason again),
+// so the results of each failed attempt are reflected here not to be mixed with the results of the next attempt.
+func (s *Service) wrapFailureHandler(handler scheduler.FailureHandlerFn) scheduler.FailureHandlerFn {
+	return func(ctx context.Context, fwk framework.Framework, podInfo *framework.QueuedPodInfo, err error, reason string, nominatingInfo *framework.NominatingInfo, start time.Time) {
+		s.sharedStore.ReflectResultsToPod(ctx, s.clientset, podInfo.Pod)
+		handler(ctx, fwk, podInfo, err, reason, nominatingInfo, start)
+	}
+}
+
 func (s *Service) ShutdownScheduler() {
 	if s.shutdownfn != nil {
 		klog.Info("shutdown scheduler...")
@@ -178,6 +217,49 @@ func (s *Service) ShutdownScheduler() {
 	}
 }
 
+// PauseScheduler makes the scheduler stop scheduling Pods.
+// Pods stay in the scheduling queue until the scheduler is resumed or the scheduling cycle is released by StepScheduler.
+func (s *Service) PauseScheduler() error {
+	if s.disabled {
+		return xerrors.Errorf("an external scheduler is enabled: %w", ErrServiceDisabled)
+	}
+
+	s.gate.pause()
+	return nil
+}
+
+// ResumeScheduler makes the paused scheduler schedule Pods again.
+func (s *Service) ResumeScheduler() error {
+	if s.disabled {
+		return xerrors.Errorf("an external scheduler is enabled: %w", ErrServiceDisabled)
+	}
+
+	s.gate.resume()
+	return nil
+}
+
+// StepScheduler releases one scheduling cycle of the paused scheduler.
+// It returns ErrSchedulerNotPaused if the scheduler isn't paused.
+func (s *Service) StepScheduler(ctx context.Context) error {
+	if s.disabled {
+		return xerrors.Errorf("an external scheduler is enabled: %w", ErrServiceDisabled)
+	}
+
+	if err := s.gate.step(ctx); err != nil {
+		return xerrors.Errorf("release a scheduling cycle: %w", err)
+	}
+	return nil
+}
+
+// SchedulerPaused returns whether the scheduler is paused.
+func (s *Service) SchedulerPaused() (bool, error) {
+	if s.disabled {
+		return false, xerrors.Errorf("an external scheduler is enabled: %w", ErrServiceDisabled)
+	}
+
+	return s.gate.isPaused(), nil
+}
+
 func (s *Service) GetSchedulerConfig() (*v1beta2config.KubeSchedulerConfiguration, error) {
 	if s.disabled {
 		return nil, xerrors.Errorf("an external scheduler is enabled: %w", ErrServiceDisabled)
@@ -192,7 +274,8 @@ func (s *Service) ExtenderService() ExtenderService {
 }
 
 // convertConfigurationForSimulator convert KubeSchedulerConfiguration to apply scheduler on simulator
-// (1) It excludes non-allowed changes. Now, we accept only changes to Profiles.Plugins field.
+// (1) It excludes non-allowed changes. Now, we accept only changes to Profiles, Extenders,
+// PercentageOfNodesToScore, Parallelism, PodInitialBackoffSeconds and PodMaxBackoffSeconds. (See also IgnoredFields.)
 // (2) It replaces all default-plugins with plugins for simulator.
 // (3) It replaces Extenders config so that the connection is directed to the simulator server.
 // (4) It converts KubeSchedulerConfiguration from v1beta2config.KubeSchedulerConfiguration to config.KubeSchedulerConfiguration.
@@ -233,9 +316,14 @@ func convertConfigurationForSimulator(versioned *v1beta2config.KubeSchedulerConf
 		return nil, xerrors.Errorf("get default scheduler config: %w", err)
 	}
 
-	// set default value to all field other than Profiles and Extenders.
+	// set default value to all field other than Profiles, Extenders and the fields which affect the scheduling.
+	// Unset fields are defaulted by SetDefaults_KubeSchedulerConfiguration below.
 	defaultCfg.Profiles = versioned.Profiles
 	defaultCfg.Extenders = versioned.Extenders
+	defaultCfg.PercentageOfNodesToScore = versioned.PercentageOfNodesToScore
+	defaultCfg.Parallelism = versioned.Parallelism
+	defaultCfg.PodInitialBackoffSeconds = versioned.PodInitialBackoffSeconds
+	defaultCfg.PodMaxBackoffSeconds = versioned.PodMaxBackoffSeconds
 	versioned = defaultCfg
 
 	v1beta2.SetDefaults_KubeSchedulerConfiguration(versioned)
@@ -245,5 +333,27 @@ func convertConfigurationForSimulator(versioned *v1beta2config.KubeSchedulerConf
 	}
 	cfg.SetGroupVersionKind(v1beta2config.SchemeGroupVersion.WithKind("KubeSchedulerConfiguration"))
 
+	if err := validateSchedulingFields(&cfg); err != nil {
+		return nil, xerrors.Errorf("validate configuration: %w", err)
+	}
+
 	return &cfg, nil
 }
+
+// validateSchedulingFields validates the fields which affect the scheduling other than Profiles and Extenders.
+// The validation is the same as the one in kube-scheduler.
+func validateSchedulingFields(cfg *config.KubeSchedulerConfiguration) error {
+	if cfg.Parallelism <= 0 {
+		return xerrors.Errorf("parallelism must be greater than 0, but got %d", cfg.Parallelism)
+	}
+	if cfg.PercentageOfNodesToScore != nil && (*cfg.PercentageOfNodesToScore < 0 || *cfg.PercentageOfNodesToScore > 100) {
+		return xerrors.Errorf("percentageOfNodesToScore must be in the range [0, 100], but got %d", *cfg.PercentageOfNodesToScore)
+	}
+	if cfg.PodInitialBackoffSeconds <= 0 {
+		return xerrors.Errorf("podInitialBackoffSeconds must be greater than 0, but got %d", cfg.PodInitialBackoffSeconds)
+	}
+	if cfg.PodMaxBackoffSeconds < cfg.PodInitialBackoffSeconds {
+		return xerrors.Errorf("podMaxBackoffSeconds must be greater than or equal to podInitialBackoffSeconds, but got %d", cfg.PodMaxBackoffSeconds)
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
 	"k8s.io/kubernetes/pkg/scheduler/apis/config"
 	"k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
+	"k8s.io/utils/pointer"
 
 	schedConfig "sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
 )
@@ -87,7 +88,7 @@ func Test_convertConfigurationForSimulator(t *testing.T) {
 			}(),
 		},
 		{
-			name: "changes of field other than Profiles and Extenders does not affects result",
+			name: "changes of field other than Profiles and Extenders does not affects plugins",
 			args: args{
 				versioned: &v1beta2config.KubeSchedulerConfiguration{
 					Parallelism: &nondefaultParallelism,
@@ -116,7 +117,7 @@ func Test_convertConfigurationForSimulator(t *testing.T) {
 			}(),
 		},
 		{
-			name: "changes of field other than Profiles.Plugins and Extenders does not affects result",
+			name: "changes of field other than Profiles.Plugins and Extenders does not affects plugins",
 			args: args{
 				versioned: &v1beta2config.KubeSchedulerConfiguration{
 					Parallelism: &nondefaultParallelism,
@@ -420,6 +421,9 @@ func configGeneratedFromDefault() config.KubeSchedulerConfiguration {
 	cfg.Profiles[0].Plugins.PreBind.Enabled = []v1beta2config.Plugin{
 		{Name: "VolumeBindingWrapped"},
 	}
+	cfg.Profiles[0].Plugins.PostBind.Enabled = []v1beta2config.Plugin{
+		{Name: "SimulatorResultFlusher"},
+	}
 	cfg.Profiles[0].Plugins.PreScore.Enabled = []v1beta2config.Plugin{
 		{Name: "InterPodAffinityWrapped"},
 		{Name: "PodTopologySpreadWrapped"},
@@ -488,3 +492,81 @@ func configGeneratedFromDefault() config.KubeSchedulerConfiguration {
 	}
 	return converted
 }
+
+func Test_convertConfigurationForSimulator_schedulingFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		versioned *v1beta2config.KubeSchedulerConfiguration
+		wantFunc  func(cfg *config.KubeSchedulerConfiguration)
+		wantErr   bool
+	}{
+		{
+			name:      "unset fields are defaulted",
+			versioned: &v1beta2config.KubeSchedulerConfiguration{},
+			wantFunc: func(cfg *config.KubeSchedulerConfiguration) {
+				cfg.Parallelism = 16
+				cfg.PercentageOfNodesToScore = pointer.Int32(0)
+				cfg.PodInitialBackoffSeconds = 1
+				cfg.PodMaxBackoffSeconds = 10
+			},
+		},
+		{
+			name: "the fields which affect the scheduling are applied",
+			versioned: &v1beta2config.KubeSchedulerConfiguration{
+				Parallelism:              pointer.Int32(3),
+				PercentageOfNodesToScore: pointer.Int32(50),
+				PodInitialBackoffSeconds: pointer.Int64(5),
+				PodMaxBackoffSeconds:     pointer.Int64(20),
+			},
+			wantFunc: func(cfg *config.KubeSchedulerConfiguration) {
+				cfg.Parallelism = 3
+				cfg.PercentageOfNodesToScore = pointer.Int32(50)
+				cfg.PodInitialBackoffSeconds = 5
+				cfg.PodMaxBackoffSeconds = 20
+			},
+		},
+		{
+			name: "fail with invalid parallelism",
+			versioned: &v1beta2config.KubeSchedulerConfiguration{
+				Parallelism: pointer.Int32(-1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail with invalid percentageOfNodesToScore",
+			versioned: &v1beta2config.KubeSchedulerConfiguration{
+				PercentageOfNodesToScore: pointer.Int32(101),
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail when podMaxBackoffSeconds is smaller than podInitialBackoffSeconds",
+			versioned: &v1beta2config.KubeSchedulerConfiguration{
+				PodInitialBackoffSeconds: pointer.Int64(5),
+				PodMaxBackoffSeconds:     pointer.Int64(3),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := convertConfigurationForSimulator(tt.versioned, 80)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			want := got.DeepCopy()
+			tt.wantFunc(want)
+			assert.Equal(t, want.Parallelism, got.Parallelism)
+			assert.Equal(t, want.PercentageOfNodesToScore, got.PercentageOfNodesToScore)
+			assert.Equal(t, want.PodInitialBackoffSeconds, got.PodInitialBackoffSeconds)
+			assert.Equal(t, want.PodMaxBackoffSeconds, got.PodMaxBackoffSeconds)
+		})
+	}
+}
@@ -1,11 +1,13 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/storereflector (interfaces: ResultStore)
+// Source: sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/storereflector (interfaces: ResultStore,ResultRecorder)
 
 // Package mock_storereflector is a generated GoMock package.
 package mock_storereflector
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/core/v1"
@@ -57,3 +59,40 @@ func (mr *MockResultStoreMockRecorder) DeleteData(arg0 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteData", reflect.TypeOf((*MockResultStore)(nil).DeleteData), arg0)
 }
+
+// MockResultRecorder is a mock of ResultRecorder interface.
+type MockResultRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockResultRecorderMockRecorder
+}
+
+// MockResultRecorderMockRecorder is the mock recorder for MockResultRecorder.
+type MockResultRecorderMockRecorder struct {
+	mock *MockResultRecorder
+}
+
+// NewMockResultRecorder creates a new mock instance.
+func NewMockResultRecorder(ctrl *gomock.Controller) *MockResultRecorder {
+	mock := &MockResultRecorder{ctrl: ctrl}
+	mock.recorder = &MockResultRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResultRecorder) EXPECT() *MockResultRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockResultRecorder) Record(arg0 context.Context, arg1 *v1.Pod, arg2 int, arg3 time.Time, arg4 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockResultRecorderMockRecorder) Record(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockResultRecorder)(nil).Record), arg0, arg1, arg2, arg3, arg4)
+}
@@ -1,14 +1,18 @@
 package storereflector
 
-//go:generate mockgen -destination=./mock_$GOPACKAGE/resultstore.go . ResultStore
+//go:generate mockgen -destination=./mock_$GOPACKAGE/resultstore.go . ResultStore,ResultRecorder
 
 import (
 	"context"
+	"strconv"
+	"sync"
+	"time"
 
 	"golang.org/x/xerrors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -17,9 +21,22 @@ import (
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
 )
 
+// AttemptAnnotationKey has the index of the scheduling attempt whose results are reflected on the Pod.
+// The index starts from 1, and it's reset when the scheduler is restarted.
+const AttemptAnnotationKey = "scheduler-simulator/attempt"
+
+// AttemptTimestampAnnotationKey has the time when the scheduling attempt whose results are reflected on the Pod started.
+const AttemptTimestampAnnotationKey = "scheduler-simulator/attempt-timestamp"
+
+// recordQueueSize is the number of the results that can wait to be recorded.
+// The reflection waits for the recorder only when it falls behind by this many results.
+const recordQueueSize = 1000
+
 type Reflector interface {
 	AddResultStore(store ResultStore, key string)
 	ResisterResultSavingToInformer(informerFactory informers.SharedInformerFactory, client clientset.Interface) error
+	ReflectResultsToPod(ctx context.Context, client clientset.Interface, pod *corev1.Pod)
+	StartAttempt(ctx context.Context, pod *corev1.Pod, index int, startedAt time.Time)
 }
 
 // ResultStore represents the store which is stores data and shared with simulator and scheduler.
@@ -32,16 +49,81 @@ type ResultStore interface {
 	DeleteData(key corev1.Pod)
 }
 
+// ResultRecorder records the results reflected on the Pod so that they are kept after the Pod is updated or deleted.
+type ResultRecorder interface {
+	// Record records the results of the scheduling attempt of the pod. results are keyed by the annotation key.
+	// attempt is 0 and startedAt is zero when the attempt is unknown.
+	Record(ctx context.Context, pod *corev1.Pod, attempt int, startedAt time.Time, results map[string]string) error
+}
+
 // store manages any ResultStore.
 // ResultStore stores any result that should be reflected to the Pod.
 type reflector struct {
 	resultStores map[string]ResultStore
+	// recorder is nil when the results don't need to be recorded.
+	recorder ResultRecorder
+	// records is the queue of the results to be recorded with the recorder in the background.
+	// It's nil when the recorder is nil.
+	records chan record
+	// recording counts the results which are queued but not recorded yet.
+	recording sync.WaitGroup
+
+	// mu guards attempts and podLocks. It's never held while the Pods are updated or the results are recorded.
+	mu sync.Mutex
+	// attempts has the latest scheduling attempt of each Pod, keyed by the Pod UID
+	// so that a recreated Pod with the same name doesn't take over the attempt.
+	attempts map[types.UID]attempt
+	// podLocks serialize the reflection of each Pod so that the same results aren't recorded twice,
+	// without blocking the reflection of the other Pods. They're keyed by the Pod UID.
+	podLocks map[types.UID]*podLock
+}
+
+// record is the results of a scheduling attempt of a Pod which are waiting to be recorded.
+type record struct {
+	pod     *corev1.Pod
+	attempt attempt
+	results map[string]string
+}
+
+// podLock is the lock of a Pod, which is deleted when no one holds or waits for it.
+type podLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// attempt is a scheduling attempt of a Pod.
+type attempt struct {
+	index     int
+	startedAt time.Time
 }
 
-func New() Reflector {
-	return &reflector{
+// New initializes Reflector.
+// The recorder can be nil.
+func New(recorder ResultRecorder) Reflector {
+	r := &reflector{
 		resultStores: map[string]ResultStore{},
+		attempts:     map[types.UID]attempt{},
+		podLocks:     map[types.UID]*podLock{},
 	}
+	if recorder != nil {
+		r.startRecording(recorder)
+	}
+	return r
+}
+
+// startRecording starts recording the queued results with the recorder in the background.
+// The results are recorded one by one in the order they're queued.
+func (s *reflector) startRecording(recorder ResultRecorder) {
+	s.recorder = recorder
+	s.records = make(chan record, recordQueueSize)
+	go func() {
+		for r := range s.records {
+			if err := s.recorder.Record(context.Background(), r.pod, r.attempt.index, r.attempt.startedAt, r.results); err != nil {
+				klog.Errorf("failed to record the scheduling results of the pod: %+v", err)
+			}
+			s.recording.Done()
+		}
+	}()
 }
 
 // AddResultStore adds the ResultStore to the map.
@@ -57,6 +139,7 @@ func (s *reflector) ResisterResultSavingToInformer(informerFactory informers.Sha
 	_, err := informerFactory.Core().V1().Pods().Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			UpdateFunc: s.storeAllResultToPodFunc(client),
+			DeleteFunc: s.forgetAttemptFunc(),
 		},
 	)
 	if err != nil {
@@ -65,55 +148,209 @@ func (s *reflector) ResisterResultSavingToInformer(informerFactory informers.Sha
 	return nil
 }
 
+// StartAttempt is called when the scheduler starts a scheduling attempt of the pod.
+// The results stored after that are reflected as the results of the attempt.
+//
+// If the results of the previous attempt still remain, (e.g., the pod couldn't be updated)
+// they're recorded and deleted here so that they aren't mixed with the results of the new attempt.
+//
+// It only waits for the reflection of the same pod, so the scheduling of the other Pods isn't blocked.
+func (s *reflector) StartAttempt(ctx context.Context, pod *corev1.Pod, index int, startedAt time.Time) {
+	k := attemptKey(pod)
+	unlock := s.lockPod(k)
+	defer unlock()
+
+	if results := s.collectResults(pod); len(results) != 0 {
+		s.recordResults(pod, s.attempt(k), results)
+		for key := range s.resultStores {
+			s.resultStores[key].DeleteData(*pod)
+		}
+	}
+	s.mu.Lock()
+	s.attempts[k] = attempt{index: index, startedAt: startedAt}
+	s.mu.Unlock()
+}
+
+// lockPod locks the pod of the key, and returns the function to unlock it.
+func (s *reflector) lockPod(key types.UID) func() {
+	s.mu.Lock()
+	l, ok := s.podLocks[key]
+	if !ok {
+		l = &podLock{}
+		s.podLocks[key] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(s.podLocks, key)
+		}
+	}
+}
+
+// attempt returns the latest scheduling attempt of the pod of the key.
+func (s *reflector) attempt(key types.UID) attempt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts[key]
+}
+
+// forgetAttemptFunc returns the function that deletes the attempt and the stored results of the deleted pod.
+// They're keyed by the Pod UID, so they'd be never used again.
+// It will be used as the event handler of resource deleting.
+func (s *reflector) forgetAttemptFunc() func(interface{}) {
+	return func(obj interface{}) {
+		if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = d.Obj
+		}
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			klog.ErrorS(nil, "Cannot convert to *v1.Pod", "obj", obj)
+			return
+		}
+
+		k := attemptKey(pod)
+		unlock := s.lockPod(k)
+		defer unlock()
+
+		for key := range s.resultStores {
+			s.resultStores[key].DeleteData(*pod)
+		}
+		s.mu.Lock()
+		delete(s.attempts, k)
+		s.mu.Unlock()
+	}
+}
+
 // storeAllResultToPodFunc returns the function that reflects all results on the pod annotation when the scheduling is finished.
 // It will be used as the even handler of resource updating.
 func (s *reflector) storeAllResultToPodFunc(client clientset.Interface) func(interface{}, interface{}) {
 	return func(_, newObj interface{}) {
-		ctx := context.Background()
 		pod, ok := newObj.(*corev1.Pod)
 		if !ok {
 			klog.ErrorS(nil, "Cannot convert to *v1.Pod", "obj", newObj)
 			return
 		}
 
-		updateFunc := func() (bool, error) {
-			// Fetch the latest Pod object and apply changes to it. Otherwise, our update may be
-			// rejected due to our copy being stale. This also ensures we don't modify the copy from
-			// the shared informer.
-			newPod, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
-			if err != nil {
-				return false, xerrors.Errorf("get pod: %w", err)
-			}
-			if newPod.UID != pod.UID {
-				return false, xerrors.Errorf("pod UID is different: %s != %s", newPod.UID, pod.UID)
-			}
+		s.ReflectResultsToPod(context.Background(), client, pod)
+	}
+}
+
+// ReflectResultsToPod reflects all results of the pod on the pod annotation and records them with the ResultRecorder.
+// It doesn't mutate the given pod.
+//
+// When the pod annotation has the results of a previous attempt, they're replaced with the results of the latest attempt.
+// Otherwise, the results are added to the annotation. (e.g., PostBind results which are stored after the others are reflected.)
+// The results are recorded only after the pod is updated. Otherwise, they're kept in the ResultStores,
+// and they're recorded when they're reflected next time or the next attempt starts.
+func (s *reflector) ReflectResultsToPod(ctx context.Context, client clientset.Interface, pod *corev1.Pod) {
+	k := attemptKey(pod)
+	unlock := s.lockPod(k)
+	defer unlock()
 
+	results := s.collectResults(pod)
+	if len(results) == 0 {
+		// no results are stored for the pod.
+		return
+	}
+	a := s.attempt(k)
+
+	updateFunc := func() (bool, error) {
+		// Fetch the latest Pod object and apply changes to it. Otherwise, our update may be
+		// rejected due to our copy being stale. This also ensures we don't modify the copy from
+		// the shared informer.
+		newPod, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, xerrors.Errorf("get pod: %w", err)
+		}
+		if newPod.UID != pod.UID {
+			return false, xerrors.Errorf("pod UID is different: %s != %s", newPod.UID, pod.UID)
+		}
+
+		if a.isReflectedOn(newPod) || a.index == 0 {
 			// Call AddStoredResultToPod of all ResultStore which is added to the map
 			// to reflects all results on the pod annotation.
 			for k := range s.resultStores {
 				s.resultStores[k].AddStoredResultToPod(newPod)
 			}
-
-			_, err = client.CoreV1().Pods(newPod.Namespace).Update(ctx, newPod, metav1.UpdateOptions{})
-			if err != nil {
-				// Even though we fetched the latest Pod object, we still might get a conflict
-				// because of a concurrent update. Retrying these conflict errors will usually help
-				// as long as we re-fetch the latest Pod object each time.
-				if apierrors.IsConflict(err) {
-					return false, nil
-				}
-				return false, xerrors.Errorf("update pod: %w", err)
+		} else {
+			for k, v := range results {
+				metav1.SetMetaDataAnnotation(&newPod.ObjectMeta, k, v)
 			}
-			return true, nil
-		}
-		if err := util.RetryWithExponentialBackOff(updateFunc); err != nil {
-			klog.Errorf("failed to update the pod with retry to record store: %+v", err)
-			return
+			a.setAnnotations(newPod)
 		}
 
-		for k := range s.resultStores {
-			// Delete the data from the Reflector only if it is successfully added on the pod's annotations.
-			s.resultStores[k].DeleteData(*pod)
+		_, err = client.CoreV1().Pods(newPod.Namespace).Update(ctx, newPod, metav1.UpdateOptions{})
+		if err != nil {
+			// Even though we fetched the latest Pod object, we still might get a conflict
+			// because of a concurrent update. Retrying these conflict errors will usually help
+			// as long as we re-fetch the latest Pod object each time.
+			if apierrors.IsConflict(err) {
+				return false, nil
+			}
+			return false, xerrors.Errorf("update pod: %w", err)
 		}
+		return true, nil
 	}
+	if err := util.RetryWithExponentialBackOff(updateFunc); err != nil {
+		klog.Errorf("failed to update the pod with retry to record store: %+v", err)
+		return
+	}
+	s.recordResults(pod, a, results)
+
+	for k := range s.resultStores {
+		// Delete the data from the Reflector only if it is successfully added on the pod's annotations.
+		s.resultStores[k].DeleteData(*pod)
+	}
+}
+
+// collectResults collects the results of the pod stored in all ResultStore.
+// The results are collected on an empty Pod so that only the stored results are returned
+// even if the pod annotation already has the previous results.
+// Note: we assume the lock of the pod is already acquired.
+func (s *reflector) collectResults(pod *corev1.Pod) map[string]string {
+	p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace, UID: pod.UID}}
+	for k := range s.resultStores {
+		s.resultStores[k].AddStoredResultToPod(p)
+	}
+	return p.Annotations
+}
+
+// recordResults queues the results of the pod to be recorded with the ResultRecorder in the background,
+// so that the scheduling doesn't wait for the recorder to write them.
+// It blocks only when the queue is full.
+// Note: we assume the lock of the pod is already acquired.
+func (s *reflector) recordResults(pod *corev1.Pod, a attempt, results map[string]string) {
+	if s.records == nil {
+		return
+	}
+
+	s.recording.Add(1)
+	s.records <- record{pod: pod.DeepCopy(), attempt: a, results: results}
+}
+
+func attemptKey(pod *corev1.Pod) types.UID {
+	return pod.UID
+}
+
+// isReflectedOn checks whether the results of the attempt are already reflected on the pod.
+func (a attempt) isReflectedOn(pod *corev1.Pod) bool {
+	annotations := pod.GetAnnotations()
+	return annotations[AttemptAnnotationKey] == strconv.Itoa(a.index) &&
+		annotations[AttemptTimestampAnnotationKey] == a.timestamp()
+}
+
+func (a attempt) setAnnotations(pod *corev1.Pod) {
+	metav1.SetMetaDataAnnotation(&pod.ObjectMeta, AttemptAnnotationKey, strconv.Itoa(a.index))
+	metav1.SetMetaDataAnnotation(&pod.ObjectMeta, AttemptTimestampAnnotationKey, a.timestamp())
+}
+
+func (a attempt) timestamp() string {
+	return a.startedAt.UTC().Format(time.RFC3339Nano)
 }
@@ -2,13 +2,20 @@ package storereflector
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/storereflector/mock_storereflector"
 )
@@ -24,7 +31,9 @@ func TestReflector_storeAllResultToPodFunc(t *testing.T) {
 		name                        string
 		podName                     string
 		podNamespace                string
+		attempts                    map[types.UID]attempt
 		prepareMockResultStoreSetFn func(m *mock_storereflector.MockResultStore)
+		prepareMockRecorderFn       func(m *mock_storereflector.MockResultRecorder)
 		prepareFakeClientSetFn      func() *fake.Clientset
 		wantAnnotation              map[string]string
 	}{
@@ -35,7 +44,7 @@ func TestReflector_storeAllResultToPodFunc(t *testing.T) {
 			prepareMockResultStoreSetFn: func(m *mock_storereflector.MockResultStore) {
 				m.EXPECT().AddStoredResultToPod(gomock.Any()).Do(func(pod *corev1.Pod) {
 					metav1.SetMetaDataAnnotation(&pod.ObjectMeta, ExtenderFilterResultAnnotationKey, "some results")
-				})
+				}).Times(2)
 				m.EXPECT().DeleteData(gomock.Any())
 			},
 			prepareFakeClientSetFn: func() *fake.Clientset {
@@ -44,12 +53,161 @@ func TestReflector_storeAllResultToPodFunc(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "pod1",
 						Namespace: "default",
+						UID:       "uid1",
 					},
 				}, metav1.CreateOptions{})
 				return c
 			},
 			wantAnnotation: map[string]string{ExtenderFilterResultAnnotationKey: "some results"},
 		},
+		{
+			name:         "success with the recorder",
+			podName:      "pod1",
+			podNamespace: "default",
+			prepareMockResultStoreSetFn: func(m *mock_storereflector.MockResultStore) {
+				m.EXPECT().AddStoredResultToPod(gomock.Any()).Do(func(pod *corev1.Pod) {
+					metav1.SetMetaDataAnnotation(&pod.ObjectMeta, ExtenderFilterResultAnnotationKey, "some results")
+				}).Times(2)
+				m.EXPECT().DeleteData(gomock.Any())
+			},
+			prepareMockRecorderFn: func(m *mock_storereflector.MockResultRecorder) {
+				m.EXPECT().Record(gomock.Any(), gomock.Any(), 0, time.Time{}, map[string]string{ExtenderFilterResultAnnotationKey: "some results"})
+			},
+			prepareFakeClientSetFn: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				c.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod1",
+						Namespace: "default",
+						UID:       "uid1",
+						Annotations: map[string]string{
+							"other-annotation": "value",
+						},
+					},
+				}, metav1.CreateOptions{})
+				return c
+			},
+			wantAnnotation: map[string]string{ExtenderFilterResultAnnotationKey: "some results", "other-annotation": "value"},
+		},
+		{
+			name:         "the pod isn't updated and the recorder isn't called when the store doesn't have the results of the pod",
+			podName:      "pod1",
+			podNamespace: "default",
+			prepareMockResultStoreSetFn: func(m *mock_storereflector.MockResultStore) {
+				m.EXPECT().AddStoredResultToPod(gomock.Any())
+			},
+			prepareMockRecorderFn: func(m *mock_storereflector.MockResultRecorder) {},
+			prepareFakeClientSetFn: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				c.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod1",
+						Namespace: "default",
+						UID:       "uid1",
+					},
+				}, metav1.CreateOptions{})
+				return c
+			},
+		},
+		{
+			name:         "the results of the previous attempt are replaced with the results of the new attempt",
+			podName:      "pod1",
+			podNamespace: "default",
+			attempts: map[types.UID]attempt{
+				"uid1": {index: 2, startedAt: time.Date(2023, 1, 1, 0, 0, 1, 0, time.UTC)},
+			},
+			prepareMockResultStoreSetFn: func(m *mock_storereflector.MockResultStore) {
+				m.EXPECT().AddStoredResultToPod(gomock.Any()).Do(func(pod *corev1.Pod) {
+					metav1.SetMetaDataAnnotation(&pod.ObjectMeta, ExtenderFilterResultAnnotationKey, "new results")
+				})
+				m.EXPECT().DeleteData(gomock.Any())
+			},
+			prepareMockRecorderFn: func(m *mock_storereflector.MockResultRecorder) {
+				m.EXPECT().Record(gomock.Any(), gomock.Any(), 2, time.Date(2023, 1, 1, 0, 0, 1, 0, time.UTC), map[string]string{ExtenderFilterResultAnnotationKey: "new results"})
+			},
+			prepareFakeClientSetFn: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				c.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod1",
+						Namespace: "default",
+						UID:       "uid1",
+						Annotations: map[string]string{
+							AttemptAnnotationKey:              "1",
+							AttemptTimestampAnnotationKey:     "2023-01-01T00:00:00Z",
+							ExtenderFilterResultAnnotationKey: "old results",
+						},
+					},
+				}, metav1.CreateOptions{})
+				return c
+			},
+			wantAnnotation: map[string]string{
+				AttemptAnnotationKey:              "2",
+				AttemptTimestampAnnotationKey:     "2023-01-01T00:00:01Z",
+				ExtenderFilterResultAnnotationKey: "new results",
+			},
+		},
+		{
+			name:         "the results aren't recorded nor deleted when the pod can't be updated",
+			podName:      "pod1",
+			podNamespace: "default",
+			prepareMockResultStoreSetFn: func(m *mock_storereflector.MockResultStore) {
+				m.EXPECT().AddStoredResultToPod(gomock.Any()).Do(func(pod *corev1.Pod) {
+					metav1.SetMetaDataAnnotation(&pod.ObjectMeta, ExtenderFilterResultAnnotationKey, "some results")
+				}).Times(2)
+			},
+			prepareMockRecorderFn: func(m *mock_storereflector.MockResultRecorder) {},
+			prepareFakeClientSetFn: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				c.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod1",
+						Namespace: "default",
+						UID:       "uid1",
+					},
+				}, metav1.CreateOptions{})
+				c.PrependReactor("update", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("update failed")
+				})
+				return c
+			},
+		},
+		{
+			name:         "the results are added to the annotation when the results of the same attempt are already reflected",
+			podName:      "pod1",
+			podNamespace: "default",
+			attempts: map[types.UID]attempt{
+				"uid1": {index: 2, startedAt: time.Date(2023, 1, 1, 0, 0, 1, 0, time.UTC)},
+			},
+			prepareMockResultStoreSetFn: func(m *mock_storereflector.MockResultStore) {
+				m.EXPECT().AddStoredResultToPod(gomock.Any()).Do(func(pod *corev1.Pod) {
+					metav1.SetMetaDataAnnotation(&pod.ObjectMeta, "scheduler-simulator/postbind-result", "postbind results")
+				}).Times(2)
+				m.EXPECT().DeleteData(gomock.Any())
+			},
+			prepareFakeClientSetFn: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				c.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod1",
+						Namespace: "default",
+						UID:       "uid1",
+						Annotations: map[string]string{
+							AttemptAnnotationKey:              "2",
+							AttemptTimestampAnnotationKey:     "2023-01-01T00:00:01Z",
+							ExtenderFilterResultAnnotationKey: "some results",
+						},
+					},
+				}, metav1.CreateOptions{})
+				return c
+			},
+			wantAnnotation: map[string]string{
+				AttemptAnnotationKey:                  "2",
+				AttemptTimestampAnnotationKey:         "2023-01-01T00:00:01Z",
+				ExtenderFilterResultAnnotationKey:     "some results",
+				"scheduler-simulator/postbind-result": "postbind results",
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -61,15 +219,182 @@ func TestReflector_storeAllResultToPodFunc(t *testing.T) {
 			tt.prepareMockResultStoreSetFn(rs)
 			r := &reflector{
 				resultStores: map[string]ResultStore{ResultStoreKey: rs},
+				attempts:     map[types.UID]attempt{},
+				podLocks:     map[types.UID]*podLock{},
+			}
+			if tt.attempts != nil {
+				r.attempts = tt.attempts
+			}
+			if tt.prepareMockRecorderFn != nil {
+				rr := mock_storereflector.NewMockResultRecorder(ctrl)
+				tt.prepareMockRecorderFn(rr)
+				r.startRecording(rr)
+				t.Cleanup(func() { close(r.records) })
 			}
 			fn := r.storeAllResultToPodFunc(c)
 			p, _ := c.CoreV1().Pods(tt.podNamespace).Get(context.Background(), tt.podName, metav1.GetOptions{})
 			original := p.DeepCopy()
 			fn(corev1.Pod{}, p)
+			r.recording.Wait()
 
 			// Check that the function doesn't mutate the input object,
 			// which is shared with other event handlers.
 			assert.Equal(t, original, p)
+
+			got, _ := c.CoreV1().Pods(tt.podNamespace).Get(context.Background(), tt.podName, metav1.GetOptions{})
+			assert.Equal(t, tt.wantAnnotation, got.Annotations)
+		})
+	}
+}
+
+func TestReflector_StartAttempt(t *testing.T) {
+	t.Parallel()
+	previous := attempt{index: 1, startedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	startedAt := time.Date(2023, 1, 1, 0, 0, 1, 0, time.UTC)
+	tests := []struct {
+		name                        string
+		prepareMockResultStoreSetFn func(m *mock_storereflector.MockResultStore)
+		prepareMockRecorderFn       func(m *mock_storereflector.MockResultRecorder)
+	}{
+		{
+			name: "start the attempt",
+			prepareMockResultStoreSetFn: func(m *mock_storereflector.MockResultStore) {
+				m.EXPECT().AddStoredResultToPod(gomock.Any())
+			},
+			prepareMockRecorderFn: func(m *mock_storereflector.MockResultRecorder) {},
+		},
+		{
+			name: "the remaining results of the previous attempt are recorded and deleted",
+			prepareMockResultStoreSetFn: func(m *mock_storereflector.MockResultStore) {
+				m.EXPECT().AddStoredResultToPod(gomock.Any()).Do(func(pod *corev1.Pod) {
+					metav1.SetMetaDataAnnotation(&pod.ObjectMeta, ExtenderFilterResultAnnotationKey, "some results")
+				})
+				m.EXPECT().DeleteData(gomock.Any())
+			},
+			prepareMockRecorderFn: func(m *mock_storereflector.MockResultRecorder) {
+				m.EXPECT().Record(gomock.Any(), gomock.Any(), previous.index, previous.startedAt, map[string]string{ExtenderFilterResultAnnotationKey: "some results"})
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			rs := mock_storereflector.NewMockResultStore(ctrl)
+			tt.prepareMockResultStoreSetFn(rs)
+			rr := mock_storereflector.NewMockResultRecorder(ctrl)
+			tt.prepareMockRecorderFn(rr)
+			r := &reflector{
+				resultStores: map[string]ResultStore{ResultStoreKey: rs},
+				attempts:     map[types.UID]attempt{"uid1": previous},
+				podLocks:     map[types.UID]*podLock{},
+			}
+			r.startRecording(rr)
+			t.Cleanup(func() { close(r.records) })
+
+			r.StartAttempt(context.Background(), &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}}, 2, startedAt)
+			r.recording.Wait()
+
+			assert.Equal(t, map[types.UID]attempt{"uid1": {index: 2, startedAt: startedAt}}, r.attempts)
+			assert.Empty(t, r.podLocks)
+		})
+	}
+}
+
+func TestReflector_StartAttempt_otherPodIsBeingReflected(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	rs := mock_storereflector.NewMockResultStore(ctrl)
+	rs.EXPECT().AddStoredResultToPod(gomock.Any())
+	r := New(nil).(*reflector)
+	r.AddResultStore(rs, ResultStoreKey)
+
+	// pod2 is being reflected.
+	unlock := r.lockPod("uid2")
+	defer unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.StartAttempt(context.Background(), &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}}, 1, time.Now())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("StartAttempt is blocked by the reflection of another pod")
+	}
+}
+
+func TestReflector_StartAttempt_recorderIsSlow(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	rs := mock_storereflector.NewMockResultStore(ctrl)
+	rs.EXPECT().AddStoredResultToPod(gomock.Any()).Do(func(pod *corev1.Pod) {
+		metav1.SetMetaDataAnnotation(&pod.ObjectMeta, ExtenderFilterResultAnnotationKey, "some results")
+	})
+	rs.EXPECT().DeleteData(gomock.Any())
+	rr := mock_storereflector.NewMockResultRecorder(ctrl)
+	recorded := make(chan struct{})
+	rr.EXPECT().Record(gomock.Any(), gomock.Any(), 0, time.Time{}, map[string]string{ExtenderFilterResultAnnotationKey: "some results"}).
+		Do(func(_ context.Context, _ *corev1.Pod, _ int, _ time.Time, _ map[string]string) {
+			<-recorded
+		})
+	r := New(rr).(*reflector)
+	r.AddResultStore(rs, ResultStoreKey)
+
+	done := make(chan struct{})
+	go func() {
+		r.StartAttempt(context.Background(), &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}}, 1, time.Now())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("StartAttempt is blocked by the recorder")
+	}
+
+	close(recorded)
+	r.recording.Wait()
+}
+
+func TestReflector_forgetAttemptFunc(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  interface{}
+	}{
+		{
+			name: "forget the attempt and the results of the deleted pod",
+			obj:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
+		},
+		{
+			name: "forget the attempt of the pod in DeletedFinalStateUnknown",
+			obj: cache.DeletedFinalStateUnknown{
+				Key: "default/pod1",
+				Obj: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			rs := mock_storereflector.NewMockResultStore(ctrl)
+			rs.EXPECT().DeleteData(corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"}})
+			r := &reflector{
+				resultStores: map[string]ResultStore{ResultStoreKey: rs},
+				attempts: map[types.UID]attempt{
+					"uid1": {index: 1},
+					"uid2": {index: 1},
+				},
+				podLocks: map[types.UID]*podLock{},
+			}
+
+			r.forgetAttemptFunc()(tt.obj)
+
+			assert.Equal(t, map[types.UID]attempt{"uid2": {index: 1}}, r.attempts)
 		})
 	}
 }
@@ -0,0 +1,338 @@
+package schedulingresult
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/plugin/annotation"
+)
+
+// EtcdPrefix is the prefix of the keys which the results are persisted with.
+// It's different from the prefix used by kube-apiserver so that the history isn't affected by resetting the cluster.
+const EtcdPrefix = "/kube-scheduler-simulator-schedulingresults/"
+
+// defaultMaxResults is the number of the results kept in the history. The oldest results are deleted when it's exceeded.
+const defaultMaxResults = 10000
+
+// Result is the scheduling result of one scheduling attempt of a Pod.
+type Result struct {
+	// ID identifies the result. Results are sorted by ID in chronological order.
+	ID        string    `json:"id"`
+	Namespace string    `json:"namespace"`
+	PodName   string    `json:"podName"`
+	PodUID    types.UID `json:"podUID"`
+	// NodeName is the name of the Node which the Pod is bound to.
+	// It's empty when the scheduling attempt failed.
+	NodeName string `json:"nodeName,omitempty"`
+	// Attempt is the index of the scheduling attempt of the Pod, starting from 1.
+	// It's reset when the scheduler is restarted, and it's 0 when the attempt is unknown.
+	Attempt int `json:"attempt"`
+	// Timestamp is the time when the scheduling attempt started.
+	// It's the time when the result is recorded if the attempt is unknown.
+	Timestamp time.Time `json:"timestamp"`
+	// Results has the results of each extension point keyed by the annotation key. (e.g., scheduler-simulator/filter-result)
+	// The values are the same as the ones in the Pod annotations.
+	Results map[string]string `json:"results"`
+
+	// plugins has the names of the plugins (and the extenders) which have any result in Results.
+	// It's built when the result is recorded so that the results aren't decoded on every List.
+	plugins map[string]bool
+}
+
+// Query is used to filter the results.
+// The empty fields don't filter the results.
+type Query struct {
+	Namespace string
+	PodName   string
+	NodeName  string
+	// Plugin filters the results to the ones that have any result of the plugin (or the extender).
+	Plugin string
+	// Since and Until filter the results by Timestamp. Both of them are inclusive.
+	Since time.Time
+	Until time.Time
+}
+
+// Service keeps the history of scheduling results.
+// The results are kept in memory, and are also persisted in etcd if the etcd client is given.
+type Service struct {
+	mu sync.RWMutex
+	// persistMu serializes the writes to etcd so that they're done in the order of the changes in memory.
+	// It's acquired before mu is released, and the writes are done after mu is released so that they don't block List.
+	persistMu sync.Mutex
+	// results are the results in the order they're recorded. The oldest ones are deleted when there are more than maxResults.
+	results []*Result
+	// latest has the latest result of each Pod, keyed by the Pod UID.
+	latest     map[types.UID]*Result
+	maxResults int
+
+	// etcdClient is nil when the results aren't persisted.
+	etcdClient *clientv3.Client
+	now        func() time.Time
+}
+
+// NewSchedulingResultService initializes Service.
+// If etcdClient is given, the results persisted in etcd are loaded, and the new results are persisted as well.
+func NewSchedulingResultService(ctx context.Context, etcdClient *clientv3.Client) (*Service, error) {
+	s := &Service{
+		results:    []*Result{},
+		latest:     map[types.UID]*Result{},
+		maxResults: defaultMaxResults,
+		etcdClient: etcdClient,
+		now:        time.Now,
+	}
+	if etcdClient == nil {
+		return s, nil
+	}
+
+	resp, err := etcdClient.Get(ctx, EtcdPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, xerrors.Errorf("get the persisted scheduling results: %w", err)
+	}
+	results := make([]*Result, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		r := &Result{}
+		if err := json.Unmarshal(kv.Value, r); err != nil {
+			return nil, xerrors.Errorf("decode the persisted scheduling result %s: %w", string(kv.Key), err)
+		}
+		results = append(results, r)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+	var evicted []string
+	for _, r := range results {
+		evicted = append(evicted, s.addWithoutLock(r)...)
+	}
+	if err := s.deletePersisted(ctx, evicted); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Record records the scheduling results of the scheduling attempt of the Pod.
+// results are keyed by the annotation key.
+// attempt is 0 and startedAt is zero when the attempt is unknown.
+//
+// The results of the attempt which is already recorded are merged into the recorded result
+// because they come from the extension points running after the others are reflected. (e.g., PostBind)
+func (s *Service) Record(ctx context.Context, pod *corev1.Pod, attempt int, startedAt time.Time, results map[string]string) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	r, evicted := s.recordWithoutLock(pod, attempt, startedAt, results)
+	// copy the result so that it can be encoded without the lock.
+	r = r.deepCopy()
+	s.persistMu.Lock()
+	s.mu.Unlock()
+	defer s.persistMu.Unlock()
+
+	if err := s.persist(ctx, r); err != nil {
+		return err
+	}
+	return s.deletePersisted(ctx, evicted)
+}
+
+// recordWithoutLock records the results in memory, and returns the recorded result and the IDs of the evicted results.
+// Note: we assume the lock is already acquired.
+func (s *Service) recordWithoutLock(pod *corev1.Pod, attempt int, startedAt time.Time, results map[string]string) (*Result, []string) {
+	if latest := s.latest[pod.UID]; latest != nil && attempt != 0 &&
+		latest.Attempt == attempt && latest.Timestamp.Equal(startedAt) {
+		for k, v := range results {
+			if isEmptyResult(v) {
+				continue
+			}
+			latest.Results[k] = v
+		}
+		latest.indexPlugins()
+		if pod.Spec.NodeName != "" {
+			latest.NodeName = pod.Spec.NodeName
+		}
+		return latest, nil
+	}
+
+	if startedAt.IsZero() {
+		startedAt = s.now()
+	}
+	r := &Result{
+		ID:        fmt.Sprintf("%020d-%s", startedAt.UnixNano(), pod.UID),
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		PodUID:    pod.UID,
+		NodeName:  pod.Spec.NodeName,
+		Attempt:   attempt,
+		Timestamp: startedAt,
+		Results:   map[string]string{},
+	}
+	for k, v := range results {
+		r.Results[k] = v
+	}
+	return r, s.addWithoutLock(r)
+}
+
+// List lists the results which match the query in chronological order.
+func (s *Service) List(_ context.Context, q Query) ([]Result, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ret := []Result{}
+	for _, r := range s.results {
+		if !q.matches(r) {
+			continue
+		}
+		ret = append(ret, *r.deepCopy())
+	}
+	return ret, nil
+}
+
+// DeleteAll deletes all results.
+func (s *Service) DeleteAll(ctx context.Context) error {
+	s.mu.Lock()
+	s.results = []*Result{}
+	s.latest = map[types.UID]*Result{}
+	s.persistMu.Lock()
+	s.mu.Unlock()
+	defer s.persistMu.Unlock()
+
+	if s.etcdClient != nil {
+		if _, err := s.etcdClient.Delete(ctx, EtcdPrefix, clientv3.WithPrefix()); err != nil {
+			return xerrors.Errorf("delete the persisted scheduling results: %w", err)
+		}
+	}
+	return nil
+}
+
+// addWithoutLock adds the result to the history, and deletes the oldest results over maxResults.
+// It returns the IDs of the deleted results, which should be deleted from etcd as well.
+// Note: we assume the lock is already acquired.
+func (s *Service) addWithoutLock(r *Result) []string {
+	r.indexPlugins()
+	s.results = append(s.results, r)
+	s.latest[r.PodUID] = r
+
+	var evicted []string
+	for len(s.results) > s.maxResults {
+		oldest := s.results[0]
+		evicted = append(evicted, oldest.ID)
+		if s.latest[oldest.PodUID] == oldest {
+			delete(s.latest, oldest.PodUID)
+		}
+		// Clear the reference so that the backing array doesn't keep the deleted result.
+		s.results[0] = nil
+		s.results = s.results[1:]
+	}
+	return evicted
+}
+
+// deletePersisted deletes the results from etcd if the results are persisted.
+func (s *Service) deletePersisted(ctx context.Context, ids []string) error {
+	if s.etcdClient == nil {
+		return nil
+	}
+
+	for _, id := range ids {
+		if _, err := s.etcdClient.Delete(ctx, EtcdPrefix+id); err != nil {
+			return xerrors.Errorf("delete the persisted scheduling result %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// persist puts the result in etcd if the results are persisted.
+func (s *Service) persist(ctx context.Context, r *Result) error {
+	if s.etcdClient == nil {
+		return nil
+	}
+
+	v, err := json.Marshal(r)
+	if err != nil {
+		return xerrors.Errorf("encode the scheduling result: %w", err)
+	}
+	if _, err := s.etcdClient.Put(ctx, EtcdPrefix+r.ID, string(v)); err != nil {
+		return xerrors.Errorf("put the scheduling result in etcd: %w", err)
+	}
+	return nil
+}
+
+func (q Query) matches(r *Result) bool {
+	if q.Namespace != "" && q.Namespace != r.Namespace {
+		return false
+	}
+	if q.PodName != "" && q.PodName != r.PodName {
+		return false
+	}
+	if q.NodeName != "" && q.NodeName != r.NodeName {
+		return false
+	}
+	if !q.Since.IsZero() && r.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && r.Timestamp.After(q.Until) {
+		return false
+	}
+	if q.Plugin != "" && !r.plugins[q.Plugin] {
+		return false
+	}
+	return true
+}
+
+// nodePluginResultKeys are the annotation keys whose results are keyed by the node name and then the plugin name.
+var nodePluginResultKeys = map[string]bool{
+	annotation.FilterResultAnnotationKey:          true,
+	annotation.PostFilterResultAnnotationKey:      true,
+	annotation.ScoreResultAnnotationKey:           true,
+	annotation.NormalizedScoreResultAnnotationKey: true,
+	annotation.FinalScoreResultAnnotationKey:      true,
+}
+
+// indexPlugins builds plugins from Results.
+// The results are keyed by the plugin name (e.g., prescore-result) or by the node name and then the plugin name (e.g., filter-result).
+func (r *Result) indexPlugins() {
+	r.plugins = map[string]bool{}
+	for k, v := range r.Results {
+		m := map[string]json.RawMessage{}
+		if err := json.Unmarshal([]byte(v), &m); err != nil {
+			// the result which isn't keyed by anything. (e.g., selected-node)
+			continue
+		}
+		for key, nested := range m {
+			if !nodePluginResultKeys[k] {
+				r.plugins[key] = true
+				continue
+			}
+			// key is the node name.
+			nm := map[string]json.RawMessage{}
+			if err := json.Unmarshal(nested, &nm); err != nil {
+				continue
+			}
+			for plugin := range nm {
+				r.plugins[plugin] = true
+			}
+		}
+	}
+}
+
+// isEmptyResult checks whether the result of an extension point is empty.
+func isEmptyResult(v string) bool {
+	return v == "" || v == "{}"
+}
+
+// deepCopy returns the copy of the result without plugins, which is used only to filter the results.
+func (r *Result) deepCopy() *Result {
+	ret := *r
+	ret.Results = make(map[string]string, len(r.Results))
+	for k, v := range r.Results {
+		ret.Results[k] = v
+	}
+	ret.plugins = nil
+	return &ret
+}
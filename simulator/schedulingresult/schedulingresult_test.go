@@ -0,0 +1,276 @@
+package schedulingresult
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/storage/etcd3/testserver"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/plugin/annotation"
+)
+
+func pod(name, uid, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + uid)},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func TestService_Record(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	started := now.Add(-time.Second)
+	tests := []struct {
+		name        string
+		recordFn    func(s *Service)
+		wantResults []Result
+	}{
+		{
+			name: "record the results of each attempt",
+			recordFn: func(s *Service) {
+				s.Record(context.Background(), pod("pod1", "1", ""), 1, started, map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`})
+				s.Record(context.Background(), pod("pod1", "1", "node1"), 2, started.Add(time.Millisecond), map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"passed"}}`})
+			},
+			wantResults: []Result{
+				{
+					ID:        fmt.Sprintf("%020d-uid-1", started.UnixNano()),
+					Namespace: "default",
+					PodName:   "pod1",
+					PodUID:    "uid-1",
+					Attempt:   1,
+					Timestamp: started,
+					Results:   map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`},
+				},
+				{
+					ID:        fmt.Sprintf("%020d-uid-1", started.Add(time.Millisecond).UnixNano()),
+					Namespace: "default",
+					PodName:   "pod1",
+					PodUID:    "uid-1",
+					NodeName:  "node1",
+					Attempt:   2,
+					Timestamp: started.Add(time.Millisecond),
+					Results:   map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"passed"}}`},
+				},
+			},
+		},
+		{
+			name: "merge the results of the same attempt",
+			recordFn: func(s *Service) {
+				s.Record(context.Background(), pod("pod1", "1", "node1"), 1, started, map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"passed"}}`, annotation.PostBindResultAnnotationKey: "{}"})
+				s.Record(context.Background(), pod("pod1", "1", "node1"), 1, started, map[string]string{annotation.FilterResultAnnotationKey: "{}", annotation.PostBindResultAnnotationKey: `{"plugin1":"success"}`})
+			},
+			wantResults: []Result{
+				{
+					ID:        fmt.Sprintf("%020d-uid-1", started.UnixNano()),
+					Namespace: "default",
+					PodName:   "pod1",
+					PodUID:    "uid-1",
+					NodeName:  "node1",
+					Attempt:   1,
+					Timestamp: started,
+					Results:   map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"passed"}}`, annotation.PostBindResultAnnotationKey: `{"plugin1":"success"}`},
+				},
+			},
+		},
+		{
+			name: "the results of the unknown attempts aren't merged, and they're recorded at the current time",
+			recordFn: func(s *Service) {
+				s.Record(context.Background(), pod("pod1", "1", ""), 0, time.Time{}, map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`})
+				s.Record(context.Background(), pod("pod1", "1", ""), 0, time.Time{}, map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`})
+			},
+			wantResults: []Result{
+				{
+					ID:        fmt.Sprintf("%020d-uid-1", now.UnixNano()),
+					Namespace: "default",
+					PodName:   "pod1",
+					PodUID:    "uid-1",
+					Timestamp: now,
+					Results:   map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`},
+				},
+				{
+					ID:        fmt.Sprintf("%020d-uid-1", now.Add(1).UnixNano()),
+					Namespace: "default",
+					PodName:   "pod1",
+					PodUID:    "uid-1",
+					Timestamp: now.Add(1),
+					Results:   map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`},
+				},
+			},
+		},
+		{
+			name: "empty results aren't recorded",
+			recordFn: func(s *Service) {
+				s.Record(context.Background(), pod("pod1", "1", ""), 1, started, map[string]string{})
+			},
+			wantResults: []Result{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := NewSchedulingResultService(context.Background(), nil)
+			assert.NoError(t, err)
+			var i time.Duration
+			s.now = func() time.Time {
+				defer func() { i++ }()
+				return now.Add(i)
+			}
+			tt.recordFn(s)
+
+			got, err := s.List(context.Background(), Query{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResults, got)
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	t.Parallel()
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := []*Result{
+		{
+			ID: "1", Namespace: "default", PodName: "pod1", PodUID: "uid-1",
+			Timestamp: base,
+			Results:   map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`, annotation.SelectedNodeAnnotationKey: ""},
+		},
+		{
+			ID: "2", Namespace: "default", PodName: "pod1", PodUID: "uid-1", NodeName: "node2",
+			Timestamp: base.Add(time.Minute),
+			Results:   map[string]string{annotation.PreScoreResultAnnotationKey: `{"plugin2":"success"}`, annotation.SelectedNodeAnnotationKey: "node2"},
+		},
+		{
+			ID: "3", Namespace: "other", PodName: "pod2", PodUID: "uid-2", NodeName: "node1",
+			Timestamp: base.Add(2 * time.Minute),
+			Results:   map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin2":"passed"}}`},
+		},
+	}
+	tests := []struct {
+		name    string
+		query   Query
+		wantIDs []string
+	}{
+		{
+			name:    "no filter",
+			query:   Query{},
+			wantIDs: []string{"1", "2", "3"},
+		},
+		{
+			name:    "filter by pod",
+			query:   Query{Namespace: "default", PodName: "pod1"},
+			wantIDs: []string{"1", "2"},
+		},
+		{
+			name:    "filter by node",
+			query:   Query{NodeName: "node1"},
+			wantIDs: []string{"3"},
+		},
+		{
+			name:    "filter by plugin keyed by node name",
+			query:   Query{Plugin: "plugin1"},
+			wantIDs: []string{"1"},
+		},
+		{
+			name:    "filter by plugin keyed by plugin name",
+			query:   Query{Plugin: "plugin2"},
+			wantIDs: []string{"2", "3"},
+		},
+		{
+			name:    "node name isn't regarded as plugin",
+			query:   Query{Plugin: "node1"},
+			wantIDs: []string{},
+		},
+		{
+			name:    "filter by time range",
+			query:   Query{Since: base.Add(time.Minute), Until: base.Add(time.Minute)},
+			wantIDs: []string{"2"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := NewSchedulingResultService(context.Background(), nil)
+			assert.NoError(t, err)
+			for _, r := range results {
+				s.addWithoutLock(r.deepCopy())
+			}
+			got, err := s.List(context.Background(), tt.query)
+			assert.NoError(t, err)
+			gotIDs := []string{}
+			for _, r := range got {
+				gotIDs = append(gotIDs, r.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestService_Record_maxResults(t *testing.T) {
+	t.Parallel()
+	s, err := NewSchedulingResultService(context.Background(), nil)
+	assert.NoError(t, err)
+	s.maxResults = 2
+	started := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, s.Record(context.Background(), pod("pod1", "1", ""), 1, started, map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`}))
+	assert.NoError(t, s.Record(context.Background(), pod("pod2", "2", ""), 1, started.Add(time.Second), map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`}))
+	assert.NoError(t, s.Record(context.Background(), pod("pod2", "2", ""), 2, started.Add(2*time.Second), map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`}))
+	// The PostBind results are merged into the latest result of pod2.
+	assert.NoError(t, s.Record(context.Background(), pod("pod2", "2", "node1"), 2, started.Add(2*time.Second), map[string]string{annotation.PostBindResultAnnotationKey: `{"plugin2":"success"}`}))
+
+	// The oldest result is deleted.
+	got, err := s.List(context.Background(), Query{})
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "pod2", got[0].PodName)
+	assert.Equal(t, 1, got[0].Attempt)
+	assert.Equal(t, "node1", got[1].NodeName)
+	assert.NotContains(t, s.latest, types.UID("uid-1"))
+
+	// The merged results are filtered by the plugin.
+	got, err = s.List(context.Background(), Query{Plugin: "plugin2"})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, 2, got[0].Attempt)
+}
+
+func TestService_persist(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip("skipping the test with etcd in short mode")
+	}
+	client := testserver.RunEtcd(t, nil)
+	ctx := context.Background()
+	started := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := NewSchedulingResultService(ctx, client)
+	assert.NoError(t, err)
+	s.maxResults = 1
+	assert.NoError(t, s.Record(ctx, pod("pod1", "1", ""), 1, started, map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`}))
+	assert.NoError(t, s.Record(ctx, pod("pod2", "2", ""), 1, started.Add(time.Second), map[string]string{annotation.FilterResultAnnotationKey: `{"node1":{"plugin1":"failed"}}`}))
+	assert.NoError(t, s.Record(ctx, pod("pod2", "2", "node1"), 1, started.Add(time.Second), map[string]string{annotation.PostBindResultAnnotationKey: `{"plugin2":"success"}`}))
+
+	// The evicted result is deleted from etcd, and the merged result is persisted.
+	loaded, err := NewSchedulingResultService(ctx, client)
+	assert.NoError(t, err)
+	got, err := loaded.List(ctx, Query{})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "pod2", got[0].PodName)
+	assert.Equal(t, "node1", got[0].NodeName)
+	assert.Equal(t, `{"plugin2":"success"}`, got[0].Results[annotation.PostBindResultAnnotationKey])
+
+	assert.NoError(t, s.DeleteAll(ctx))
+	loaded, err = NewSchedulingResultService(ctx, client)
+	assert.NoError(t, err)
+	got, err = loaded.List(ctx, Query{})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -4,28 +4,40 @@
 package di
 
 import (
+	"context"
+
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"golang.org/x/xerrors"
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
 
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/autoscaler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/batch"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/node"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/nodegroup"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/persistentvolume"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/persistentvolumeclaim"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/pod"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/priorityclass"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/replay"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/replicateexistingcluster"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/reset"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/resourcewatcher"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/schedulingresult"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/snapshot"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/storageclass"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/workload"
 )
 
 // Container saves and provides dependencies.
 type Container struct {
 	nodeService                     NodeService
+	nodeGroupService                NodeGroupService
+	autoscalerService               AutoscalerService
+	workloadService                 WorkloadService
 	podService                      PodService
 	pvService                       PersistentVolumeService
 	pvcService                      PersistentVolumeClaimService
@@ -34,45 +46,74 @@ type Container struct {
 	exportService                   ExportService
 	priorityClassService            PriorityClassService
 	resetService                    ResetService
+	snapshotService                 SnapshotService
 	replicateExistingClusterService ReplicateExistingClusterService
+	existingClusterSyncer           ExistingClusterSyncer
 	resourceWatcherService          ResourceWatcherService
+	schedulingResultService         *schedulingresult.Service
+	batchService                    BatchService
+	replayService                   ReplayService
 }
 
 // NewDIContainer initializes Container.
 // It initializes all service and puts to Container.
 // If externalImportEnabled is false, the simulator will not use externalClient and will not create ReplicateExistingClusterService.
+// If externalImportSyncEnabled is false, the simulator will not create ExistingClusterSyncer.
+// If schedulingResultsPersistenceEnabled is false, the history of scheduling results is kept only in memory.
 func NewDIContainer(
 	client clientset.Interface,
 	etcdclient *clientv3.Client,
 	restclientCfg *restclient.Config,
 	initialSchedulerCfg *v1beta2config.KubeSchedulerConfiguration,
 	externalImportEnabled bool,
+	externalImportSyncEnabled bool,
+	externalImportOptions replicateexistingcluster.ImportOptions,
 	externalClient clientset.Interface,
 	externalSchedulerEnabled bool,
 	simulatorPort int,
+	schedulingResultsPersistenceEnabled bool,
 ) (*Container, error) {
 	c := &Container{}
 
+	var err error
+	var resultEtcdClient *clientv3.Client
+	if schedulingResultsPersistenceEnabled {
+		resultEtcdClient = etcdclient
+	}
+	c.schedulingResultService, err = schedulingresult.NewSchedulingResultService(context.Background(), resultEtcdClient)
+	if err != nil {
+		return nil, xerrors.Errorf("initialize scheduling result service: %w", err)
+	}
+
 	// initializes each service
 	c.pvService = persistentvolume.NewPersistentVolumeService(client)
 	c.pvcService = persistentvolumeclaim.NewPersistentVolumeClaimService(client)
 	c.storageClassService = storageclass.NewStorageClassService(client)
-	c.schedulerService = scheduler.NewSchedulerService(client, restclientCfg, initialSchedulerCfg, externalSchedulerEnabled, simulatorPort)
+	c.schedulerService = scheduler.NewSchedulerService(client, restclientCfg, initialSchedulerCfg, externalSchedulerEnabled, simulatorPort, c.schedulingResultService)
 	c.podService = pod.NewPodService(client)
 	c.nodeService = node.NewNodeService(client, c.podService)
+	c.nodeGroupService = nodegroup.NewNodeGroupService(c.nodeService)
+	c.autoscalerService = autoscaler.NewAutoscalerService(c.podService, c.nodeService, c.nodeGroupService)
+	c.workloadService = workload.NewWorkloadService(c.podService)
 	c.priorityClassService = priorityclass.NewPriorityClassService(client)
-	var err error
 	c.resetService, err = reset.NewResetService(etcdclient, client, c.schedulerService)
 	if err != nil {
 		return nil, xerrors.Errorf("initialize reset service: %w", err)
 	}
+	c.snapshotService = snapshot.NewSnapshotService(etcdclient, c.schedulerService)
 	exportService := export.NewExportService(client, c.podService, c.nodeService, c.pvService, c.pvcService, c.storageClassService, c.priorityClassService, c.schedulerService)
 	c.exportService = exportService
 	if externalImportEnabled {
 		existingClusterExportService := createExportServiceForReplicateExistingClusterService(externalClient, c.schedulerService)
-		c.replicateExistingClusterService = replicateexistingcluster.NewReplicateExistingClusterService(exportService, existingClusterExportService)
+		replicateExistingClusterService := replicateexistingcluster.NewReplicateExistingClusterService(exportService, existingClusterExportService, externalImportOptions)
+		c.replicateExistingClusterService = replicateExistingClusterService
+		if externalImportSyncEnabled {
+			c.existingClusterSyncer = replicateexistingcluster.NewSyncer(replicateExistingClusterService, client, externalClient)
+		}
 	}
 	c.resourceWatcherService = resourcewatcher.NewService(client)
+	c.batchService = batch.NewBatchService(client, exportService, c.schedulerService)
+	c.replayService = replay.NewReplayService(c.podService, c.nodeService, exportService, c.schedulingResultService)
 
 	return c, nil
 }
@@ -82,6 +123,21 @@ func (c *Container) NodeService() NodeService {
 	return c.nodeService
 }
 
+// NodeGroupService returns NodeGroupService.
+func (c *Container) NodeGroupService() NodeGroupService {
+	return c.nodeGroupService
+}
+
+// AutoscalerService returns AutoscalerService.
+func (c *Container) AutoscalerService() AutoscalerService {
+	return c.autoscalerService
+}
+
+// WorkloadService returns WorkloadService.
+func (c *Container) WorkloadService() WorkloadService {
+	return c.workloadService
+}
+
 // PodService returns PodService.
 func (c *Container) PodService() PodService {
 	return c.podService
@@ -122,17 +178,43 @@ func (c *Container) ResetService() ResetService {
 	return c.resetService
 }
 
+// SnapshotService returns SnapshotService.
+func (c *Container) SnapshotService() SnapshotService {
+	return c.snapshotService
+}
+
 // ReplicateExistingClusterService returns ReplicateExistingClusterService.
 // Note: this service will return nil when `externalImportEnabled` is false.
 func (c *Container) ReplicateExistingClusterService() ReplicateExistingClusterService {
 	return c.replicateExistingClusterService
 }
 
+// ExistingClusterSyncer returns ExistingClusterSyncer.
+// Note: this service will return nil when `externalImportSyncEnabled` is false.
+func (c *Container) ExistingClusterSyncer() ExistingClusterSyncer {
+	return c.existingClusterSyncer
+}
+
 // ResourceWatcherService returns ResourceWatcherService.
 func (c *Container) ResourceWatcherService() ResourceWatcherService {
 	return c.resourceWatcherService
 }
 
+// SchedulingResultService returns SchedulingResultService.
+func (c *Container) SchedulingResultService() SchedulingResultService {
+	return c.schedulingResultService
+}
+
+// BatchService returns BatchService.
+func (c *Container) BatchService() BatchService {
+	return c.batchService
+}
+
+// ReplayService returns ReplayService.
+func (c *Container) ReplayService() ReplayService {
+	return c.replayService
+}
+
 // ExtenderService returns ExtenderService.
 func (c *Container) ExtenderService() ExtenderService {
 	return c.schedulerService.ExtenderService()
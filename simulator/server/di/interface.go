@@ -2,6 +2,7 @@ package di
 
 import (
 	"context"
+	"io"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/scheduling/v1"
@@ -12,10 +13,19 @@ import (
 	"k8s.io/kube-scheduler/config/v1beta2"
 	extenderv1 "k8s.io/kube-scheduler/extender/v1"
 
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/autoscaler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/batch"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/node"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/nodegroup"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/replay"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/replicateexistingcluster"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/resourcewatcher"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/resourcewatcher/streamwriter"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/schedulingresult"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/snapshot"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/workload"
 )
 
 // PodService represents service for manage Pods.
@@ -31,7 +41,33 @@ type NodeService interface {
 	Get(ctx context.Context, name string) (*corev1.Node, error)
 	List(ctx context.Context) (*corev1.NodeList, error)
 	Apply(ctx context.Context, node *configv1.NodeApplyConfiguration) (*corev1.Node, error)
+	ApplyStatus(ctx context.Context, node *configv1.NodeApplyConfiguration) (*corev1.Node, error)
 	Delete(ctx context.Context, name string) error
+	InjectFailure(ctx context.Context, name string, f node.FailureType) error
+	Recover(ctx context.Context, name string) error
+}
+
+// NodeGroupService represents service for the groups of Nodes generated from the templates.
+type NodeGroupService interface {
+	List(ctx context.Context) ([]nodegroup.NodeGroup, error)
+	Generate(ctx context.Context, g nodegroup.NodeGroup) error
+	Scale(ctx context.Context, name string, count int) error
+	Delete(ctx context.Context, name string) error
+	Add(ctx context.Context, g nodegroup.NodeGroup, indexes []int) ([]string, error)
+}
+
+// AutoscalerService represents service for the cluster autoscaler which scales the node groups.
+type AutoscalerService interface {
+	Configure(cfg autoscaler.Config) error
+	Disable()
+	Status() autoscaler.Status
+}
+
+// WorkloadService represents service for the workloads which generate Pods over time.
+type WorkloadService interface {
+	Start(w workload.Workload) (*workload.Status, error)
+	Stop(name string) error
+	List() []workload.Status
 }
 
 // PersistentVolumeService represents service for manage Pods.
@@ -65,6 +101,10 @@ type SchedulerService interface {
 	StartScheduler(cfg *v1beta2.KubeSchedulerConfiguration) error
 	ResetScheduler() error
 	ShutdownScheduler()
+	PauseScheduler() error
+	ResumeScheduler() error
+	StepScheduler(ctx context.Context) error
+	SchedulerPaused() (bool, error)
 	ExtenderService() scheduler.ExtenderService
 }
 
@@ -79,16 +119,39 @@ type PriorityClassService interface {
 type ExportService interface {
 	Export(ctx context.Context, opts ...export.Option) (*export.ResourcesForExport, error)
 	Import(ctx context.Context, resources *export.ResourcesForImport, opts ...export.Option) error
+	ExportManifests(ctx context.Context, w io.Writer, format export.ManifestFormat, opts ...export.Option) error
+	ImportStream(ctx context.Context, reader io.Reader, progress func(export.ImportProgress), opts ...export.Option) error
 	IgnoreErr() export.Option
+	DryRun() export.Option
+	WithImportReport(r *export.ImportReport) export.Option
+	Anonymize() export.Option
 }
 
 type ResetService interface {
 	Reset(ctx context.Context) error
 }
 
+// SnapshotService represents service for the named snapshots of the cluster.
+type SnapshotService interface {
+	Save(ctx context.Context, name string) (*snapshot.Snapshot, error)
+	List(ctx context.Context) ([]snapshot.Snapshot, error)
+	Restore(ctx context.Context, name string) error
+	Delete(ctx context.Context, name string) error
+}
+
 // ReplicateExistingClusterService represents a service to import resources from the existing cluster.
 type ReplicateExistingClusterService interface {
 	ImportFromExistingCluster(ctx context.Context) error
+	ImportFromExistingClusterWithOptions(ctx context.Context, opts replicateexistingcluster.ImportOptions) (*export.ImportReport, error)
+}
+
+// ExistingClusterSyncer represents a service to keep mirroring the existing cluster into the simulator.
+type ExistingClusterSyncer interface {
+	Start(ctx context.Context) error
+	Pause()
+	Resume(ctx context.Context) error
+	Freeze(ctx context.Context) error
+	Status() replicateexistingcluster.SyncStatus
 }
 
 // ResourceWatcherService represents service for watch k8s resources.
@@ -96,6 +159,22 @@ type ResourceWatcherService interface {
 	ListWatch(ctx context.Context, stream streamwriter.ResponseStream, lrVersions *resourcewatcher.LastResourceVersions) error
 }
 
+// SchedulingResultService represents service for the history of scheduling results.
+type SchedulingResultService interface {
+	List(ctx context.Context, q schedulingresult.Query) ([]schedulingresult.Result, error)
+	DeleteAll(ctx context.Context) error
+}
+
+// BatchService represents service for running a simulation without the clients of the simulator server.
+type BatchService interface {
+	Run(ctx context.Context, opts batch.Options) (*batch.Report, error)
+}
+
+// ReplayService represents service for replaying the traces of the Pods and the Nodes.
+type ReplayService interface {
+	Run(ctx context.Context, opts replay.Options) (*replay.Report, error)
+}
+
 // ExtenderService represents service for the extender of scheduler.
 type ExtenderService interface {
 	Filter(id int, args extenderv1.ExtenderArgs) (*extenderv1.ExtenderFilterResult, error)
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/autoscaler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+)
+
+// AutoscalerHandler is handler for the cluster autoscaler.
+type AutoscalerHandler struct {
+	service di.AutoscalerService
+}
+
+// NewAutoscalerHandler initializes AutoscalerHandler.
+func NewAutoscalerHandler(s di.AutoscalerService) *AutoscalerHandler {
+	return &AutoscalerHandler{service: s}
+}
+
+// GetStatus returns the status of the autoscaler.
+func (h *AutoscalerHandler) GetStatus(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.service.Status())
+}
+
+// Configure enables the autoscaler with the config, or replaces the config.
+func (h *AutoscalerHandler) Configure(c echo.Context) error {
+	req := new(autoscaler.Config)
+	if err := c.Bind(req); err != nil {
+		klog.Errorf("failed to bind configure autoscaler request: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	err := h.service.Configure(*req)
+	switch {
+	case errors.Is(err, autoscaler.ErrInvalidConfig):
+		return c.JSON(http.StatusBadRequest, err.Error())
+	case err != nil:
+		klog.Errorf("failed to configure autoscaler: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, h.service.Status())
+}
+
+// Disable stops the autoscaler.
+func (h *AutoscalerHandler) Disable(c echo.Context) error {
+	h.service.Disable()
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+)
+
+// ExistingClusterSyncHandler is handler for controlling the sync with the existing cluster.
+type ExistingClusterSyncHandler struct {
+	// syncer is nil when the sync with the existing cluster is disabled.
+	syncer di.ExistingClusterSyncer
+}
+
+// NewExistingClusterSyncHandler initializes ExistingClusterSyncHandler.
+func NewExistingClusterSyncHandler(s di.ExistingClusterSyncer) *ExistingClusterSyncHandler {
+	return &ExistingClusterSyncHandler{syncer: s}
+}
+
+const syncDisabledMessage = "The sync with the existing cluster is disabled. Set EXTERNAL_IMPORT_SYNC_ENABLED to enable it."
+
+func (h *ExistingClusterSyncHandler) GetStatus(c echo.Context) error {
+	if h.syncer == nil {
+		return c.JSON(http.StatusBadRequest, syncDisabledMessage)
+	}
+
+	return c.JSON(http.StatusOK, h.syncer.Status())
+}
+
+// Pause stops mirroring the changes in the existing cluster until Resume is called.
+func (h *ExistingClusterSyncHandler) Pause(c echo.Context) error {
+	if h.syncer == nil {
+		return c.JSON(http.StatusBadRequest, syncDisabledMessage)
+	}
+	h.syncer.Pause()
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// Resume makes the simulator the same as the existing cluster, and restarts mirroring the changes.
+func (h *ExistingClusterSyncHandler) Resume(c echo.Context) error {
+	if h.syncer == nil {
+		return c.JSON(http.StatusBadRequest, syncDisabledMessage)
+	}
+	ctx := c.Request().Context()
+	if err := h.syncer.Resume(ctx); err != nil {
+		klog.Errorf("failed to resume sync with existing cluster: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// Freeze makes the simulator the same as the existing cluster at this moment, and pauses the sync.
+func (h *ExistingClusterSyncHandler) Freeze(c echo.Context) error {
+	if h.syncer == nil {
+		return c.JSON(http.StatusBadRequest, syncDisabledMessage)
+	}
+	ctx := c.Request().Context()
+	if err := h.syncer.Freeze(ctx); err != nil {
+		klog.Errorf("failed to freeze existing cluster: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
@@ -1,76 +1,190 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	v1 "k8s.io/client-go/applyconfigurations/core/v1"
-	schedulingcfgv1 "k8s.io/client-go/applyconfigurations/scheduling/v1"
-	confstoragev1 "k8s.io/client-go/applyconfigurations/storage/v1"
 	"k8s.io/klog/v2"
-	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
 
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/export"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
 )
 
+// mimeApplicationNDJSON is the content type of newline-delimited JSON.
+const mimeApplicationNDJSON = "application/x-ndjson"
+
 type ExportHandler struct {
 	service di.ExportService
 }
 
-type ResourcesForImport struct {
-	Pods            []v1.PodApplyConfiguration                        `json:"pods"`
-	Nodes           []v1.NodeApplyConfiguration                       `json:"nodes"`
-	Pvs             []v1.PersistentVolumeApplyConfiguration           `json:"pvs"`
-	Pvcs            []v1.PersistentVolumeClaimApplyConfiguration      `json:"pvcs"`
-	StorageClasses  []confstoragev1.StorageClassApplyConfiguration    `json:"storageClasses"`
-	PriorityClasses []schedulingcfgv1.PriorityClassApplyConfiguration `json:"priorityClasses"`
-	SchedulerConfig *v1beta2config.KubeSchedulerConfiguration         `json:"schedulerConfig"`
-	Namespaces      []v1.NamespaceApplyConfiguration                  `json:"namespaces"`
-}
-
 func NewExportHandler(s di.ExportService) *ExportHandler {
 	return &ExportHandler{service: s}
 }
 
+// Export returns all resources and the scheduler configuration.
+// They are returned as ResourcesForExport in JSON by default,
+// or as the manifests if the "format" query parameter is "yaml", "list" or "ndjson".
+// The manifests are streamed while the resources are listed page by page.
+// If the "anonymize" query parameter is "true", the resources are anonymized with export.Anonymizer.
 func (h *ExportHandler) Export(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	rs, err := h.service.Export(ctx)
+	format := export.ManifestFormat(c.QueryParam("format"))
+	switch format {
+	case "", "json", export.ManifestFormatYAML, export.ManifestFormatList, export.ManifestFormatNDJSON:
+	default:
+		return c.JSON(http.StatusBadRequest, "The format must be one of json, yaml, list and ndjson.")
+	}
+
+	var opts []export.Option
+	switch c.QueryParam("anonymize") {
+	case "", "false":
+	case "true":
+		opts = append(opts, h.service.Anonymize())
+	default:
+		return c.JSON(http.StatusBadRequest, "The anonymize must be true or false.")
+	}
+
+	switch format {
+	case export.ManifestFormatYAML:
+		c.Response().Header().Set(echo.HeaderContentType, "application/yaml")
+	case export.ManifestFormatList:
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	case export.ManifestFormatNDJSON:
+		c.Response().Header().Set(echo.HeaderContentType, mimeApplicationNDJSON)
+	default:
+		rs, err := h.service.Export(ctx, opts...)
+		if err != nil {
+			klog.Errorf("failed to export all resources: %+v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		return c.JSON(http.StatusOK, rs)
+	}
+	c.Response().WriteHeader(http.StatusOK)
+	if err := h.service.ExportManifests(ctx, c.Response(), format, opts...); err != nil {
+		// The status code has been sent already, so the error is told at the end of the response instead.
+		klog.Errorf("failed to export manifests: %+v", err)
+		writeExportError(c.Response(), format, err)
+	}
+	return nil
+}
+
+// exportStreamError is the last line of the response of the streaming export in NDJSON when it's aborted.
+type exportStreamError struct {
+	Error string `json:"error"`
+}
+
+// writeExportError writes the error at the end of the truncated manifests
+// so that the clients, including Import, don't regard them as the complete export.
+// In YAML, it's written as a document without kind, which kubectl and Import reject.
+// In the list format, nothing is written since the response is no longer valid JSON anyway.
+func writeExportError(w io.Writer, format export.ManifestFormat, exportErr error) {
+	var err error
+	switch format {
+	case export.ManifestFormatNDJSON:
+		err = json.NewEncoder(w).Encode(exportStreamError{Error: exportErr.Error()})
+	case export.ManifestFormatYAML:
+		var msg []byte
+		// a JSON string is a valid YAML scalar.
+		msg, err = json.Marshal(exportErr.Error())
+		if err == nil {
+			_, err = fmt.Fprintf(w, "---\nerror: %s\n", msg)
+		}
+	}
 	if err != nil {
-		klog.Errorf("failed to export all resources: %+v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError)
+		klog.Errorf("failed to write export error: %+v", err)
 	}
-	return c.JSON(http.StatusOK, rs)
 }
 
+// Import applies the resources and the scheduler configuration.
+// The request body can be anything that export.DecodeResourcesForImport accepts.
+// It tries to apply all objects even if some of them fail, and returns export.ImportReport which has the result of each object.
+// If the "dryRun" query parameter is "true", the objects are only validated by kube-apiserver and not persisted.
+// If the "stream" query parameter is "true", the objects are applied while the body is decoded, and the progress is streamed. (See importStream.)
 func (h *ExportHandler) Import(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	reqResources := new(ResourcesForImport)
-	if err := c.Bind(reqResources); err != nil {
-		klog.Errorf("failed to bind import resources all request: %+v", err)
+	var dryRun bool
+	switch c.QueryParam("dryRun") {
+	case "", "false":
+	case "true":
+		dryRun = true
+	default:
+		return c.JSON(http.StatusBadRequest, "The dryRun must be true or false.")
+	}
+	var stream bool
+	switch c.QueryParam("stream") {
+	case "", "false":
+	case "true":
+		stream = true
+	default:
+		return c.JSON(http.StatusBadRequest, "The stream must be true or false.")
+	}
+
+	report := &export.ImportReport{}
+	opts := []export.Option{h.service.IgnoreErr(), h.service.WithImportReport(report)}
+	if dryRun {
+		opts = append(opts, h.service.DryRun())
+	}
+	if stream {
+		return h.importStream(c, report, opts)
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		klog.Errorf("failed to read import request: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	reqResources, err := export.DecodeResourcesForImport(body)
+	if err != nil {
+		klog.Errorf("failed to decode import resources all request: %+v", err)
 		return echo.NewHTTPError(http.StatusBadRequest)
 	}
 
-	err := h.service.Import(ctx, convertToResourcesApplyConfiguration(reqResources))
+	err = h.service.Import(ctx, reqResources, opts...)
 	if err != nil {
 		klog.Errorf("failed to import all resources: %+v", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
-	return c.NoContent(http.StatusOK)
+	if report.HasFailure() {
+		return c.JSON(http.StatusBadRequest, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// importStreamResult is the last line of the response of the streaming import.
+type importStreamResult struct {
+	Report *export.ImportReport `json:"report"`
+	// Error is set when the import is aborted. (e.g., the body can't be decoded.)
+	Error string `json:"error,omitempty"`
 }
 
-// convertToResourcesApplyConfiguration converts from *ResourcesApplyConfiguration to *export.ResourcesApplyConfiguration.
-func convertToResourcesApplyConfiguration(r *ResourcesForImport) *export.ResourcesForImport {
-	return &export.ResourcesForImport{
-		Pods:            r.Pods,
-		Nodes:           r.Nodes,
-		Pvs:             r.Pvs,
-		Pvcs:            r.Pvcs,
-		StorageClasses:  r.StorageClasses,
-		PriorityClasses: r.PriorityClasses,
-		SchedulerConfig: r.SchedulerConfig,
-		Namespaces:      r.Namespaces,
+// importStream imports the manifests in the body with ImportStream.
+// The response is NDJSON: export.ImportProgress per line while the objects are applied, and importStreamResult at the last line.
+// The status code is always 200 since it's sent before the import starts.
+func (h *ExportHandler) importStream(c echo.Context, report *export.ImportReport, opts []export.Option) error {
+	c.Response().Header().Set(echo.HeaderContentType, mimeApplicationNDJSON)
+	c.Response().WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(c.Response())
+	progress := func(p export.ImportProgress) {
+		if err := encoder.Encode(p); err != nil {
+			klog.Errorf("failed to write import progress: %+v", err)
+			return
+		}
+		c.Response().Flush()
+	}
+
+	result := importStreamResult{Report: report}
+	if err := h.service.ImportStream(c.Request().Context(), c.Request().Body, progress, opts...); err != nil {
+		klog.Errorf("failed to import the manifests: %+v", err)
+		result.Error = err.Error()
+	}
+	if err := encoder.Encode(result); err != nil {
+		klog.Errorf("failed to write import result: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	return nil
 }
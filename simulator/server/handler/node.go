@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/node"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+)
+
+// NodeHandler is handler for the failures injected to Nodes.
+type NodeHandler struct {
+	service di.NodeService
+}
+
+// NewNodeHandler initializes NodeHandler.
+func NewNodeHandler(s di.NodeService) *NodeHandler {
+	return &NodeHandler{service: s}
+}
+
+// injectFailureRequest is the request to inject a failure to a Node.
+type injectFailureRequest struct {
+	Type node.FailureType `json:"type"`
+}
+
+// InjectFailure injects the failure to the Node.
+func (h *NodeHandler) InjectFailure(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(injectFailureRequest)
+	if err := c.Bind(req); err != nil {
+		klog.Errorf("failed to bind inject failure request: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	err := h.service.InjectFailure(ctx, c.Param("name"), req.Type)
+	switch {
+	case errors.Is(err, node.ErrNodeNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case errors.Is(err, node.ErrInvalidFailure):
+		return c.JSON(http.StatusBadRequest, err.Error())
+	case err != nil:
+		klog.Errorf("failed to inject failure: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// RecoverNode removes all failures injected to the Node.
+func (h *NodeHandler) RecoverNode(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.Recover(ctx, c.Param("name"))
+	switch {
+	case errors.Is(err, node.ErrNodeNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case err != nil:
+		klog.Errorf("failed to recover node: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/nodegroup"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+)
+
+// NodeGroupHandler is handler for the groups of Nodes generated from the templates.
+type NodeGroupHandler struct {
+	service di.NodeGroupService
+}
+
+// NewNodeGroupHandler initializes NodeGroupHandler.
+func NewNodeGroupHandler(s di.NodeGroupService) *NodeGroupHandler {
+	return &NodeGroupHandler{service: s}
+}
+
+// scaleNodeGroupRequest is the request to scale a node group.
+type scaleNodeGroupRequest struct {
+	Count int `json:"count"`
+}
+
+// ListNodeGroups lists all node groups.
+func (h *NodeGroupHandler) ListNodeGroups(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	gs, err := h.service.List(ctx)
+	if err != nil {
+		klog.Errorf("failed to list node groups: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, gs)
+}
+
+// GenerateNodeGroup creates or updates the Nodes of the node group.
+func (h *NodeGroupHandler) GenerateNodeGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(nodegroup.NodeGroup)
+	if err := c.Bind(req); err != nil {
+		klog.Errorf("failed to bind generate node group request: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	err := h.service.Generate(ctx, *req)
+	switch {
+	case errors.Is(err, nodegroup.ErrInvalidNodeGroup):
+		return c.JSON(http.StatusBadRequest, err.Error())
+	case err != nil:
+		klog.Errorf("failed to generate node group: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, req)
+}
+
+// ScaleNodeGroup changes the number of the Nodes in the node group.
+func (h *NodeGroupHandler) ScaleNodeGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(scaleNodeGroupRequest)
+	if err := c.Bind(req); err != nil {
+		klog.Errorf("failed to bind scale node group request: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	err := h.service.Scale(ctx, c.Param("name"), req.Count)
+	switch {
+	case errors.Is(err, nodegroup.ErrNodeGroupNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case errors.Is(err, nodegroup.ErrInvalidNodeGroup):
+		return c.JSON(http.StatusBadRequest, err.Error())
+	case err != nil:
+		klog.Errorf("failed to scale node group: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteNodeGroup deletes all Nodes in the node group.
+func (h *NodeGroupHandler) DeleteNodeGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.Delete(ctx, c.Param("name"))
+	switch {
+	case errors.Is(err, nodegroup.ErrNodeGroupNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case err != nil:
+		klog.Errorf("failed to delete node group: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusOK)
+}
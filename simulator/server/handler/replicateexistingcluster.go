@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/replicateexistingcluster"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+)
+
+// ReplicateExistingClusterHandler is handler for importing resources from the existing cluster.
+type ReplicateExistingClusterHandler struct {
+	// service is nil when the import from the existing cluster is disabled.
+	service di.ReplicateExistingClusterService
+}
+
+// NewReplicateExistingClusterHandler initializes ReplicateExistingClusterHandler.
+func NewReplicateExistingClusterHandler(s di.ReplicateExistingClusterService) *ReplicateExistingClusterHandler {
+	return &ReplicateExistingClusterHandler{service: s}
+}
+
+// Import imports the resources from the existing cluster with replicateexistingcluster.ImportOptions in the request body,
+// and returns export.ImportReport.
+func (h *ReplicateExistingClusterHandler) Import(c echo.Context) error {
+	if h.service == nil {
+		return c.JSON(http.StatusBadRequest, "The import from the existing cluster is disabled. Set EXTERNAL_IMPORT_ENABLED to enable it.")
+	}
+	ctx := c.Request().Context()
+
+	opts := replicateexistingcluster.ImportOptions{}
+	if err := c.Bind(&opts); err != nil {
+		klog.Errorf("failed to bind the import options: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	report, err := h.service.ImportFromExistingClusterWithOptions(ctx, opts)
+	if errors.Is(err, replicateexistingcluster.ErrInvalidImportOptions) {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		klog.Errorf("failed to import from existing cluster: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if report.HasFailure() {
+		return c.JSON(http.StatusBadRequest, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+)
+
+// SchedulerHandler is handler for controlling the running scheduler.
+type SchedulerHandler struct {
+	service di.SchedulerService
+}
+
+// NewSchedulerHandler initializes SchedulerHandler.
+func NewSchedulerHandler(s di.SchedulerService) *SchedulerHandler {
+	return &SchedulerHandler{service: s}
+}
+
+// schedulerStatusResponse is the response of the scheduler status.
+type schedulerStatusResponse struct {
+	Paused bool `json:"paused"`
+}
+
+const externalSchedulerMessage = "When using an external scheduler, you cannot control the scheduler."
+
+func (h *SchedulerHandler) GetStatus(c echo.Context) error {
+	paused, err := h.service.SchedulerPaused()
+	if err != nil && !errors.Is(err, scheduler.ErrServiceDisabled) {
+		klog.Errorf("failed to get scheduler status: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if errors.Is(err, scheduler.ErrServiceDisabled) {
+		return c.JSON(http.StatusBadRequest, externalSchedulerMessage)
+	}
+
+	return c.JSON(http.StatusOK, schedulerStatusResponse{Paused: paused})
+}
+
+// Pause holds Pods in the scheduling queue until Resume is called.
+func (h *SchedulerHandler) Pause(c echo.Context) error {
+	err := h.service.PauseScheduler()
+	if err != nil && !errors.Is(err, scheduler.ErrServiceDisabled) {
+		klog.Errorf("failed to pause scheduler: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if errors.Is(err, scheduler.ErrServiceDisabled) {
+		return c.JSON(http.StatusBadRequest, externalSchedulerMessage)
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+func (h *SchedulerHandler) Resume(c echo.Context) error {
+	err := h.service.ResumeScheduler()
+	if err != nil && !errors.Is(err, scheduler.ErrServiceDisabled) {
+		klog.Errorf("failed to resume scheduler: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if errors.Is(err, scheduler.ErrServiceDisabled) {
+		return c.JSON(http.StatusBadRequest, externalSchedulerMessage)
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// Step releases one scheduling cycle of the paused scheduler.
+func (h *SchedulerHandler) Step(c echo.Context) error {
+	ctx := c.Request().Context()
+	err := h.service.StepScheduler(ctx)
+	switch {
+	case errors.Is(err, scheduler.ErrServiceDisabled):
+		return c.JSON(http.StatusBadRequest, externalSchedulerMessage)
+	case errors.Is(err, scheduler.ErrSchedulerNotPaused):
+		return c.JSON(http.StatusBadRequest, "The scheduler must be paused before stepping.")
+	case err != nil:
+		klog.Errorf("failed to step scheduler: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
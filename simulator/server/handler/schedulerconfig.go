@@ -2,13 +2,14 @@ package handler
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"k8s.io/klog/v2"
-	"k8s.io/kube-scheduler/config/v1beta2"
 
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler/config"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
 )
 
@@ -23,7 +24,14 @@ func NewSchedulerConfigHandler(s di.SchedulerService) *SchedulerConfigHandler {
 	}
 }
 
+// GetSchedulerConfig returns the current scheduler configuration.
+// The configuration is returned in the version specified by the "version" query parameter. (v1beta2 by default.)
 func (h *SchedulerConfigHandler) GetSchedulerConfig(c echo.Context) error {
+	gv, err := config.ParseVersion(c.QueryParam("version"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
 	cfg, err := h.service.GetSchedulerConfig()
 	if err != nil && !errors.Is(err, scheduler.ErrServiceDisabled) {
 		klog.Errorf("failed to get scheduler config: %+v", err)
@@ -33,28 +41,57 @@ func (h *SchedulerConfigHandler) GetSchedulerConfig(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, "When using an external scheduler, you cannot see and edit the scheduler configuration.")
 	}
 
-	return c.JSON(http.StatusOK, cfg)
+	versioned, err := config.ConvertFromV1beta2(cfg, gv)
+	if err != nil {
+		klog.Errorf("failed to convert scheduler config to %s: %+v", gv, err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, versioned)
+}
+
+// applySchedulerConfigResponse is the response of ApplySchedulerConfig.
+type applySchedulerConfigResponse struct {
+	// IgnoredFields are the fields in the posted configuration which cannot be applied to the scheduler in the simulator.
+	IgnoredFields []string `json:"ignoredFields"`
 }
 
-// ApplySchedulerConfig currently only takes profiles from the posted payload and applies them.
+// ApplySchedulerConfig takes profiles and the fields which affect the scheduling from the posted payload and applies them.
+// The fields which cannot be applied to the scheduler in the simulator are returned in the response.
+// The payload can be written in any version in config.SupportedVersions, and it's handled as v1beta2 if apiVersion is empty.
 func (h *SchedulerConfigHandler) ApplySchedulerConfig(c echo.Context) error {
-	reqSchedulerCfg := new(v1beta2.KubeSchedulerConfiguration)
-	if err := c.Bind(reqSchedulerCfg); err != nil {
-		klog.Errorf("failed to bind scheduler config request: %+v", err)
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		klog.Errorf("failed to read scheduler config request: %+v", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	reqSchedulerCfg, err := config.DecodeSchedulerCfg(body)
+	if err != nil {
+		klog.Errorf("failed to decode scheduler config request: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
 	cfg, err := h.service.GetSchedulerConfig()
 	if err != nil {
 		klog.Errorf("failed to get scheduler config: %+v", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	ignored, err := scheduler.IgnoredFields(reqSchedulerCfg)
+	if err != nil {
+		klog.Errorf("failed to check ignored fields in scheduler config: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	cfg = cfg.DeepCopy()
 	cfg.Profiles = reqSchedulerCfg.Profiles
+	cfg.PercentageOfNodesToScore = reqSchedulerCfg.PercentageOfNodesToScore
+	cfg.Parallelism = reqSchedulerCfg.Parallelism
+	cfg.PodInitialBackoffSeconds = reqSchedulerCfg.PodInitialBackoffSeconds
+	cfg.PodMaxBackoffSeconds = reqSchedulerCfg.PodMaxBackoffSeconds
 	if err := h.service.RestartScheduler(cfg); err != nil {
 		klog.Errorf("failed to restart scheduler: %+v", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	return c.NoContent(http.StatusAccepted)
+	return c.JSON(http.StatusAccepted, applySchedulerConfigResponse{IgnoredFields: ignored})
 }
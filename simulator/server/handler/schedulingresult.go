@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/xerrors"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/schedulingresult"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+)
+
+// SchedulingResultHandler is handler for the history of scheduling results.
+type SchedulingResultHandler struct {
+	service di.SchedulingResultService
+}
+
+// NewSchedulingResultHandler initializes SchedulingResultHandler.
+func NewSchedulingResultHandler(s di.SchedulingResultService) *SchedulingResultHandler {
+	return &SchedulingResultHandler{service: s}
+}
+
+// ListSchedulingResults lists the scheduling results filtered by the query parameters.
+func (h *SchedulingResultHandler) ListSchedulingResults(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	q, err := schedulingResultQuery(c)
+	if err != nil {
+		klog.Errorf("failed to parse the query of scheduling results: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	results, err := h.service.List(ctx, q)
+	if err != nil {
+		klog.Errorf("failed to list scheduling results: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, results)
+}
+
+// DeleteSchedulingResults deletes all scheduling results.
+func (h *SchedulingResultHandler) DeleteSchedulingResults(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := h.service.DeleteAll(ctx); err != nil {
+		klog.Errorf("failed to delete scheduling results: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func schedulingResultQuery(c echo.Context) (schedulingresult.Query, error) {
+	q := schedulingresult.Query{
+		Namespace: c.QueryParam("namespace"),
+		PodName:   c.QueryParam("pod"),
+		NodeName:  c.QueryParam("node"),
+		Plugin:    c.QueryParam("plugin"),
+	}
+
+	var err error
+	if since := c.QueryParam("since"); since != "" {
+		q.Since, err = time.Parse(time.RFC3339, since)
+		if err != nil {
+			return schedulingresult.Query{}, xerrors.Errorf("parse since: %w", err)
+		}
+	}
+	if until := c.QueryParam("until"); until != "" {
+		q.Until, err = time.Parse(time.RFC3339, until)
+		if err != nil {
+			return schedulingresult.Query{}, xerrors.Errorf("parse until: %w", err)
+		}
+	}
+	return q, nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/snapshot"
+)
+
+// SnapshotHandler is handler for the named snapshots of the cluster.
+type SnapshotHandler struct {
+	service di.SnapshotService
+}
+
+// NewSnapshotHandler initializes SnapshotHandler.
+func NewSnapshotHandler(s di.SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{service: s}
+}
+
+// saveSnapshotRequest is the request to save a snapshot.
+type saveSnapshotRequest struct {
+	Name string `json:"name"`
+}
+
+// SaveSnapshot saves all resources and the current scheduler configuration as the snapshot.
+func (h *SnapshotHandler) SaveSnapshot(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(saveSnapshotRequest)
+	if err := c.Bind(req); err != nil {
+		klog.Errorf("failed to bind save snapshot request: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	s, err := h.service.Save(ctx, req.Name)
+	switch {
+	case errors.Is(err, snapshot.ErrInvalidSnapshotName):
+		return c.JSON(http.StatusBadRequest, err.Error())
+	case errors.Is(err, snapshot.ErrSnapshotAlreadyExists):
+		return c.JSON(http.StatusConflict, err.Error())
+	case err != nil:
+		klog.Errorf("failed to save snapshot: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, s)
+}
+
+// ListSnapshots lists all snapshots.
+func (h *SnapshotHandler) ListSnapshots(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	ss, err := h.service.List(ctx)
+	if err != nil {
+		klog.Errorf("failed to list snapshots: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, ss)
+}
+
+// RestoreSnapshot restores all resources and the scheduler configuration to the snapshot.
+func (h *SnapshotHandler) RestoreSnapshot(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.Restore(ctx, c.Param("name"))
+	switch {
+	case errors.Is(err, snapshot.ErrSnapshotNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case err != nil:
+		klog.Errorf("failed to restore snapshot: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
+// DeleteSnapshot deletes the snapshot.
+func (h *SnapshotHandler) DeleteSnapshot(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.Delete(ctx, c.Param("name"))
+	switch {
+	case errors.Is(err, snapshot.ErrSnapshotNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case err != nil:
+		klog.Errorf("failed to delete snapshot: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusOK)
+}
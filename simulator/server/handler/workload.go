@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/workload"
+)
+
+// WorkloadHandler is handler for the workloads which generate Pods over time.
+type WorkloadHandler struct {
+	service di.WorkloadService
+}
+
+// NewWorkloadHandler initializes WorkloadHandler.
+func NewWorkloadHandler(s di.WorkloadService) *WorkloadHandler {
+	return &WorkloadHandler{service: s}
+}
+
+// StartWorkload starts generating the Pods of the workload.
+func (h *WorkloadHandler) StartWorkload(c echo.Context) error {
+	req := new(workload.Workload)
+	if err := c.Bind(req); err != nil {
+		klog.Errorf("failed to bind start workload request: %+v", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	st, err := h.service.Start(*req)
+	switch {
+	case errors.Is(err, workload.ErrInvalidWorkload):
+		return c.JSON(http.StatusBadRequest, err.Error())
+	case errors.Is(err, workload.ErrWorkloadAlreadyRunning):
+		return c.JSON(http.StatusConflict, err.Error())
+	case err != nil:
+		klog.Errorf("failed to start workload: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusAccepted, st)
+}
+
+// ListWorkloads lists the status of all workloads.
+func (h *WorkloadHandler) ListWorkloads(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.service.List())
+}
+
+// StopWorkload stops generating the Pods of the workload.
+func (h *WorkloadHandler) StopWorkload(c echo.Context) error {
+	err := h.service.Stop(c.Param("name"))
+	switch {
+	case errors.Is(err, workload.ErrWorkloadNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case err != nil:
+		klog.Errorf("failed to stop workload: %+v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusOK)
+}
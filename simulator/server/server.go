@@ -33,10 +33,19 @@ func NewSimulatorServer(cfg *config.Config, dic *di.Container) *SimulatorServer
 
 	// initialize each handler
 	schedulercfgHandler := handler.NewSchedulerConfigHandler(dic.SchedulerService())
+	schedulerHandler := handler.NewSchedulerHandler(dic.SchedulerService())
 	exportHandler := handler.NewExportHandler(dic.ExportService())
 	resetHandler := handler.NewResetHandler(dic.ResetService())
+	snapshotHandler := handler.NewSnapshotHandler(dic.SnapshotService())
+	nodeHandler := handler.NewNodeHandler(dic.NodeService())
+	nodeGroupHandler := handler.NewNodeGroupHandler(dic.NodeGroupService())
+	autoscalerHandler := handler.NewAutoscalerHandler(dic.AutoscalerService())
+	workloadHandler := handler.NewWorkloadHandler(dic.WorkloadService())
 	resourcewatcherHandler := handler.NewResourceWatcherHandler(dic.ResourceWatcherService())
 	extenderHandler := handler.NewExtenderHandler(dic.ExtenderService())
+	schedulingResultHandler := handler.NewSchedulingResultHandler(dic.SchedulingResultService())
+	replicateExistingClusterHandler := handler.NewReplicateExistingClusterHandler(dic.ReplicateExistingClusterService())
+	existingClusterSyncHandler := handler.NewExistingClusterSyncHandler(dic.ExistingClusterSyncer())
 
 	// register apis
 	v1 := e.Group("/api/v1")
@@ -44,13 +53,48 @@ func NewSimulatorServer(cfg *config.Config, dic *di.Container) *SimulatorServer
 	v1.GET("/schedulerconfiguration", schedulercfgHandler.GetSchedulerConfig)
 	v1.POST("/schedulerconfiguration", schedulercfgHandler.ApplySchedulerConfig)
 
+	v1.GET("/scheduler/status", schedulerHandler.GetStatus)
+	v1.PUT("/scheduler/pause", schedulerHandler.Pause)
+	v1.PUT("/scheduler/resume", schedulerHandler.Resume)
+	v1.PUT("/scheduler/step", schedulerHandler.Step)
+
 	v1.PUT("/reset", resetHandler.Reset)
 
+	v1.GET("/snapshots", snapshotHandler.ListSnapshots)
+	v1.POST("/snapshots", snapshotHandler.SaveSnapshot)
+	v1.PUT("/snapshots/:name/restore", snapshotHandler.RestoreSnapshot)
+	v1.DELETE("/snapshots/:name", snapshotHandler.DeleteSnapshot)
+
+	v1.POST("/nodes/:name/failures", nodeHandler.InjectFailure)
+	v1.DELETE("/nodes/:name/failures", nodeHandler.RecoverNode)
+
+	v1.GET("/nodegroups", nodeGroupHandler.ListNodeGroups)
+	v1.POST("/nodegroups", nodeGroupHandler.GenerateNodeGroup)
+	v1.PUT("/nodegroups/:name/scale", nodeGroupHandler.ScaleNodeGroup)
+	v1.DELETE("/nodegroups/:name", nodeGroupHandler.DeleteNodeGroup)
+
+	v1.GET("/autoscaler", autoscalerHandler.GetStatus)
+	v1.PUT("/autoscaler", autoscalerHandler.Configure)
+	v1.DELETE("/autoscaler", autoscalerHandler.Disable)
+
+	v1.GET("/workloads", workloadHandler.ListWorkloads)
+	v1.POST("/workloads", workloadHandler.StartWorkload)
+	v1.PUT("/workloads/:name/stop", workloadHandler.StopWorkload)
+
 	v1.GET("/export", exportHandler.Export)
 	v1.POST("/import", exportHandler.Import)
 
+	v1.POST("/existingcluster/import", replicateExistingClusterHandler.Import)
+	v1.GET("/existingcluster/sync/status", existingClusterSyncHandler.GetStatus)
+	v1.PUT("/existingcluster/sync/pause", existingClusterSyncHandler.Pause)
+	v1.PUT("/existingcluster/sync/resume", existingClusterSyncHandler.Resume)
+	v1.PUT("/existingcluster/sync/freeze", existingClusterSyncHandler.Freeze)
+
 	v1.GET("/listwatchresources", resourcewatcherHandler.ListWatchResources)
 
+	v1.GET("/schedulingresults", schedulingResultHandler.ListSchedulingResults)
+	v1.DELETE("/schedulingresults", schedulingResultHandler.DeleteSchedulingResults)
+
 	v1.POST("/extender/filter/:id", extenderHandler.Filter)
 	v1.POST("/extender/prioritize/:id", extenderHandler.Prioritize)
 	v1.POST("/extender/preempt/:id", extenderHandler.Preempt)
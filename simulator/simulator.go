@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,24 +13,139 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/batch"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/config"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/controller"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/k8sapiserver"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/replay"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/server"
 	"sigs.k8s.io/kube-scheduler-simulator/simulator/server/di"
 )
 
+const (
+	// batchCommand is the subcommand to run a simulation without the clients of the simulator server.
+	batchCommand = "batch"
+	// replayCommand is the subcommand to replay a trace without the clients of the simulator server.
+	replayCommand = "replay"
+)
+
 // entry point.
 func main() {
-	if err := startSimulator(); err != nil {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case batchCommand:
+			os.Exit(runBatch(os.Args[2:]))
+		case replayCommand:
+			os.Exit(runReplay(os.Args[2:]))
+		}
+	}
+
+	if err := startSimulator(true, waitSignal); err != nil {
 		klog.Fatalf("failed with error on running simulator: %+v", err)
 	}
 }
 
-// startSimulator starts simulator and needed k8s components.
+// runBatch runs a simulation with the resources file and writes the report to the file.
+// It returns the exit code which represents the result. (See batch.ExitCode*.)
+func runBatch(args []string) int {
+	fs := flag.NewFlagSet(batchCommand, flag.ContinueOnError)
+	resourcesPath := fs.String("resources", "", "path to the file of ResourcesForImport in JSON (required)")
+	schedulerConfigPath := fs.String("scheduler-config", "", "path to the file of KubeSchedulerConfiguration to be applied (optional)")
+	outputPath := fs.String("output", "report.json", "path to the file which the report is written to")
+	timeout := fs.Duration("timeout", 10*time.Minute, "time to wait for all Pods to be bound or unschedulable (0 means no timeout)")
+	serve := fs.Bool("serve", false, "start the simulator server during the simulation so that it can be watched from the web UI")
+	if err := fs.Parse(args); err != nil {
+		return batch.ExitCodeFailed
+	}
+	if *resourcesPath == "" {
+		klog.Errorf("-resources is required")
+		return batch.ExitCodeFailed
+	}
+
+	exitCode := batch.ExitCodeFailed
+	err := startSimulator(*serve, func(dic *di.Container) error {
+		report, err := dic.BatchService().Run(context.Background(), batch.Options{
+			ResourcesPath:       *resourcesPath,
+			SchedulerConfigPath: *schedulerConfigPath,
+			Timeout:             *timeout,
+		})
+		if err != nil {
+			return xerrors.Errorf("run simulation: %w", err)
+		}
+		if err := batch.WriteReport(*outputPath, report); err != nil {
+			return xerrors.Errorf("write report: %w", err)
+		}
+		exitCode = report.ExitCode()
+		return nil
+	})
+	if err != nil {
+		klog.Errorf("failed with error on running simulation: %+v", err)
+		return batch.ExitCodeFailed
+	}
+	return exitCode
+}
+
+// runReplay replays a trace and writes the report to the file.
+// It returns 0 if the report is written, or 1 otherwise.
+func runReplay(args []string) int {
+	fs := flag.NewFlagSet(replayCommand, flag.ContinueOnError)
+	tracePath := fs.String("trace", "", "path to the trace file in CSV, JSON or newline-delimited JSON (required)")
+	resourcesPath := fs.String("resources", "", "path to the file of the resources imported before the replay (optional)")
+	schedulerConfigPath := fs.String("scheduler-config", "", "path to the file of KubeSchedulerConfiguration to be applied (optional)")
+	speedUp := fs.Float64("speedup", 1, "how many times faster than the trace the events are replayed")
+	outputPath := fs.String("output", "report.json", "path to the file which the report is written to")
+	timeout := fs.Duration("timeout", 10*time.Minute, "time to wait for the Pods to be bound or unschedulable after the last event (0 means no timeout)")
+	serve := fs.Bool("serve", false, "start the simulator server during the replay so that it can be watched from the web UI")
+	if err := fs.Parse(args); err != nil {
+		return batch.ExitCodeFailed
+	}
+	if *tracePath == "" {
+		klog.Errorf("-trace is required")
+		return batch.ExitCodeFailed
+	}
+	if *speedUp <= 0 {
+		klog.Errorf("-speedup must be positive")
+		return batch.ExitCodeFailed
+	}
+
+	err := startSimulator(*serve, func(dic *di.Container) error {
+		report, err := dic.ReplayService().Run(context.Background(), replay.Options{
+			TracePath:           *tracePath,
+			ResourcesPath:       *resourcesPath,
+			SchedulerConfigPath: *schedulerConfigPath,
+			SpeedUp:             *speedUp,
+			Timeout:             *timeout,
+		})
+		if err != nil {
+			return xerrors.Errorf("replay trace: %w", err)
+		}
+		if err := batch.WriteReport(*outputPath, report); err != nil {
+			return xerrors.Errorf("write report: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		klog.Errorf("failed with error on replaying trace: %+v", err)
+		return batch.ExitCodeFailed
+	}
+	return batch.ExitCodeSucceeded
+}
+
+// waitSignal waits for SIGTERM or interrupt.
+func waitSignal(_ *di.Container) error {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGTERM, os.Interrupt)
+	<-quit
+
+	return nil
+}
+
+// startSimulator starts simulator and needed k8s components, and then calls run.
+// The simulator server is started only when serve is true.
+// They are shut down after run returns.
 //
 //nolint:funlen,cyclop
-func startSimulator() error {
+func startSimulator(serve bool, run func(dic *di.Container) error) error {
 	cfg, err := config.NewConfig()
 	if err != nil {
 		return xerrors.Errorf("get config: %w", err)
@@ -43,7 +159,7 @@ func startSimulator() error {
 
 	client := clientset.NewForConfigOrDie(restclientCfg)
 
-	ctrlerShutdown, err := controller.RunController(client, restclientCfg)
+	ctrlerShutdown, err := controller.RunController(client, restclientCfg, cfg.Controllers)
 	if err != nil {
 		return xerrors.Errorf("start controllers: %w", err)
 	}
@@ -68,7 +184,7 @@ func startSimulator() error {
 	// need to sleep here to make all controllers create initial resources. (like "system-" priorityclass.)
 	time.Sleep(1 * time.Second)
 
-	dic, err := di.NewDIContainer(client, etcdclient, restclientCfg, cfg.InitialSchedulerCfg, cfg.ExternalImportEnabled, existingClusterClient, cfg.ExternalSchedulerEnabled, cfg.Port)
+	dic, err := di.NewDIContainer(client, etcdclient, restclientCfg, cfg.InitialSchedulerCfg, cfg.ExternalImportEnabled, cfg.ExternalImportSyncEnabled, cfg.ExternalImportOptions, existingClusterClient, cfg.ExternalSchedulerEnabled, cfg.Port, cfg.SchedulingResultsPersistenceEnabled)
 	if err != nil {
 		return xerrors.Errorf("create di container: %w", err)
 	}
@@ -81,7 +197,16 @@ func startSimulator() error {
 
 	// If ExternalImportEnabled is enabled, the simulator import resources
 	// from the existing cluster that indicated by the `KUBECONFIG`.
-	if cfg.ExternalImportEnabled {
+	// If ExternalImportSyncEnabled is enabled as well, the simulator keeps mirroring the existing cluster after that.
+	switch {
+	case cfg.ExternalImportSyncEnabled:
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// This must be called after `StartScheduler`
+		if err := dic.ExistingClusterSyncer().Start(ctx); err != nil {
+			return xerrors.Errorf("start syncing with existing cluster: %w", err)
+		}
+	case cfg.ExternalImportEnabled:
 		ctx := context.Background()
 		// This must be called after `StartScheduler`
 		if err := dic.ReplicateExistingClusterService().ImportFromExistingCluster(ctx); err != nil {
@@ -89,18 +214,22 @@ func startSimulator() error {
 		}
 	}
 
-	// start simulator server
-	s := server.NewSimulatorServer(cfg, dic)
-	shutdownFn3, err := s.Start(cfg.Port)
-	if err != nil {
-		return xerrors.Errorf("start simulator server: %w", err)
+	if cfg.AutoscalerConfig != nil {
+		if err := dic.AutoscalerService().Configure(*cfg.AutoscalerConfig); err != nil {
+			return xerrors.Errorf("configure autoscaler: %w", err)
+		}
+		defer dic.AutoscalerService().Disable()
 	}
-	defer shutdownFn3()
 
-	// wait the signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGTERM, os.Interrupt)
-	<-quit
+	if serve {
+		// start simulator server
+		s := server.NewSimulatorServer(cfg, dic)
+		shutdownFn3, err := s.Start(cfg.Port)
+		if err != nil {
+			return xerrors.Errorf("start simulator server: %w", err)
+		}
+		defer shutdownFn3()
+	}
 
-	return nil
+	return run(dic)
 }
@@ -0,0 +1,175 @@
+package snapshot
+
+//go:generate mockgen -destination=./mock_$GOPACKAGE/scheduler.go . SchedulerService
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/k8sapiserver"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+var (
+	ErrSnapshotNotFound      = errors.New("snapshot not found")
+	ErrSnapshotAlreadyExists = errors.New("snapshot already exists")
+	ErrInvalidSnapshotName   = errors.New("invalid snapshot name")
+)
+
+type SchedulerService interface {
+	GetSchedulerConfig() (*v1beta2config.KubeSchedulerConfiguration, error)
+	RestartScheduler(cfg *v1beta2config.KubeSchedulerConfiguration) error
+}
+
+// Snapshot is the information of a saved snapshot.
+type Snapshot struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// snapshot has the data to restore the cluster.
+type snapshot struct {
+	Snapshot
+	// data has the all resource data in etcd keyed by the etcd key.
+	data map[string]string
+	// schedulerCfg is nil when an external scheduler is enabled.
+	schedulerCfg *v1beta2config.KubeSchedulerConfiguration
+}
+
+// Service saves the resources stored in etcd and the scheduler configuration as named snapshots,
+// and restores them.
+// The snapshots are kept in memory, and thus, they are lost when the simulator is restarted.
+type Service struct {
+	mu        sync.Mutex
+	snapshots map[string]*snapshot
+
+	etcdClient   clientv3.KV
+	schedService SchedulerService
+	now          func() time.Time
+}
+
+// NewSnapshotService initializes Service.
+func NewSnapshotService(etcdClient clientv3.KV, schedService SchedulerService) *Service {
+	return &Service{
+		snapshots:    map[string]*snapshot{},
+		etcdClient:   etcdClient,
+		schedService: schedService,
+		now:          time.Now,
+	}
+}
+
+// Save saves all resources and the current scheduler configuration as the snapshot named name.
+func (s *Service) Save(ctx context.Context, name string) (*Snapshot, error) {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+		return nil, xerrors.Errorf("%s: %v: %w", name, errs, ErrInvalidSnapshotName)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.snapshots[name]; ok {
+		return nil, xerrors.Errorf("save snapshot %s: %w", name, ErrSnapshotAlreadyExists)
+	}
+
+	cfg, err := s.schedService.GetSchedulerConfig()
+	if err != nil && !errors.Is(err, scheduler.ErrServiceDisabled) {
+		return nil, xerrors.Errorf("get scheduler config: %w", err)
+	}
+
+	result, err := s.etcdClient.Get(ctx, "/"+k8sapiserver.EtcdPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, xerrors.Errorf("get all data in etcd: %w", err)
+	}
+
+	snap := &snapshot{
+		Snapshot: Snapshot{Name: name, CreatedAt: s.now()},
+		data:     make(map[string]string, len(result.Kvs)),
+	}
+	for _, v := range result.Kvs {
+		snap.data[string(v.Key)] = string(v.Value)
+	}
+	if cfg != nil {
+		snap.schedulerCfg = cfg.DeepCopy()
+	}
+	s.snapshots[name] = snap
+
+	ret := snap.Snapshot
+	return &ret, nil
+}
+
+// List lists all snapshots in the order of their names.
+func (s *Service) List(_ context.Context) ([]Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := make([]Snapshot, 0, len(s.snapshots))
+	for _, snap := range s.snapshots {
+		ret = append(ret, snap.Snapshot)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret, nil
+}
+
+// Restore restores all resources and the scheduler configuration to the snapshot named name.
+// The resources which are created after the snapshot is saved are deleted.
+func (s *Service) Restore(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap, ok := s.snapshots[name]
+	if !ok {
+		return xerrors.Errorf("restore snapshot %s: %w", name, ErrSnapshotNotFound)
+	}
+
+	if _, err := s.etcdClient.Delete(ctx, "/"+k8sapiserver.EtcdPrefix, clientv3.WithPrefix()); err != nil {
+		return xerrors.Errorf("delete all data in etcd: %w", err)
+	}
+
+	eg := util.NewErrGroupWithSemaphore(ctx)
+	for k, v := range snap.data {
+		k := k
+		v := v
+		err := eg.Go(func() error {
+			if _, err := s.etcdClient.Put(ctx, k, v); err != nil {
+				return xerrors.Errorf("put snapshot data in etcd: key: %s, error: %w", k, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if snap.schedulerCfg == nil {
+		// an external scheduler is enabled.
+		return nil
+	}
+	if err := s.schedService.RestartScheduler(snap.schedulerCfg.DeepCopy()); err != nil && !errors.Is(err, scheduler.ErrServiceDisabled) {
+		return xerrors.Errorf("restart scheduler with the snapshot config: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes the snapshot named name.
+func (s *Service) Delete(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.snapshots[name]; !ok {
+		return xerrors.Errorf("delete snapshot %s: %w", name, ErrSnapshotNotFound)
+	}
+	delete(s.snapshots, name)
+	return nil
+}
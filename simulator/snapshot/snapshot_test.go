@@ -0,0 +1,216 @@
+package snapshot
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/xerrors"
+	v1beta2config "k8s.io/kube-scheduler/config/v1beta2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/scheduler"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/snapshot/mock_snapshot"
+)
+
+// fakeKV is the in-memory clientv3.KV which supports only Get, Put and Delete with or without the prefix option.
+type fakeKV struct {
+	clientv3.KV
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func (f *fakeKV) Get(_ context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &clientv3.GetResponse{}
+	for k, v := range f.data {
+		if k == key || (len(opts) != 0 && strings.HasPrefix(k, key)) {
+			resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(k), Value: []byte(v)})
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeKV) Put(_ context.Context, key, val string, _ ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[key] = val
+	return &clientv3.PutResponse{}, nil
+}
+
+func (f *fakeKV) Delete(_ context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for k := range f.data {
+		if k == key || (len(opts) != 0 && strings.HasPrefix(k, key)) {
+			delete(f.data, k)
+		}
+	}
+	return &clientv3.DeleteResponse{}, nil
+}
+
+func TestService_SaveAndRestore(t *testing.T) {
+	t.Parallel()
+	cfg := &v1beta2config.KubeSchedulerConfiguration{Parallelism: new(int32)}
+	tests := []struct {
+		name                  string
+		prepareEtcdDataFn     func(kv *fakeKV)
+		prepareSchedulerFn    func(m *mock_snapshot.MockSchedulerService)
+		changeAfterSavingFn   func(kv *fakeKV)
+		wantDataAfterRestored map[string]string
+		wantErr               bool
+	}{
+		{
+			name: "restore the resources and the scheduler config",
+			prepareEtcdDataFn: func(kv *fakeKV) {
+				kv.data["/kube-scheduler-simulator/pods/default/pod1"] = "pod1"
+				kv.data["/kube-scheduler-simulator/nodes/node1"] = "node1"
+			},
+			prepareSchedulerFn: func(m *mock_snapshot.MockSchedulerService) {
+				m.EXPECT().GetSchedulerConfig().Return(cfg, nil)
+				m.EXPECT().RestartScheduler(cfg).Return(nil)
+			},
+			changeAfterSavingFn: func(kv *fakeKV) {
+				delete(kv.data, "/kube-scheduler-simulator/pods/default/pod1")
+				kv.data["/kube-scheduler-simulator/nodes/node1"] = "updated node1"
+				kv.data["/kube-scheduler-simulator/nodes/node2"] = "node2"
+				kv.data["/other/key"] = "other"
+			},
+			wantDataAfterRestored: map[string]string{
+				"/kube-scheduler-simulator/pods/default/pod1": "pod1",
+				"/kube-scheduler-simulator/nodes/node1":       "node1",
+				"/other/key":                                  "other",
+			},
+		},
+		{
+			name: "restore only the resources when an external scheduler is enabled",
+			prepareEtcdDataFn: func(kv *fakeKV) {
+				kv.data["/kube-scheduler-simulator/nodes/node1"] = "node1"
+			},
+			prepareSchedulerFn: func(m *mock_snapshot.MockSchedulerService) {
+				m.EXPECT().GetSchedulerConfig().Return(nil, xerrors.Errorf("an external scheduler is enabled: %w", scheduler.ErrServiceDisabled))
+			},
+			changeAfterSavingFn: func(kv *fakeKV) {
+				kv.data["/kube-scheduler-simulator/nodes/node2"] = "node2"
+			},
+			wantDataAfterRestored: map[string]string{
+				"/kube-scheduler-simulator/nodes/node1": "node1",
+			},
+		},
+		{
+			name:              "return error if the scheduler fails to restart",
+			prepareEtcdDataFn: func(kv *fakeKV) {},
+			prepareSchedulerFn: func(m *mock_snapshot.MockSchedulerService) {
+				m.EXPECT().GetSchedulerConfig().Return(cfg, nil)
+				m.EXPECT().RestartScheduler(cfg).Return(xerrors.New("error"))
+			},
+			changeAfterSavingFn:   func(kv *fakeKV) {},
+			wantDataAfterRestored: map[string]string{},
+			wantErr:               true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			sched := mock_snapshot.NewMockSchedulerService(ctrl)
+			tt.prepareSchedulerFn(sched)
+			kv := &fakeKV{data: map[string]string{}}
+			tt.prepareEtcdDataFn(kv)
+			s := NewSnapshotService(kv, sched)
+
+			_, err := s.Save(context.Background(), "snapshot1")
+			assert.NoError(t, err)
+			tt.changeAfterSavingFn(kv)
+
+			err = s.Restore(context.Background(), "snapshot1")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDataAfterRestored, kv.data)
+		})
+	}
+}
+
+func TestService_Save(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		saved     []string
+		saveName  string
+		want      *Snapshot
+		wantErrIs error
+	}{
+		{
+			name:     "save the snapshot",
+			saveName: "snapshot1",
+			want:     &Snapshot{Name: "snapshot1", CreatedAt: now},
+		},
+		{
+			name:      "return error if the snapshot already exists",
+			saved:     []string{"snapshot1"},
+			saveName:  "snapshot1",
+			wantErrIs: ErrSnapshotAlreadyExists,
+		},
+		{
+			name:      "return error if the name is invalid",
+			saveName:  "Invalid_Name",
+			wantErrIs: ErrInvalidSnapshotName,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			sched := mock_snapshot.NewMockSchedulerService(ctrl)
+			sched.EXPECT().GetSchedulerConfig().Return(&v1beta2config.KubeSchedulerConfiguration{}, nil).AnyTimes()
+			s := NewSnapshotService(&fakeKV{data: map[string]string{}}, sched)
+			s.now = func() time.Time { return now }
+			for _, name := range tt.saved {
+				_, err := s.Save(context.Background(), name)
+				assert.NoError(t, err)
+			}
+
+			got, err := s.Save(context.Background(), tt.saveName)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ListAndDelete(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctrl := gomock.NewController(t)
+	sched := mock_snapshot.NewMockSchedulerService(ctrl)
+	sched.EXPECT().GetSchedulerConfig().Return(&v1beta2config.KubeSchedulerConfiguration{}, nil).AnyTimes()
+	s := NewSnapshotService(&fakeKV{data: map[string]string{}}, sched)
+	s.now = func() time.Time { return now }
+	for _, name := range []string{"snapshot2", "snapshot1", "snapshot3"} {
+		_, err := s.Save(context.Background(), name)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, s.Delete(context.Background(), "snapshot2"))
+	assert.ErrorIs(t, s.Delete(context.Background(), "snapshot2"), ErrSnapshotNotFound)
+	assert.ErrorIs(t, s.Restore(context.Background(), "snapshot2"), ErrSnapshotNotFound)
+
+	got, err := s.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Snapshot{{Name: "snapshot1", CreatedAt: now}, {Name: "snapshot3", CreatedAt: now}}, got)
+}
@@ -6,8 +6,11 @@ import (
 	"golang.org/x/xerrors"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	v1 "k8s.io/client-go/applyconfigurations/storage/v1"
 	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
 )
 
 // Service manages storageClasss.
@@ -33,7 +36,10 @@ func (s *Service) Get(ctx context.Context, name string) (*storagev1.StorageClass
 
 // List list all storageClass.
 func (s *Service) List(ctx context.Context) (*storagev1.StorageClassList, error) {
-	pl, err := s.client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	pl := &storagev1.StorageClassList{}
+	err := util.ListAllPages(ctx, pl, func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.StorageV1().StorageClasses().List(ctx, opts)
+	})
 	if err != nil {
 		return nil, xerrors.Errorf("list storageClasss: %w", err)
 	}
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"errors"
+	"math/rand"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Distribution is the distribution of a resource quantity.
+// Exactly one of Value, Choices, or Min and Max has to be set.
+type Distribution struct {
+	// Value is the fixed value.
+	Value *resource.Quantity `json:"value,omitempty"`
+	// Choices are the values, one of which is chosen randomly by the weights.
+	Choices []WeightedQuantity `json:"choices,omitempty"`
+	// Min and Max are the range of the uniform distribution.
+	Min *resource.Quantity `json:"min,omitempty"`
+	Max *resource.Quantity `json:"max,omitempty"`
+	// Step rounds the value in the range down to a multiple of it.
+	// It defaults to 1. (e.g., 1 CPU and 1 byte of memory.)
+	Step *resource.Quantity `json:"step,omitempty"`
+}
+
+type WeightedQuantity struct {
+	Value  resource.Quantity `json:"value"`
+	Weight int               `json:"weight"`
+}
+
+// Sample returns a random value in the distribution.
+func (d *Distribution) Sample(rng *rand.Rand) resource.Quantity {
+	switch {
+	case d.Value != nil:
+		return d.Value.DeepCopy()
+	case len(d.Choices) > 0:
+		weights := make([]int, len(d.Choices))
+		for i := range d.Choices {
+			weights[i] = d.Choices[i].Weight
+		}
+		return d.Choices[WeightedChoice(rng, weights)].Value.DeepCopy()
+	}
+
+	step := int64(1000)
+	if d.Step != nil {
+		step = d.Step.MilliValue()
+	}
+	// The value is the multiple of step in [min, max], or min if there is no such value.
+	lo := (d.Min.MilliValue() + step - 1) / step
+	hi := d.Max.MilliValue() / step
+	if lo > hi {
+		return d.Min.DeepCopy()
+	}
+	v := (lo + rng.Int63n(hi-lo+1)) * step
+	return *resource.NewMilliQuantity(v, d.Min.Format)
+}
+
+// WeightedChoice returns the index chosen randomly by the weights. The total of the weights must be positive.
+func WeightedChoice(rng *rand.Rand, weights []int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	n := rng.Intn(total)
+	for i, w := range weights {
+		if n < w {
+			return i
+		}
+		n -= w
+	}
+	return len(weights) - 1
+}
+
+// Validate validates the distribution.
+func (d *Distribution) Validate() error {
+	set := 0
+	if d.Value != nil {
+		set++
+	}
+	if len(d.Choices) > 0 {
+		set++
+		for i := range d.Choices {
+			if d.Choices[i].Weight <= 0 {
+				return xerrors.Errorf("choices[%d]: weight must be positive", i)
+			}
+		}
+	}
+	if d.Min != nil || d.Max != nil {
+		set++
+		if d.Min == nil || d.Max == nil {
+			return errors.New("both min and max must be set")
+		}
+		if d.Min.Cmp(*d.Max) > 0 {
+			return errors.New("min must not be greater than max")
+		}
+		if d.Step != nil && d.Step.MilliValue() <= 0 {
+			return errors.New("step must be positive")
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of value, choices, or min and max must be set")
+	}
+	return nil
+}
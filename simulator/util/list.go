@@ -0,0 +1,73 @@
+package util
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ListPageSize is the maximum number of objects got from kube-apiserver in a list request.
+const ListPageSize = 500
+
+// ListFunc lists the objects with the options. It returns XXXList. (e.g., *corev1.PodList.)
+type ListFunc func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error)
+
+// EachListPage lists all objects page by page with the continue tokens, and calls fn with each page.
+// The page given to fn is XXXList returned from listFunc.
+func EachListPage(ctx context.Context, listFunc ListFunc, fn func(page runtime.Object) error) error {
+	opts := metav1.ListOptions{Limit: ListPageSize}
+	for {
+		page, err := listFunc(ctx, opts)
+		if err != nil {
+			return xerrors.Errorf("list page: %w", err)
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		l, err := meta.ListAccessor(page)
+		if err != nil {
+			return xerrors.Errorf("get list metadata: %w", err)
+		}
+		if l.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = l.GetContinue()
+	}
+}
+
+// ListAllPages lists all objects page by page, and sets them to list, which should be the pointer of XXXList.
+// If the continue token expires during the listing, it lists all objects again in one request.
+// The items of list are left nil if there are no objects.
+func ListAllPages(ctx context.Context, list runtime.Object, listFunc ListFunc) error {
+	var items []runtime.Object
+	err := EachListPage(ctx, listFunc, func(page runtime.Object) error {
+		objs, err := meta.ExtractList(page)
+		if err != nil {
+			return xerrors.Errorf("extract items: %w", err)
+		}
+		items = append(items, objs...)
+		return nil
+	})
+	if apierrors.IsResourceExpired(err) {
+		page, err := listFunc(ctx, metav1.ListOptions{})
+		if err != nil {
+			return xerrors.Errorf("list all: %w", err)
+		}
+		if items, err = meta.ExtractList(page); err != nil {
+			return xerrors.Errorf("extract items: %w", err)
+		}
+	} else if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if err := meta.SetList(list, items); err != nil {
+		return xerrors.Errorf("set items: %w", err)
+	}
+	return nil
+}
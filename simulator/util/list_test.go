@@ -0,0 +1,83 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func pod(name string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func TestListAllPages(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		listFunc ListFunc
+		want     []corev1.Pod
+		wantErr  bool
+	}{
+		{
+			name: "list all pages with the continue tokens",
+			listFunc: func(_ context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				assert.Equal(t, int64(ListPageSize), opts.Limit)
+				switch opts.Continue {
+				case "":
+					return &corev1.PodList{ListMeta: metav1.ListMeta{Continue: "page2"}, Items: []corev1.Pod{pod("pod1"), pod("pod2")}}, nil
+				case "page2":
+					return &corev1.PodList{Items: []corev1.Pod{pod("pod3")}}, nil
+				}
+				t.Fatalf("unexpected continue token %q", opts.Continue)
+				return nil, nil
+			},
+			want: []corev1.Pod{pod("pod1"), pod("pod2"), pod("pod3")},
+		},
+		{
+			name: "list all objects at once if the continue token expires",
+			listFunc: func(_ context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				switch {
+				case opts.Limit == 0:
+					return &corev1.PodList{Items: []corev1.Pod{pod("pod1"), pod("pod2"), pod("pod3")}}, nil
+				case opts.Continue == "":
+					return &corev1.PodList{ListMeta: metav1.ListMeta{Continue: "page2"}, Items: []corev1.Pod{pod("pod1")}}, nil
+				}
+				return nil, apierrors.NewResourceExpired("the continue token is too old")
+			},
+			want: []corev1.Pod{pod("pod1"), pod("pod2"), pod("pod3")},
+		},
+		{
+			name: "leave the items nil if there are no objects",
+			listFunc: func(_ context.Context, _ metav1.ListOptions) (runtime.Object, error) {
+				return &corev1.PodList{}, nil
+			},
+			want: nil,
+		},
+		{
+			name: "return error if the list fails",
+			listFunc: func(_ context.Context, _ metav1.ListOptions) (runtime.Object, error) {
+				return nil, apierrors.NewInternalError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			list := &corev1.PodList{}
+			err := ListAllPages(context.Background(), list, tt.listFunc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, list.Items)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+package util
+
+// DefaultNodePods is the number of Pods allowed on a Node when its capacity doesn't have it. It's the default of kubelet.
+const DefaultNodePods = "110"
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// PauseImage is the image of the containers in the Pods which the simulator generates. It's never pulled in the simulator.
+const PauseImage = "registry.k8s.io/pause:3.9"
+
+// PodApplyConfiguration converts the Pod to the apply configuration.
+func PodApplyConfiguration(pod *corev1.Pod) (*v1.PodApplyConfiguration, error) {
+	b, err := json.Marshal(pod)
+	if err != nil {
+		return nil, xerrors.Errorf("call Marshal to convert Pod: %w", err)
+	}
+	pac := &v1.PodApplyConfiguration{}
+	if err := json.Unmarshal(b, pac); err != nil {
+		return nil, xerrors.Errorf("call Unmarshal to convert Pod: %w", err)
+	}
+	return pac, nil
+}
+
+// UnschedulableCondition returns the PodScheduled condition of the pod if the scheduler has failed to schedule it, or nil otherwise.
+func UnschedulableCondition(pod *corev1.Pod) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		c := &pod.Status.Conditions[i]
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+			return c
+		}
+	}
+	return nil
+}
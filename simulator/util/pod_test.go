@@ -0,0 +1,55 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodApplyConfiguration(t *testing.T) {
+	t.Parallel()
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: PauseImage}}},
+	}
+
+	got, err := PodApplyConfiguration(p)
+	assert.NoError(t, err)
+	assert.Equal(t, "pod1", *got.Name)
+	assert.Equal(t, "default", *got.Namespace)
+	assert.Equal(t, PauseImage, *got.Spec.Containers[0].Image)
+}
+
+func TestUnschedulableCondition(t *testing.T) {
+	t.Parallel()
+	unschedulable := corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable, Message: "0/1 nodes are available"}
+	tests := []struct {
+		name       string
+		conditions []corev1.PodCondition
+		want       *corev1.PodCondition
+	}{
+		{
+			name:       "the pod is unschedulable",
+			conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}, unschedulable},
+			want:       &unschedulable,
+		},
+		{
+			name:       "the pod is scheduled",
+			conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+		},
+		{
+			name:       "the scheduling is gated",
+			conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonSchedulingGated}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := UnschedulableCondition(&corev1.Pod{Status: corev1.PodStatus{Conditions: tt.conditions}})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
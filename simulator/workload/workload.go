@@ -0,0 +1,472 @@
+package workload
+
+//go:generate mockgen -destination=./mock_$GOPACKAGE/$GOFILE . PodService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+)
+
+const (
+	// WorkloadLabel is the label which has the name of the workload that generated the Pod.
+	WorkloadLabel = "scheduler-simulator/workload"
+	// TemplateLabel is the label which has the name of the template that the Pod is generated from.
+	TemplateLabel = "scheduler-simulator/workload-template"
+)
+
+const (
+	// defaultPollInterval is the interval to check the unschedulable Pods.
+	defaultPollInterval = time.Second
+)
+
+var (
+	ErrInvalidWorkload        = errors.New("invalid workload")
+	ErrWorkloadAlreadyRunning = errors.New("workload already running")
+	ErrWorkloadNotFound       = errors.New("workload not found")
+)
+
+// PodService represents service for manage Pods.
+type PodService interface {
+	List(ctx context.Context, namespace string) (*corev1.PodList, error)
+	Apply(ctx context.Context, namespace string, pod *v1.PodApplyConfiguration) (*corev1.Pod, error)
+}
+
+// Service generates the Pods of the workloads over time.
+type Service struct {
+	podService   PodService
+	pollInterval time.Duration
+
+	mu sync.Mutex
+	// runs has the runs of the workloads by their names, including the finished ones.
+	runs map[string]*run
+}
+
+// run is a workload being generated or finished.
+type run struct {
+	cancel context.CancelFunc
+	// done is closed when the run finishes.
+	done chan struct{}
+	// status is guarded by Service.mu.
+	status Status
+}
+
+// Workload describes the Pods generated over time.
+type Workload struct {
+	// Name is the name of the workload. The Pods are named "<name>-<index>" and have WorkloadLabel.
+	Name string `json:"name"`
+	// Namespace is the namespace of the Pods. It defaults to "default".
+	Namespace string `json:"namespace,omitempty"`
+	// Seed is the seed of the random values. The same Workload always generates the same Pods at the same times.
+	Seed    int64         `json:"seed,omitempty"`
+	Arrival Arrival       `json:"arrival"`
+	Stop    StopCondition `json:"stop"`
+	// Templates are the templates of the Pods, one of which is chosen randomly by the weights for each Pod.
+	Templates []PodTemplate `json:"templates"`
+}
+
+// ArrivalProcess is the process of the Pods' arrivals.
+type ArrivalProcess string
+
+const (
+	// ArrivalBurst creates Size Pods at once every Interval.
+	ArrivalBurst ArrivalProcess = "Burst"
+	// ArrivalConstant creates a Pod every 1/Rate seconds.
+	ArrivalConstant ArrivalProcess = "Constant"
+	// ArrivalPoisson creates the Pods with the exponentially distributed intervals, which are 1/Rate seconds on average.
+	ArrivalPoisson ArrivalProcess = "Poisson"
+)
+
+// Arrival is when the Pods are created.
+type Arrival struct {
+	Process ArrivalProcess `json:"process"`
+	// Rate is the number of Pods per second for Constant and Poisson.
+	Rate float64 `json:"rate,omitempty"`
+	// Size is the number of Pods in a burst for Burst.
+	Size int `json:"size,omitempty"`
+	// Interval is the interval between the bursts for Burst.
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// StopCondition is when the workload stops creating the Pods.
+// At least one of them has to be set, and the workload stops when any of them is met.
+type StopCondition struct {
+	// Count is the number of the Pods to create.
+	Count int `json:"count,omitempty"`
+	// Duration is the time to create the Pods for.
+	Duration metav1.Duration `json:"duration,omitempty"`
+	// Unschedulable stops the workload when one of its Pods becomes unschedulable.
+	Unschedulable bool `json:"unschedulable,omitempty"`
+}
+
+// PodTemplate describes the generated Pods.
+type PodTemplate struct {
+	// Name is the name of the template. The Pods have TemplateLabel with it.
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	// Labels are added to the Pods. They can be used in the selectors of Affinity and TopologySpreadConstraints.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Requests is the distribution of the requests of each resource.
+	Requests map[corev1.ResourceName]util.Distribution `json:"requests,omitempty"`
+	// PriorityClasses are the PriorityClasses, one of which is chosen randomly by the weights for each Pod.
+	PriorityClasses           []WeightedPriorityClass           `json:"priorityClasses,omitempty"`
+	SchedulerName             string                            `json:"schedulerName,omitempty"`
+	NodeSelector              map[string]string                 `json:"nodeSelector,omitempty"`
+	Affinity                  *corev1.Affinity                  `json:"affinity,omitempty"`
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	Tolerations               []corev1.Toleration               `json:"tolerations,omitempty"`
+}
+
+type WeightedPriorityClass struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// Phase is the phase of a workload.
+type Phase string
+
+const (
+	PhaseRunning   Phase = "Running"
+	PhaseCompleted Phase = "Completed"
+	PhaseStopped   Phase = "Stopped"
+	PhaseFailed    Phase = "Failed"
+)
+
+// Reasons why the workload is completed.
+const (
+	ReasonCount         = "Count"
+	ReasonDuration      = "Duration"
+	ReasonUnschedulable = "Unschedulable"
+)
+
+// Status is the status of a workload.
+type Status struct {
+	Name  string `json:"name"`
+	Phase Phase  `json:"phase"`
+	// Created is the number of the Pods created.
+	Created int `json:"created"`
+	// Reason is the stop condition which is met when the workload is completed.
+	Reason string `json:"reason,omitempty"`
+	// UnschedulablePod is the Pod which stops the workload with the Unschedulable stop condition.
+	UnschedulablePod string `json:"unschedulablePod,omitempty"`
+	// Error is the error which the workload failed with.
+	Error      string       `json:"error,omitempty"`
+	StartedAt  metav1.Time  `json:"startedAt"`
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+}
+
+// NewWorkloadService initializes Service.
+func NewWorkloadService(ps PodService) *Service {
+	return &Service{
+		podService:   ps,
+		pollInterval: defaultPollInterval,
+		runs:         map[string]*run{},
+	}
+}
+
+// Start starts generating the Pods of the workload in background.
+// The workload with the same name can be started again after it finishes,
+// but the Pods generated last time should be deleted before that because the same names are used.
+func (s *Service) Start(w Workload) (*Status, error) {
+	if w.Namespace == "" {
+		w.Namespace = metav1.NamespaceDefault
+	}
+	if err := w.validate(); err != nil {
+		return nil, xerrors.Errorf("%s: %v: %w", w.Name, err, ErrInvalidWorkload)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.runs[w.Name]; ok && r.status.Phase == PhaseRunning {
+		return nil, xerrors.Errorf("start workload %s: %w", w.Name, ErrWorkloadAlreadyRunning)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &run{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		status: Status{Name: w.Name, Phase: PhaseRunning, StartedAt: metav1.Now()},
+	}
+	s.runs[w.Name] = r
+	go func() {
+		defer close(r.done)
+		s.run(ctx, &w, r)
+	}()
+	st := r.status
+	return &st, nil
+}
+
+// Stop stops the workload and waits for it to finish. The Pods already created are kept.
+// It does nothing if the workload has finished already.
+func (s *Service) Stop(name string) error {
+	s.mu.Lock()
+	r, ok := s.runs[name]
+	s.mu.Unlock()
+	if !ok {
+		return xerrors.Errorf("stop workload %s: %w", name, ErrWorkloadNotFound)
+	}
+	r.cancel()
+	<-r.done
+	return nil
+}
+
+// List returns the status of all workloads in the order of their names.
+func (s *Service) List() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ss := make([]Status, 0, len(s.runs))
+	for _, r := range s.runs {
+		ss = append(ss, r.status)
+	}
+	sort.Slice(ss, func(i, j int) bool { return ss[i].Name < ss[j].Name })
+	return ss
+}
+
+// run creates the Pods until one of the stop conditions is met or ctx is canceled.
+//
+//nolint:cyclop // For readability.
+func (s *Service) run(ctx context.Context, w *Workload, r *run) {
+	//nolint:gosec // The values don't have to be secure, but have to be reproducible.
+	rng := rand.New(rand.NewSource(w.Seed))
+
+	var deadline <-chan time.Time
+	if w.Stop.Duration.Duration > 0 {
+		t := time.NewTimer(w.Stop.Duration.Duration)
+		defer t.Stop()
+		deadline = t.C
+	}
+	var poll <-chan time.Time
+	if w.Stop.Unschedulable {
+		t := time.NewTicker(s.pollInterval)
+		defer t.Stop()
+		poll = t.C
+	}
+	next := time.NewTimer(0)
+	defer next.Stop()
+
+	created := 0
+	for {
+		select {
+		case <-ctx.Done():
+			s.finish(r, PhaseStopped, "", nil)
+			return
+		case <-deadline:
+			s.finish(r, PhaseCompleted, ReasonDuration, nil)
+			return
+		case <-poll:
+			pod, err := s.unschedulablePod(ctx, w)
+			if err != nil {
+				s.finish(r, PhaseFailed, "", err)
+				return
+			}
+			if pod != "" {
+				s.mu.Lock()
+				r.status.UnschedulablePod = pod
+				s.mu.Unlock()
+				s.finish(r, PhaseCompleted, ReasonUnschedulable, nil)
+				return
+			}
+		case <-next.C:
+			n := 1
+			if w.Arrival.Process == ArrivalBurst {
+				n = w.Arrival.Size
+			}
+			for i := 0; i < n; i++ {
+				if err := s.createPod(ctx, w, rng, created); err != nil {
+					if ctx.Err() != nil {
+						s.finish(r, PhaseStopped, "", nil)
+					} else {
+						s.finish(r, PhaseFailed, "", err)
+					}
+					return
+				}
+				created++
+				s.mu.Lock()
+				r.status.Created = created
+				s.mu.Unlock()
+				if created == w.Stop.Count {
+					s.finish(r, PhaseCompleted, ReasonCount, nil)
+					return
+				}
+			}
+			next.Reset(w.Arrival.interval(rng))
+		}
+	}
+}
+
+func (s *Service) finish(r *run, phase Phase, reason string, err error) {
+	if err != nil {
+		klog.Errorf("workload %s failed: %+v", r.status.Name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r.status.Phase = phase
+	r.status.Reason = reason
+	if err != nil {
+		r.status.Error = err.Error()
+	}
+	now := metav1.Now()
+	r.status.FinishedAt = &now
+}
+
+// createPod creates the Pod at the index.
+func (s *Service) createPod(ctx context.Context, w *Workload, rng *rand.Rand, index int) error {
+	pod := w.pod(rng, index)
+	pac, err := util.PodApplyConfiguration(pod)
+	if err != nil {
+		return xerrors.Errorf("convert Pod: %w", err)
+	}
+	if _, err := s.podService.Apply(ctx, w.Namespace, pac); err != nil {
+		return xerrors.Errorf("apply pod %s: %w", pod.Name, err)
+	}
+	return nil
+}
+
+// unschedulablePod returns the name of a Pod of the workload which is unschedulable, or "" if there is no such Pod.
+func (s *Service) unschedulablePod(ctx context.Context, w *Workload) (string, error) {
+	pl, err := s.podService.List(ctx, w.Namespace)
+	if err != nil {
+		return "", xerrors.Errorf("list pods: %w", err)
+	}
+	for i := range pl.Items {
+		p := &pl.Items[i]
+		if p.Labels[WorkloadLabel] == w.Name && util.UnschedulableCondition(p) != nil {
+			return p.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// pod generates the Pod at the index from one of the templates.
+func (w *Workload) pod(rng *rand.Rand, index int) *corev1.Pod {
+	weights := make([]int, len(w.Templates))
+	for i := range w.Templates {
+		weights[i] = w.Templates[i].Weight
+	}
+	t := &w.Templates[util.WeightedChoice(rng, weights)]
+
+	labels := map[string]string{}
+	for k, v := range t.Labels {
+		labels[k] = v
+	}
+	labels[WorkloadLabel] = w.Name
+	labels[TemplateLabel] = t.Name
+
+	// The requests are generated in the order of the names so that the same random values are used for them.
+	names := make([]string, 0, len(t.Requests))
+	for rn := range t.Requests {
+		names = append(names, string(rn))
+	}
+	sort.Strings(names)
+	requests := corev1.ResourceList{}
+	for _, rn := range names {
+		d := t.Requests[corev1.ResourceName(rn)]
+		requests[corev1.ResourceName(rn)] = d.Sample(rng)
+	}
+
+	var priorityClassName string
+	if len(t.PriorityClasses) > 0 {
+		weights := make([]int, len(t.PriorityClasses))
+		for i := range t.PriorityClasses {
+			weights[i] = t.PriorityClasses[i].Weight
+		}
+		priorityClassName = t.PriorityClasses[util.WeightedChoice(rng, weights)].Name
+	}
+
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", w.Name, index),
+			Namespace: w.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:      "app",
+				Image:     util.PauseImage,
+				Resources: corev1.ResourceRequirements{Requests: requests},
+			}},
+			PriorityClassName:         priorityClassName,
+			SchedulerName:             t.SchedulerName,
+			NodeSelector:              t.NodeSelector,
+			Affinity:                  t.Affinity,
+			TopologySpreadConstraints: t.TopologySpreadConstraints,
+			Tolerations:               t.Tolerations,
+		},
+	}
+}
+
+// interval returns the time until the next arrival.
+func (a *Arrival) interval(rng *rand.Rand) time.Duration {
+	switch a.Process {
+	case ArrivalBurst:
+		return a.Interval.Duration
+	case ArrivalPoisson:
+		return time.Duration(rng.ExpFloat64() / a.Rate * float64(time.Second))
+	default:
+		return time.Duration(float64(time.Second) / a.Rate)
+	}
+}
+
+func (w *Workload) validate() error {
+	var errs []string
+	errs = append(errs, validation.IsDNS1123Label(w.Name)...)
+	switch w.Arrival.Process {
+	case ArrivalBurst:
+		if w.Arrival.Size <= 0 {
+			errs = append(errs, "arrival.size must be positive")
+		}
+		if w.Arrival.Interval.Duration <= 0 {
+			errs = append(errs, "arrival.interval must be positive")
+		}
+	case ArrivalConstant, ArrivalPoisson:
+		if w.Arrival.Rate <= 0 {
+			errs = append(errs, "arrival.rate must be positive")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("arrival.process must be one of %s, %s and %s", ArrivalBurst, ArrivalConstant, ArrivalPoisson))
+	}
+	if w.Stop.Count < 0 || w.Stop.Duration.Duration < 0 {
+		errs = append(errs, "stop.count and stop.duration must not be negative")
+	}
+	if w.Stop.Count == 0 && w.Stop.Duration.Duration == 0 && !w.Stop.Unschedulable {
+		errs = append(errs, "at least one of the stop conditions must be set")
+	}
+	if len(w.Templates) == 0 {
+		errs = append(errs, "at least one template must be set")
+	}
+	for i := range w.Templates {
+		t := &w.Templates[i]
+		if t.Weight <= 0 {
+			errs = append(errs, fmt.Sprintf("templates[%d]: weight must be positive", i))
+		}
+		for rn, d := range t.Requests {
+			if err := d.Validate(); err != nil {
+				errs = append(errs, fmt.Sprintf("templates[%d].requests[%s]: %v", i, rn, err))
+			}
+		}
+		for j := range t.PriorityClasses {
+			if t.PriorityClasses[j].Weight <= 0 {
+				errs = append(errs, fmt.Sprintf("templates[%d].priorityClasses[%d]: weight must be positive", i, j))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
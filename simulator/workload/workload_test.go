@@ -0,0 +1,224 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/util"
+	"sigs.k8s.io/kube-scheduler-simulator/simulator/workload/mock_workload"
+)
+
+func quantity(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func testWorkload(arrival Arrival, stop StopCondition) Workload {
+	return Workload{
+		Name:    "web",
+		Seed:    1,
+		Arrival: arrival,
+		Stop:    stop,
+		Templates: []PodTemplate{
+			{
+				Name:   "small",
+				Weight: 3,
+				Labels: map[string]string{"app": "web"},
+				Requests: map[corev1.ResourceName]util.Distribution{
+					corev1.ResourceCPU:    {Min: quantity("100m"), Max: quantity("500m"), Step: quantity("100m")},
+					corev1.ResourceMemory: {Value: quantity("128Mi")},
+				},
+				PriorityClasses: []WeightedPriorityClass{{Name: "low", Weight: 1}, {Name: "high", Weight: 1}},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelTopologyZone,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				}},
+			},
+			{
+				Name:   "large",
+				Weight: 1,
+				Requests: map[corev1.ResourceName]util.Distribution{
+					corev1.ResourceCPU: {Value: quantity("4")},
+				},
+			},
+		},
+	}
+}
+
+// recordApplies records the names of the Pods applied via the mock.
+func recordApplies(m *mock_workload.MockPodService) func() []string {
+	var mu sync.Mutex
+	names := []string{}
+	m.EXPECT().Apply(gomock.Any(), metav1.NamespaceDefault, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, pac *v1.PodApplyConfiguration) (*corev1.Pod, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		names = append(names, *pac.Name)
+		return &corev1.Pod{}, nil
+	}).AnyTimes()
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, names...)
+	}
+}
+
+// wait waits for the workload to finish and returns the status.
+func wait(t *testing.T, s *Service, name string) Status {
+	t.Helper()
+	s.mu.Lock()
+	r := s.runs[name]
+	s.mu.Unlock()
+	select {
+	case <-r.done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("workload %s didn't finish", name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return r.status
+}
+
+func TestService_Start(t *testing.T) {
+	t.Parallel()
+	unschedulable := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Labels: map[string]string{WorkloadLabel: "web"}},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+			{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable},
+		}},
+	}
+	tests := []struct {
+		name                  string
+		workload              Workload
+		preparePodServiceMock func(m *mock_workload.MockPodService)
+		wantCreated           int
+		wantPhase             Phase
+		wantReason            string
+	}{
+		{
+			name:        "create the Pods in bursts until the count",
+			workload:    testWorkload(Arrival{Process: ArrivalBurst, Size: 3, Interval: metav1.Duration{Duration: 10 * time.Millisecond}}, StopCondition{Count: 7}),
+			wantCreated: 7,
+			wantPhase:   PhaseCompleted,
+			wantReason:  ReasonCount,
+		},
+		{
+			name:        "create the Pods in the Poisson process until the count",
+			workload:    testWorkload(Arrival{Process: ArrivalPoisson, Rate: 1000}, StopCondition{Count: 20}),
+			wantCreated: 20,
+			wantPhase:   PhaseCompleted,
+			wantReason:  ReasonCount,
+		},
+		{
+			name:        "create the Pods at the constant rate until the duration passes",
+			workload:    testWorkload(Arrival{Process: ArrivalConstant, Rate: 1}, StopCondition{Duration: metav1.Duration{Duration: 50 * time.Millisecond}}),
+			wantCreated: 1,
+			wantPhase:   PhaseCompleted,
+			wantReason:  ReasonDuration,
+		},
+		{
+			name:     "stop when a Pod becomes unschedulable",
+			workload: testWorkload(Arrival{Process: ArrivalConstant, Rate: 1}, StopCondition{Count: 100, Unschedulable: true}),
+			preparePodServiceMock: func(m *mock_workload.MockPodService) {
+				m.EXPECT().List(gomock.Any(), metav1.NamespaceDefault).Return(&corev1.PodList{Items: []corev1.Pod{unschedulable}}, nil)
+			},
+			wantCreated: 1,
+			wantPhase:   PhaseCompleted,
+			wantReason:  ReasonUnschedulable,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := mock_workload.NewMockPodService(gomock.NewController(t))
+			if tt.preparePodServiceMock != nil {
+				tt.preparePodServiceMock(m)
+			}
+			applied := recordApplies(m)
+			s := NewWorkloadService(m)
+			s.pollInterval = 10 * time.Millisecond
+
+			_, err := s.Start(tt.workload)
+			assert.NoError(t, err)
+			st := wait(t, s, tt.workload.Name)
+
+			assert.Equal(t, tt.wantPhase, st.Phase)
+			assert.Equal(t, tt.wantReason, st.Reason)
+			assert.Equal(t, tt.wantCreated, st.Created)
+			assert.Len(t, applied(), tt.wantCreated)
+			assert.Equal(t, "web-0", applied()[0])
+			assert.NotNil(t, st.FinishedAt)
+		})
+	}
+}
+
+func TestService_Start_Invalid(t *testing.T) {
+	t.Parallel()
+	s := NewWorkloadService(mock_workload.NewMockPodService(gomock.NewController(t)))
+
+	_, err := s.Start(testWorkload(Arrival{Process: ArrivalPoisson}, StopCondition{}))
+	assert.True(t, errors.Is(err, ErrInvalidWorkload))
+	assert.Empty(t, s.List())
+}
+
+func TestService_Stop(t *testing.T) {
+	t.Parallel()
+	m := mock_workload.NewMockPodService(gomock.NewController(t))
+	applied := recordApplies(m)
+	s := NewWorkloadService(m)
+
+	w := testWorkload(Arrival{Process: ArrivalConstant, Rate: 0.1}, StopCondition{Count: 100})
+	_, err := s.Start(w)
+	assert.NoError(t, err)
+	_, err = s.Start(w)
+	assert.True(t, errors.Is(err, ErrWorkloadAlreadyRunning))
+
+	assert.NoError(t, s.Stop("web"))
+	st := s.List()
+	assert.Len(t, st, 1)
+	assert.Equal(t, PhaseStopped, st[0].Phase)
+	assert.Equal(t, len(applied()), st[0].Created)
+	assert.True(t, errors.Is(s.Stop("not-found"), ErrWorkloadNotFound))
+}
+
+func TestWorkload_pod(t *testing.T) {
+	t.Parallel()
+	w := testWorkload(Arrival{Process: ArrivalBurst, Size: 1}, StopCondition{Count: 1})
+	//nolint:gosec // It's a test.
+	rng := rand.New(rand.NewSource(1))
+	templates := map[string]int{}
+	priorities := map[string]int{}
+	for i := 0; i < 100; i++ {
+		p := w.pod(rng, i)
+		assert.Equal(t, "web", p.Labels[WorkloadLabel])
+		templates[p.Labels[TemplateLabel]]++
+		requests := p.Spec.Containers[0].Resources.Requests
+		if p.Labels[TemplateLabel] == "large" {
+			assert.Equal(t, "4", requests.Cpu().String())
+			assert.Empty(t, p.Spec.PriorityClassName)
+			continue
+		}
+		assert.Equal(t, "web", p.Labels["app"])
+		assert.Contains(t, []string{"100m", "200m", "300m", "400m", "500m"}, requests.Cpu().String())
+		assert.Equal(t, "128Mi", requests.Memory().String())
+		assert.Len(t, p.Spec.TopologySpreadConstraints, 1)
+		priorities[p.Spec.PriorityClassName]++
+	}
+	// The templates and the PriorityClasses are chosen by the weights.
+	assert.Greater(t, templates["small"], templates["large"])
+	assert.Greater(t, priorities["low"], 0)
+	assert.Greater(t, priorities["high"], 0)
+}
@@ -16,7 +16,7 @@ export default function exportAPI(instance: AxiosInstance) {
 
     importScheduler: async (data: ResourcesForImport) => {
       try {
-        const res = await instance.post<ResourcesForImport>(`/import`, data);
+        const res = await instance.post<ImportReport>(`/import`, data);
         return res.data;
       } catch (e: any) {
         throw new Error(e);
@@ -36,4 +36,20 @@ export declare class ResourcesForImport {
   "namespaces": V1Namespace[];
 }
 
+export declare class ImportReport {
+  "dryRun": boolean;
+  "succeeded": number;
+  "skipped": number;
+  "failed": number;
+  "objects": ObjectResult[];
+}
+
+export declare class ObjectResult {
+  "kind": string;
+  "namespace"?: string;
+  "name": string;
+  "result": "Succeeded" | "Skipped" | "Failed";
+  "message"?: string;
+}
+
 export type ExportAPI = ReturnType<typeof exportAPI>;